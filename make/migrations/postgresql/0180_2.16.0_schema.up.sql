@@ -0,0 +1,98 @@
+CREATE TABLE IF NOT EXISTS vex_document (
+    id SERIAL PRIMARY KEY NOT NULL,
+    project_id int NOT NULL,
+    name varchar(255) NOT NULL,
+    format varchar(32) NOT NULL,
+    content text,
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    update_time timestamp default CURRENT_TIMESTAMP,
+    CONSTRAINT unique_vex_document UNIQUE (project_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS admission_policy (
+    id SERIAL PRIMARY KEY NOT NULL,
+    project_id int NOT NULL,
+    name varchar(255) NOT NULL,
+    description text,
+    module text NOT NULL,
+    actions varchar(64) NOT NULL,
+    enabled boolean NOT NULL DEFAULT true,
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    update_time timestamp default CURRENT_TIMESTAMP,
+    CONSTRAINT unique_admission_policy UNIQUE (project_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS sbom_component (
+    id SERIAL PRIMARY KEY NOT NULL,
+    artifact_id int NOT NULL,
+    source varchar(255) NOT NULL,
+    name varchar(255) NOT NULL,
+    version varchar(255),
+    purl text,
+    type varchar(64),
+    licenses text,
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    CONSTRAINT fk_sbom_component_artifact_id FOREIGN KEY(artifact_id) REFERENCES artifact(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sbom_component_artifact_id_source ON sbom_component (artifact_id, source);
+CREATE INDEX IF NOT EXISTS idx_sbom_component_source ON sbom_component (source);
+CREATE INDEX IF NOT EXISTS idx_sbom_component_name ON sbom_component (lower(name));
+CREATE INDEX IF NOT EXISTS idx_sbom_component_purl ON sbom_component (purl text_pattern_ops);
+
+ALTER TABLE scanner_registration ADD COLUMN IF NOT EXISTS db_updated_at timestamp;
+
+CREATE INDEX IF NOT EXISTS idx_artifact_pull_time_id ON artifact (pull_time DESC NULLS LAST, id);
+
+CREATE TABLE IF NOT EXISTS security_hub_snapshot (
+    id SERIAL PRIMARY KEY NOT NULL,
+    project_id int NOT NULL,
+    snapshot_time timestamp NOT NULL,
+    critical_cnt bigint NOT NULL DEFAULT 0,
+    high_cnt bigint NOT NULL DEFAULT 0,
+    medium_cnt bigint NOT NULL DEFAULT 0,
+    low_cnt bigint NOT NULL DEFAULT 0,
+    none_cnt bigint NOT NULL DEFAULT 0,
+    unknown_cnt bigint NOT NULL DEFAULT 0,
+    fixable_cnt bigint NOT NULL DEFAULT 0,
+    scanned_cnt bigint NOT NULL DEFAULT 0,
+    total_artifact_cnt bigint NOT NULL DEFAULT 0,
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    CONSTRAINT unique_security_hub_snapshot UNIQUE (project_id, snapshot_time)
+);
+
+CREATE INDEX IF NOT EXISTS idx_security_hub_snapshot_time ON security_hub_snapshot (snapshot_time);
+
+CREATE TABLE IF NOT EXISTS vulnerability_remediation (
+    id SERIAL PRIMARY KEY NOT NULL,
+    project_id int NOT NULL,
+    cve_id varchar(255) NOT NULL,
+    package varchar(255) NOT NULL,
+    severity varchar(64) NOT NULL,
+    registration_uuid varchar(64) NOT NULL,
+    state varchar(32) NOT NULL,
+    owner varchar(255),
+    due_time timestamp,
+    justification text,
+    risk_expiry_time timestamp,
+    resolved_time timestamp,
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    update_time timestamp default CURRENT_TIMESTAMP,
+    CONSTRAINT unique_vulnerability_remediation UNIQUE (project_id, cve_id, package)
+);
+
+CREATE INDEX IF NOT EXISTS idx_vulnerability_remediation_state ON vulnerability_remediation (state);
+
+ALTER TABLE schedule ADD COLUMN IF NOT EXISTS cron_options text;
+
+CREATE TABLE IF NOT EXISTS signing_key (
+    id SERIAL PRIMARY KEY NOT NULL,
+    project_id int NOT NULL,
+    provider varchar(64) NOT NULL,
+    key_ref varchar(1024),
+    private_key text,
+    public_key text NOT NULL,
+    certificate text NOT NULL,
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    CONSTRAINT unique_signing_key_project_id UNIQUE (project_id)
+);
@@ -11,10 +11,13 @@ core_conf_template_path = os.path.join(templates_dir, "core", "app.conf.jinja")
 core_conf = os.path.join(config_dir, "core", "app.conf")
 
 ca_download_dir = os.path.join(data_dir, 'ca_download')
+# the keys of the "file" KMS plugin signing the artifacts, under the sub directory named by the project ID
+signing_keys_dir = os.path.join(data_dir, 'secret', 'signing')
 
 
 def prepare_core(config_dict, with_trivy):
     prepare_dir(ca_download_dir, uid=DEFAULT_UID, gid=DEFAULT_GID)
+    prepare_dir(signing_keys_dir, uid=DEFAULT_UID, gid=DEFAULT_GID, mode=0o700)
     prepare_dir(core_config_dir)
     # Render Core
 
@@ -129,6 +129,7 @@ const (
 	GroupMember                       = "g"
 	ReadOnly                          = "read_only"
 	TrivyAdapterURL                   = "trivy_adapter_url"
+	AdmissionPolicyEngineURL          = "admission_policy_engine_url"
 	DefaultCoreEndpoint               = "http://core:8080"
 	LDAPGroupType                     = 1
 	HTTPGroupType                     = 2
@@ -221,6 +222,12 @@ const (
 	MaxAuditRetentionHour = 240000
 	// ScannerSkipUpdatePullTime
 	ScannerSkipUpdatePullTime = "scanner_skip_update_pulltime"
+	// ScanOnDBUpdateEnabled enables to rescan the artifacts when the vulnerability database of the scanner updates
+	ScanOnDBUpdateEnabled = "scan_on_db_update_enabled"
+	// ScanOnDBUpdatePulledWithinDays only the artifacts pulled within the days are rescanned when the database updates
+	ScanOnDBUpdatePulledWithinDays = "scan_on_db_update_pulled_within_days"
+	// VulnerabilitySLADays the days to fix the vulnerabilities of each severity
+	VulnerabilitySLADays = "vulnerability_sla_days"
 
 	// AuditLogEventsDisabled ...
 	AuditLogEventsDisabled = "disabled_audit_log_event_types"
@@ -235,6 +242,17 @@ const (
 	UIMaxLengthLimitedOfNumber = 10
 	// ExecutionStatusRefreshIntervalSeconds is the interval seconds for refreshing execution status
 	ExecutionStatusRefreshIntervalSeconds = "execution_status_refresh_interval_seconds"
+	// TaskReconcileIntervalSeconds is the interval seconds for reconciling the stuck tasks with jobservice
+	TaskReconcileIntervalSeconds = "task_reconcile_interval_seconds"
+	// TaskHeartbeatTimeoutSeconds is the seconds after which the running task without heartbeat is considered as stuck
+	TaskHeartbeatTimeoutSeconds = "task_heartbeat_timeout_seconds"
+	// TaskMaxRelaunches is the max times that a stuck task of the idempotent vendor type can be re-launched
+	TaskMaxRelaunches = "task_max_relaunches"
+	// CosignFulcioRootsPath is the path of the PEM file holding the Fulcio root and intermediate certificates trusted
+	// by the cosign keyless verification
+	CosignFulcioRootsPath = "cosign_fulcio_roots_path"
+	// CosignRekorPublicKeysPath is the path of the PEM file holding the Rekor public keys trusted by the cosign keyless verification
+	CosignRekorPublicKeysPath = "cosign_rekor_public_keys_path"
 	// QuotaUpdateProvider is the provider for updating quota, currently support Redis and DB
 	QuotaUpdateProvider = "quota_update_provider"
 	// IllegalCharsInUsername is the illegal chars in username
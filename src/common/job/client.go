@@ -16,6 +16,7 @@ package job
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -46,7 +47,12 @@ var (
 type Client interface {
 	SubmitJob(*models.JobData) (string, error)
 	GetJobLog(uuid string) ([]byte, error)
+	// FollowJobLog returns the log of the job as a stream of Server-Sent Events which ends
+	// when the job is done or the context is canceled, the caller is responsible for closing the stream
+	FollowJobLog(ctx context.Context, uuid string, offset int64) (io.ReadCloser, error)
 	PostAction(uuid, action string) error
+	// GetJobStats returns the stats of the job, ErrJobNotFound is returned if the job doesn't exist in jobservice
+	GetJobStats(uuid string) (*job.Stats, error)
 	GetExecutions(uuid string) ([]job.Stats, error)
 	// TODO Redirect joblog when we see there's memory issue.
 	// GetJobServiceConfig retrieves the job config
@@ -176,6 +182,64 @@ func (d *DefaultClient) GetJobLog(uuid string) ([]byte, error) {
 	return data, nil
 }
 
+// FollowJobLog call jobservice API to follow the log of a job from the offset.  It only accepts the UUID of the job
+func (d *DefaultClient) FollowJobLog(ctx context.Context, uuid string, offset int64) (io.ReadCloser, error) {
+	url := fmt.Sprintf("%s/api/v1/jobs/%s/log?follow=true&offset=%d", d.endpoint, uuid, offset)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, &commonhttp.Error{
+			Code:    resp.StatusCode,
+			Message: string(data),
+		}
+	}
+	return resp.Body, nil
+}
+
+// GetJobStats call jobservice API to get the stats of a job.  It only accepts the UUID of the job
+func (d *DefaultClient) GetJobStats(uuid string) (*job.Stats, error) {
+	url := d.endpoint + "/api/v1/jobs/" + uuid
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrJobNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &commonhttp.Error{
+			Code:    resp.StatusCode,
+			Message: string(data),
+		}
+	}
+	stats := &job.Stats{}
+	if err = json.Unmarshal(data, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
 // GetExecutions ...
 func (d *DefaultClient) GetExecutions(periodicJobID string) ([]job.Stats, error) {
 	url := fmt.Sprintf("%s/api/v1/jobs/%s/executions?page_number=1&page_size=100", d.endpoint, periodicJobID)
@@ -50,6 +50,18 @@ func TestGetJobLog(t *testing.T) {
 	assert.Contains(text, "The content in this file is for mocking the get log api.")
 }
 
+func TestGetJobStats(t *testing.T) {
+	assert := assert.New(t)
+	_, err := testClient.GetJobStats("non")
+	assert.Equal(ErrJobNotFound, err)
+
+	stats, err := testClient.GetJobStats(ID)
+	assert.Nil(err)
+	assert.Equal(ID, stats.Info.JobID)
+	assert.Equal("Running", stats.Info.Status)
+	assert.NotZero(stats.Info.HeartbeatAt)
+}
+
 func TestGetExecutions(t *testing.T) {
 	assert := assert.New(t)
 	exes, err := testClient.GetExecutions(ID)
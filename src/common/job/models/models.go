@@ -14,6 +14,10 @@
 
 package models
 
+import (
+	"github.com/goharbor/harbor/src/jobservice/job"
+)
+
 // Parameters for job execution.
 type Parameters map[string]any
 
@@ -36,6 +40,10 @@ type JobMetadata struct {
 	ScheduleDelay uint64 `json:"schedule_delay,omitempty"`
 	Cron          string `json:"cron_spec,omitempty"`
 	IsUnique      bool   `json:"unique"`
+	Tenant        string `json:"tenant,omitempty"`
+	ExecutionID   int64  `json:"execution_id,omitempty"`
+	// The timezone, jitter and blackout windows of the periodic job
+	ScheduleOptions *job.ScheduleOptions `json:"schedule_options,omitempty"`
 }
 
 // JobStats keeps the result of job launching.
@@ -86,6 +86,21 @@ func NewJobServiceServer() *httptest.Server {
 		})
 	mux.HandleFunc(fmt.Sprintf("%s/%s", jobsPrefix, jobUUID),
 		func(rw http.ResponseWriter, req *http.Request) {
+			if req.Method == http.MethodGet {
+				stat := job.Stats{
+					Info: &job.StatsInfo{
+						JobID:       jobUUID,
+						Status:      "Running",
+						RunAt:       time.Now().Unix(),
+						HeartbeatAt: time.Now().Unix(),
+					},
+				}
+				b, _ := json.Marshal(stat)
+				if _, err := rw.Write(b); err != nil {
+					panic(err)
+				}
+				return
+			}
 			if req.Method != http.MethodPost {
 				rw.WriteHeader(http.StatusMethodNotAllowed)
 				return
@@ -50,10 +50,14 @@ const (
 	ResourceRepository         = Resource("repository")
 	ResourceTagRetention       = Resource("tag-retention")
 	ResourceImmutableTag       = Resource("immutable-tag")
+	ResourceAdmissionPolicy    = Resource("admission-policy")
 	ResourceRobot              = Resource("robot")
 	ResourceNotificationPolicy = Resource("notification-policy")
 	ResourceScan               = Resource("scan")
 	ResourceSBOM               = Resource("sbom")
+	ResourceVEX                = Resource("vex")
+	ResourceRemediation        = Resource("remediation")
+	ResourceSigningKey         = Resource("signing-key")
 	ResourceScanner            = Resource("scanner")
 	ResourceArtifact           = Resource("artifact")
 	ResourceTag                = Resource("tag")
@@ -213,6 +217,7 @@ var (
 
 			{Resource: ResourceJobServiceMonitor, Action: ActionList},
 			{Resource: ResourceJobServiceMonitor, Action: ActionStop},
+			{Resource: ResourceJobServiceMonitor, Action: ActionUpdate},
 
 			{Resource: ResourceScanner, Action: ActionRead},
 			{Resource: ResourceScanner, Action: ActionCreate},
@@ -232,6 +237,12 @@ var (
 
 			{Resource: ResourceQuota, Action: ActionRead},
 			{Resource: ResourceQuota, Action: ActionList},
+
+			{Resource: ResourceAdmissionPolicy, Action: ActionCreate},
+			{Resource: ResourceAdmissionPolicy, Action: ActionDelete},
+			{Resource: ResourceAdmissionPolicy, Action: ActionList},
+			{Resource: ResourceAdmissionPolicy, Action: ActionRead},
+			{Resource: ResourceAdmissionPolicy, Action: ActionUpdate},
 		},
 		ScopeProject: {
 			{Resource: ResourceLog, Action: ActionList},
@@ -266,6 +277,13 @@ var (
 			{Resource: ResourceSBOM, Action: ActionStop},
 			{Resource: ResourceSBOM, Action: ActionRead},
 
+			{Resource: ResourceVEX, Action: ActionCreate},
+			{Resource: ResourceVEX, Action: ActionRead},
+			{Resource: ResourceVEX, Action: ActionList},
+			{Resource: ResourceVEX, Action: ActionDelete},
+
+			{Resource: ResourceSigningKey, Action: ActionRead},
+
 			{Resource: ResourceTag, Action: ActionCreate},
 			{Resource: ResourceTag, Action: ActionList},
 			{Resource: ResourceTag, Action: ActionDelete},
@@ -291,6 +309,9 @@ var (
 			{Resource: ResourceImmutableTag, Action: ActionList},
 			{Resource: ResourceImmutableTag, Action: ActionUpdate},
 
+			{Resource: ResourceAdmissionPolicy, Action: ActionList},
+			{Resource: ResourceAdmissionPolicy, Action: ActionRead},
+
 			{Resource: ResourceNotificationPolicy, Action: ActionRead},
 			{Resource: ResourceNotificationPolicy, Action: ActionCreate},
 			{Resource: ResourceNotificationPolicy, Action: ActionDelete},
@@ -68,6 +68,9 @@ var (
 			{Resource: rbac.ResourceImmutableTag, Action: rbac.ActionDelete},
 			{Resource: rbac.ResourceImmutableTag, Action: rbac.ActionList},
 
+			{Resource: rbac.ResourceAdmissionPolicy, Action: rbac.ActionRead},
+			{Resource: rbac.ResourceAdmissionPolicy, Action: rbac.ActionList},
+
 			{Resource: rbac.ResourceConfiguration, Action: rbac.ActionRead},
 			{Resource: rbac.ResourceConfiguration, Action: rbac.ActionUpdate},
 
@@ -89,6 +92,16 @@ var (
 			{Resource: rbac.ResourceSBOM, Action: rbac.ActionCreate},
 			{Resource: rbac.ResourceSBOM, Action: rbac.ActionStop},
 			{Resource: rbac.ResourceSBOM, Action: rbac.ActionRead},
+			{Resource: rbac.ResourceVEX, Action: rbac.ActionCreate},
+			{Resource: rbac.ResourceVEX, Action: rbac.ActionRead},
+			{Resource: rbac.ResourceVEX, Action: rbac.ActionList},
+			{Resource: rbac.ResourceVEX, Action: rbac.ActionDelete},
+			{Resource: rbac.ResourceRemediation, Action: rbac.ActionCreate},
+			{Resource: rbac.ResourceRemediation, Action: rbac.ActionUpdate},
+			{Resource: rbac.ResourceRemediation, Action: rbac.ActionDelete},
+			{Resource: rbac.ResourceSigningKey, Action: rbac.ActionCreate},
+			{Resource: rbac.ResourceSigningKey, Action: rbac.ActionRead},
+			{Resource: rbac.ResourceSigningKey, Action: rbac.ActionDelete},
 
 			{Resource: rbac.ResourceScanner, Action: rbac.ActionRead},
 			{Resource: rbac.ResourceScanner, Action: rbac.ActionCreate},
@@ -157,6 +170,9 @@ var (
 			{Resource: rbac.ResourceImmutableTag, Action: rbac.ActionDelete},
 			{Resource: rbac.ResourceImmutableTag, Action: rbac.ActionList},
 
+			{Resource: rbac.ResourceAdmissionPolicy, Action: rbac.ActionRead},
+			{Resource: rbac.ResourceAdmissionPolicy, Action: rbac.ActionList},
+
 			{Resource: rbac.ResourceConfiguration, Action: rbac.ActionRead},
 
 			{Resource: rbac.ResourceRobot, Action: rbac.ActionRead},
@@ -171,6 +187,14 @@ var (
 			{Resource: rbac.ResourceSBOM, Action: rbac.ActionCreate},
 			{Resource: rbac.ResourceSBOM, Action: rbac.ActionStop},
 			{Resource: rbac.ResourceSBOM, Action: rbac.ActionRead},
+			{Resource: rbac.ResourceVEX, Action: rbac.ActionCreate},
+			{Resource: rbac.ResourceVEX, Action: rbac.ActionRead},
+			{Resource: rbac.ResourceVEX, Action: rbac.ActionList},
+			{Resource: rbac.ResourceVEX, Action: rbac.ActionDelete},
+			{Resource: rbac.ResourceRemediation, Action: rbac.ActionCreate},
+			{Resource: rbac.ResourceRemediation, Action: rbac.ActionUpdate},
+			{Resource: rbac.ResourceRemediation, Action: rbac.ActionDelete},
+			{Resource: rbac.ResourceSigningKey, Action: rbac.ActionRead},
 
 			{Resource: rbac.ResourceScanner, Action: rbac.ActionRead},
 
@@ -224,6 +248,9 @@ var (
 
 			{Resource: rbac.ResourceScan, Action: rbac.ActionRead},
 			{Resource: rbac.ResourceSBOM, Action: rbac.ActionRead},
+			{Resource: rbac.ResourceVEX, Action: rbac.ActionRead},
+			{Resource: rbac.ResourceVEX, Action: rbac.ActionList},
+			{Resource: rbac.ResourceSigningKey, Action: rbac.ActionRead},
 
 			{Resource: rbac.ResourceScanner, Action: rbac.ActionRead},
 
@@ -267,6 +294,9 @@ var (
 
 			{Resource: rbac.ResourceScan, Action: rbac.ActionRead},
 			{Resource: rbac.ResourceSBOM, Action: rbac.ActionRead},
+			{Resource: rbac.ResourceVEX, Action: rbac.ActionRead},
+			{Resource: rbac.ResourceVEX, Action: rbac.ActionList},
+			{Resource: rbac.ResourceSigningKey, Action: rbac.ActionRead},
 
 			{Resource: rbac.ResourceScanner, Action: rbac.ActionRead},
 
@@ -291,6 +321,9 @@ var (
 
 			{Resource: rbac.ResourceScan, Action: rbac.ActionRead},
 			{Resource: rbac.ResourceSBOM, Action: rbac.ActionRead},
+			{Resource: rbac.ResourceVEX, Action: rbac.ActionRead},
+			{Resource: rbac.ResourceVEX, Action: rbac.ActionList},
+			{Resource: rbac.ResourceSigningKey, Action: rbac.ActionRead},
 
 			{Resource: rbac.ResourceScanner, Action: rbac.ActionRead},
 
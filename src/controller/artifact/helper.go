@@ -59,3 +59,45 @@ func Iterator(ctx context.Context, chunkSize int, query *q.Query, option *Option
 
 	return ch
 }
+
+// KeysetIterator returns the iterator to fetch all artifacts with query in the order of ID. The artifacts are read
+// page by page after the last ID read rather than by the page number, so the pages aren't shifted by the artifacts
+// created, deleted or updated during the iteration.
+func KeysetIterator(ctx context.Context, chunkSize int, query *q.Query, option *Option) <-chan *Artifact {
+	ch := make(chan *Artifact, chunkSize)
+
+	go func() {
+		defer close(ch)
+
+		clone := q.MustClone(query)
+		clone.PageNumber = 1
+		clone.PageSize = int64(chunkSize)
+		clone.Sorts = []*q.Sort{q.NewSort("id", false)}
+
+		for {
+			artifacts, err := Ctl.List(ctx, clone, option)
+			if err != nil {
+				log.G(ctx).Errorf("list artifacts failed, error: %v", err)
+				return
+			}
+
+			for _, artifact := range artifacts {
+				select {
+				case <-ctx.Done():
+					log.G(ctx).Errorf("context done, list artifacts exited, error: %v", ctx.Err())
+					return
+				case ch <- artifact:
+					continue
+				}
+			}
+
+			if len(artifacts) < chunkSize {
+				break
+			}
+
+			clone.Keywords["id"] = &q.Range{Min: artifacts[len(artifacts)-1].ID + 1}
+		}
+	}()
+
+	return ch
+}
@@ -80,6 +80,30 @@ func (suite *IteratorTestSuite) TestIterator() {
 	suite.Len(artifacts, 8)
 }
 
+func (suite *IteratorTestSuite) TestKeysetIterator() {
+	suite.accMgr.On("List", mock.Anything, mock.Anything).Return([]accessorymodel.Accessory{}, nil)
+	sorts := []*q.Sort{q.NewSort("id", false)}
+	q1 := &q.Query{PageNumber: 1, PageSize: 3, Keywords: map[string]any{"project_id": 1}, Sorts: sorts}
+	suite.artMgr.On("List", mock.Anything, q1).Return([]*artifact.Artifact{
+		{ID: 11},
+		{ID: 12},
+		{ID: 13},
+	}, nil)
+
+	q2 := &q.Query{PageNumber: 1, PageSize: 3, Keywords: map[string]any{"project_id": 1, "id": &q.Range{Min: int64(14)}}, Sorts: sorts}
+	suite.artMgr.On("List", mock.Anything, q2).Return([]*artifact.Artifact{
+		{ID: 15},
+	}, nil)
+
+	var artifacts []*Artifact
+	for art := range KeysetIterator(context.TODO(), 3, q.New(q.KeyWords{"project_id": 1}), nil) {
+		artifacts = append(artifacts, art)
+	}
+
+	suite.Len(artifacts, 4)
+	suite.Equal(int64(15), artifacts[3].ID)
+}
+
 func TestIteratorTestSuite(t *testing.T) {
 	suite.Run(t, &IteratorTestSuite{})
 }
@@ -0,0 +1,126 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/goharbor/harbor/src/controller/artifact/processor"
+	"github.com/goharbor/harbor/src/controller/artifact/processor/base"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+)
+
+const (
+	// ArtifactTypeAttestation is the artifact type for in-toto attestation
+	ArtifactTypeAttestation = "ATTESTATION"
+	// AdditionTypeAttestation is the addition type for the in-toto statement of the attestation
+	AdditionTypeAttestation = "ATTESTATION"
+	// mediaTypeInToto is the media type of the plain in-toto statement
+	mediaTypeInToto = "application/vnd.in-toto+json"
+	// mediaTypeDSSEEnvelope is the media type of the DSSE envelope wrapping the in-toto statement
+	mediaTypeDSSEEnvelope = "application/vnd.dsse.envelope.v1+json"
+)
+
+func init() {
+	pc := &Processor{}
+	pc.ManifestProcessor = base.NewManifestProcessor()
+	if err := processor.Register(pc, mediaTypeInToto, mediaTypeDSSEEnvelope); err != nil {
+		log.Errorf("failed to register processor for attestation: %v", err)
+		return
+	}
+}
+
+// envelope is the DSSE envelope, only the fields needed to extract the statement are declared
+type envelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+}
+
+// Processor is the processor for in-toto attestation
+type Processor struct {
+	*base.ManifestProcessor
+}
+
+// AbstractAddition returns the in-toto statement of the attestation,
+// the statement is extracted from the DSSE envelope if the attestation is signed
+func (m *Processor) AbstractAddition(_ context.Context, art *artifact.Artifact, _ string) (*processor.Addition, error) {
+	man, _, err := m.RegCli.PullManifest(art.RepositoryName, art.Digest)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to pull manifest")
+	}
+	_, payload, err := man.Payload()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get payload")
+	}
+	manifest := &v1.Manifest{}
+	if err := json.Unmarshal(payload, manifest); err != nil {
+		return nil, err
+	}
+	// attestation artifact should only have one layer
+	if len(manifest.Layers) != 1 {
+		return nil, errors.New(nil).WithCode(errors.NotFoundCode).WithMessage("The attestation is not found")
+	}
+	_, blob, err := m.RegCli.PullBlob(art.RepositoryName, manifest.Layers[0].Digest.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to pull the blob")
+	}
+	defer blob.Close()
+	content, err := io.ReadAll(blob)
+	if err != nil {
+		return nil, err
+	}
+	statement, err := extractStatement(content)
+	if err != nil {
+		return nil, err
+	}
+	return &processor.Addition{
+		Content:     statement,
+		ContentType: mediaTypeInToto,
+	}, nil
+}
+
+// extractStatement returns the in-toto statement carried by the content,
+// the content is returned directly when it isn't a DSSE envelope
+func extractStatement(content []byte) ([]byte, error) {
+	env := &envelope{}
+	if err := json.Unmarshal(content, env); err != nil {
+		return nil, errors.BadRequestError(err).WithMessage("the attestation isn't a valid JSON document")
+	}
+	if len(env.PayloadType) == 0 || len(env.Payload) == 0 {
+		return content, nil
+	}
+	statement, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return nil, errors.BadRequestError(err).WithMessage("failed to decode the payload of the DSSE envelope")
+	}
+	return statement, nil
+}
+
+// ListAdditionTypes returns the supported addition types
+func (m *Processor) ListAdditionTypes(_ context.Context, _ *artifact.Artifact) []string {
+	return []string{AdditionTypeAttestation}
+}
+
+// GetArtifactType the artifact type is used to display the artifact type in the UI
+func (m *Processor) GetArtifactType(_ context.Context, _ *artifact.Artifact) string {
+	return ArtifactTypeAttestation
+}
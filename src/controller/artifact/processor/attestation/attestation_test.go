@@ -0,0 +1,116 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/docker/distribution"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/artifact/processor/base"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/testing/pkg/registry"
+)
+
+const (
+	manContent = `{
+    "schemaVersion": 2,
+    "config": {
+        "mediaType": "application/vnd.oci.empty.v1+json",
+        "digest": "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a",
+        "size": 2
+    },
+    "layers": [
+    {
+      "mediaType": "application/vnd.in-toto+json",
+      "size": 120,
+      "digest": "sha256:abc"
+    }]
+}`
+	statement = `{"_type":"https://in-toto.io/Statement/v1","predicateType":"https://slsa.dev/provenance/v1"}`
+)
+
+type AttestationProcessorTestSuite struct {
+	suite.Suite
+	processor *Processor
+	regCli    *registry.Client
+}
+
+func (suite *AttestationProcessorTestSuite) SetupTest() {
+	suite.regCli = &registry.Client{}
+	suite.processor = &Processor{
+		&base.ManifestProcessor{
+			RegCli: suite.regCli,
+		},
+	}
+}
+
+func (suite *AttestationProcessorTestSuite) mockPull(content string) {
+	mani, _, err := distribution.UnmarshalManifest(v1.MediaTypeImageManifest, []byte(manContent))
+	suite.Require().NoError(err)
+	suite.regCli.On("PullManifest", mock.Anything, mock.Anything).Return(mani, "sha256:123", nil).Once()
+	suite.regCli.On("PullBlob", mock.Anything, mock.Anything).Return(int64(len(content)), io.NopCloser(strings.NewReader(content)), nil).Once()
+}
+
+func (suite *AttestationProcessorTestSuite) TestAbstractAdditionStatement() {
+	suite.mockPull(statement)
+	addition, err := suite.processor.AbstractAddition(context.Background(), &artifact.Artifact{RepositoryName: "repo", Digest: "digest"}, AdditionTypeAttestation)
+	suite.Require().NoError(err)
+	suite.Equal(statement, string(addition.Content))
+	suite.Equal(mediaTypeInToto, addition.ContentType)
+}
+
+func (suite *AttestationProcessorTestSuite) TestAbstractAdditionEnvelope() {
+	env := fmt.Sprintf(`{"payloadType":"application/vnd.in-toto+json","payload":"%s","signatures":[{"keyid":"","sig":"c2ln"}]}`,
+		base64.StdEncoding.EncodeToString([]byte(statement)))
+	suite.mockPull(env)
+	addition, err := suite.processor.AbstractAddition(context.Background(), &artifact.Artifact{RepositoryName: "repo", Digest: "digest"}, AdditionTypeAttestation)
+	suite.Require().NoError(err)
+	suite.Equal(statement, string(addition.Content))
+	suite.Equal(mediaTypeInToto, addition.ContentType)
+}
+
+func (suite *AttestationProcessorTestSuite) TestAbstractAdditionInvalidEnvelope() {
+	suite.mockPull(`{"payloadType":"application/vnd.in-toto+json","payload":"%%%"}`)
+	_, err := suite.processor.AbstractAddition(context.Background(), &artifact.Artifact{RepositoryName: "repo", Digest: "digest"}, AdditionTypeAttestation)
+	suite.True(errors.IsErr(err, errors.BadRequestCode))
+}
+
+func (suite *AttestationProcessorTestSuite) TestAbstractAdditionPullManifestError() {
+	suite.regCli.On("PullManifest", mock.Anything, mock.Anything).Return(nil, "", errors.NotFoundError(fmt.Errorf("not found"))).Once()
+	_, err := suite.processor.AbstractAddition(context.Background(), &artifact.Artifact{RepositoryName: "repo", Digest: "digest"}, AdditionTypeAttestation)
+	suite.NotNil(err)
+}
+
+func (suite *AttestationProcessorTestSuite) TestListAdditionTypes() {
+	suite.Equal([]string{AdditionTypeAttestation}, suite.processor.ListAdditionTypes(context.Background(), &artifact.Artifact{}))
+}
+
+func (suite *AttestationProcessorTestSuite) TestGetArtifactType() {
+	suite.Equal(ArtifactTypeAttestation, suite.processor.GetArtifactType(context.Background(), &artifact.Artifact{}))
+}
+
+func TestAttestationProcessorTestSuite(t *testing.T) {
+	suite.Run(t, &AttestationProcessorTestSuite{})
+}
@@ -31,15 +31,21 @@ import (
 const (
 	// ArtifactTypeSBOM is the artifact type for SBOM, it's scope is only used in the processor
 	ArtifactTypeSBOM = "SBOM"
+	// AdditionTypeSBOM is the addition type for the content of SBOM
+	AdditionTypeSBOM = "SBOM"
 	// processorMediaType is the media type for SBOM, it's scope is only used to register the processor
 	processorMediaType = "application/vnd.goharbor.harbor.sbom.v1"
+	// the media types of the CycloneDX and SPDX SBOMs generated outside of Harbor
+	mediaTypeCycloneDX = "application/vnd.cyclonedx+json"
+	mediaTypeSPDX      = "application/spdx+json"
+	mediaTypeSPDXVnd   = "application/vnd.spdx+json"
 )
 
 func init() {
 	pc := &Processor{}
 	pc.ManifestProcessor = base.NewManifestProcessor()
-	if err := processor.Register(pc, processorMediaType); err != nil {
-		log.Errorf("failed to register processor for media type %s: %v", processorMediaType, err)
+	if err := processor.Register(pc, processorMediaType, mediaTypeCycloneDX, mediaTypeSPDX, mediaTypeSPDXVnd); err != nil {
+		log.Errorf("failed to register processor for SBOM: %v", err)
 		return
 	}
 }
@@ -77,12 +83,22 @@ func (m *Processor) AbstractAddition(_ context.Context, art *artifact.Artifact,
 	if err != nil {
 		return nil, err
 	}
+	// the artifact type is the media type of the SBOM which the processor is registered for
+	contentType := art.ResolveArtifactType()
+	if len(contentType) == 0 {
+		contentType = processorMediaType
+	}
 	return &processor.Addition{
 		Content:     content,
-		ContentType: processorMediaType,
+		ContentType: contentType,
 	}, nil
 }
 
+// ListAdditionTypes returns the supported addition types
+func (m *Processor) ListAdditionTypes(_ context.Context, _ *artifact.Artifact) []string {
+	return []string{AdditionTypeSBOM}
+}
+
 // GetArtifactType the artifact type is used to display the artifact type in the UI
 func (m *Processor) GetArtifactType(_ context.Context, _ *artifact.Artifact) string {
 	return ArtifactTypeSBOM
@@ -175,6 +175,7 @@ func verifyValueLengthCfg(_ context.Context, cfgs map[string]any) error {
 		common.TokenExpiration,
 		common.RobotTokenDuration,
 		common.SessionTimeout,
+		common.ScanOnDBUpdatePulledWithinDays,
 	}
 
 	for _, c := range validateCfgs {
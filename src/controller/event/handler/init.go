@@ -68,12 +68,15 @@ func init() {
 	_ = notifier.Subscribe(event.TopicCreateRobot, &auditlog.Handler{})
 	_ = notifier.Subscribe(event.TopicDeleteRobot, &auditlog.Handler{})
 	_ = notifier.Subscribe(event.TopicCommonEvent, &auditlog.Handler{})
+	_ = notifier.Subscribe(event.TopicAdmission, &auditlog.Handler{})
 
 	// internal
 	_ = notifier.Subscribe(event.TopicPullArtifact, &internal.ArtifactEventHandler{})
 	_ = notifier.Subscribe(event.TopicPushArtifact, &internal.ArtifactEventHandler{})
 	_ = notifier.Subscribe(event.TopicDeleteArtifact, &internal.ArtifactEventHandler{})
+	_ = notifier.Subscribe(event.TopicArtifactLabeled, &internal.ArtifactEventHandler{})
 	_ = notifier.Subscribe(event.TopicDeleteProject, &internal.ProjectEventHandler{})
+	_ = notifier.Subscribe(event.TopicScanningCompleted, &internal.ScanEventHandler{})
 
 	_ = task.RegisterTaskStatusChangePostFunc(job.ReplicationVendorType, func(ctx context.Context, taskID int64, status string) error {
 		notification.AddEvent(ctx, &metadata.ReplicationMetaData{
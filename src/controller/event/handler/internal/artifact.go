@@ -36,15 +36,19 @@ import (
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg"
 	pkgArt "github.com/goharbor/harbor/src/pkg/artifact"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 	"github.com/goharbor/harbor/src/pkg/scan/report"
 	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
 	"github.com/goharbor/harbor/src/pkg/scan/sbom"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom/component"
 	"github.com/goharbor/harbor/src/pkg/task"
 )
 
 const (
 	// defaultAsyncFlushDuration is the default flush interval.
 	defaultAsyncFlushDuration = 10 * time.Second
+	// promotedLabel is the name of the label which marks the artifact as promoted
+	promotedLabel = "promoted"
 )
 
 var (
@@ -107,6 +111,8 @@ func (a *ArtifactEventHandler) Handle(ctx context.Context, value any) error {
 		return a.onPush(ctx, v.ArtifactEvent)
 	case *event.DeleteArtifactEvent:
 		return a.onDelete(ctx, v.ArtifactEvent)
+	case *event.ArtifactLabeledEvent:
+		return a.onLabeled(ctx, v)
 	default:
 		log.Errorf("Can not handler this event type! %#v", v)
 	}
@@ -268,11 +274,41 @@ func (a *ArtifactEventHandler) onPush(ctx context.Context, event *event.Artifact
 		if err := autoGenSBOM(ctx, &artifact.Artifact{Artifact: *event.Artifact}); err != nil {
 			log.Errorf("generate sbom for artifact %s@%s failed, error: %v", event.Artifact.RepositoryName, event.Artifact.Digest, err)
 		}
+
+		if err := autoSign(ctx, &artifact.Artifact{Artifact: *event.Artifact}, proModels.AutoSignTriggerPush); err != nil {
+			log.Errorf("sign artifact %s@%s failed, error: %v", event.Artifact.RepositoryName, event.Artifact.Digest, err)
+		}
+
+		if err := indexSBOMs(ctx, &artifact.Artifact{Artifact: *event.Artifact}); err != nil {
+			log.Errorf("index the sboms of artifact %s@%s failed, error: %v", event.Artifact.RepositoryName, event.Artifact.Digest, err)
+		}
 	}()
 
 	return nil
 }
 
+// onLabeled signs the artifact when it is labeled as promoted
+func (a *ArtifactEventHandler) onLabeled(ctx context.Context, event *event.ArtifactLabeledEvent) error {
+	art, err := artifact.Ctl.Get(ctx, event.ArtifactID, &artifact.Option{WithLabel: true})
+	if err != nil {
+		return err
+	}
+	promoted := false
+	for _, label := range art.Labels {
+		if label.ID == event.LabelID && label.Name == promotedLabel {
+			promoted = true
+			break
+		}
+	}
+	if !promoted {
+		return nil
+	}
+	if event.Operator != "" {
+		ctx = context.WithValue(ctx, operator.ContextKey{}, event.Operator)
+	}
+	return autoSign(ctx, art, proModels.AutoSignTriggerPromotion)
+}
+
 func (a *ArtifactEventHandler) onDelete(ctx context.Context, event *event.ArtifactEvent) error {
 	execMgr := task.ExecMgr
 	reportMgr := report.Mgr
@@ -324,6 +360,14 @@ func (a *ArtifactEventHandler) onDelete(ctx context.Context, event *event.Artifa
 		log.Errorf("failed to delete scan reports of artifact %v, error: %v", unrefDigests, err)
 	}
 
+	// clean up the components indexed from the artifacts as SBOMs, the ones indexed for the artifacts as subjects
+	// are deleted with the artifacts
+	for _, digest := range unrefDigests {
+		if err := component.Mgr.DeleteBySource(ctx, digest); err != nil {
+			log.Errorf("failed to delete the components indexed from sbom %s, error: %v", digest, err)
+		}
+	}
+
 	// delete sbom_report when the subject artifact is deleted
 	if err := sbom.Mgr.DeleteByArtifactID(ctx, event.Artifact.ID); err != nil {
 		log.Errorf("failed to delete sbom reports of artifact ID %v, error: %v", event.Artifact.ID, err)
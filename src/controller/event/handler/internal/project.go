@@ -20,8 +20,11 @@ import (
 	"github.com/goharbor/harbor/src/controller/event"
 	"github.com/goharbor/harbor/src/controller/immutable"
 	"github.com/goharbor/harbor/src/controller/retention"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg/member"
+	"github.com/goharbor/harbor/src/pkg/securityhub"
+	"github.com/goharbor/harbor/src/pkg/signing"
 )
 
 // ProjectEventHandler process project event data
@@ -49,6 +52,12 @@ func (a *ProjectEventHandler) onProjectDelete(ctx context.Context, event *event.
 	if err := member.Mgr.DeleteMemberByProjectID(ctx, event.ProjectID); err != nil {
 		log.Errorf("failed to delete project member, error %v", err)
 	}
+	if err := securityhub.RemediationMgr.DeleteByProject(ctx, event.ProjectID); err != nil {
+		log.Errorf("failed to delete vulnerability remediations, error %v", err)
+	}
+	if err := signing.Mgr.Delete(ctx, event.ProjectID); err != nil && !errors.IsNotFoundErr(err) {
+		log.Errorf("failed to delete signing key, error %v", err)
+	}
 	return nil
 }
 
@@ -0,0 +1,78 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/controller/securityhub"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/orm"
+	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+)
+
+// reconcileDelay is the delay to reconcile the remediations of the project after the scan completes,
+// the scans of the project completed within the delay are reconciled once
+var reconcileDelay = time.Minute
+
+// ScanEventHandler process scan event data
+type ScanEventHandler struct {
+	mu sync.Mutex
+	// the projects waiting to be reconciled
+	pending map[int64]struct{}
+}
+
+// Name return the name of this handler
+func (s *ScanEventHandler) Name() string {
+	return "InternalScan"
+}
+
+// IsStateful return false
+func (s *ScanEventHandler) IsStateful() bool {
+	return false
+}
+
+// Handle reconciles the vulnerability remediations of the project when the vulnerability scan completes,
+// the reconciliation is delayed to merge the scans completed together, e.g. the scan of all artifacts
+func (s *ScanEventHandler) Handle(_ context.Context, value any) error {
+	e, ok := value.(*event.ScanImageEvent)
+	if !ok || e.Artifact == nil || e.ScanType == v1.ScanTypeSbom {
+		return nil
+	}
+
+	projectID := e.Artifact.NamespaceID
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pending == nil {
+		s.pending = map[int64]struct{}{}
+	}
+	if _, exist := s.pending[projectID]; exist {
+		return nil
+	}
+	s.pending[projectID] = struct{}{}
+	time.AfterFunc(reconcileDelay, func() {
+		s.mu.Lock()
+		delete(s.pending, projectID)
+		s.mu.Unlock()
+
+		if err := securityhub.Ctl.ReconcileRemediations(orm.Context(), projectID); err != nil {
+			log.Errorf("failed to reconcile the remediations of project %d: %v", projectID, err)
+		}
+	})
+	return nil
+}
@@ -20,9 +20,14 @@ import (
 	"github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/controller/scan"
+	"github.com/goharbor/harbor/src/controller/signing"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/accessory"
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
 	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom/component"
 )
 
 // autoScan scan artifact when the project of the artifact enable auto scan
@@ -62,3 +67,56 @@ func autoGenSBOM(ctx context.Context, a *artifact.Artifact) error {
 		return scan.DefaultController.Scan(ctx, a, options...)
 	})(orm.SetTransactionOpNameToContext(ctx, "tx-auto-gen-sbom"))
 }
+
+// autoSign signs the artifact when the project of the artifact enables auto signing with the trigger
+func autoSign(ctx context.Context, a *artifact.Artifact, trigger string) error {
+	proj, err := project.Ctl.Get(ctx, a.ProjectID)
+	if err != nil {
+		return err
+	}
+	if !proj.AutoSign() || proj.AutoSignTrigger() != trigger {
+		return nil
+	}
+	// the accessories(signatures, SBOMs, etc.) are not signed
+	count, err := accessory.Mgr.Count(ctx, q.New(q.KeyWords{"ArtifactID": a.ID}))
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	log.Debugf("auto signing is triggered by %s for artifact %s@%s", trigger, a.RepositoryName, a.Digest)
+	return signing.Ctl.Sign(ctx, a, proj.AutoSignFormat())
+}
+
+// indexSBOMs indexes the components of the SBOMs pushed as the accessories, the artifact is either the SBOM
+// pushed for an existing subject or the subject which the SBOMs are pushed for before it. The SBOM generated
+// by Harbor is indexed when the generation completes, so it's skipped here.
+func indexSBOMs(ctx context.Context, a *artifact.Artifact) error {
+	accs, err := accessory.Mgr.List(ctx, q.New(q.KeyWords{"ArtifactID": a.ID}))
+	if err != nil {
+		return err
+	}
+	subjectOf, err := accessory.Mgr.List(ctx, q.New(q.KeyWords{
+		"SubjectArtifactDigest": a.Digest,
+		"SubjectArtifactRepo":   a.RepositoryName,
+	}))
+	if err != nil {
+		return err
+	}
+
+	for _, acc := range append(accs, subjectOf...) {
+		data := acc.GetData()
+		if data.Type != model.TypeCycloneDXSBOM && data.Type != model.TypeSPDXSBOM {
+			continue
+		}
+		// the subject isn't pushed yet
+		if data.SubArtifactID == 0 {
+			continue
+		}
+		if _, err := component.Mgr.IndexAccessory(ctx, data.SubArtifactID, data.SubArtifactRepo, data.Digest); err != nil {
+			log.Warningf("failed to index the components of SBOM %s@%s, error: %v", data.SubArtifactRepo, data.Digest, err)
+		}
+	}
+	return nil
+}
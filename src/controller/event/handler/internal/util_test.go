@@ -15,6 +15,7 @@
 package internal
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -23,14 +24,23 @@ import (
 	"github.com/goharbor/harbor/src/controller/event"
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/controller/scan"
+	"github.com/goharbor/harbor/src/controller/signing"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/accessory"
+	accessoryModel "github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/pkg/accessory/model/base"
 	pkg "github.com/goharbor/harbor/src/pkg/artifact"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom/component"
 	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
 	scantesting "github.com/goharbor/harbor/src/testing/controller/scan"
+	signingtesting "github.com/goharbor/harbor/src/testing/controller/signing"
 	ormtesting "github.com/goharbor/harbor/src/testing/lib/orm"
 	"github.com/goharbor/harbor/src/testing/mock"
+	accessorytesting "github.com/goharbor/harbor/src/testing/pkg/accessory"
+	componenttesting "github.com/goharbor/harbor/src/testing/pkg/scan/sbom/component"
 )
 
 type AutoScanTestSuite struct {
@@ -158,3 +168,147 @@ func (suite *AutoScanTestSuite) TestWithArtifactEvent() {
 func TestAutoScanTestSuite(t *testing.T) {
 	suite.Run(t, &AutoScanTestSuite{})
 }
+
+type AutoSignTestSuite struct {
+	suite.Suite
+
+	originalProjectController project.Controller
+	projectController         *projecttesting.Controller
+
+	originalSigningController signing.Controller
+	signingController         *signingtesting.Controller
+
+	originalAccessoryManager accessory.Manager
+	accessoryManager         *accessorytesting.Manager
+}
+
+func (suite *AutoSignTestSuite) SetupTest() {
+	suite.originalProjectController = project.Ctl
+	suite.projectController = &projecttesting.Controller{}
+	project.Ctl = suite.projectController
+
+	suite.originalSigningController = signing.Ctl
+	suite.signingController = &signingtesting.Controller{}
+	signing.Ctl = suite.signingController
+
+	suite.originalAccessoryManager = accessory.Mgr
+	suite.accessoryManager = &accessorytesting.Manager{}
+	accessory.Mgr = suite.accessoryManager
+}
+
+func (suite *AutoSignTestSuite) TearDownTest() {
+	project.Ctl = suite.originalProjectController
+	signing.Ctl = suite.originalSigningController
+	accessory.Mgr = suite.originalAccessoryManager
+}
+
+func (suite *AutoSignTestSuite) TestAutoSignDisabled() {
+	mock.OnAnything(suite.projectController, "Get").Return(&proModels.Project{
+		Metadata: map[string]string{
+			proModels.ProMetaAutoSign: "false",
+		},
+	}, nil)
+
+	suite.Nil(autoSign(orm.NewContext(nil, &ormtesting.FakeOrmer{}), &artifact.Artifact{}, proModels.AutoSignTriggerPush))
+	suite.signingController.AssertNotCalled(suite.T(), "Sign", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *AutoSignTestSuite) TestAutoSignOtherTrigger() {
+	mock.OnAnything(suite.projectController, "Get").Return(&proModels.Project{
+		Metadata: map[string]string{
+			proModels.ProMetaAutoSign:        "true",
+			proModels.ProMetaAutoSignTrigger: proModels.AutoSignTriggerPromotion,
+		},
+	}, nil)
+
+	suite.Nil(autoSign(orm.NewContext(nil, &ormtesting.FakeOrmer{}), &artifact.Artifact{}, proModels.AutoSignTriggerPush))
+	suite.signingController.AssertNotCalled(suite.T(), "Sign", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *AutoSignTestSuite) TestAutoSignAccessory() {
+	mock.OnAnything(suite.projectController, "Get").Return(&proModels.Project{
+		Metadata: map[string]string{
+			proModels.ProMetaAutoSign: "true",
+		},
+	}, nil)
+	mock.OnAnything(suite.accessoryManager, "Count").Return(int64(1), nil)
+
+	suite.Nil(autoSign(orm.NewContext(nil, &ormtesting.FakeOrmer{}), &artifact.Artifact{}, proModels.AutoSignTriggerPush))
+	suite.signingController.AssertNotCalled(suite.T(), "Sign", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *AutoSignTestSuite) TestAutoSign() {
+	mock.OnAnything(suite.projectController, "Get").Return(&proModels.Project{
+		Metadata: map[string]string{
+			proModels.ProMetaAutoSign:        "true",
+			proModels.ProMetaAutoSignFormat:  "notation",
+			proModels.ProMetaAutoSignTrigger: proModels.AutoSignTriggerPromotion,
+		},
+	}, nil)
+	mock.OnAnything(suite.accessoryManager, "Count").Return(int64(0), nil)
+	suite.signingController.On("Sign", mock.Anything, mock.Anything, "notation").Return(nil).Once()
+
+	suite.Nil(autoSign(orm.NewContext(nil, &ormtesting.FakeOrmer{}), &artifact.Artifact{}, proModels.AutoSignTriggerPromotion))
+	suite.signingController.AssertExpectations(suite.T())
+}
+
+func TestAutoSignTestSuite(t *testing.T) {
+	suite.Run(t, &AutoSignTestSuite{})
+}
+
+type IndexSBOMTestSuite struct {
+	suite.Suite
+
+	originalAccessoryManager accessory.Manager
+	accessoryManager         *accessorytesting.Manager
+
+	originalComponentManager component.Manager
+	componentManager         *componenttesting.Manager
+}
+
+func (suite *IndexSBOMTestSuite) SetupTest() {
+	suite.originalAccessoryManager = accessory.Mgr
+	suite.accessoryManager = &accessorytesting.Manager{}
+	accessory.Mgr = suite.accessoryManager
+
+	suite.originalComponentManager = component.Mgr
+	suite.componentManager = &componenttesting.Manager{}
+	component.Mgr = suite.componentManager
+}
+
+func (suite *IndexSBOMTestSuite) TearDownTest() {
+	accessory.Mgr = suite.originalAccessoryManager
+	component.Mgr = suite.originalComponentManager
+}
+
+func (suite *IndexSBOMTestSuite) TestIndexSBOMs() {
+	art := &artifact.Artifact{Artifact: pkg.Artifact{ID: 1, RepositoryName: "library/hello", Digest: "sha256:subject"}}
+	suite.accessoryManager.On("List", mock.Anything, q.New(q.KeyWords{"ArtifactID": int64(1)})).Return(nil, nil)
+	suite.accessoryManager.On("List", mock.Anything, q.New(q.KeyWords{
+		"SubjectArtifactDigest": "sha256:subject",
+		"SubjectArtifactRepo":   "library/hello",
+	})).Return([]accessoryModel.Accessory{
+		&base.Default{Data: accessoryModel.AccessoryData{Type: accessoryModel.TypeCycloneDXSBOM, Digest: "sha256:cyclonedx", SubArtifactID: 1, SubArtifactRepo: "library/hello"}},
+		&base.Default{Data: accessoryModel.AccessoryData{Type: accessoryModel.TypeCosignSignature, Digest: "sha256:cosign", SubArtifactID: 1, SubArtifactRepo: "library/hello"}},
+		&base.Default{Data: accessoryModel.AccessoryData{Type: accessoryModel.TypeHarborSBOM, Digest: "sha256:harbor", SubArtifactID: 1, SubArtifactRepo: "library/hello"}},
+	}, nil)
+	suite.componentManager.On("IndexAccessory", mock.Anything, int64(1), "library/hello", "sha256:cyclonedx").Return(2, nil).Once()
+
+	suite.Nil(indexSBOMs(context.TODO(), art))
+	suite.componentManager.AssertExpectations(suite.T())
+}
+
+func (suite *IndexSBOMTestSuite) TestIndexSBOMsWithoutSubject() {
+	art := &artifact.Artifact{Artifact: pkg.Artifact{ID: 2, RepositoryName: "library/hello", Digest: "sha256:spdx"}}
+	suite.accessoryManager.On("List", mock.Anything, q.New(q.KeyWords{"ArtifactID": int64(2)})).Return([]accessoryModel.Accessory{
+		&base.Default{Data: accessoryModel.AccessoryData{Type: accessoryModel.TypeSPDXSBOM, Digest: "sha256:spdx", SubArtifactRepo: "library/hello"}},
+	}, nil)
+	suite.accessoryManager.On("List", mock.Anything, mock.Anything).Return(nil, nil)
+
+	suite.Nil(indexSBOMs(context.TODO(), art))
+	suite.componentManager.AssertNotCalled(suite.T(), "IndexAccessory", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIndexSBOMTestSuite(t *testing.T) {
+	suite.Run(t, &IndexSBOMTestSuite{})
+}
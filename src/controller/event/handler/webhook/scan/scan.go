@@ -52,6 +52,11 @@ func (si *Handler) Handle(ctx context.Context, value any) error {
 		return errors.New("invalid scan artifact event type")
 	}
 
+	if e.NoNewFindings {
+		log.Debugf("skip to notify the %s event as no new vulnerability found: %v", e.EventType, e)
+		return nil
+	}
+
 	policies, err := notification.PolicyMgr.GetRelatedPolices(ctx, e.Artifact.NamespaceID, e.EventType)
 	if err != nil {
 		return errors.Wrap(err, "scan preprocess handler")
@@ -0,0 +1,55 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"context"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/security"
+	event2 "github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/pkg/admission"
+	"github.com/goharbor/harbor/src/pkg/notifier/event"
+)
+
+// AdmissionEventMetadata is the metadata from which the admission event can be resolved
+type AdmissionEventMetadata struct {
+	Ctx        context.Context
+	ProjectID  int64
+	Action     string
+	Repository string
+	Reference  string
+	Decision   *admission.Decision
+}
+
+// Resolve to the event from the metadata
+func (a *AdmissionEventMetadata) Resolve(event *event.Event) error {
+	data := &event2.AdmissionEvent{
+		EventType:  event2.TopicAdmission,
+		ProjectID:  a.ProjectID,
+		Action:     a.Action,
+		Repository: a.Repository,
+		Reference:  a.Reference,
+		Allowed:    a.Decision.Allowed,
+		Reasons:    a.Decision.Reasons,
+		OccurAt:    time.Now(),
+	}
+	if cx, exist := security.FromContext(a.Ctx); exist {
+		data.Operator = cx.GetUsername()
+	}
+	event.Topic = event2.TopicAdmission
+	event.Data = data
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	event2 "github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/pkg/admission"
+	"github.com/goharbor/harbor/src/pkg/notifier/event"
+)
+
+type admissionEventTestSuite struct {
+	suite.Suite
+}
+
+func (a *admissionEventTestSuite) TestResolveOfAdmissionEventMetadata() {
+	e := &event.Event{}
+	metadata := &AdmissionEventMetadata{
+		Ctx:        context.Background(),
+		ProjectID:  1,
+		Action:     "pull",
+		Repository: "library/hello-world",
+		Reference:  "latest",
+		Decision:   &admission.Decision{Allowed: false, Reasons: []string{"require-signature: not signed"}},
+	}
+	err := metadata.Resolve(e)
+	a.Require().Nil(err)
+	a.Equal(event2.TopicAdmission, e.Topic)
+	a.Require().NotNil(e.Data)
+	data, ok := e.Data.(*event2.AdmissionEvent)
+	a.Require().True(ok)
+	a.False(data.Allowed)
+
+	log, err := data.ResolveToAuditLog()
+	a.Require().Nil(err)
+	a.Equal(event2.OperationAdmit, log.Operation)
+	a.Equal("library/hello-world:latest", log.Resource)
+	a.False(log.IsSuccessful)
+	a.Equal("pull artifact library/hello-world:latest denied by admission policies: require-signature: not signed", log.OperationDescription)
+}
+
+func TestAdmissionEventTestSuite(t *testing.T) {
+	suite.Run(t, &admissionEventTestSuite{})
+}
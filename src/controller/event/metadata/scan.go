@@ -30,6 +30,8 @@ type ScanImageMetaData struct {
 	ScanType string
 	Status   string
 	Operator string
+	// NoNewFindings is true when the rescan finds no vulnerability other than the known ones
+	NoNewFindings bool
 }
 
 // Resolve image scanning metadata into common chart event
@@ -57,6 +59,8 @@ func (si *ScanImageMetaData) Resolve(evt *event.Event) error {
 		OccurAt:   time.Now(),
 		Operator:  si.Operator,
 		ScanType:  si.ScanType,
+
+		NoNewFindings: si.NoNewFindings,
 	}
 
 	evt.Topic = topic
@@ -16,6 +16,7 @@ package event
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/goharbor/harbor/src/common/rbac"
@@ -51,11 +52,14 @@ const (
 	TopicCreateRobot       = "CREATE_ROBOT"
 	TopicDeleteRobot       = "DELETE_ROBOT"
 	TopicCommonEvent       = "COMMON_API"
+	TopicAdmission         = "ADMISSION"
 	ResourceTypeProject    = "project"
 	ResourceTypeArtifact   = "artifact"
 	ResourceTypeRepository = "repository"
 	ResourceTypeRobot      = "robot"
 	ResourceTypeTag        = "tag"
+	// OperationAdmit is the operation of the admission decisions recorded in the audit log
+	OperationAdmit = "admit"
 )
 
 // CreateProjectEvent is the creating project event
@@ -321,6 +325,9 @@ type ScanImageEvent struct {
 	Artifact  *v1.Artifact
 	OccurAt   time.Time
 	Operator  string
+	// NoNewFindings is true when the rescan finds no vulnerability other than the known ones,
+	// no webhook is sent for it
+	NoNewFindings bool
 }
 
 func (s *ScanImageEvent) String() string {
@@ -451,3 +458,45 @@ func (c *DeleteRobotEvent) String() string {
 	return fmt.Sprintf("Name-%s Operator-%s OccurAt-%s",
 		c.Robot.Name, c.Operator, c.OccurAt.Format("2006-01-02 15:04:05"))
 }
+
+// AdmissionEvent is the event of the admission policies evaluated for pushing or pulling the artifact
+type AdmissionEvent struct {
+	EventType  string
+	ProjectID  int64
+	Action     string
+	Repository string
+	Reference  string
+	Allowed    bool
+	Reasons    []string
+	Operator   string
+	OccurAt    time.Time
+}
+
+// ResolveToAuditLog ...
+func (a *AdmissionEvent) ResolveToAuditLog() (*model.AuditLogExt, error) {
+	resource := fmt.Sprintf("%s:%s", a.Repository, a.Reference)
+	if strings.Contains(a.Reference, ":") {
+		resource = fmt.Sprintf("%s@%s", a.Repository, a.Reference)
+	}
+
+	desc := fmt.Sprintf("%s artifact %s allowed by admission policies", a.Action, resource)
+	if !a.Allowed {
+		desc = fmt.Sprintf("%s artifact %s denied by admission policies: %s", a.Action, resource, strings.Join(a.Reasons, "; "))
+	}
+
+	auditLog := &model.AuditLogExt{
+		ProjectID:            a.ProjectID,
+		OpTime:               a.OccurAt,
+		Operation:            OperationAdmit,
+		Username:             a.Operator,
+		ResourceType:         ResourceTypeArtifact,
+		IsSuccessful:         a.Allowed,
+		OperationDescription: desc,
+		Resource:             resource}
+	return auditLog, nil
+}
+
+func (a *AdmissionEvent) String() string {
+	return fmt.Sprintf("Action-%s Repository-%s Reference-%s Allowed-%t Operator-%s OccurAt-%s",
+		a.Action, a.Repository, a.Reference, a.Allowed, a.Operator, a.OccurAt.Format("2006-01-02 15:04:05"))
+}
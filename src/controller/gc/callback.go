@@ -18,12 +18,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"time"
 
 	"github.com/goharbor/harbor/src/controller/quota"
 	"github.com/goharbor/harbor/src/jobservice/job"
+	gcjob "github.com/goharbor/harbor/src/jobservice/job/impl/gc"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
 	"github.com/goharbor/harbor/src/pkg/task"
 )
@@ -41,6 +45,11 @@ func init() {
 	if err := task.RegisterCheckInProcessor(job.GarbageCollectionVendorType, gcCheckIn); err != nil {
 		log.Fatalf("failed to register the checkin processor for the garbage collection job, error %v", err)
 	}
+
+	// both the mark and sweep jobs are safe to run again
+	if err := task.RegisterRelaunchFunc(job.GarbageCollectionVendorType, gcRelaunch); err != nil {
+		log.Fatalf("failed to register the relaunch function for the garbage collection job, error %v", err)
+	}
 }
 
 func gcCallback(ctx context.Context, p string) error {
@@ -52,6 +61,15 @@ func gcCallback(ctx context.Context, p string) error {
 	return err
 }
 
+// the key of the task extra attribute holding the shard of the sweep task
+const shardKey = "shard"
+
+// the parameters of the GC execution which are passed to the sweep tasks
+var sweepParamKeys = []string{"delete_tag", "workers", "redis_url_reg", "time_window", "online", "grace_period"}
+
+// the key of the execution extra attribute counting the creations of the sweep tasks
+const sweepCreatedKey = "sweep_created"
+
 func gcTaskStatusChange(ctx context.Context, _ int64, status string) error {
 	if status == job.SuccessStatus.String() && config.QuotaPerProjectEnable(ctx) {
 		go func() {
@@ -65,6 +83,118 @@ func gcTaskStatusChange(ctx context.Context, _ int64, status string) error {
 	return nil
 }
 
+// createSweepTasks creates the sweep tasks in the execution when the mark task checks in that the candidates are marked,
+// each task sweeps the candidates of one shard. The mark task is still running at that time, so the execution keeps
+// running until all the sweep tasks finish.
+func createSweepTasks(ctx context.Context, t *task.Task) error {
+	var (
+		e   *task.Execution
+		ids []int64
+	)
+	// commit the task records with the claim before submitting the jobs, so the jobs never run without their
+	// task records and a check in delivered again never creates the tasks twice
+	err := orm.WithTransaction(func(ctx context.Context) error {
+		var err error
+		e, err = task.ExecMgr.Get(ctx, t.ExecutionID)
+		if err != nil {
+			return err
+		}
+		// the execution is stopped or the check in is delivered after the mark task finished
+		if job.Status(e.Status).Final() {
+			log.Warningf("the garbage collection execution %d is %s, skip creating the sweep tasks", e.ID, e.Status)
+			return nil
+		}
+		shards := toInt(e.ExtraAttrs["shards"])
+		if dryRun, _ := e.ExtraAttrs["dry_run"].(bool); dryRun || shards <= 1 {
+			return nil
+		}
+		// the check in may be delivered more than once, claim the creation atomically to only create the sweep tasks once,
+		// the claim is rolled back with the transaction if the creation fails
+		values, err := task.ExecMgr.IncreaseExtraAttrs(ctx, e.ID, map[string]int64{sweepCreatedKey: 1})
+		if err != nil {
+			return err
+		}
+		if values[sweepCreatedKey] > 1 {
+			return nil
+		}
+
+		for shard := range shards {
+			id, err := task.Mgr.CreateRecord(ctx, e.ID, map[string]any{shardKey: shard})
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+		return nil
+	})(orm.SetTransactionOpNameToContext(ctx, "tx-gc-create-sweep-tasks"))
+	if err != nil {
+		return err
+	}
+
+	// the task whose job fails to be submitted is marked as error, submit the others anyway
+	var submitErr error
+	for shard, id := range ids {
+		if err := task.Mgr.Submit(ctx, id, sweepJob(e, t.StartTime, shard)); err != nil {
+			log.Errorf("failed to submit the sweep job of shard %d for the garbage collection execution %d: %v", shard, e.ID, err)
+			submitErr = err
+		}
+	}
+	if len(ids) > 0 {
+		log.Infof("created %d sweep tasks for the garbage collection execution %d", len(ids), e.ID)
+	}
+	return submitErr
+}
+
+// sweepJob builds the job sweeping the candidates of the shard which are marked at the mark time
+func sweepJob(e *task.Execution, markTime time.Time, shard int) *task.Job {
+	params := map[string]any{
+		"shards":    toInt(e.ExtraAttrs["shards"]),
+		"mark_time": markTime.Format(time.RFC3339),
+		shardKey:    shard,
+	}
+	for _, key := range sweepParamKeys {
+		if v, exist := e.ExtraAttrs[key]; exist {
+			params[key] = v
+		}
+	}
+	return &task.Job{
+		Name: job.GarbageCollectionSweepVendorType,
+		Metadata: &job.Metadata{
+			JobKind: job.KindGeneric,
+		},
+		Parameters: params,
+	}
+}
+
+// gcRelaunch rebuilds the mark or sweep job of the stuck garbage collection task
+func gcRelaunch(ctx context.Context, t *task.Task) (*task.Job, error) {
+	e, err := task.ExecMgr.Get(ctx, t.ExecutionID)
+	if err != nil {
+		return nil, err
+	}
+	// the mark task runs with the parameters of the execution
+	if _, exist := t.ExtraAttrs[shardKey]; !exist {
+		return &task.Job{
+			Name: job.GarbageCollectionVendorType,
+			Metadata: &job.Metadata{
+				JobKind: job.KindGeneric,
+			},
+			Parameters: maps.Clone(e.ExtraAttrs),
+		}, nil
+	}
+	// the sweep task sweeps the candidates marked by the mark task
+	tasks, err := task.Mgr.List(ctx, q.New(q.KeyWords{"ExecutionID": e.ID}))
+	if err != nil {
+		return nil, err
+	}
+	for _, mt := range tasks {
+		if _, exist := mt.ExtraAttrs[shardKey]; !exist {
+			return sweepJob(e, mt.StartTime, toInt(t.ExtraAttrs[shardKey])), nil
+		}
+	}
+	return nil, fmt.Errorf("the mark task of the garbage collection execution %d not found", e.ID)
+}
+
 func gcCheckIn(ctx context.Context, t *task.Task, sc *job.StatusChange) error {
 	taskID := t.ID
 	status := t.Status
@@ -75,6 +205,11 @@ func gcCheckIn(ctx context.Context, t *task.Task, sc *job.StatusChange) error {
 			SweepSize int64 `json:"freed_space"`
 			Blobs     int64 `json:"purged_blobs"`
 			Manifests int64 `json:"purged_manifests"`
+			// the dry-run report saved as system artifact
+			ReportRepository string `json:"report_repository"`
+			ReportDigest     string `json:"report_digest"`
+			// the candidates are marked and ready to be swept by the sweep tasks
+			Marked bool `json:"marked"`
 		}
 		if err := json.Unmarshal([]byte(sc.CheckIn), &gcObj); err != nil {
 			log.Errorf("failed to resolve checkin of garbage collection task %d: %v", taskID, err)
@@ -86,6 +221,19 @@ func gcCheckIn(ctx context.Context, t *task.Task, sc *job.StatusChange) error {
 			return err
 		}
 
+		if gcObj.Marked {
+			if err := createSweepTasks(ctx, t); err != nil {
+				log.Errorf("failed to create the sweep tasks of the garbage collection task %d: %v", taskID, err)
+				return err
+			}
+			return nil
+		}
+
+		// for the sharded GC, the results are the sum of all the swept shards
+		if _, exist := t.ExtraAttrs[shardKey]; exist {
+			return sumSweepResults(ctx, t, gcObj.SweepSize, gcObj.Blobs, gcObj.Manifests)
+		}
+
 		e, err := task.ExecMgr.Get(ctx, t.ExecutionID)
 		if err != nil {
 			return err
@@ -94,6 +242,10 @@ func gcCheckIn(ctx context.Context, t *task.Task, sc *job.StatusChange) error {
 		e.ExtraAttrs["freed_space"] = gcObj.SweepSize
 		e.ExtraAttrs["purged_blobs"] = gcObj.Blobs
 		e.ExtraAttrs["purged_manifests"] = gcObj.Manifests
+		if gcObj.ReportDigest != "" {
+			e.ExtraAttrs[gcjob.ReportRepositoryKey] = gcObj.ReportRepository
+			e.ExtraAttrs[gcjob.ReportDigestKey] = gcObj.ReportDigest
+		}
 
 		err = task.ExecMgr.UpdateExtraAttrs(ctx, e.ID, e.ExtraAttrs)
 		if err != nil {
@@ -103,3 +255,44 @@ func gcCheckIn(ctx context.Context, t *task.Task, sc *job.StatusChange) error {
 	}
 	return nil
 }
+
+// sumSweepResults saves the result of the sweep task, and adds it to the totals of the execution, the totals are
+// increased atomically as the shards check in concurrently
+func sumSweepResults(ctx context.Context, t *task.Task, sweepSize, blobs, manifests int64) error {
+	// the check in is delivered more than once
+	if _, exist := t.ExtraAttrs["freed_space"]; exist {
+		log.Debugf("the result of the sweep task %d is already summed, skip", t.ID)
+		return nil
+	}
+
+	if t.ExtraAttrs == nil {
+		t.ExtraAttrs = map[string]any{}
+	}
+	t.ExtraAttrs["freed_space"] = sweepSize
+	t.ExtraAttrs["purged_blobs"] = blobs
+	t.ExtraAttrs["purged_manifests"] = manifests
+	if err := task.Mgr.UpdateExtraAttrs(ctx, t.ID, t.ExtraAttrs); err != nil {
+		return err
+	}
+
+	_, err := task.ExecMgr.IncreaseExtraAttrs(ctx, t.ExecutionID, map[string]int64{
+		"freed_space":      sweepSize,
+		"purged_blobs":     blobs,
+		"purged_manifests": manifests,
+		"swept_shards":     1,
+	})
+	return err
+}
+
+// toInt converts the number in the extra attributes to int, the numbers are float64 after being unmarshalled from JSON
+func toInt(v any) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case int64:
+		return int(n)
+	case float64:
+		return int(n)
+	}
+	return 0
+}
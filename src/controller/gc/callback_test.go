@@ -3,9 +3,12 @@ package gc
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/pkg/task"
+	ormtesting "github.com/goharbor/harbor/src/testing/lib/orm"
 	"github.com/goharbor/harbor/src/testing/mock"
 	tasktesting "github.com/goharbor/harbor/src/testing/pkg/task"
 	"github.com/stretchr/testify/suite"
@@ -39,6 +42,132 @@ func (c *callbackTestSuite) TestCheckIn() {
 	gcCheckIn(context.Background(), t, sc)
 }
 
+func (c *callbackTestSuite) TestCreateSweepTasks() {
+	taskMgr, execMgr := task.Mgr, task.ExecMgr
+	defer func() {
+		task.Mgr, task.ExecMgr = taskMgr, execMgr
+	}()
+	task.Mgr, task.ExecMgr = c.taskMgr, c.execMgr
+
+	ctx := orm.NewContext(context.Background(), &ormtesting.FakeOrmer{})
+	mark := &task.Task{ID: 1, ExecutionID: 1, Status: job.RunningStatus.String()}
+	c.taskMgr.On("Get", mock.Anything, int64(1)).Return(mark, nil)
+	c.execMgr.On("Get", mock.Anything, int64(1)).Return(&task.Execution{
+		ID:     1,
+		Status: job.RunningStatus.String(),
+		ExtraAttrs: map[string]any{
+			"shards":    float64(3),
+			"workers":   float64(2),
+			"dry_run":   false,
+			"unrelated": true,
+		},
+	}, nil)
+	c.execMgr.On("IncreaseExtraAttrs", mock.Anything, int64(1), map[string]int64{sweepCreatedKey: 1}).
+		Return(map[string]int64{sweepCreatedKey: 1}, nil).Once()
+	for shard := range 3 {
+		c.taskMgr.On("CreateRecord", mock.Anything, int64(1), map[string]any{shardKey: shard}).Return(int64(shard+2), nil).Once()
+	}
+	c.taskMgr.On("Submit", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// the mark task checks in before it finishes
+	c.Nil(gcCheckIn(ctx, mark, &job.StatusChange{CheckIn: `{"marked":true,"freed_space":0}`}))
+	c.taskMgr.AssertNumberOfCalls(c.T(), "CreateRecord", 3)
+	c.taskMgr.AssertNumberOfCalls(c.T(), "Submit", 3)
+	last := c.taskMgr.Calls[len(c.taskMgr.Calls)-1]
+	c.Equal(int64(4), last.Arguments.Get(1))
+	jb := last.Arguments.Get(2).(*task.Job)
+	c.Equal(job.GarbageCollectionSweepVendorType, jb.Name)
+	c.Equal(2, jb.Parameters[shardKey])
+	c.Equal(3, jb.Parameters["shards"])
+	c.Equal(float64(2), jb.Parameters["workers"])
+	c.NotContains(jb.Parameters, "unrelated")
+	c.execMgr.AssertNotCalled(c.T(), "UpdateExtraAttrs", mock.Anything, mock.Anything, mock.Anything)
+
+	// the check in is delivered again
+	c.execMgr.On("IncreaseExtraAttrs", mock.Anything, int64(1), map[string]int64{sweepCreatedKey: 1}).
+		Return(map[string]int64{sweepCreatedKey: 2}, nil).Once()
+	c.Nil(createSweepTasks(ctx, mark))
+	c.taskMgr.AssertNumberOfCalls(c.T(), "CreateRecord", 3)
+	c.taskMgr.AssertNumberOfCalls(c.T(), "Submit", 3)
+}
+
+func (c *callbackTestSuite) TestCreateSweepTasksStopped() {
+	taskMgr, execMgr := task.Mgr, task.ExecMgr
+	defer func() {
+		task.Mgr, task.ExecMgr = taskMgr, execMgr
+	}()
+	task.Mgr, task.ExecMgr = c.taskMgr, c.execMgr
+
+	ctx := orm.NewContext(context.Background(), &ormtesting.FakeOrmer{})
+	c.execMgr.On("Get", mock.Anything, int64(1)).Return(&task.Execution{
+		ID:         1,
+		Status:     job.StoppedStatus.String(),
+		ExtraAttrs: map[string]any{"shards": float64(3)},
+	}, nil)
+	c.Nil(createSweepTasks(ctx, &task.Task{ID: 1, ExecutionID: 1}))
+	c.execMgr.AssertNotCalled(c.T(), "IncreaseExtraAttrs", mock.Anything, mock.Anything, mock.Anything)
+	c.taskMgr.AssertNotCalled(c.T(), "CreateRecord", mock.Anything, mock.Anything, mock.Anything)
+	c.taskMgr.AssertNotCalled(c.T(), "Submit", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (c *callbackTestSuite) TestSumSweepResults() {
+	taskMgr, execMgr := task.Mgr, task.ExecMgr
+	defer func() {
+		task.Mgr, task.ExecMgr = taskMgr, execMgr
+	}()
+	task.Mgr, task.ExecMgr = c.taskMgr, c.execMgr
+
+	t := &task.Task{ID: 2, ExecutionID: 1, ExtraAttrs: map[string]any{shardKey: float64(0)}}
+	c.taskMgr.On("UpdateExtraAttrs", mock.Anything, int64(2), mock.Anything).Return(nil).Once()
+	c.execMgr.On("IncreaseExtraAttrs", mock.Anything, int64(1), map[string]int64{
+		"freed_space":      50,
+		"purged_blobs":     2,
+		"purged_manifests": 0,
+		"swept_shards":     1,
+	}).Return(map[string]int64{}, nil).Once()
+	c.Nil(sumSweepResults(context.Background(), t, 50, 2, 0))
+	c.Equal(int64(50), t.ExtraAttrs["freed_space"])
+
+	// the check in is delivered again
+	c.Nil(sumSweepResults(context.Background(), t, 50, 2, 0))
+	c.taskMgr.AssertExpectations(c.T())
+	c.execMgr.AssertExpectations(c.T())
+}
+
+func (c *callbackTestSuite) TestRelaunch() {
+	taskMgr, execMgr := task.Mgr, task.ExecMgr
+	defer func() {
+		task.Mgr, task.ExecMgr = taskMgr, execMgr
+	}()
+	task.Mgr, task.ExecMgr = c.taskMgr, c.execMgr
+
+	markTime := time.Now()
+	c.execMgr.On("Get", mock.Anything, int64(1)).Return(&task.Execution{
+		ID: 1,
+		ExtraAttrs: map[string]any{
+			"shards":  float64(3),
+			"workers": float64(2),
+		},
+	}, nil)
+	c.taskMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Task{
+		{ID: 1, ExecutionID: 1, StartTime: markTime},
+		{ID: 2, ExecutionID: 1, ExtraAttrs: map[string]any{shardKey: float64(1)}},
+	}, nil)
+
+	// the mark task
+	jb, err := gcRelaunch(context.Background(), &task.Task{ID: 1, ExecutionID: 1})
+	c.Require().Nil(err)
+	c.Equal(job.GarbageCollectionVendorType, jb.Name)
+	c.Equal(float64(3), jb.Parameters["shards"])
+
+	// the sweep task
+	jb, err = gcRelaunch(context.Background(), &task.Task{ID: 2, ExecutionID: 1, ExtraAttrs: map[string]any{shardKey: float64(1)}})
+	c.Require().Nil(err)
+	c.Equal(job.GarbageCollectionSweepVendorType, jb.Name)
+	c.Equal(1, jb.Parameters[shardKey])
+	c.Equal(markTime.Format(time.RFC3339), jb.Parameters["mark_time"])
+}
+
 func TestCallBackTestSuite(t *testing.T) {
 	suite.Run(t, &callbackTestSuite{})
 }
@@ -16,11 +16,14 @@ package gc
 
 import (
 	"context"
+	"io"
 
 	"github.com/goharbor/harbor/src/jobservice/job"
+	gcjob "github.com/goharbor/harbor/src/jobservice/job/impl/gc"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
+	"github.com/goharbor/harbor/src/pkg/systemartifact"
 	"github.com/goharbor/harbor/src/pkg/task"
 )
 
@@ -49,11 +52,15 @@ type Controller interface {
 	ListTasks(ctx context.Context, query *q.Query) (tasks []*Task, err error)
 	// GetTaskLog gets log of the specific task
 	GetTaskLog(ctx context.Context, id int64) ([]byte, error)
+	// FollowTaskLog follows the log of the specific task from the offset, the caller is responsible for closing the stream
+	FollowTaskLog(ctx context.Context, id int64, offset int64) (io.ReadCloser, error)
+	// GetReport gets the dry-run report of the specific execution, the caller is responsible for closing the reader
+	GetReport(ctx context.Context, executionID int64) (io.ReadCloser, error)
 
 	// GetSchedule get the current gc schedule
 	GetSchedule(ctx context.Context) (*scheduler.Schedule, error)
-	// CreateSchedule create the gc schedule with cron type & string
-	CreateSchedule(ctx context.Context, cronType, cron string, policy Policy) (int64, error)
+	// CreateSchedule create the gc schedule with cron type & string, the options customize how the cron is evaluated
+	CreateSchedule(ctx context.Context, cronType, cron string, options *job.ScheduleOptions, policy Policy) (int64, error)
 	// DeleteSchedule remove the gc schedule
 	DeleteSchedule(ctx context.Context) error
 }
@@ -64,6 +71,7 @@ func NewController() Controller {
 		taskMgr:      task.NewManager(),
 		exeMgr:       task.NewExecutionManager(),
 		schedulerMgr: scheduler.New(),
+		sysArtMgr:    systemartifact.Mgr,
 	}
 }
 
@@ -71,6 +79,7 @@ type controller struct {
 	taskMgr      task.Manager
 	exeMgr       task.ExecutionManager
 	schedulerMgr scheduler.Scheduler
+	sysArtMgr    systemartifact.Manager
 }
 
 // Start starts the manual GC
@@ -82,6 +91,16 @@ func (c *controller) Start(ctx context.Context, policy Policy, trigger string) (
 	para["workers"] = policy.Workers
 	para["redis_url_reg"] = policy.ExtraAttrs["redis_url_reg"]
 	para["time_window"] = policy.ExtraAttrs["time_window"]
+	if len(policy.ProjectIDs) > 0 {
+		para["project_ids"] = policy.ProjectIDs
+	}
+	if policy.Online {
+		para["online"] = policy.Online
+		para["grace_period"] = policy.GracePeriod
+	}
+	if policy.Shards > 1 {
+		para["shards"] = policy.Shards
+	}
 
 	execID, err := c.exeMgr.Create(ctx, job.GarbageCollectionVendorType, -1, trigger, para)
 	if err != nil {
@@ -187,6 +206,30 @@ func (c *controller) GetTaskLog(ctx context.Context, id int64) ([]byte, error) {
 	return c.taskMgr.GetLog(ctx, id)
 }
 
+// FollowTaskLog ...
+func (c *controller) FollowTaskLog(ctx context.Context, id int64, offset int64) (io.ReadCloser, error) {
+	_, err := c.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return c.taskMgr.FollowLog(ctx, id, offset)
+}
+
+// GetReport ...
+func (c *controller) GetReport(ctx context.Context, executionID int64) (io.ReadCloser, error) {
+	exec, err := c.GetExecution(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+	repository, _ := exec.ExtraAttrs[gcjob.ReportRepositoryKey].(string)
+	digest, _ := exec.ExtraAttrs[gcjob.ReportDigestKey].(string)
+	if repository == "" || digest == "" {
+		return nil, errors.New(nil).WithCode(errors.NotFoundCode).
+			WithMessagef("no dry-run report found for garbage collection execution %d", executionID)
+	}
+	return c.sysArtMgr.Read(ctx, gcjob.ReportVendor, repository, digest)
+}
+
 // GetSchedule ...
 func (c *controller) GetSchedule(ctx context.Context) (*scheduler.Schedule, error) {
 	sch, err := c.schedulerMgr.ListSchedules(ctx, q.New(q.KeyWords{"VendorType": job.GarbageCollectionVendorType}))
@@ -203,12 +246,22 @@ func (c *controller) GetSchedule(ctx context.Context) (*scheduler.Schedule, erro
 }
 
 // CreateSchedule ...
-func (c *controller) CreateSchedule(ctx context.Context, cronType, cron string, policy Policy) (int64, error) {
+func (c *controller) CreateSchedule(ctx context.Context, cronType, cron string, options *job.ScheduleOptions, policy Policy) (int64, error) {
 	extras := make(map[string]any)
 	extras["delete_untagged"] = policy.DeleteUntagged
 	extras["delete_tag"] = policy.DeleteTag
 	extras["workers"] = policy.Workers
-	return c.schedulerMgr.Schedule(ctx, job.GarbageCollectionVendorType, -1, cronType, cron, job.GarbageCollectionVendorType, policy, extras)
+	if len(policy.ProjectIDs) > 0 {
+		extras["project_ids"] = policy.ProjectIDs
+	}
+	if policy.Online {
+		extras["online"] = policy.Online
+		extras["grace_period"] = policy.GracePeriod
+	}
+	if policy.Shards > 1 {
+		extras["shards"] = policy.Shards
+	}
+	return c.schedulerMgr.ScheduleWithOptions(ctx, job.GarbageCollectionVendorType, -1, cronType, cron, options, job.GarbageCollectionVendorType, policy, extras)
 }
 
 // DeleteSchedule ...
@@ -1,16 +1,21 @@
 package gc
 
 import (
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 
 	"github.com/goharbor/harbor/src/jobservice/job"
+	gcjob "github.com/goharbor/harbor/src/jobservice/job/impl/gc"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
 	"github.com/goharbor/harbor/src/pkg/task"
 	"github.com/goharbor/harbor/src/testing/mock"
 	schedulertesting "github.com/goharbor/harbor/src/testing/pkg/scheduler"
+	systemartifacttesting "github.com/goharbor/harbor/src/testing/pkg/systemartifact"
 	tasktesting "github.com/goharbor/harbor/src/testing/pkg/task"
 )
 
@@ -19,6 +24,7 @@ type gcCtrTestSuite struct {
 	scheduler *schedulertesting.Scheduler
 	execMgr   *tasktesting.ExecutionManager
 	taskMgr   *tasktesting.Manager
+	sysArtMgr *systemartifacttesting.Manager
 	ctl       *controller
 }
 
@@ -26,10 +32,12 @@ func (g *gcCtrTestSuite) SetupTest() {
 	g.execMgr = &tasktesting.ExecutionManager{}
 	g.taskMgr = &tasktesting.Manager{}
 	g.scheduler = &schedulertesting.Scheduler{}
+	g.sysArtMgr = &systemartifacttesting.Manager{}
 	g.ctl = &controller{
 		taskMgr:      g.taskMgr,
 		exeMgr:       g.execMgr,
 		schedulerMgr: g.scheduler,
+		sysArtMgr:    g.sysArtMgr,
 	}
 }
 
@@ -69,6 +77,56 @@ func (g *gcCtrTestSuite) TestGetTaskLog() {
 	g.Equal([]byte("hello world"), log)
 }
 
+func (g *gcCtrTestSuite) TestFollowTaskLog() {
+	g.taskMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Task{
+		{
+			ID:          1,
+			ExecutionID: 1,
+			Status:      job.RunningStatus.String(),
+		},
+	}, nil)
+	g.taskMgr.On("FollowLog", mock.Anything, int64(1), int64(10)).Return(io.NopCloser(strings.NewReader("id: 20\ndata: hello\n\n")), nil)
+
+	stream, err := g.ctl.FollowTaskLog(nil, 1, 10)
+	g.Require().Nil(err)
+	defer stream.Close()
+	data, err := io.ReadAll(stream)
+	g.Nil(err)
+	g.Equal("id: 20\ndata: hello\n\n", string(data))
+}
+
+func (g *gcCtrTestSuite) TestGetReport() {
+	g.execMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Execution{
+		{
+			ID:     1,
+			Status: job.SuccessStatus.String(),
+			ExtraAttrs: map[string]any{
+				gcjob.ReportRepositoryKey: "gc_dry_run_report_1",
+				gcjob.ReportDigestKey:     "sha256:1234",
+			},
+		},
+	}, nil).Once()
+	g.sysArtMgr.On("Read", mock.Anything, gcjob.ReportVendor, "gc_dry_run_report_1", "sha256:1234").
+		Return(io.NopCloser(strings.NewReader("{}")), nil)
+
+	report, err := g.ctl.GetReport(nil, 1)
+	g.Require().Nil(err)
+	data, err := io.ReadAll(report)
+	g.Nil(err)
+	g.Equal("{}", string(data))
+
+	// no report for the execution which is not a dry-run
+	g.execMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Execution{
+		{
+			ID:         2,
+			Status:     job.SuccessStatus.String(),
+			ExtraAttrs: map[string]any{},
+		},
+	}, nil).Once()
+	_, err = g.ctl.GetReport(nil, 2)
+	g.True(errors.IsNotFoundErr(err))
+}
+
 func (g *gcCtrTestSuite) TestExecutionCount() {
 	g.execMgr.On("Count", mock.Anything, mock.Anything).Return(int64(1), nil)
 	count, err := g.ctl.ExecutionCount(nil, q.New(q.KeyWords{"VendorType": "gc"}))
@@ -144,8 +202,9 @@ func (g *gcCtrTestSuite) TestGetSchedule() {
 }
 
 func (g *gcCtrTestSuite) TestCreateSchedule() {
-	g.scheduler.On("Schedule", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
-		mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+	options := &job.ScheduleOptions{Timezone: "Asia/Shanghai", Jitter: 600}
+	g.scheduler.On("ScheduleWithOptions", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, options, mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
 
 	dataMap := make(map[string]any)
 	p := Policy{
@@ -154,7 +213,7 @@ func (g *gcCtrTestSuite) TestCreateSchedule() {
 		ExtraAttrs:     dataMap,
 		Workers:        3,
 	}
-	id, err := g.ctl.CreateSchedule(nil, "Daily", "* * * * * *", p)
+	id, err := g.ctl.CreateSchedule(nil, "Daily", "* * * * * *", options, p)
 	g.Nil(err)
 	g.Equal(int64(1), id)
 }
@@ -25,6 +25,10 @@ type Policy struct {
 	DeleteTag      bool           `json:"deletetag"`
 	DryRun         bool           `json:"dryrun"`
 	Workers        int            `json:"workers"`
+	ProjectIDs     []int64        `json:"project_ids"`
+	Online         bool           `json:"online"`
+	GracePeriod    int            `json:"grace_period"`
+	Shards         int            `json:"shards"`
 	ExtraAttrs     map[string]any `json:"extra_attrs"`
 }
 
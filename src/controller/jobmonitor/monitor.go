@@ -22,6 +22,7 @@ import (
 	"time"
 
 	jobSvc "github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/pkg/queuestatus"
 
@@ -72,6 +73,12 @@ type MonitorController interface {
 	// ResumeJobQueues resume the job queue by type
 	ResumeJobQueues(ctx context.Context, jobType string) error
 	GetJobLog(ctx context.Context, jobID string) ([]byte, error)
+	// GetJobPriorities returns the job priorities in effect
+	GetJobPriorities(ctx context.Context) (*jobSvc.Priorities, error)
+	// UpdateJobPriorities adjusts the job priorities at runtime
+	UpdateJobPriorities(ctx context.Context, priorities *jobSvc.Priorities) error
+	// ResetJobPriorities drops the runtime adjustments and restores the configured job priorities
+	ResetJobPriorities(ctx context.Context) error
 }
 
 type monitorController struct {
@@ -82,6 +89,7 @@ type monitorController struct {
 	queueStatusManager    queuestatus.Manager
 	monitorClient         func() (jm.JobServiceMonitorClient, error)
 	jobServiceRedisClient func() (jm.RedisClient, error)
+	jobServiceConfig      func() (*jobSvc.Config, error)
 	executionDAO          taskDao.ExecutionDAO
 }
 
@@ -95,10 +103,15 @@ func NewMonitorController() MonitorController {
 		queueStatusManager:    queuestatus.Mgr,
 		monitorClient:         jobServiceMonitorClient,
 		jobServiceRedisClient: jm.JobServiceRedisClient,
+		jobServiceConfig:      jobServiceConfig,
 		executionDAO:          taskDao.NewExecutionDAO(),
 	}
 }
 
+func jobServiceConfig() (*jobSvc.Config, error) {
+	return job.GlobalClient.GetJobServiceConfig()
+}
+
 func jobServiceMonitorClient() (jm.JobServiceMonitorClient, error) {
 	cfg, err := job.GlobalClient.GetJobServiceConfig()
 	if err != nil {
@@ -367,3 +380,48 @@ func (w *monitorController) resumeQueue(ctx context.Context, jobType string) err
 func (w *monitorController) GetJobLog(ctx context.Context, jobID string) ([]byte, error) {
 	return w.taskManager.GetLogByJobID(ctx, jobID)
 }
+
+func (w *monitorController) GetJobPriorities(ctx context.Context) (*jobSvc.Priorities, error) {
+	redisClient, err := w.jobServiceRedisClient()
+	if err != nil {
+		return nil, err
+	}
+	priorities, err := redisClient.GetJobPriorities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if priorities != nil {
+		return priorities, nil
+	}
+	// not adjusted at runtime, return the configured ones
+	cfg, err := w.jobServiceConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Priorities == nil {
+		return &jobSvc.Priorities{}, nil
+	}
+	return cfg.Priorities, nil
+}
+
+func (w *monitorController) UpdateJobPriorities(ctx context.Context, priorities *jobSvc.Priorities) error {
+	if priorities == nil {
+		return errors.BadRequestError(nil).WithMessage("the job priorities are required")
+	}
+	if err := priorities.Validate(); err != nil {
+		return errors.BadRequestError(err)
+	}
+	redisClient, err := w.jobServiceRedisClient()
+	if err != nil {
+		return err
+	}
+	return redisClient.SetJobPriorities(ctx, priorities)
+}
+
+func (w *monitorController) ResetJobPriorities(ctx context.Context) error {
+	redisClient, err := w.jobServiceRedisClient()
+	if err != nil {
+		return err
+	}
+	return redisClient.DeleteJobPriorities(ctx)
+}
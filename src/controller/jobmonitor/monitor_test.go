@@ -25,6 +25,8 @@ import (
 	"github.com/gocraft/work"
 	"github.com/stretchr/testify/suite"
 
+	jobSvc "github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/pkg/jobmonitor"
 	"github.com/goharbor/harbor/src/pkg/task"
 	"github.com/goharbor/harbor/src/testing/mock"
@@ -62,6 +64,9 @@ func (s *JobServiceMonitorTestSuite) SetupSuite() {
 		jobServiceRedisClient: func() (jobmonitor.RedisClient, error) {
 			return s.redisClient, nil
 		},
+		jobServiceConfig: func() (*jobSvc.Config, error) {
+			return &jobSvc.Config{Priorities: &jobSvc.Priorities{Default: 500}}, nil
+		},
 	}
 }
 
@@ -124,6 +129,33 @@ func (s *JobServiceMonitorTestSuite) TestPauseJob() {
 	s.Assert().Nil(err)
 }
 
+func (s *JobServiceMonitorTestSuite) TestGetJobPriorities() {
+	// not adjusted at runtime
+	mock.OnAnything(s.redisClient, "GetJobPriorities").Return(nil, nil).Once()
+	p, err := s.monitController.GetJobPriorities(nil)
+	s.Require().Nil(err)
+	s.Equal(uint(500), p.Default)
+
+	mock.OnAnything(s.redisClient, "GetJobPriorities").Return(&jobSvc.Priorities{Default: 2000}, nil).Once()
+	p, err = s.monitController.GetJobPriorities(nil)
+	s.Require().Nil(err)
+	s.Equal(uint(2000), p.Default)
+}
+
+func (s *JobServiceMonitorTestSuite) TestUpdateJobPriorities() {
+	err := s.monitController.UpdateJobPriorities(nil, &jobSvc.Priorities{VendorTypes: map[string]uint{"IMAGE_SCAN": 0}})
+	s.True(errors.IsErr(err, errors.BadRequestCode))
+
+	priorities := &jobSvc.Priorities{VendorTypes: map[string]uint{"IMAGE_SCAN": 2000}, MaxRunningPerTenant: 5}
+	s.redisClient.(*monitorMock.RedisClient).On("SetJobPriorities", mock.Anything, priorities).Return(nil).Once()
+	err = s.monitController.UpdateJobPriorities(nil, priorities)
+	s.Nil(err)
+
+	mock.OnAnything(s.redisClient, "DeleteJobPriorities").Return(nil).Once()
+	err = s.monitController.ResetJobPriorities(nil)
+	s.Nil(err)
+}
+
 func TestJobServiceMonitorTestSuite(t *testing.T) {
 	suite.Run(t, &JobServiceMonitorTestSuite{})
 }
@@ -17,6 +17,7 @@ package jobservice
 import (
 	"context"
 
+	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/q"
 	jm "github.com/goharbor/harbor/src/pkg/jobmonitor"
@@ -34,8 +35,8 @@ var (
 type SchedulerController interface {
 	// Get the schedule
 	Get(ctx context.Context, vendorType string) (*scheduler.Schedule, error)
-	// Create with cron type & string
-	Create(ctx context.Context, vendorType, cronType, cron, callbackFuncName string, policy any, extrasParam map[string]any) (int64, error)
+	// Create with cron type & string, the options customize how the cron is evaluated
+	Create(ctx context.Context, vendorType, cronType, cron string, options *job.ScheduleOptions, callbackFuncName string, policy any, extrasParam map[string]any) (int64, error)
 	// Delete the schedule
 	Delete(ctx context.Context, vendorType string) error
 	// List lists schedules
@@ -75,9 +76,9 @@ func (s *schedulerController) Get(ctx context.Context, vendorType string) (*sche
 	return sch[0], nil
 }
 
-func (s *schedulerController) Create(ctx context.Context, vendorType, cronType, cron, callbackFuncName string,
-	policy any, extrasParam map[string]any) (int64, error) {
-	return s.schedulerMgr.Schedule(ctx, vendorType, -1, cronType, cron, callbackFuncName, policy, extrasParam)
+func (s *schedulerController) Create(ctx context.Context, vendorType, cronType, cron string, options *job.ScheduleOptions,
+	callbackFuncName string, policy any, extrasParam map[string]any) (int64, error) {
+	return s.schedulerMgr.ScheduleWithOptions(ctx, vendorType, -1, cronType, cron, options, callbackFuncName, policy, extrasParam)
 }
 
 func (s *schedulerController) Delete(ctx context.Context, vendorType string) error {
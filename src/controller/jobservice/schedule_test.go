@@ -46,12 +46,12 @@ func (s *ScheduleTestSuite) SetupSuite() {
 }
 
 func (s *ScheduleTestSuite) TestCreateSchedule() {
-	s.scheduler.On("Schedule", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
-		mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+	s.scheduler.On("ScheduleWithOptions", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
 
 	dataMap := make(map[string]any)
 	p := purge.JobPolicy{}
-	id, err := s.ctl.Create(nil, job.PurgeAuditVendorType, "Daily", "* * * * * *", purge.SchedulerCallback, p, dataMap)
+	id, err := s.ctl.Create(nil, job.PurgeAuditVendorType, "Daily", "* * * * * *", nil, purge.SchedulerCallback, p, dataMap)
 	s.Nil(err)
 	s.Equal(int64(1), id)
 }
@@ -0,0 +1,55 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/goharbor/harbor/src/controller/replication/transfer"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/task"
+	"github.com/goharbor/harbor/src/pkg/task/dao"
+)
+
+func init() {
+	if err := task.RegisterCheckInProcessor(job.ReplicationVendorType, replicationCheckIn); err != nil {
+		log.Fatalf("failed to register the checkin processor for the replication job, error %v", err)
+	}
+}
+
+// replicationCheckIn records the bytes transferred by the replication task, the bytes of all the runs of
+// the task are summed up as the blobs are transferred again when retrying
+func replicationCheckIn(ctx context.Context, t *task.Task, sc *job.StatusChange) error {
+	if sc.CheckIn == "" {
+		return nil
+	}
+	checkIn := &transfer.CheckIn{}
+	if err := json.Unmarshal([]byte(sc.CheckIn), checkIn); err != nil {
+		log.G(ctx).Errorf("failed to resolve checkin of replication task %d: %v", t.ID, err)
+		return err
+	}
+	attrs := map[string]any{}
+	for key, value := range t.ExtraAttrs {
+		attrs[key] = value
+	}
+	var transferred int64
+	if v, ok := attrs[dao.BytesTransferredAttr].(float64); ok {
+		transferred = int64(v)
+	}
+	attrs[dao.BytesTransferredAttr] = transferred + checkIn.BytesTransferred
+	return task.Mgr.UpdateExtraAttrs(ctx, t.ID, attrs)
+}
@@ -0,0 +1,46 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/pkg/task"
+	"github.com/goharbor/harbor/src/pkg/task/dao"
+	"github.com/goharbor/harbor/src/testing/mock"
+	testingTask "github.com/goharbor/harbor/src/testing/pkg/task"
+)
+
+func TestReplicationCheckIn(t *testing.T) {
+	taskMgr := task.Mgr
+	defer func() {
+		task.Mgr = taskMgr
+	}()
+	mgr := &testingTask.Manager{}
+	task.Mgr = mgr
+
+	// the bytes of the retried run are added up
+	tk := &task.Task{ID: 1, ExtraAttrs: map[string]any{"resource_type": "image", dao.BytesTransferredAttr: float64(100)}}
+	mgr.On("UpdateExtraAttrs", mock.Anything, int64(1), map[string]any{"resource_type": "image", dao.BytesTransferredAttr: int64(150)}).Return(nil).Once()
+	assert.Nil(t, replicationCheckIn(context.TODO(), tk, &job.StatusChange{CheckIn: `{"bytes_transferred":50}`}))
+	mgr.AssertExpectations(t)
+
+	// invalid check in
+	assert.NotNil(t, replicationCheckIn(context.TODO(), tk, &job.StatusChange{CheckIn: "invalid"}))
+}
@@ -17,6 +17,7 @@ package replication
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/goharbor/harbor/src/controller/event/operator"
@@ -71,6 +72,8 @@ type Controller interface {
 	GetTask(ctx context.Context, taskID int64) (task *Task, err error)
 	// GetTaskLog gets the log of the specific task
 	GetTaskLog(ctx context.Context, taskID int64) (log []byte, err error)
+	// FollowTaskLog follows the log of the specific task from the offset, the caller is responsible for closing the stream
+	FollowTaskLog(ctx context.Context, taskID int64, offset int64) (stream io.ReadCloser, err error)
 }
 
 // NewController creates a new instance of the replication controller
@@ -291,6 +294,15 @@ func (c *controller) GetTaskLog(ctx context.Context, id int64) ([]byte, error) {
 	return c.taskMgr.GetLog(ctx, id)
 }
 
+func (c *controller) FollowTaskLog(ctx context.Context, id int64, offset int64) (io.ReadCloser, error) {
+	// make sure the task specified by ID is replication task
+	_, err := c.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return c.taskMgr.FollowLog(ctx, id, offset)
+}
+
 func convertExecution(exec *task.Execution) *Execution {
 	replicationExec := &Execution{
 		ID:            exec.ID,
@@ -17,6 +17,8 @@ package replication
 import (
 	"context"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -288,6 +290,22 @@ func (r *replicationTestSuite) TestGetTaskLog() {
 	r.taskMgr.AssertExpectations(r.T())
 }
 
+func (r *replicationTestSuite) TestFollowTaskLog() {
+	r.taskMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Task{
+		{
+			ID: 1,
+		},
+	}, nil)
+	r.taskMgr.On("FollowLog", mock.Anything, int64(1), int64(0)).Return(io.NopCloser(strings.NewReader("id: 1\ndata: a\n\n")), nil)
+	stream, err := r.ctl.FollowTaskLog(nil, 1, 0)
+	r.Require().Nil(err)
+	defer stream.Close()
+	data, err := io.ReadAll(stream)
+	r.Require().Nil(err)
+	r.Equal("id: 1\ndata: a\n\n", string(data))
+	r.taskMgr.AssertExpectations(r.T())
+}
+
 func TestReplicationTestSuite(t *testing.T) {
 	suite.Run(t, &replicationTestSuite{})
 }
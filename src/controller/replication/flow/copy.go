@@ -135,6 +135,7 @@ func (c *copyFlow) createTasks(ctx context.Context, srcResources, dstResources [
 			Name: job.ReplicationVendorType,
 			Metadata: &job.Metadata{
 				JobKind: job.KindGeneric,
+				Tenant:  getTenant(c.policy, srcResource, dstResource),
 			},
 			Parameters: map[string]any{
 				"src_resource":  string(src),
@@ -77,6 +77,7 @@ func (d *deletionFlow) createTasks(ctx context.Context, srcResources, dstResourc
 			Name: job.ReplicationVendorType,
 			Metadata: &job.Metadata{
 				JobKind: job.KindGeneric,
+				Tenant:  getTenant(d.policy, resource, dstResources[i]),
 			},
 			Parameters: map[string]any{
 				"src_resource": string(src),
@@ -19,6 +19,7 @@ import (
 	"path"
 	"strings"
 
+	"github.com/goharbor/harbor/src/common/utils"
 	repctlmodel "github.com/goharbor/harbor/src/controller/replication/model"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
@@ -124,6 +125,20 @@ func prepareForPush(adapter adp.Adapter, resources []*model.Resource) error {
 
 // return the name with format "res_name" or "res_name:[vtag1,vtag2,vtag3]"
 // if the resource has vtags
+// getTenant returns the local project of the replication as the tenant used for the fair queuing of the jobs
+func getTenant(policy *repctlmodel.Policy, src, dst *model.Resource) string {
+	res := src
+	// the destination is the local registry for the pull-based replication
+	if policy != nil && policy.SrcRegistry != nil && policy.SrcRegistry.ID != 0 {
+		res = dst
+	}
+	if res == nil || res.Metadata == nil || res.Metadata.Repository == nil {
+		return ""
+	}
+	project, _ := utils.ParseRepository(res.Metadata.Repository.Name)
+	return project
+}
+
 func getResourceName(res *model.Resource) string {
 	if res == nil {
 		return ""
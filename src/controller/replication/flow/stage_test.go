@@ -197,6 +197,35 @@ func (s *stageTestSuite) TestReplaceNamespace() {
 	s.Equal("n/a", result)
 }
 
+func (s *stageTestSuite) TestGetTenant() {
+	src := &model.Resource{
+		Metadata: &model.ResourceMetadata{
+			Repository: &model.Repository{Name: "library/hello-world"},
+		},
+	}
+	dst := &model.Resource{
+		Metadata: &model.ResourceMetadata{
+			Repository: &model.Repository{Name: "mirror/library/hello-world"},
+		},
+	}
+
+	// push-based
+	policy := &repctlmodel.Policy{
+		SrcRegistry:  &model.Registry{ID: 0},
+		DestRegistry: &model.Registry{ID: 1},
+	}
+	s.Equal("library", getTenant(policy, src, dst))
+
+	// pull-based
+	policy = &repctlmodel.Policy{
+		SrcRegistry:  &model.Registry{ID: 1},
+		DestRegistry: &model.Registry{ID: 0},
+	}
+	s.Equal("mirror", getTenant(policy, src, dst))
+
+	s.Equal("", getTenant(policy, src, &model.Resource{}))
+}
+
 func TestStage(t *testing.T) {
 	suite.Run(t, &stageTestSuite{})
 }
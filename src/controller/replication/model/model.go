@@ -116,6 +116,10 @@ func (p *Policy) Validate() error {
 			if cronParts[1] == "*" {
 				return errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("* is not allowed for the Minutes field of the cron setting of replication policy")
 			}
+			if err := p.Trigger.Settings.ScheduleOptions().Validate(); err != nil {
+				return errors.New(nil).WithCode(errors.BadRequestCode).
+					WithMessagef("invalid schedule options for scheduled trigger: %v", err)
+			}
 		default:
 			return errors.New(nil).WithCode(errors.BadRequestCode).
 				WithMessage("invalid trigger type")
@@ -138,8 +138,8 @@ func (c *controller) CreatePolicy(ctx context.Context, policy *model.Policy) (in
 			// the operator of schedule job is harbor-jobservice
 			"operator": secret.JobserviceUser,
 		}
-		if _, err = c.scheduler.Schedule(ctx, job.ReplicationVendorType, id, "", policy.Trigger.Settings.Cron,
-			callbackFuncName, cbParams, map[string]any{}); err != nil {
+		if _, err = c.scheduler.ScheduleWithOptions(ctx, job.ReplicationVendorType, id, "", policy.Trigger.Settings.Cron,
+			policy.Trigger.Settings.ScheduleOptions(), callbackFuncName, cbParams, map[string]any{}); err != nil {
 			return 0, err
 		}
 	}
@@ -170,8 +170,8 @@ func (c *controller) UpdatePolicy(ctx context.Context, policy *model.Policy, pro
 			// the operator of schedule job is harbor-jobservice
 			"operator": secret.JobserviceUser,
 		}
-		if _, err := c.scheduler.Schedule(ctx, job.ReplicationVendorType, policy.ID, "", policy.Trigger.Settings.Cron,
-			callbackFuncName, cbParams, map[string]any{}); err != nil {
+		if _, err := c.scheduler.ScheduleWithOptions(ctx, job.ReplicationVendorType, policy.ID, "", policy.Trigger.Settings.Cron,
+			policy.Trigger.Settings.ScheduleOptions(), callbackFuncName, cbParams, map[string]any{}); err != nil {
 			return err
 		}
 	}
@@ -69,7 +69,7 @@ func (r *replicationTestSuite) TestCreatePolicy() {
 	mock.OnAnything(r.regMgr, "Get").Return(&model.Registry{
 		ID: 1,
 	}, nil)
-	mock.OnAnything(r.scheduler, "Schedule").Return(int64(1), nil)
+	mock.OnAnything(r.scheduler, "ScheduleWithOptions").Return(int64(1), nil)
 	id, err := r.ctl.CreatePolicy(context.TODO(), &repmodel.Policy{
 		Name: "rule",
 		SrcRegistry: &model.Registry{
@@ -95,7 +95,7 @@ func (r *replicationTestSuite) TestUpdatePolicy() {
 		ID: 1,
 	}, nil)
 	mock.OnAnything(r.scheduler, "UnScheduleByVendor").Return(nil)
-	mock.OnAnything(r.scheduler, "Schedule").Return(int64(1), nil)
+	mock.OnAnything(r.scheduler, "ScheduleWithOptions").Return(int64(1), nil)
 	mock.OnAnything(r.repMgr, "Update").Return(nil)
 	err := r.ctl.UpdatePolicy(context.TODO(), &repmodel.Policy{
 		ID:   1,
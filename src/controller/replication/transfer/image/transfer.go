@@ -85,6 +85,13 @@ type transfer struct {
 	isStopped trans.StopFunc
 	src       adapter.ArtifactRegistry
 	dst       adapter.ArtifactRegistry
+	// the bytes of the blobs pushed to the destination, the mounted ones aren't counted
+	transferred int64
+}
+
+// Transferred returns the bytes of the blobs pushed to the destination
+func (t *transfer) Transferred() int64 {
+	return t.transferred
 }
 
 func (t *transfer) Transfer(src *model.Resource, dst *model.Resource, opts *trans.Options) error {
@@ -396,6 +403,7 @@ func (t *transfer) copyBlobByMonolithic(srcRepo, dstRepo, digest string, sizeFro
 		t.logger.Errorf("failed to pushing the blob %s, size %d: %v", digest, size, err)
 		return err
 	}
+	t.transferred += size
 
 	return nil
 }
@@ -446,6 +454,7 @@ func (t *transfer) copyBlobByChunk(srcRepo, dstRepo, digest string, sizeFromDesc
 		}
 
 		data.Close()
+		t.transferred += *end - *start + 1
 
 		t.logger.Infof("copy the blob chunk: %d-%d/%d completed", *start, *end, sizeFromDescriptor)
 		// if the end equals (blobSize-1), that means it is last chunk, return if this is the last chunk
@@ -156,6 +156,8 @@ func TestCopy(t *testing.T) {
 	}
 	err := tr.copy(src, dst, true, trans.NewOptions())
 	require.Nil(t, err)
+	// the config and 3 layers of the artifact b2 are pushed, b1 already exists
+	assert.Equal(t, int64(4), tr.Transferred())
 }
 
 func TestCopyByChunk(t *testing.T) {
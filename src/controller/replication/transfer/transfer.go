@@ -37,6 +37,17 @@ type Transfer interface {
 	Transfer(src *model.Resource, dst *model.Resource, opts *Options) error
 }
 
+// Counter is implemented by the Transfer which counts the bytes it transferred
+type Counter interface {
+	// Transferred returns the bytes transferred
+	Transferred() int64
+}
+
+// CheckIn is checked in by the replication job to report the bytes transferred
+type CheckIn struct {
+	BytesTransferred int64 `json:"bytes_transferred"`
+}
+
 // Logger defines an interface for logging
 type Logger interface {
 	// For debuging
@@ -17,6 +17,7 @@ package retention
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/goharbor/harbor/src/common/secret"
@@ -116,8 +117,12 @@ func (r *defaultController) CreateRetention(ctx context.Context, p *policy.Metad
 	if p.Trigger.Kind == policy.TriggerKindSchedule {
 		cron, ok := p.Trigger.Settings[policy.TriggerSettingsCron]
 		if ok && len(cron.(string)) > 0 {
+			options, err := p.Trigger.ScheduleOptions()
+			if err != nil {
+				return 0, err
+			}
 			extras := make(map[string]any)
-			if _, err = r.scheduler.Schedule(ctx, schedulerVendorType, id, "", cron.(string), SchedulerCallback, TriggerParam{
+			if _, err = r.scheduler.ScheduleWithOptions(ctx, schedulerVendorType, id, "", cron.(string), options, SchedulerCallback, TriggerParam{
 				PolicyID: id,
 				Trigger:  retention.ExecutionTriggerSchedule,
 				// the operator of schedule job is harbor-jobservice
@@ -155,7 +160,7 @@ func (r *defaultController) UpdateRetention(ctx context.Context, p *policy.Metad
 	} else {
 		switch p.Trigger.Kind {
 		case policy.TriggerKindSchedule:
-			if p0.Trigger.Settings["cron"] != p.Trigger.Settings["cron"] {
+			if p0.Trigger.Settings["cron"] != p.Trigger.Settings["cron"] || !sameScheduleOptions(p0.Trigger, p.Trigger) {
 				// unschedule old
 				if len(p0.Trigger.Settings[policy.TriggerSettingsCron].(string)) > 0 {
 					needUn = true
@@ -182,8 +187,12 @@ func (r *defaultController) UpdateRetention(ctx context.Context, p *policy.Metad
 		}
 	}
 	if needSch {
+		options, err := p.Trigger.ScheduleOptions()
+		if err != nil {
+			return err
+		}
 		extras := make(map[string]any)
-		_, err := r.scheduler.Schedule(ctx, schedulerVendorType, p.ID, "", p.Trigger.Settings[policy.TriggerSettingsCron].(string), SchedulerCallback, TriggerParam{
+		_, err = r.scheduler.ScheduleWithOptions(ctx, schedulerVendorType, p.ID, "", p.Trigger.Settings[policy.TriggerSettingsCron].(string), options, SchedulerCallback, TriggerParam{
 			PolicyID: p.ID,
 			Trigger:  retention.ExecutionTriggerSchedule,
 			// the operator of schedule job is harbor-jobservice
@@ -197,6 +206,16 @@ func (r *defaultController) UpdateRetention(ctx context.Context, p *policy.Metad
 	return nil
 }
 
+// sameScheduleOptions reports whether the two triggers carry the same schedule options
+func sameScheduleOptions(t0, t1 *policy.Trigger) bool {
+	o0, err0 := t0.ScheduleOptions()
+	o1, err1 := t1.ScheduleOptions()
+	if err0 != nil || err1 != nil {
+		return false
+	}
+	return reflect.DeepEqual(o0, o1)
+}
+
 // DeleteRetention Delete Retention
 func (r *defaultController) DeleteRetention(ctx context.Context, id int64) error {
 	p, err := r.manager.GetPolicy(ctx, id)
@@ -320,6 +320,10 @@ func (f *fakeRetentionScheduler) Schedule(ctx context.Context, vendorType string
 	return 111, nil
 }
 
+func (f *fakeRetentionScheduler) ScheduleWithOptions(ctx context.Context, vendorType string, vendorID int64, cronType string, cron string, options *job.ScheduleOptions, callbackFuncName string, params any, extras map[string]any) (int64, error) {
+	return 111, nil
+}
+
 func (f *fakeRetentionScheduler) UnScheduleByID(ctx context.Context, id int64) error {
 	return nil
 }
@@ -25,6 +25,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/goharbor/harbor/src/common/utils"
 	ar "github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/controller/event/operator"
 	"github.com/goharbor/harbor/src/controller/robot"
@@ -50,6 +51,7 @@ import (
 	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
 	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 	"github.com/goharbor/harbor/src/pkg/task"
+	"github.com/goharbor/harbor/src/pkg/vex"
 )
 
 var (
@@ -57,6 +59,8 @@ var (
 	DefaultController = NewController()
 
 	errScanAllStopped = errors.New("scanAll stopped")
+	// the interval to check whether the scanners are available when the concurrency of the scan all is limited
+	scannerPollInterval = 5 * time.Second
 )
 
 // const definitions
@@ -72,6 +76,9 @@ const (
 	reportUUIDsKey      = "report_uuids"
 	robotIDKey          = "robot_id"
 	enabledCapabilities = "enabled_capabilities"
+
+	knownVulnerabilitiesKey = "known_vulnerability_hashes"
+	registrationUUIDKey     = "registration_uuid"
 )
 
 // uuidGenerator is a func template which is for generating UUID.
@@ -92,6 +99,8 @@ type launchScanJobParam struct {
 	Tag          string
 	Reports      []*scan.Report
 	Type         string
+	// KnownVulnerabilities the keys of the vulnerabilities found by the previous scan
+	KnownVulnerabilities []string
 }
 
 // basicController is default implementation of api.Controller interface
@@ -122,6 +131,10 @@ type basicController struct {
 	reportConverter postprocessors.NativeScanReportConverter
 	// cache stores the stop scan all marks
 	cache cacheGetter
+	// VEX manager
+	vexMgr vex.Manager
+	// vexCache caches the VEX statements applying to the artifacts
+	vexCache cache.Cache
 }
 
 // NewController news a scan API controller
@@ -170,6 +183,9 @@ func NewController() Controller {
 		cache: func() cache.Cache {
 			return cache.Default()
 		},
+		// Refer to the default VEX manager
+		vexMgr:   vex.Mgr,
+		vexCache: newVEXCache(),
 	}
 }
 
@@ -236,81 +252,68 @@ func (bc *basicController) Scan(ctx context.Context, artifact *ar.Artifact, opti
 		return errors.New("nil artifact to scan")
 	}
 
-	r, err := bc.sc.GetRegistrationByProject(ctx, artifact.ProjectID)
+	registrations, err := bc.sc.GetRegistrationsByProject(ctx, artifact.ProjectID)
 	if err != nil {
 		return errors.Wrap(err, "scan controller: scan")
 	}
 
 	// In case it does not exist
-	if r == nil {
+	if len(registrations) == 0 {
 		return errors.PreconditionFailedError(nil).WithMessagef("no available scanner for project: %d", artifact.ProjectID)
 	}
 
-	// Check if it is disabled
+	// Check if the primary scanner is disabled
+	r := registrations[0]
 	if r.Disabled {
 		return errors.PreconditionFailedError(nil).WithMessagef("scanner %s is deactivated", r.Name)
 	}
 
-	artifacts, scannable, err := bc.collectScanningArtifacts(ctx, r, artifact)
-	if err != nil {
-		return err
-	}
 	// Parse options
 	opts, err := parseOptions(options...)
 	if err != nil {
 		return errors.Wrap(err, "scan controller: scan")
 	}
 
-	if !scannable {
-		if opts.FromEvent {
-			// skip to return err for event related scan
-			return nil
-		}
-		return errors.BadRequestError(nil).WithMessagef("the configured scanner %s does not support scanning artifact with mime type %s", r.Name, artifact.ManifestMediaType)
-	}
-
 	var (
 		errs                []error
 		launchScanJobParams []*launchScanJobParam
+		prepared            int
 	)
 	handler := sca.GetScanHandler(opts.GetScanType())
-	for _, art := range artifacts {
-		reports, err := handler.MakePlaceHolder(ctx, art, r)
+	// fan out the scan to all the scanners of the project, the reports of them are merged when reading
+	for _, registration := range registrations {
+		if registration.Disabled {
+			log.G(ctx).Warningf("skip the deactivated scanner %s for artifact %s@%s", registration.Name, artifact.RepositoryName, artifact.Digest)
+			continue
+		}
+
+		params, err := bc.prepareScan(ctx, handler, registration, artifact, opts)
 		if err != nil {
-			if errors.IsConflictErr(err) {
-				errs = append(errs, err)
-			} else {
+			if !errors.IsConflictErr(err) && !errors.IsErr(err, errors.BadRequestCode) {
 				return err
 			}
-		}
 
-		var tag string
-		if art.Digest == artifact.Digest {
-			tag = opts.Tag
+			errs = append(errs, err)
+			continue
 		}
 
-		if tag == "" {
-			latestTag, err := bc.getLatestTagOfArtifact(ctx, art.ID)
-			if err != nil {
+		prepared++
+		launchScanJobParams = append(launchScanJobParams, params...)
+	}
+
+	// none of the scanners is able to scan the artifact
+	if prepared == 0 {
+		for _, err := range errs {
+			if errors.IsConflictErr(err) {
 				return err
 			}
-
-			tag = latestTag
 		}
 
-		if len(reports) > 0 {
-			launchScanJobParams = append(launchScanJobParams, &launchScanJobParam{
-				Registration: r,
-				Artifact:     art,
-				Tag:          tag,
-				Reports:      reports,
-				Type:         opts.GetScanType(),
-			})
+		if opts.FromEvent {
+			// skip to return err for event related scan
+			return nil
 		}
-	}
 
-	// all report placeholder conflicted
-	if len(errs) == len(artifacts) {
 		return errs[0]
 	}
 
@@ -363,6 +366,93 @@ func (bc *basicController) Scan(ctx context.Context, artifact *ar.Artifact, opti
 	return nil
 }
 
+// prepareScan makes the report placeholders of the artifact for the scanner and returns the params to launch the scan jobs
+func (bc *basicController) prepareScan(ctx context.Context, handler sca.Handler, r *scanner.Registration, artifact *ar.Artifact, opts *Options) ([]*launchScanJobParam, error) {
+	artifacts, scannable, err := bc.collectScanningArtifacts(ctx, r, artifact)
+	if err != nil {
+		return nil, err
+	}
+
+	if !scannable {
+		return nil, errors.BadRequestError(nil).WithMessagef("the configured scanner %s does not support scanning artifact with mime type %s", r.Name, artifact.ManifestMediaType)
+	}
+
+	var (
+		errs                []error
+		launchScanJobParams []*launchScanJobParam
+	)
+	for _, art := range artifacts {
+		var known []string
+		if opts.NewFindingsOnly && opts.GetScanType() == v1.ScanTypeVulnerability {
+			// collect the vulnerabilities found by the previous scan before the reports are replaced by the placeholders
+			known, err = bc.knownVulnerabilities(ctx, r, art)
+			if err != nil {
+				log.G(ctx).Warningf("failed to get the known vulnerabilities of artifact %s@%s, error: %v", art.RepositoryName, art.Digest, err)
+			}
+		}
+
+		reports, err := handler.MakePlaceHolder(ctx, art, r)
+		if err != nil {
+			if errors.IsConflictErr(err) {
+				errs = append(errs, err)
+			} else {
+				return nil, err
+			}
+		}
+
+		var tag string
+		if art.Digest == artifact.Digest {
+			tag = opts.Tag
+		}
+
+		if tag == "" {
+			latestTag, err := bc.getLatestTagOfArtifact(ctx, art.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			tag = latestTag
+		}
+
+		if len(reports) > 0 {
+			launchScanJobParams = append(launchScanJobParams, &launchScanJobParam{
+				Registration: r,
+				Artifact:     art,
+				Tag:          tag,
+				Reports:      reports,
+				Type:         opts.GetScanType(),
+
+				KnownVulnerabilities: known,
+			})
+		}
+	}
+
+	// all report placeholder conflicted
+	if len(errs) == len(artifacts) {
+		return nil, errs[0]
+	}
+
+	return launchScanJobParams, nil
+}
+
+// knownVulnerabilities returns the keys of the vulnerabilities in the existing reports of the artifact generated by the scanner
+func (bc *basicController) knownVulnerabilities(ctx context.Context, r *scanner.Registration, art *ar.Artifact) ([]string, error) {
+	reports, err := bc.manager.GetBy(ctx, art.Digest, r.UUID, r.GetProducesMimeTypes(art.ManifestMediaType, v1.ScanTypeVulnerability))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rp := range reports {
+		completeReport, err := bc.reportConverter.FromRelationalSchema(ctx, rp.UUID, rp.Digest, rp.Report)
+		if err != nil {
+			return nil, err
+		}
+		rp.Report = completeReport
+	}
+
+	return report.Reports(reports).VulnerabilityKeys()
+}
+
 // Stop scan job of a given artifact
 func (bc *basicController) Stop(ctx context.Context, artifact *ar.Artifact, capType string) error {
 	if artifact == nil {
@@ -382,11 +472,14 @@ func (bc *basicController) Stop(ctx context.Context, artifact *ar.Artifact, capT
 	return bc.execMgr.Stop(ctx, execution.ID)
 }
 
-func (bc *basicController) ScanAll(ctx context.Context, trigger string, async bool) (int64, error) {
+func (bc *basicController) ScanAll(ctx context.Context, trigger string, async bool, scope *ScanAllScope) (int64, error) {
 	extra := make(map[string]any)
 	if op := operator.FromContext(ctx); op != "" {
 		extra["operator"] = op
 	}
+	if !scope.IsEmpty() {
+		extra["scope"] = scope.ToMap()
+	}
 	executionID, err := bc.execMgr.Create(ctx, job.ScanAllVendorType, 0, trigger, extra)
 	if err != nil {
 		return 0, err
@@ -404,13 +497,13 @@ func (bc *basicController) ScanAll(ctx context.Context, trigger string, async bo
 				return
 			}
 
-			err = bc.startScanAll(ctx, executionID)
+			err = bc.startScanAll(ctx, executionID, scope)
 			if err != nil {
 				log.Errorf("failed to start scan all, executionID=%d, error: %v", executionID, err)
 			}
 		}(bc.makeCtx())
 	} else {
-		if err := bc.startScanAll(ctx, executionID); err != nil {
+		if err := bc.startScanAll(ctx, executionID, scope); err != nil {
 			return 0, err
 		}
 	}
@@ -440,6 +533,54 @@ func (bc *basicController) StopScanAll(ctx context.Context, executionID int64, a
 	return stopScanAll(ctx, executionID)
 }
 
+// waitForScanners blocks until the ongoing scan jobs of the execution submitted to each scanner of the project are fewer than the limit
+func (bc *basicController) waitForScanners(ctx context.Context, executionID, projectID int64, limit int) error {
+	registrations, err := bc.sc.GetRegistrationsByProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	query := q.New(q.KeyWords{
+		"execution_id": executionID,
+		"status":       &q.OrList{Values: []any{job.PendingStatus.String(), job.ScheduledStatus.String(), job.RunningStatus.String()}},
+	})
+	for {
+		tasks, err := bc.taskMgr.List(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		ongoing := map[string]int{}
+		for _, t := range tasks {
+			if uuid, ok := t.ExtraAttrs[registrationUUIDKey].(string); ok {
+				ongoing[uuid]++
+			}
+		}
+
+		busy := false
+		for _, r := range registrations {
+			if !r.Disabled && ongoing[r.UUID] >= limit {
+				busy = true
+				break
+			}
+		}
+
+		if !busy {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(scannerPollInterval):
+		}
+
+		if bc.isScanAllStopped(ctx, executionID) {
+			return errScanAllStopped
+		}
+	}
+}
+
 func scanAllStoppedKey(execID int64) string {
 	return fmt.Sprintf("scan_all:execution_id:%d:stopped", execID)
 }
@@ -456,7 +597,91 @@ func (bc *basicController) isScanAllStopped(ctx context.Context, execID int64) b
 	return bc.cache().Contains(ctx, scanAllStoppedKey(execID))
 }
 
-func (bc *basicController) startScanAll(ctx context.Context, executionID int64) error {
+func (bc *basicController) startScanAll(ctx context.Context, executionID int64, scope *ScanAllScope) error {
+	return bc.scanArtifacts(ctx, executionID, scope, nil)
+}
+
+// RescanOnDBUpdate rescans the recently pulled artifacts when the vulnerability database of the scanners updates
+func (bc *basicController) RescanOnDBUpdate(ctx context.Context, trigger string) (int64, error) {
+	updated, err := bc.sc.CheckDBUpdates(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(updated) == 0 {
+		log.G(ctx).Debug("no vulnerability database of the scanners updated, skip to rescan")
+		return 0, nil
+	}
+
+	scanners := make([]string, len(updated))
+	updatedUUIDs := make(map[string]struct{}, len(updated))
+	for i, r := range updated {
+		scanners[i] = r.Name
+		updatedUUIDs[r.UUID] = struct{}{}
+	}
+
+	days := config.ScanOnDBUpdatePulledWithinDays(ctx)
+	extra := map[string]any{
+		"scanners":           scanners,
+		"pulled_within_days": days,
+	}
+	if op := operator.FromContext(ctx); op != "" {
+		extra["operator"] = op
+	}
+	executionID, err := bc.execMgr.Create(ctx, job.ScanOnDBUpdateVendorType, 0, trigger, extra)
+	if err != nil {
+		return 0, err
+	}
+
+	// only the artifacts pulled within the days are rescanned
+	scope := &ScanAllScope{PulledWithinDays: days, OrderByPullTime: true}
+
+	// only rescan the artifacts of the projects which use the updated scanners
+	projects := map[int64]bool{}
+	filter := func(ctx context.Context, art *ar.Artifact) bool {
+		if matched, ok := projects[art.ProjectID]; ok {
+			return matched
+		}
+
+		registrations, err := bc.sc.GetRegistrationsByProject(ctx, art.ProjectID)
+		if err != nil {
+			log.G(ctx).Warningf("failed to get the scanners of project %d, error: %v", art.ProjectID, err)
+			return false
+		}
+
+		matched := false
+		for _, r := range registrations {
+			if _, ok := updatedUUIDs[r.UUID]; ok {
+				matched = true
+				break
+			}
+		}
+		projects[art.ProjectID] = matched
+
+		return matched
+	}
+
+	go func(ctx context.Context) {
+		// this is running in another goroutine ensure the execution exists in db
+		err := retry.Retry(func() error {
+			_, err := bc.execMgr.Get(ctx, executionID)
+			return err
+		})
+		if err != nil {
+			log.Errorf("failed to get the execution %d for the rescan on database update", executionID)
+			return
+		}
+
+		if err := bc.scanArtifacts(ctx, executionID, scope, filter, WithNewFindingsOnly(true)); err != nil {
+			log.Errorf("failed to rescan on database update, executionID=%d, error: %v", executionID, err)
+		}
+	}(bc.makeCtx())
+
+	return executionID, nil
+}
+
+// scanArtifacts scans the artifacts in the scope and matched the filter in the execution
+func (bc *basicController) scanArtifacts(ctx context.Context, executionID int64, scope *ScanAllScope, filter func(context.Context, *ar.Artifact) bool, options ...Option) error {
 	batchSize := 50
 
 	summary := struct {
@@ -471,15 +696,29 @@ func (bc *basicController) startScanAll(ctx context.Context, executionID int64)
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	for artifact := range ar.Iterator(ctx, batchSize, nil, nil) {
+	for artifact := range scope.artifacts(ctx, batchSize) {
 		if bc.isScanAllStopped(ctx, executionID) {
 			return errScanAllStopped
 		}
 
+		if !scope.match(artifact) || (filter != nil && !filter(ctx, artifact)) {
+			continue
+		}
+
 		summary.TotalCount++
 
+		if scope != nil && scope.ScannerConcurrency > 0 {
+			if err := bc.waitForScanners(ctx, executionID, artifact.ProjectID, scope.ScannerConcurrency); err != nil {
+				if err == errScanAllStopped || ctx.Err() != nil {
+					return err
+				}
+				// Just logged
+				log.Warningf("failed to wait for the scanners of project %d, error: %v", artifact.ProjectID, err)
+			}
+		}
+
 		scan := func(ctx context.Context) error {
-			return bc.Scan(ctx, artifact, WithExecutionID(executionID))
+			return bc.Scan(ctx, artifact, append([]Option{WithExecutionID(executionID)}, options...)...)
 		}
 
 		if err := orm.WithTransaction(scan)(orm.SetTransactionOpNameToContext(bc.makeCtx(), "tx-start-scanall")); err != nil {
@@ -583,15 +822,56 @@ func (bc *basicController) GetReport(ctx context.Context, artifact *ar.Artifact,
 	}
 
 	// Get current scanner settings
-	r, err := bc.sc.GetRegistrationByProject(ctx, artifact.ProjectID)
+	registrations, err := bc.sc.GetRegistrationsByProject(ctx, artifact.ProjectID)
 	if err != nil {
 		return nil, errors.Wrap(err, "scan controller: get report")
 	}
 
-	if r == nil {
+	if len(registrations) == 0 {
 		return nil, errors.NotFoundError(nil).WithMessagef("no scanner registration configured for project: %d", artifact.ProjectID)
 	}
 
+	var (
+		reports []*scan.Report
+		errs    []error
+	)
+	// merge the reports of all the scanners of the project
+	for _, r := range registrations {
+		rps, err := bc.getReportsOfRegistration(ctx, r, artifact, mimes)
+		if err != nil {
+			if !errors.IsNotFoundErr(err) {
+				return nil, err
+			}
+
+			errs = append(errs, err)
+			continue
+		}
+
+		reports = append(reports, rps...)
+	}
+
+	// report not found for all the scanners
+	if len(errs) == len(registrations) {
+		return nil, errs[0]
+	}
+
+	if len(reports) == 0 {
+		return nil, nil
+	}
+
+	if err := bc.assembleReports(ctx, reports...); err != nil {
+		return nil, err
+	}
+
+	if err := bc.applyVEX(ctx, artifact, reports...); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// getReportsOfRegistration returns the reports of the artifact generated by the scanner
+func (bc *basicController) getReportsOfRegistration(ctx context.Context, r *scanner.Registration, artifact *ar.Artifact, mimes []string) ([]*scan.Report, error) {
 	artifacts, scannable, err := bc.collectScanningArtifacts(ctx, r, artifact)
 	if err != nil {
 		return nil, err
@@ -634,14 +914,6 @@ func (bc *basicController) GetReport(ctx context.Context, artifact *ar.Artifact,
 		}
 	}
 
-	if len(reports) == 0 {
-		return nil, nil
-	}
-
-	if err := bc.assembleReports(ctx, reports...); err != nil {
-		return nil, err
-	}
-
 	return reports, nil
 }
 
@@ -656,18 +928,20 @@ func (bc *basicController) GetScanLog(ctx context.Context, artifact *ar.Artifact
 	if len(uuid) == 0 {
 		return nil, errors.New("empty uuid to get scan log")
 	}
-	r, err := bc.sc.GetRegistrationByProject(ctx, artifact.ProjectID)
+	registrations, err := bc.sc.GetRegistrationsByProject(ctx, artifact.ProjectID)
 	if err != nil {
 		return nil, err
 	}
 
-	artifacts, _, err := bc.collectScanningArtifacts(ctx, r, artifact)
-	if err != nil {
-		return nil, err
-	}
 	artifactMap := map[int64]any{}
-	for _, a := range artifacts {
-		artifactMap[a.ID] = struct{}{}
+	for _, r := range registrations {
+		artifacts, _, err := bc.collectScanningArtifacts(ctx, r, artifact)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range artifacts {
+			artifactMap[a.ID] = struct{}{}
+		}
 	}
 	reportUUIDs := vuln.ParseReportIDs(uuid)
 	tasks, err := bc.listScanTasks(ctx, reportUUIDs)
@@ -835,6 +1109,22 @@ func (bc *basicController) GetVulnerable(ctx context.Context, artifact *ar.Artif
 				continue
 			}
 
+			if v.VEX.IsNotAffected() {
+				// Append the CVEs declared not affected by the VEX statements
+				vulnerable.CVESuppressed = append(vulnerable.CVESuppressed, &SuppressedCVE{
+					ID:              v.ID,
+					Package:         v.Package,
+					Justification:   v.VEX.Justification,
+					ImpactStatement: v.VEX.ImpactStatement,
+				})
+
+				vulnerable.VulnerabilitiesCount--
+
+				continue
+			}
+
+			vulnerable.Vulnerabilities = append(vulnerable.Vulnerabilities, v)
+
 			if severity == "" || v.Severity.Code() > severity.Code() {
 				severity = v.Severity
 			}
@@ -971,10 +1261,13 @@ func (bc *basicController) launchScanJob(ctx context.Context, param *launchScanJ
 	params[sca.JobParameterRobot] = robotJSON
 	// because there is only one task type implementation
 	// both the vulnerability scan and generate sbom use the same job type for now
+	// the scan jobs are shared fairly among the projects
+	projectName, _ := utils.ParseRepository(param.Artifact.RepositoryName)
 	j := &task.Job{
 		Name: job.ImageScanJobVendorType,
 		Metadata: &job.Metadata{
 			JobKind: job.KindGeneric,
+			Tenant:  projectName,
 		},
 		Parameters: params,
 	}
@@ -986,6 +1279,12 @@ func (bc *basicController) launchScanJob(ctx context.Context, param *launchScanJ
 		artifactTagKey: param.Tag,
 		robotIDKey:     robot.ID,
 		reportUUIDsKey: reportUUIDs,
+		// the uuid of the scanner is used to limit the concurrency of the scan jobs per scanner
+		registrationUUIDKey: param.Registration.UUID,
+	}
+	if param.KnownVulnerabilities != nil {
+		// only the hashes are kept as the artifact may have thousands of vulnerabilities
+		extraAttrs[knownVulnerabilitiesKey] = hashVulnerabilityKeys(param.KnownVulnerabilities)
 	}
 
 	_, err = bc.taskMgr.Create(ctx, param.ExecutionID, j, extraAttrs)
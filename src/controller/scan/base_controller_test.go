@@ -46,6 +46,7 @@ import (
 	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
 	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 	"github.com/goharbor/harbor/src/pkg/task"
+	"github.com/goharbor/harbor/src/pkg/vex"
 	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
 	robottesting "github.com/goharbor/harbor/src/testing/controller/robot"
 	scannertesting "github.com/goharbor/harbor/src/testing/controller/scanner"
@@ -58,6 +59,7 @@ import (
 	postprocessorstesting "github.com/goharbor/harbor/src/testing/pkg/scan/postprocessors"
 	reporttesting "github.com/goharbor/harbor/src/testing/pkg/scan/report"
 	tasktesting "github.com/goharbor/harbor/src/testing/pkg/task"
+	vextesting "github.com/goharbor/harbor/src/testing/pkg/vex"
 )
 
 // ControllerTestSuite is the test suite for scan controller.
@@ -84,6 +86,7 @@ type ControllerTestSuite struct {
 	c               *basicController
 	reportConverter *postprocessorstesting.NativeScanReportConverter
 	cache           *mockcache.Cache
+	vexMgr          *vextesting.Manager
 }
 
 // TestController is the entry point of ControllerTestSuite.
@@ -151,6 +154,7 @@ func (suite *ControllerTestSuite) SetupSuite() {
 
 	sc := &scannertesting.Controller{}
 	sc.On("GetRegistrationByProject", mock.Anything, suite.artifact.ProjectID).Return(suite.registration, nil)
+	sc.On("GetRegistrationsByProject", mock.Anything, suite.artifact.ProjectID).Return([]*scanner.Registration{suite.registration}, nil)
 	sc.On("Ping", suite.registration).Return(m, nil)
 
 	mgr := &reporttesting.Manager{}
@@ -318,6 +322,8 @@ func (suite *ControllerTestSuite) SetupSuite() {
 
 	suite.cache = &mockcache.Cache{}
 
+	suite.vexMgr = &vextesting.Manager{}
+
 	suite.c = &basicController{
 		manager: mgr,
 		ar:      suite.ar,
@@ -346,6 +352,8 @@ func (suite *ControllerTestSuite) SetupSuite() {
 		taskMgr:         suite.taskMgr,
 		reportConverter: &postprocessorstesting.NativeScanReportConverter{},
 		cache:           func() cache.Cache { return suite.cache },
+		vexMgr:          suite.vexMgr,
+		vexCache:        newVEXCache(),
 	}
 	mock.OnAnything(suite.scanHandler, "JobVendorType").Return("IMAGE_SCAN")
 
@@ -439,6 +447,88 @@ func (suite *ControllerTestSuite) TestScanControllerScan() {
 	}
 }
 
+// TestScanWithMultipleScanners ...
+func (suite *ControllerTestSuite) TestScanWithMultipleScanners() {
+	another := &scanner.Registration{
+		ID:       2,
+		UUID:     "uuid002",
+		Name:     "Another-scanner",
+		URL:      "http://another.com:3128",
+		Metadata: suite.registration.Metadata,
+	}
+	deactivated := &scanner.Registration{
+		ID:       3,
+		UUID:     "uuid003",
+		Name:     "Deactivated-scanner",
+		Disabled: true,
+		Metadata: suite.registration.Metadata,
+	}
+
+	sc := &scannertesting.Controller{}
+	sc.On("GetRegistrationsByProject", mock.Anything, suite.artifact.ProjectID).Return([]*scanner.Registration{suite.registration, another, deactivated}, nil)
+
+	ar := &artifacttesting.Controller{}
+	mock.OnAnything(ar, "Walk").Return(nil).Run(func(args mock.Arguments) {
+		walkFn := args.Get(2).(func(*artifact.Artifact) error)
+		walkFn(suite.artifact)
+	})
+	mock.OnAnything(ar, "HasUnscannableLayer").Return(false, nil)
+
+	acc := &accessorytesting.Manager{}
+	mock.OnAnything(acc, "List").Return([]accessoryModel.Accessory{}, nil)
+
+	rc := &robottesting.Controller{}
+	mock.OnAnything(rc, "Create").Return(int64(1), "secret", nil)
+	mock.OnAnything(rc, "Get").Return(&robot.Robot{Robot: model.Robot{ID: 1}}, nil)
+
+	execMgr := &tasktesting.ExecutionManager{}
+	taskMgr := &tasktesting.Manager{}
+
+	c := *suite.c
+	c.sc = sc
+	c.ar = ar
+	c.acc = acc
+	c.rc = rc
+	c.execMgr = execMgr
+	c.taskMgr = taskMgr
+
+	{
+		// the scan is fanned out to all the enabled scanners within one execution
+		var registrations []string
+		mock.OnAnything(suite.scanHandler, "MakePlaceHolder").Return([]*scan.Report{{UUID: "uuid"}}, nil).Twice().Run(func(args mock.Arguments) {
+			registrations = append(registrations, args.Get(2).(*scanner.Registration).UUID)
+		})
+		mock.OnAnything(suite.scanHandler, "RequiredPermissions").Return([]*types.Policy{}).Twice()
+		mock.OnAnything(execMgr, "Create").Return(int64(1), nil).Once()
+		mock.OnAnything(taskMgr, "Create").Return(int64(1), nil).Twice()
+
+		suite.Require().NoError(c.Scan(context.TODO(), suite.artifact))
+		suite.Equal([]string{"uuid001", "uuid002"}, registrations)
+		execMgr.AssertExpectations(suite.T())
+		taskMgr.AssertExpectations(suite.T())
+	}
+
+	{
+		// the reports of all the scanners are returned
+		mgr := &reporttesting.Manager{}
+		mgr.On("GetBy", mock.Anything, suite.artifact.Digest, "uuid001", []string{v1.MimeTypeNativeReport}).Return([]*scan.Report{{UUID: "rp-uuid-001", MimeType: v1.MimeTypeNativeReport}}, nil)
+		mgr.On("GetBy", mock.Anything, suite.artifact.Digest, "uuid002", []string{v1.MimeTypeNativeReport}).Return([]*scan.Report{{UUID: "rp-uuid-002", MimeType: v1.MimeTypeNativeReport}}, nil)
+		mgr.On("GetBy", mock.Anything, suite.artifact.Digest, "uuid003", []string{v1.MimeTypeNativeReport}).Return(nil, nil)
+		c.manager = mgr
+
+		mock.OnAnything(taskMgr, "ListScanTasksByReportUUID").Return([]*task.Task{}, nil)
+		reportConverter := &postprocessorstesting.NativeScanReportConverter{}
+		mock.OnAnything(reportConverter, "FromRelationalSchema").Return("", nil)
+		c.reportConverter = reportConverter
+
+		reports, err := c.GetReport(context.TODO(), suite.artifact, []string{v1.MimeTypeNativeReport})
+		suite.Require().NoError(err)
+		suite.Require().Len(reports, 2)
+		suite.Equal("rp-uuid-001", reports[0].UUID)
+		suite.Equal("rp-uuid-002", reports[1].UUID)
+	}
+}
+
 // TestScanControllerStop ...
 func (suite *ControllerTestSuite) TestScanControllerStop() {
 	{
@@ -497,6 +587,44 @@ func (suite *ControllerTestSuite) TestScanControllerGetReport() {
 	assert.Equal(suite.T(), 1, len(rep))
 }
 
+// TestScanControllerGetVulnerable ...
+func (suite *ControllerTestSuite) TestScanControllerGetVulnerable() {
+	mock.OnAnything(suite.ar, "HasUnscannableLayer").Return(false, nil).Once()
+	ctx := orm.NewContext(nil, &ormtesting.FakeOrmer{})
+	mock.OnAnything(suite.ar, "Walk").Return(nil).Run(func(args mock.Arguments) {
+		walkFn := args.Get(2).(func(*artifact.Artifact) error)
+		walkFn(suite.artifact)
+	}).Once()
+
+	mock.OnAnything(suite.taskMgr, "ListScanTasksByReportUUID").Return([]*task.Task{
+		{ExtraAttrs: suite.makeExtraAttrs(int64(1), "rp-uuid-001"), Status: "Success"},
+	}, nil).Once()
+	mock.OnAnything(suite.accessoryMgr, "List").Return(nil, nil)
+	reportConverter := suite.c.reportConverter
+	defer func() { suite.c.reportConverter = reportConverter }()
+	suite.c.reportConverter = &postprocessorstesting.NativeScanReportConverter{}
+	mock.OnAnything(suite.c.reportConverter, "FromRelationalSchema").Return(suite.rawReport, nil).Once()
+	mock.OnAnything(suite.vexMgr, "ListStatements").Return([]*vex.Statement{
+		{
+			VulnerabilityID: "2019-0980-0909",
+			Products:        []string{"library/photon"},
+			Subcomponents:   []string{"pkg:deb/photon/dpkg@0.9.1"},
+			Status:          vex.StatusNotAffected,
+			Justification:   vex.JustificationComponentNotPresent,
+		},
+	}, nil).Once()
+
+	vulnerable, err := suite.c.GetVulnerable(ctx, suite.artifact, nil, false)
+	suite.Require().NoError(err)
+	suite.True(vulnerable.IsScanSuccess())
+	suite.Equal(0, vulnerable.VulnerabilitiesCount)
+	suite.Empty(vulnerable.Vulnerabilities)
+	suite.Nil(vulnerable.Severity)
+	suite.Require().Len(vulnerable.CVESuppressed, 1)
+	suite.Equal("2019-0980-0909", vulnerable.CVESuppressed[0].ID)
+	suite.Equal(vex.JustificationComponentNotPresent, vulnerable.CVESuppressed[0].Justification)
+}
+
 // TestScanControllerGetScanLog ...
 func (suite *ControllerTestSuite) TestScanControllerGetScanLog() {
 	mock.OnAnything(suite.ar, "HasUnscannableLayer").Return(false, nil).Once()
@@ -623,7 +751,7 @@ func (suite *ControllerTestSuite) TestScanAll() {
 
 		suite.cache.On("Contains", mock.Anything, scanAllStoppedKey(1)).Return(false).Once()
 
-		_, err := suite.c.ScanAll(context.TODO(), "SCHEDULE", false)
+		_, err := suite.c.ScanAll(context.TODO(), "SCHEDULE", false, nil)
 		suite.NoError(err)
 	}
 
@@ -652,11 +780,91 @@ func (suite *ControllerTestSuite) TestScanAll() {
 		mock.OnAnything(suite.execMgr, "UpdateExtraAttrs").Return(nil).Once()
 		suite.execMgr.On("MarkError", mock.Anything, executionID, mock.Anything).Return(nil).Once()
 
-		_, err := suite.c.ScanAll(ctx, "SCHEDULE", false)
+		_, err := suite.c.ScanAll(ctx, "SCHEDULE", false, nil)
 		suite.NoError(err)
 	}
 }
 
+func (suite *ControllerTestSuite) TestRescanOnDBUpdate() {
+	sc := &scannertesting.Controller{}
+	execMgr := &tasktesting.ExecutionManager{}
+
+	c := *suite.c
+	c.sc = sc
+	c.execMgr = execMgr
+
+	{
+		// check database updates failed
+		sc.On("CheckDBUpdates", mock.Anything).Return(nil, fmt.Errorf("failed")).Once()
+		_, err := c.RescanOnDBUpdate(context.TODO(), "SCHEDULE")
+		suite.Error(err)
+	}
+
+	{
+		// no database updated
+		sc.On("CheckDBUpdates", mock.Anything).Return(nil, nil).Once()
+		id, err := c.RescanOnDBUpdate(context.TODO(), "SCHEDULE")
+		suite.NoError(err)
+		suite.Equal(int64(0), id)
+	}
+
+	{
+		// create execution failed
+		sc.On("CheckDBUpdates", mock.Anything).Return([]*scanner.Registration{suite.registration}, nil).Once()
+		execMgr.On("Create", mock.Anything, "SCAN_ON_DB_UPDATE", int64(0), "SCHEDULE", mock.Anything).Return(int64(0), fmt.Errorf("failed")).Once()
+		_, err := c.RescanOnDBUpdate(context.TODO(), "SCHEDULE")
+		suite.Error(err)
+	}
+
+	sc.AssertExpectations(suite.T())
+	execMgr.AssertExpectations(suite.T())
+}
+
+func (suite *ControllerTestSuite) TestWaitForScanners() {
+	sc := &scannertesting.Controller{}
+	sc.On("GetRegistrationsByProject", mock.Anything, int64(1)).Return([]*scanner.Registration{suite.registration}, nil)
+	taskMgr := &tasktesting.Manager{}
+	mc := &mockcache.Cache{}
+
+	c := *suite.c
+	c.sc = sc
+	c.taskMgr = taskMgr
+	c.cache = func() cache.Cache { return mc }
+
+	interval := scannerPollInterval
+	scannerPollInterval = time.Millisecond
+	defer func() { scannerPollInterval = interval }()
+
+	busy := []*task.Task{
+		{ExtraAttrs: map[string]any{registrationUUIDKey: suite.registration.UUID}},
+		{ExtraAttrs: map[string]any{registrationUUIDKey: "another-uuid"}},
+	}
+
+	{
+		// the scanner is busy at first, then available
+		taskMgr.On("List", mock.Anything, mock.Anything).Return(busy, nil).Once()
+		taskMgr.On("List", mock.Anything, mock.Anything).Return(busy[1:], nil).Once()
+		mc.On("Contains", mock.Anything, scanAllStoppedKey(1)).Return(false).Once()
+		suite.NoError(c.waitForScanners(context.TODO(), 1, 1, 1))
+	}
+
+	{
+		// the scan all is stopped while waiting
+		taskMgr.On("List", mock.Anything, mock.Anything).Return(busy, nil).Once()
+		mc.On("Contains", mock.Anything, scanAllStoppedKey(1)).Return(true).Once()
+		suite.Equal(errScanAllStopped, c.waitForScanners(context.TODO(), 1, 1, 1))
+	}
+
+	{
+		// list tasks failed
+		taskMgr.On("List", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("failed")).Once()
+		suite.Error(c.waitForScanners(context.TODO(), 1, 1, 1))
+	}
+
+	taskMgr.AssertExpectations(suite.T())
+	mc.AssertExpectations(suite.T())
+}
+
 func (suite *ControllerTestSuite) TestStopScanAll() {
 	mockExecID := int64(100)
 	// mock error case
@@ -16,7 +16,10 @@ package scan
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/binary"
 	"encoding/json"
+	"hash/fnv"
 
 	"github.com/goharbor/harbor/src/common/secret"
 	"github.com/goharbor/harbor/src/controller/artifact"
@@ -24,8 +27,13 @@ import (
 	"github.com/goharbor/harbor/src/controller/event/operator"
 	"github.com/goharbor/harbor/src/controller/robot"
 	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+	"github.com/goharbor/harbor/src/pkg/scan/postprocessors"
+	"github.com/goharbor/harbor/src/pkg/scan/report"
 	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
 	"github.com/goharbor/harbor/src/pkg/task"
@@ -34,6 +42,14 @@ import (
 const (
 	// ScanAllCallback the scheduler callback name of the scan all
 	ScanAllCallback = "scanAll"
+	// ScanOnDBUpdateCallback the scheduler callback name of the vulnerability database update checking
+	ScanOnDBUpdateCallback = "scanOnDBUpdate"
+
+	cronTypeCustom = "Custom"
+	// run for every hour
+	scanOnDBUpdateCron = "0 0 * * * *"
+	// systemVendorID represents the id for system job.
+	systemVendorID = -1
 )
 
 var (
@@ -42,6 +58,9 @@ var (
 	scanCtl     = DefaultController
 	taskMgr     = task.Mgr
 	execMgr     = task.ExecMgr
+	reportMgr   = report.Mgr
+	converter   = postprocessors.Converter
+	sched       = scheduler.Sched
 )
 
 func init() {
@@ -49,11 +68,19 @@ func init() {
 		log.Fatalf("failed to register the callback for the scan all schedule, error %v", err)
 	}
 
+	if err := scheduler.RegisterCallbackFunc(ScanOnDBUpdateCallback, scanOnDBUpdateCallback); err != nil {
+		log.Fatalf("failed to register the callback for the scan on database update schedule, error %v", err)
+	}
+
 	// NOTE: the vendor type of execution for the scan job trigger by the scan all is VendorTypeScanAll
 	if err := task.RegisterTaskStatusChangePostFunc(job.ScanAllVendorType, scanTaskStatusChange); err != nil {
 		log.Fatalf("failed to register the task status change post for the scan all job, error %v", err)
 	}
 
+	if err := task.RegisterTaskStatusChangePostFunc(job.ScanOnDBUpdateVendorType, scanTaskStatusChange); err != nil {
+		log.Fatalf("failed to register the task status change post for the scan on database update job, error %v", err)
+	}
+
 	if err := task.RegisterTaskStatusChangePostFunc(job.ImageScanJobVendorType, scanTaskStatusChange); err != nil {
 		log.Fatalf("failed to register the task status change post for the scan job, error %v", err)
 	}
@@ -63,6 +90,7 @@ func init() {
 }
 
 func scanAllCallback(ctx context.Context, param string) error {
+	var scope *ScanAllScope
 	if param != "" {
 		params := make(map[string]any)
 		if err := json.Unmarshal([]byte(param), &params); err != nil {
@@ -72,12 +100,124 @@ func scanAllCallback(ctx context.Context, param string) error {
 		if op, ok := params["operator"].(string); ok {
 			ctx = context.WithValue(ctx, operator.ContextKey{}, op)
 		}
+
+		if sp, ok := params["scope"].(map[string]any); ok {
+			var err error
+			if scope, err = ParseScanAllScope(sp); err != nil {
+				return err
+			}
+		}
 	}
 
-	_, err := scanCtl.ScanAll(ctx, task.ExecutionTriggerSchedule, true)
+	_, err := scanCtl.ScanAll(ctx, task.ExecutionTriggerSchedule, true, scope)
 	return err
 }
 
+func scanOnDBUpdateCallback(ctx context.Context, _ string) error {
+	if !config.ScanOnDBUpdateEnabled(ctx) {
+		return nil
+	}
+
+	_, err := scanCtl.RescanOnDBUpdate(ctx, task.ExecutionTriggerSchedule)
+	return err
+}
+
+// ScheduleDBUpdateCheck schedules the system job to check the vulnerability database updates of the scanners.
+func ScheduleDBUpdateCheck(ctx context.Context) error {
+	schedules, err := sched.ListSchedules(ctx, q.New(q.KeyWords{"vendor_type": job.ScanOnDBUpdateVendorType}))
+	if err != nil {
+		return err
+	}
+
+	if len(schedules) > 0 {
+		// unschedule the job if the cron changed
+		if schedules[0].CRON == scanOnDBUpdateCron {
+			log.Debug("skip to schedule the database update checking job because the old one existed and cron not changed")
+			return nil
+		}
+
+		if err = sched.UnScheduleByID(ctx, schedules[0].ID); err != nil {
+			return err
+		}
+	}
+
+	scheduleID, err := sched.Schedule(ctx, job.ScanOnDBUpdateVendorType, systemVendorID, cronTypeCustom, scanOnDBUpdateCron, ScanOnDBUpdateCallback, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("scheduled the database update checking job, id: %d", scheduleID)
+	return nil
+}
+
+// hashVulnerabilityKeys packs the 32-bit FNV-1a hashes of the vulnerability keys into a base64 string,
+// it's several times smaller than the keys and a new vulnerability colliding with the known ones is unlikely
+func hashVulnerabilityKeys(keys []string) string {
+	data := make([]byte, 0, 4*len(keys))
+	for _, key := range keys {
+		data = binary.BigEndian.AppendUint32(data, hashVulnerabilityKey(key))
+	}
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+func hashVulnerabilityKey(key string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return h.Sum32()
+}
+
+// hasNewFindings returns false only when the reports of the task contain no vulnerabilities other than the known ones
+func hasNewFindings(ctx context.Context, t *task.Task) bool {
+	hashes, ok := t.ExtraAttrs[knownVulnerabilitiesKey].(string)
+	if !ok {
+		return true
+	}
+	data, err := base64.StdEncoding.DecodeString(hashes)
+	if err != nil || len(data)%4 != 0 {
+		log.G(ctx).Warningf("invalid known vulnerabilities of the task %d", t.ID)
+		return true
+	}
+
+	known := make(map[uint32]struct{}, len(data)/4)
+	for i := 0; i < len(data); i += 4 {
+		known[binary.BigEndian.Uint32(data[i:])] = struct{}{}
+	}
+
+	var reports []*scan.Report
+	for _, uuid := range GetReportUUIDs(t.ExtraAttrs) {
+		rps, err := reportMgr.List(ctx, q.New(q.KeyWords{"uuid": uuid}))
+		if err != nil {
+			log.G(ctx).Warningf("failed to list the report %s, error: %v", uuid, err)
+			return true
+		}
+
+		for _, rp := range rps {
+			completeReport, err := converter.FromRelationalSchema(ctx, rp.UUID, rp.Digest, rp.Report)
+			if err != nil {
+				log.G(ctx).Warningf("failed to convert the report %s, error: %v", uuid, err)
+				return true
+			}
+			rp.Report = completeReport
+		}
+
+		reports = append(reports, rps...)
+	}
+
+	keys, err := report.Reports(reports).VulnerabilityKeys()
+	if err != nil {
+		log.G(ctx).Warningf("failed to get the vulnerabilities of the reports, error: %v", err)
+		return true
+	}
+
+	for _, key := range keys {
+		if _, ok := known[hashVulnerabilityKey(key)]; !ok {
+			return true
+		}
+	}
+
+	return false
+}
+
 func scanTaskStatusChange(ctx context.Context, taskID int64, status string) (err error) {
 	logger := log.G(ctx).WithFields(log.Fields{"task_id": taskID, "status": status})
 
@@ -120,6 +260,11 @@ func scanTaskStatusChange(ctx context.Context, taskID int64, status string) (err
 					},
 					Status: status,
 				}
+				if js == job.SuccessStatus && !hasNewFindings(ctx, t) {
+					// the rescan found nothing new, the event still fires to reconcile the resolved vulnerabilities
+					logger.WithField("artifact_id", artifactID).Debug("no new vulnerability found by the rescan")
+					e.NoNewFindings = true
+				}
 
 				if operator, ok := exec.ExtraAttrs["operator"].(string); ok {
 					e.Operator = operator
@@ -25,6 +25,9 @@ import (
 	"github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 	"github.com/goharbor/harbor/src/pkg/task"
 	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
 	robottesting "github.com/goharbor/harbor/src/testing/controller/robot"
@@ -71,6 +74,9 @@ func (suite *CallbackTestSuite) SetupSuite() {
 
 	suite.reportConverter = &postprocessorstesting.NativeScanReportConverter{}
 
+	reportMgr = suite.reportMgr
+	converter = suite.reportConverter
+
 	suite.scanCtl = &basicController{
 		makeCtx:         context.TODO,
 		manager:         suite.reportMgr,
@@ -168,6 +174,55 @@ func (suite *CallbackTestSuite) TestScanAllCallback() {
 	}
 }
 
+func (suite *CallbackTestSuite) TestHasNewFindings() {
+	makeTask := func(known []string) *task.Task {
+		b, _ := json.Marshal(map[string]any{reportUUIDsKey: []string{"uuid"}, knownVulnerabilitiesKey: hashVulnerabilityKeys(known)})
+
+		extraAttrs := map[string]any{}
+		json.Unmarshal(b, &extraAttrs)
+
+		return &task.Task{ExtraAttrs: extraAttrs}
+	}
+
+	data, _ := json.Marshal(&vuln.Report{
+		Vulnerabilities: []*vuln.VulnerabilityItem{
+			{ID: "CVE-1", Package: "curl", Version: "7.0"},
+			{ID: "CVE-2", Package: "openssl", Version: "1.1"},
+		},
+	})
+	rp := &scan.Report{UUID: "uuid", Digest: "digest", MimeType: v1.MimeTypeNativeReport}
+
+	{
+		// not a rescan
+		suite.True(hasNewFindings(suite.ctx, &task.Task{ExtraAttrs: suite.makeExtraAttrs(1, 0)}))
+	}
+
+	{
+		// no new vulnerability
+		suite.reportMgr.On("List", mock.Anything, mock.Anything).Return([]*scan.Report{rp}, nil).Once()
+		suite.reportConverter.On("FromRelationalSchema", mock.Anything, "uuid", "digest", mock.Anything).Return(string(data), nil).Once()
+		suite.False(hasNewFindings(suite.ctx, makeTask([]string{"CVE-1-curl-7.0", "CVE-2-openssl-1.1"})))
+	}
+
+	{
+		// new vulnerability found
+		suite.reportMgr.On("List", mock.Anything, mock.Anything).Return([]*scan.Report{rp}, nil).Once()
+		suite.reportConverter.On("FromRelationalSchema", mock.Anything, "uuid", "digest", mock.Anything).Return(string(data), nil).Once()
+		suite.True(hasNewFindings(suite.ctx, makeTask([]string{"CVE-1-curl-7.0"})))
+	}
+
+	{
+		// invalid known vulnerabilities
+		suite.True(hasNewFindings(suite.ctx, &task.Task{ExtraAttrs: map[string]any{knownVulnerabilitiesKey: "invalid"}}))
+	}
+
+	{
+		// list reports failed
+		suite.reportMgr.On("List", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("failed")).Once()
+		suite.True(hasNewFindings(suite.ctx, makeTask([]string{})))
+	}
+}
+
 func (suite *CallbackTestSuite) makeExtraAttrs(artifactID, robotID int64) map[string]any {
 	b, _ := json.Marshal(map[string]any{artifactIDKey: artifactID, robotIDKey: robotID})
 
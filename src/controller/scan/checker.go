@@ -38,7 +38,7 @@ func NewChecker() Checker {
 		artifactCtl:   artifact.Ctl,
 		accMgr:        accessory.Mgr,
 		scannerCtl:    scanner.DefaultController,
-		registrations: map[int64]*models.Registration{},
+		registrations: map[int64][]*models.Registration{},
 	}
 }
 
@@ -46,7 +46,7 @@ type checker struct {
 	artifactCtl   artifact.Controller
 	accMgr        accessory.Manager
 	scannerCtl    scanner.Controller
-	registrations map[int64]*models.Registration
+	registrations map[int64][]*models.Registration
 }
 
 func (c *checker) IsScannable(ctx context.Context, art *artifact.Artifact) (bool, error) {
@@ -56,19 +56,19 @@ func (c *checker) IsScannable(ctx context.Context, art *artifact.Artifact) (bool
 
 	projectID := art.ProjectID
 
-	r, ok := c.registrations[projectID]
+	rs, ok := c.registrations[projectID]
 	if !ok {
-		registration, err := c.scannerCtl.GetRegistrationByProject(ctx, projectID)
+		registrations, err := c.scannerCtl.GetRegistrationsByProject(ctx, projectID)
 		if err != nil {
 			return false, err
 		}
 
-		if registration == nil {
-			return false, nil
-		}
+		rs = registrations
+		c.registrations[projectID] = registrations
+	}
 
-		r = registration
-		c.registrations[projectID] = registration
+	if len(rs) == 0 {
+		return false, nil
 	}
 
 	var scannable bool
@@ -82,9 +82,12 @@ func (c *checker) IsScannable(ctx context.Context, art *artifact.Artifact) (bool
 			return nil
 		}
 
-		if hasCapability(r, a) {
-			scannable = true
-			return artifact.ErrBreak
+		// the artifact is scannable when any scanner of the project has capability for it
+		for _, r := range rs {
+			if hasCapability(r, a) {
+				scannable = true
+				return artifact.ErrBreak
+			}
 		}
 
 		// because there are lots of in-toto sbom artifacts in dockerhub and replicated to Harbor, they are considered as image type
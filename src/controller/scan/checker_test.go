@@ -43,7 +43,7 @@ func (suite *CheckerTestSuite) new() *checker {
 		artifactCtl:   artifactCtl,
 		scannerCtl:    scannerCtl,
 		accMgr:        accessoryMgr,
-		registrations: map[int64]*scanner.Registration{},
+		registrations: map[int64][]*scanner.Registration{},
 	}
 }
 
@@ -51,7 +51,7 @@ func (suite *CheckerTestSuite) TestScannerNotFound() {
 	c := suite.new()
 
 	{
-		mock.OnAnything(c.scannerCtl, "GetRegistrationByProject").Return(nil, nil)
+		mock.OnAnything(c.scannerCtl, "GetRegistrationsByProject").Return(nil, nil)
 
 		isScannable, err := c.IsScannable(context.TODO(), &artifact.Artifact{})
 		suite.Nil(err)
@@ -64,10 +64,20 @@ func (suite *CheckerTestSuite) TestIsScannable() {
 
 	supportMimeType := "support mime type"
 
-	mock.OnAnything(c.scannerCtl, "GetRegistrationByProject").Return(&scanner.Registration{
-		Metadata: &v1.ScannerAdapterMetadata{
-			Capabilities: []*v1.ScannerCapability{
-				{ConsumesMimeTypes: []string{supportMimeType}},
+	// only the additional scanner supports the mime type
+	mock.OnAnything(c.scannerCtl, "GetRegistrationsByProject").Return([]*scanner.Registration{
+		{
+			Metadata: &v1.ScannerAdapterMetadata{
+				Capabilities: []*v1.ScannerCapability{
+					{ConsumesMimeTypes: []string{"another mime type"}},
+				},
+			},
+		},
+		{
+			Metadata: &v1.ScannerAdapterMetadata{
+				Capabilities: []*v1.ScannerCapability{
+					{ConsumesMimeTypes: []string{supportMimeType}},
+				},
 			},
 		},
 	}, nil)
@@ -30,6 +30,18 @@ type Vulnerable struct {
 	ScanStatus           string
 	Severity             *vuln.Severity
 	CVEBypassed          []string
+	// CVESuppressed the CVEs declared not affected by the VEX statements
+	CVESuppressed []*SuppressedCVE
+	// Vulnerabilities the vulnerability items which are neither bypassed nor suppressed
+	Vulnerabilities []*vuln.VulnerabilityItem
+}
+
+// SuppressedCVE is the CVE suppressed by the not_affected VEX statement
+type SuppressedCVE struct {
+	ID              string
+	Package         string
+	Justification   string
+	ImpactStatement string
 }
 
 // IsScanSuccess returns true when the artifact scanned success
@@ -102,10 +114,11 @@ type Controller interface {
 	//     ctx context.Context : the context for this method
 	//     trigger string      : the trigger mode to start the scan all job
 	//     async bool          : scan all the artifacts in background
+	//     scope *ScanAllScope : the scope of the artifacts to scan, nil means all the artifacts
 	//
 	//   Returns:
 	//     error  : non nil error if any errors occurred
-	ScanAll(ctx context.Context, trigger string, async bool) (int64, error)
+	ScanAll(ctx context.Context, trigger string, async bool, scope *ScanAllScope) (int64, error)
 
 	// StopScanAll stops the scanAll
 	//
@@ -117,7 +130,18 @@ type Controller interface {
 	//     error  : non nil error if any errors occurred
 	StopScanAll(ctx context.Context, executionID int64, async bool) error
 
-	// GetVulnerable returns the vulnerable of the artifact for the allowlist
+	// RescanOnDBUpdate rescans the recently pulled artifacts in background when the vulnerability database of the scanners updates
+	//
+	//   Arguments:
+	//     ctx context.Context : the context for this method
+	//     trigger string      : the trigger mode to start the rescan
+	//
+	//   Returns:
+	//     int64  : the id of the execution, 0 if no vulnerability database updated
+	//     error  : non nil error if any errors occurred
+	RescanOnDBUpdate(ctx context.Context, trigger string) (int64, error)
+
+	// GetVulnerable returns the vulnerable of the artifact for the allowlist and the VEX statements
 	//
 	//   Arguments:
 	//     ctx context.Context : the context for this method
@@ -129,4 +153,15 @@ type Controller interface {
 	//      *Vulnerable : the vulnerable
 	//     error        : non nil error if any errors occurred
 	GetVulnerable(ctx context.Context, artifact *artifact.Artifact, allowlist allowlist.CVESet, allowlistIsExpired bool) (*Vulnerable, error)
+
+	// EvictVEXCache evicts the cached VEX statements of the artifacts in the project, it's called when
+	// the VEX documents of the project are changed
+	//
+	//   Arguments:
+	//     ctx context.Context : the context for this method
+	//     projectID int64     : the id of the project
+	//
+	//   Returns:
+	//     error  : non nil error if any errors occurred
+	EvictVEXCache(ctx context.Context, projectID int64) error
 }
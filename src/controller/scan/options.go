@@ -22,6 +22,8 @@ type Options struct {
 	Tag         string // The tag of the artifact to scan
 	ScanType    string // The scan type could be sbom or vulnerability
 	FromEvent   bool   // indicate the current call from event or not
+	// NewFindingsOnly indicates to fire the scanning completed event only when new vulnerabilities are found
+	NewFindingsOnly bool
 }
 
 // GetScanType returns the scan type. for backward compatibility, the default type is vulnerability.
@@ -72,3 +74,11 @@ func WithFromEvent(fromEvent bool) Option {
 		return nil
 	}
 }
+
+// WithNewFindingsOnly sets to fire the scanning completed event only when new vulnerabilities are found
+func WithNewFindingsOnly(newFindingsOnly bool) Option {
+	return func(options *Options) error {
+		options.NewFindingsOnly = newFindingsOnly
+		return nil
+	}
+}
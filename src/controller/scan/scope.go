@@ -0,0 +1,204 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/bmatcuk/doublestar"
+
+	ar "github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+)
+
+// ScanAllScope limits the artifacts scanned by the scan all and the pace of the scanning.
+// The zero value scans all the artifacts in the system.
+type ScanAllScope struct {
+	// ProjectIDs only scans the artifacts of the projects
+	ProjectIDs []int64 `json:"project_ids,omitempty"`
+	// Repositories only scans the artifacts of the repositories matched the doublestar patterns, e.g. library/**
+	Repositories []string `json:"repositories,omitempty"`
+	// PulledWithinDays only scans the artifacts pulled within the days
+	PulledWithinDays int `json:"pulled_within_days,omitempty"`
+	// OrderByPullTime scans the most recently pulled artifacts first
+	OrderByPullTime bool `json:"order_by_pull_time,omitempty"`
+	// ScannerConcurrency limits the ongoing scan jobs submitted to each scanner, 0 means no limit
+	ScannerConcurrency int `json:"scanner_concurrency,omitempty"`
+}
+
+// ParseScanAllScope parses the scope from the parameters of the scan all
+func ParseScanAllScope(params map[string]any) (*ScanAllScope, error) {
+	scope := &ScanAllScope{}
+	if len(params) == 0 {
+		return scope, nil
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, scope); err != nil {
+		return nil, errors.BadRequestError(err).WithMessagef("invalid scan all parameters: %v", err)
+	}
+
+	if err := scope.Validate(); err != nil {
+		return nil, err
+	}
+
+	return scope, nil
+}
+
+// Validate validates the scope
+func (s *ScanAllScope) Validate() error {
+	if s.PulledWithinDays < 0 {
+		return errors.BadRequestError(nil).WithMessagef("invalid pulled_within_days %d, it must not be negative", s.PulledWithinDays)
+	}
+
+	if s.ScannerConcurrency < 0 {
+		return errors.BadRequestError(nil).WithMessagef("invalid scanner_concurrency %d, it must not be negative", s.ScannerConcurrency)
+	}
+
+	for _, pattern := range s.Repositories {
+		// matching the pattern against itself walks through all the components of it to catch the malformed one
+		if _, err := doublestar.Match(pattern, pattern); err != nil {
+			return errors.BadRequestError(err).WithMessagef("invalid repository pattern %s", pattern)
+		}
+	}
+
+	return nil
+}
+
+// ToMap converts the scope to the map which is kept in the extra attributes of the execution and the schedule
+func (s *ScanAllScope) ToMap() map[string]any {
+	result := map[string]any{}
+	if s == nil {
+		return result
+	}
+
+	data, _ := json.Marshal(s)
+	_ = json.Unmarshal(data, &result)
+
+	return result
+}
+
+// IsEmpty returns true when the scope scans all the artifacts without limitation
+func (s *ScanAllScope) IsEmpty() bool {
+	return s == nil || len(s.ToMap()) == 0
+}
+
+// query returns the query of the artifacts in the scope
+func (s *ScanAllScope) query() *q.Query {
+	kw := q.KeyWords{}
+	if s == nil {
+		return q.New(kw)
+	}
+
+	if len(s.ProjectIDs) > 0 {
+		ids := make([]any, len(s.ProjectIDs))
+		for i, id := range s.ProjectIDs {
+			ids[i] = id
+		}
+		kw["project_id"] = &q.OrList{Values: ids}
+	}
+
+	if s.PulledWithinDays > 0 {
+		kw["pull_time"] = &q.Range{Min: time.Now().AddDate(0, 0, -s.PulledWithinDays)}
+	}
+
+	return q.New(kw)
+}
+
+// artifacts iterates the artifacts in the scope, they are paged by ID by default. When ordering by the pull time,
+// they are paged by the keyset of (pull time, ID) in the database and the artifacts never pulled come last, the
+// artifacts pulled during the iteration move ahead of the current page and are left to the next scan.
+func (s *ScanAllScope) artifacts(ctx context.Context, batchSize int) <-chan *ar.Artifact {
+	if s == nil || !s.OrderByPullTime {
+		return ar.KeysetIterator(ctx, batchSize, s.query(), nil)
+	}
+
+	ch := make(chan *ar.Artifact, batchSize)
+	go func() {
+		defer close(ch)
+
+		query := s.query()
+		query.PageSize = int64(batchSize)
+		var last *artifact.Artifact
+		for {
+			keys, err := pkg.ArtifactMgr.ListByPullTime(ctx, query, last)
+			if err != nil {
+				log.G(ctx).Errorf("list artifacts by pull time failed, error: %v", err)
+				return
+			}
+			if len(keys) == 0 {
+				return
+			}
+			last = keys[len(keys)-1]
+
+			// the controller filters out the artifacts only referenced by the others, e.g. the children of the index
+			ids := make([]any, len(keys))
+			for i, key := range keys {
+				ids[i] = key.ID
+			}
+			artifacts, err := ar.Ctl.List(ctx, q.New(q.KeyWords{"id": &q.OrList{Values: ids}}), nil)
+			if err != nil {
+				log.G(ctx).Errorf("list artifacts failed, error: %v", err)
+				return
+			}
+			found := make(map[int64]*ar.Artifact, len(artifacts))
+			for _, art := range artifacts {
+				found[art.ID] = art
+			}
+			for _, key := range keys {
+				art, exist := found[key.ID]
+				if !exist {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case ch <- art:
+				}
+			}
+
+			if len(keys) < batchSize {
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// match returns true when the artifact is in the repositories of the scope
+func (s *ScanAllScope) match(art *ar.Artifact) bool {
+	if s == nil || len(s.Repositories) == 0 {
+		return true
+	}
+
+	for _, pattern := range s.Repositories {
+		if matched, _ := doublestar.Match(pattern, art.RepositoryName); matched {
+			return true
+		}
+	}
+
+	return false
+}
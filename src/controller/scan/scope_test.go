@@ -0,0 +1,139 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	ar "github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
+	artifactmgrtesting "github.com/goharbor/harbor/src/testing/pkg/artifact"
+)
+
+func TestParseScanAllScope(t *testing.T) {
+	scope, err := ParseScanAllScope(nil)
+	require.NoError(t, err)
+	assert.True(t, scope.IsEmpty())
+
+	scope, err = ParseScanAllScope(map[string]any{
+		"project_ids":         []any{float64(1), float64(2)},
+		"repositories":        []any{"library/**"},
+		"pulled_within_days":  float64(7),
+		"order_by_pull_time":  true,
+		"scanner_concurrency": float64(10),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &ScanAllScope{
+		ProjectIDs:         []int64{1, 2},
+		Repositories:       []string{"library/**"},
+		PulledWithinDays:   7,
+		OrderByPullTime:    true,
+		ScannerConcurrency: 10,
+	}, scope)
+	assert.False(t, scope.IsEmpty())
+	assert.Equal(t, float64(7), scope.ToMap()["pulled_within_days"])
+
+	_, err = ParseScanAllScope(map[string]any{"pulled_within_days": float64(-1)})
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+
+	_, err = ParseScanAllScope(map[string]any{"scanner_concurrency": float64(-1)})
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+
+	_, err = ParseScanAllScope(map[string]any{"repositories": []any{"library/[a"}})
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+
+	_, err = ParseScanAllScope(map[string]any{"project_ids": "1"})
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+}
+
+func TestScanAllScopeQuery(t *testing.T) {
+	// scan all the artifacts
+	query := (*ScanAllScope)(nil).query()
+	assert.Empty(t, query.Keywords)
+
+	// scan the artifacts of the projects pulled recently
+	query = (&ScanAllScope{ProjectIDs: []int64{1}, PulledWithinDays: 3}).query()
+	assert.Equal(t, &q.OrList{Values: []any{int64(1)}}, query.Keywords["project_id"])
+	assert.IsType(t, &q.Range{}, query.Keywords["pull_time"])
+}
+
+func TestScanAllScopeArtifacts(t *testing.T) {
+	ctl, artMgr := ar.Ctl, pkg.ArtifactMgr
+	defer func() {
+		ar.Ctl, pkg.ArtifactMgr = ctl, artMgr
+	}()
+	artCtl := &artifacttesting.Controller{}
+	ar.Ctl = artCtl
+	mgr := &artifactmgrtesting.Manager{}
+	pkg.ArtifactMgr = mgr
+
+	now := time.Now()
+	page1 := []*artifact.Artifact{
+		{ID: 3, PullTime: now},
+		{ID: 2, PullTime: now.Add(-time.Hour)},
+	}
+	page2 := []*artifact.Artifact{
+		{ID: 4, PullTime: now.Add(-time.Hour)},
+		{ID: 1},
+	}
+	mgr.On("ListByPullTime", mock.Anything, mock.Anything, (*artifact.Artifact)(nil)).Return(page1, nil).Once()
+	mgr.On("ListByPullTime", mock.Anything, mock.Anything, page1[1]).Return(page2, nil).Once()
+	mgr.On("ListByPullTime", mock.Anything, mock.Anything, page2[1]).Return([]*artifact.Artifact{}, nil).Once()
+	artCtl.On("List", mock.Anything, mock.MatchedBy(func(query *q.Query) bool {
+		ol, ok := query.Keywords["id"].(*q.OrList)
+		return ok && ol.Values[0] == int64(3)
+	}), mock.Anything).Return([]*ar.Artifact{
+		{Artifact: artifact.Artifact{ID: 2}},
+		{Artifact: artifact.Artifact{ID: 3}},
+	}, nil)
+	// the artifact 4 is the child of an index
+	artCtl.On("List", mock.Anything, mock.MatchedBy(func(query *q.Query) bool {
+		ol, ok := query.Keywords["id"].(*q.OrList)
+		return ok && ol.Values[0] == int64(4)
+	}), mock.Anything).Return([]*ar.Artifact{
+		{Artifact: artifact.Artifact{ID: 1}},
+	}, nil)
+
+	var ids []int64
+	for art := range (&ScanAllScope{OrderByPullTime: true}).artifacts(context.TODO(), 2) {
+		ids = append(ids, art.ID)
+	}
+	// the most recently pulled artifacts come first, the never pulled ones come last
+	assert.Equal(t, []int64{3, 2, 1}, ids)
+	mgr.AssertExpectations(t)
+}
+
+func TestScanAllScopeMatch(t *testing.T) {
+	pulled := &ar.Artifact{Artifact: artifact.Artifact{RepositoryName: "library/nginx", PullTime: time.Now()}}
+	neverPulled := &ar.Artifact{Artifact: artifact.Artifact{RepositoryName: "library/redis"}}
+
+	var scope *ScanAllScope
+	assert.True(t, scope.match(pulled))
+	assert.True(t, scope.match(neverPulled))
+
+	scope = &ScanAllScope{Repositories: []string{"library/ng*", "dev/**"}}
+	assert.True(t, scope.match(pulled))
+	assert.False(t, scope.match(neverPulled))
+}
@@ -0,0 +1,205 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	ar "github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/lib/cache"
+	_ "github.com/goharbor/harbor/src/lib/cache/memory" // memory cache of the VEX statements
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	accessoryModel "github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	"github.com/goharbor/harbor/src/pkg/vex"
+)
+
+// newVEXCache returns the memory cache of the VEX statements applying to the artifacts
+func newVEXCache() cache.Cache {
+	c, _ := cache.New(cache.Memory, cache.Expiration(vexCacheExpiration))
+	return c
+}
+
+// vexCacheExpiration is how long the VEX statements applying to an artifact are cached, the reports are read
+// frequently, e.g. when listing the vulnerabilities, so the documents and the accessories are not read every time
+const vexCacheExpiration = time.Minute
+
+// vexStatements are the VEX statements applying to an artifact which are cached by the artifact
+type vexStatements struct {
+	// Product identifies the artifact which the statements of the project VEX documents are matched against
+	Product *vex.Product `json:"product,omitempty"`
+	// Project are the statements of the VEX documents uploaded to the project
+	Project []*vex.Statement `json:"project,omitempty"`
+	// Accessories are the statements of the VEX accessories attached to the artifact
+	Accessories []*vex.Statement `json:"accessories,omitempty"`
+}
+
+func vexCacheKey(artifact *ar.Artifact) string {
+	return fmt.Sprintf("%s%s@%s", vexCacheProjectPrefix(artifact.ProjectID), artifact.RepositoryName, artifact.Digest)
+}
+
+func vexCacheProjectPrefix(projectID int64) string {
+	return fmt.Sprintf("vex:%d:", projectID)
+}
+
+// EvictVEXCache evicts the cached VEX statements of the artifacts in the project
+func (bc *basicController) EvictVEXCache(ctx context.Context, projectID int64) error {
+	iter, err := bc.vexCache.Scan(ctx, vexCacheProjectPrefix(projectID))
+	if err != nil {
+		return err
+	}
+	for iter.Next(ctx) {
+		if err := bc.vexCache.Delete(ctx, iter.Val()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getVEXIndex returns the index of the VEX statements applying to the artifact, the statements come
+// from the VEX documents uploaded to the project and the VEX accessories attached to the artifact,
+// the statements of the accessories take precedence as they are bound to the artifact by the subject.
+func (bc *basicController) getVEXIndex(ctx context.Context, artifact *ar.Artifact) (*vex.Index, error) {
+	key := vexCacheKey(artifact)
+	sts := &vexStatements{}
+	if err := bc.vexCache.Fetch(ctx, key, sts); err != nil {
+		var complete bool
+		sts, complete, err = bc.listVEXStatements(ctx, artifact)
+		if err != nil {
+			return nil, err
+		}
+		// the statements are read again next time if any accessory is unreadable
+		if complete {
+			if err := bc.vexCache.Save(ctx, key, sts, vexCacheExpiration); err != nil {
+				log.G(ctx).Warningf("failed to cache the VEX statements of %s@%s, error: %v", artifact.RepositoryName, artifact.Digest, err)
+			}
+		}
+	}
+
+	index := vex.NewIndex()
+	if len(sts.Project) > 0 {
+		index.Add(sts.Product, sts.Project...)
+	}
+	index.Add(nil, sts.Accessories...)
+	return index, nil
+}
+
+// listVEXStatements reads the VEX statements applying to the artifact, false is returned if any VEX accessory is unreadable
+func (bc *basicController) listVEXStatements(ctx context.Context, artifact *ar.Artifact) (*vexStatements, bool, error) {
+	sts := &vexStatements{}
+
+	statements, err := bc.vexMgr.ListStatements(ctx, artifact.ProjectID)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(statements) > 0 {
+		product := &vex.Product{
+			Repository: artifact.RepositoryName,
+			Digest:     artifact.Digest,
+		}
+		tags, err := bc.tagCtl.List(ctx, q.New(q.KeyWords{"artifact_id": artifact.ID}), nil)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, t := range tags {
+			product.Tags = append(product.Tags, t.Name)
+		}
+		sts.Product = product
+		sts.Project = statements
+	}
+
+	accs, err := bc.acc.List(ctx, q.New(q.KeyWords{
+		"SubjectArtifactDigest": artifact.Digest,
+		"SubjectArtifactRepo":   artifact.RepositoryName,
+	}))
+	if err != nil {
+		return nil, false, err
+	}
+	complete := true
+	for _, acc := range accs {
+		data := acc.GetData()
+		if data.Type != accessoryModel.TypeOpenVEX && data.Type != accessoryModel.TypeCycloneDXVEX {
+			continue
+		}
+		statements, err := bc.vexMgr.ReadAccessory(ctx, artifact.RepositoryName, data.Digest)
+		if err != nil {
+			log.G(ctx).Warningf("failed to read the VEX accessory %s of %s@%s, error: %v", data.Digest, artifact.RepositoryName, artifact.Digest, err)
+			complete = false
+			continue
+		}
+		sts.Accessories = append(sts.Accessories, statements...)
+	}
+
+	return sts, complete, nil
+}
+
+// applyVEX annotates the vulnerabilities in the reports with the VEX statements applying to the artifact
+func (bc *basicController) applyVEX(ctx context.Context, artifact *ar.Artifact, reports ...*scan.Report) error {
+	var vulReports []*scan.Report
+	for _, r := range reports {
+		if len(r.Report) > 0 && (r.MimeType == v1.MimeTypeNativeReport || r.MimeType == v1.MimeTypeGenericVulnerabilityReport) {
+			vulReports = append(vulReports, r)
+		}
+	}
+	if len(vulReports) == 0 {
+		return nil
+	}
+
+	index, err := bc.getVEXIndex(ctx, artifact)
+	if err != nil {
+		return err
+	}
+	if index.Len() == 0 {
+		return nil
+	}
+
+	for _, r := range vulReports {
+		rp := &vuln.Report{}
+		if err := json.Unmarshal([]byte(r.Report), rp); err != nil {
+			return err
+		}
+
+		annotated := false
+		for _, v := range rp.Vulnerabilities {
+			s := index.Lookup(v.ID, v.Package, v.Version)
+			if s == nil {
+				continue
+			}
+			v.VEX = &vuln.VEXAnnotation{
+				Status:          s.Status,
+				Justification:   s.Justification,
+				ImpactStatement: s.ImpactStatement,
+			}
+			annotated = true
+		}
+		if !annotated {
+			continue
+		}
+
+		data, err := json.Marshal(rp)
+		if err != nil {
+			return err
+		}
+		r.Report = string(data)
+	}
+
+	return nil
+}
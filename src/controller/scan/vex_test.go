@@ -0,0 +1,191 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/tag"
+	"github.com/goharbor/harbor/src/lib/errors"
+	accessoryModel "github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/pkg/accessory/model/base"
+	art "github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	model_tag "github.com/goharbor/harbor/src/pkg/tag/model/tag"
+	"github.com/goharbor/harbor/src/pkg/vex"
+	tagtesting "github.com/goharbor/harbor/src/testing/controller/tag"
+	"github.com/goharbor/harbor/src/testing/mock"
+	accessorytesting "github.com/goharbor/harbor/src/testing/pkg/accessory"
+	vextesting "github.com/goharbor/harbor/src/testing/pkg/vex"
+)
+
+type VEXTestSuite struct {
+	suite.Suite
+
+	artifact *artifact.Artifact
+	acc      *accessorytesting.Manager
+	tagCtl   *tagtesting.FakeController
+	vexMgr   *vextesting.Manager
+	c        *basicController
+}
+
+func (suite *VEXTestSuite) SetupTest() {
+	suite.artifact = &artifact.Artifact{Artifact: art.Artifact{
+		ID:             1,
+		ProjectID:      1,
+		RepositoryName: "library/nginx",
+		Digest:         "sha256:05bef0d6a1a6f8d4d4bd8cd3b2d6b3ee49f6f5f9c2aa4bd5b2d1c1e2f3a4b5c6",
+	}}
+	suite.acc = &accessorytesting.Manager{}
+	suite.tagCtl = &tagtesting.FakeController{}
+	suite.vexMgr = &vextesting.Manager{}
+	suite.c = &basicController{
+		acc:      suite.acc,
+		tagCtl:   suite.tagCtl,
+		vexMgr:   suite.vexMgr,
+		vexCache: newVEXCache(),
+	}
+}
+
+func (suite *VEXTestSuite) TestGetVEXIndex() {
+	suite.vexMgr.On("ListStatements", mock.Anything, int64(1)).Return([]*vex.Statement{
+		{VulnerabilityID: "CVE-2023-0001", Products: []string{"library/nginx:1.25"}, Status: vex.StatusNotAffected},
+		{VulnerabilityID: "CVE-2023-0002", Products: []string{"library/nginx:1.24"}, Status: vex.StatusNotAffected},
+	}, nil)
+	suite.tagCtl.On("List", mock.Anything, mock.Anything, mock.Anything).Return([]*tag.Tag{
+		{Tag: model_tag.Tag{Name: "1.25"}},
+	}, nil)
+	suite.acc.On("List", mock.Anything, mock.Anything).Return([]accessoryModel.Accessory{
+		newAccessory(accessoryModel.TypeCosignSignature, "sha256:cosign"),
+		newAccessory(accessoryModel.TypeOpenVEX, "sha256:openvex"),
+		newAccessory(accessoryModel.TypeCycloneDXVEX, "sha256:broken"),
+	}, nil)
+	suite.vexMgr.On("ReadAccessory", mock.Anything, "library/nginx", "sha256:openvex").Return([]*vex.Statement{
+		{VulnerabilityID: "CVE-2023-0003", Products: []string{"pkg:oci/other"}, Status: vex.StatusAffected},
+	}, nil)
+	suite.vexMgr.On("ReadAccessory", mock.Anything, "library/nginx", "sha256:broken").Return(nil, errors.New("broken"))
+
+	index, err := suite.c.getVEXIndex(context.TODO(), suite.artifact)
+	suite.Require().NoError(err)
+	suite.NotNil(index.Lookup("CVE-2023-0001", "openssl", "3.0.7"))
+	suite.Nil(index.Lookup("CVE-2023-0002", "openssl", "3.0.7"))
+	// the statements of the accessories are bound to the subject artifact
+	suite.NotNil(index.Lookup("CVE-2023-0003", "openssl", "3.0.7"))
+	suite.vexMgr.AssertNotCalled(suite.T(), "ReadAccessory", mock.Anything, "library/nginx", "sha256:cosign")
+
+	// the statements are read again as the broken accessory isn't read
+	_, err = suite.c.getVEXIndex(context.TODO(), suite.artifact)
+	suite.Require().NoError(err)
+	suite.vexMgr.AssertNumberOfCalls(suite.T(), "ListStatements", 2)
+}
+
+func (suite *VEXTestSuite) TestGetVEXIndexCached() {
+	suite.vexMgr.On("ListStatements", mock.Anything, int64(1)).Return([]*vex.Statement{
+		{VulnerabilityID: "CVE-2023-0001", Products: []string{"library/nginx:1.25"}, Status: vex.StatusNotAffected},
+	}, nil)
+	suite.tagCtl.On("List", mock.Anything, mock.Anything, mock.Anything).Return([]*tag.Tag{
+		{Tag: model_tag.Tag{Name: "1.25"}},
+	}, nil)
+	suite.acc.On("List", mock.Anything, mock.Anything).Return([]accessoryModel.Accessory{
+		newAccessory(accessoryModel.TypeOpenVEX, "sha256:openvex"),
+	}, nil)
+	suite.vexMgr.On("ReadAccessory", mock.Anything, "library/nginx", "sha256:openvex").Return([]*vex.Statement{
+		{VulnerabilityID: "CVE-2023-0003", Status: vex.StatusFixed},
+	}, nil)
+
+	for range 2 {
+		index, err := suite.c.getVEXIndex(context.TODO(), suite.artifact)
+		suite.Require().NoError(err)
+		suite.NotNil(index.Lookup("CVE-2023-0001", "openssl", "3.0.7"))
+		suite.NotNil(index.Lookup("CVE-2023-0003", "openssl", "3.0.7"))
+	}
+	suite.vexMgr.AssertNumberOfCalls(suite.T(), "ListStatements", 1)
+	suite.vexMgr.AssertNumberOfCalls(suite.T(), "ReadAccessory", 1)
+}
+
+func (suite *VEXTestSuite) TestEvictVEXCache() {
+	suite.vexMgr.On("ListStatements", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.acc.On("List", mock.Anything, mock.Anything).Return(nil, nil)
+	other := &artifact.Artifact{Artifact: art.Artifact{
+		ID:             2,
+		ProjectID:      11,
+		RepositoryName: "other/nginx",
+		Digest:         suite.artifact.Digest,
+	}}
+
+	ctx := context.TODO()
+	for _, a := range []*artifact.Artifact{suite.artifact, other} {
+		_, err := suite.c.getVEXIndex(ctx, a)
+		suite.Require().NoError(err)
+	}
+	suite.Require().NoError(suite.c.EvictVEXCache(ctx, 1))
+	// only the statements of the artifacts in the project are read again
+	for _, a := range []*artifact.Artifact{suite.artifact, other} {
+		_, err := suite.c.getVEXIndex(ctx, a)
+		suite.Require().NoError(err)
+	}
+	suite.vexMgr.AssertNumberOfCalls(suite.T(), "ListStatements", 3)
+}
+
+func (suite *VEXTestSuite) TestApplyVEX() {
+	suite.vexMgr.On("ListStatements", mock.Anything, int64(1)).Return([]*vex.Statement{
+		{
+			VulnerabilityID: "CVE-2023-0001",
+			Subcomponents:   []string{"pkg:deb/debian/openssl@3.0.7"},
+			Status:          vex.StatusNotAffected,
+			Justification:   vex.JustificationVulnerableCodeNotInExecutePath,
+		},
+	}, nil)
+	suite.tagCtl.On("List", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	suite.acc.On("List", mock.Anything, mock.Anything).Return(nil, nil)
+
+	data, _ := json.Marshal(&vuln.Report{Vulnerabilities: []*vuln.VulnerabilityItem{
+		{ID: "CVE-2023-0001", Package: "openssl", Version: "3.0.7"},
+		{ID: "CVE-2023-0001", Package: "libssl3", Version: "3.0.7"},
+	}})
+	reports := []*scan.Report{
+		{MimeType: v1.MimeTypeNativeReport, Report: string(data)},
+		{MimeType: v1.MimeTypeSBOMReport, Report: `{"sbom_digest": "sha256:1234567890"}`},
+	}
+	suite.Require().NoError(suite.c.applyVEX(context.TODO(), suite.artifact, reports...))
+
+	rp := &vuln.Report{}
+	suite.Require().NoError(json.Unmarshal([]byte(reports[0].Report), rp))
+	suite.Require().Len(rp.Vulnerabilities, 2)
+	suite.True(rp.Vulnerabilities[0].VEX.IsNotAffected())
+	suite.Equal(vex.JustificationVulnerableCodeNotInExecutePath, rp.Vulnerabilities[0].VEX.Justification)
+	suite.Nil(rp.Vulnerabilities[1].VEX)
+	suite.Equal(`{"sbom_digest": "sha256:1234567890"}`, reports[1].Report)
+}
+
+func (suite *VEXTestSuite) TestApplyVEXWithoutReport() {
+	suite.Require().NoError(suite.c.applyVEX(context.TODO(), suite.artifact, &scan.Report{MimeType: v1.MimeTypeNativeReport}))
+	suite.vexMgr.AssertNotCalled(suite.T(), "ListStatements", mock.Anything, mock.Anything)
+}
+
+func newAccessory(typ, digest string) accessoryModel.Accessory {
+	return &base.Default{Data: accessoryModel.AccessoryData{Type: typ, Digest: digest}}
+}
+
+func TestVEXTestSuite(t *testing.T) {
+	suite.Run(t, &VEXTestSuite{})
+}
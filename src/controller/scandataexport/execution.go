@@ -129,6 +129,10 @@ func (c *controller) Start(ctx context.Context, request export.Request) (executi
 	extraAttrs[export.ProjectIDsAttribute] = request.Projects
 	extraAttrs[export.JobNameAttribute] = request.JobName
 	extraAttrs[export.UserNameAttribute] = request.UserName
+	if request.Format == "" {
+		request.Format = export.FormatCSV
+	}
+	extraAttrs[export.FormatAttribute] = request.Format
 	id, err := c.execMgr.Create(ctx, job.ScanDataExportVendorType, vendorID, task.ExecutionTriggerManual, extraAttrs)
 	logger.Infof("Created an execution record with id : %d for vendorID: %d", id, vendorID)
 	if err != nil {
@@ -200,6 +204,11 @@ func (c *controller) convertToExportExecStatus(ctx context.Context, exec *task.E
 	if statusMessage, ok := exec.ExtraAttrs[export.StatusMessageAttribute]; ok {
 		execStatus.StatusMessage = statusMessage.(string)
 	}
+	// the executions created before the format supported are exported as CSV
+	execStatus.Format = export.FormatCSV
+	if format, ok := exec.ExtraAttrs[export.FormatAttribute].(string); ok && format != "" {
+		execStatus.Format = format
+	}
 
 	if len(execStatus.ExportDataDigest) > 0 {
 		artifactExists := c.isCsvArtifactPresent(ctx, exec.ID, execStatus.ExportDataDigest)
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -36,8 +37,10 @@ import (
 
 const (
 	proScannerMetaKey = "projectScanner"
-	StatusUnhealthy   = "unhealthy"
-	StatusHealthy     = "healthy"
+	// proAdditionalScannersMetaKey keeps the UUIDs of the additional scanners of the project separated by comma
+	proAdditionalScannersMetaKey = "projectAdditionalScanners"
+	StatusUnhealthy              = "unhealthy"
+	StatusHealthy                = "healthy"
 	// RetrieveCapFailMsg the message indicate failed to retrieve the scanner capabilities
 	RetrieveCapFailMsg = "failed to retrieve scanner capabilities, error %v"
 )
@@ -282,24 +285,133 @@ func (bc *basicController) GetRegistrationByProject(ctx context.Context, project
 	opts := newOptions(options...)
 
 	if opts.Ping {
-		// Get metadata of the configured registration
-		meta, err := bc.Ping(ctx, registration)
+		bc.fillHealth(ctx, registration)
+	}
+
+	return registration, nil
+}
+
+// SetRegistrationsByProject ...
+func (bc *basicController) SetRegistrationsByProject(ctx context.Context, projectID int64, registrationIDs []string) error {
+	if projectID == 0 {
+		return errors.New("invalid project ID")
+	}
+
+	var uuids []string
+	for _, id := range registrationIDs {
+		if len(id) == 0 {
+			return errors.BadRequestError(nil).WithMessage("missing scanner UUID")
+		}
+		if slices.Contains(uuids, id) {
+			continue
+		}
+
+		r, err := bc.manager.Get(ctx, id)
 		if err != nil {
-			// Not blocked, just logged it
-			log.Error(errors.Wrap(err, "api controller: get project scanner"))
-			registration.Health = StatusUnhealthy
-		} else {
-			registration.Health = StatusHealthy
-			// Fill in some metadata
-			registration.Adapter = meta.Scanner.Name
-			registration.Vendor = meta.Scanner.Vendor
-			registration.Version = meta.Scanner.Version
-
-			registration.Metadata = meta
+			return errors.Wrap(err, "api controller: set project scanners")
 		}
+		if r == nil {
+			return errors.NotFoundError(nil).WithMessagef("scanner %s not found", id)
+		}
+
+		uuids = append(uuids, id)
 	}
 
-	return registration, nil
+	if len(uuids) == 0 {
+		return errors.BadRequestError(nil).WithMessage("at least one scanner is required")
+	}
+
+	// The first one is kept as the primary scanner of the project
+	if err := bc.SetRegistrationByProject(ctx, projectID, uuids[0]); err != nil {
+		return err
+	}
+
+	m, err := bc.proMetaMgr.Get(ctx, projectID, proAdditionalScannersMetaKey)
+	if err != nil {
+		return errors.Wrap(err, "api controller: set project scanners")
+	}
+
+	additional := strings.Join(uuids[1:], ",")
+	switch {
+	case len(m) > 0 && len(additional) == 0:
+		err = bc.proMetaMgr.Delete(ctx, projectID, proAdditionalScannersMetaKey)
+	case len(m) > 0:
+		if additional != m[proAdditionalScannersMetaKey] {
+			err = bc.proMetaMgr.Update(ctx, projectID, map[string]string{proAdditionalScannersMetaKey: additional})
+		}
+	case len(additional) > 0:
+		err = bc.proMetaMgr.Add(ctx, projectID, map[string]string{proAdditionalScannersMetaKey: additional})
+	}
+	if err != nil {
+		return errors.Wrap(err, "api controller: set project scanners")
+	}
+
+	return nil
+}
+
+// GetRegistrationsByProject ...
+func (bc *basicController) GetRegistrationsByProject(ctx context.Context, projectID int64, options ...Option) ([]*scanner.Registration, error) {
+	primary, err := bc.GetRegistrationByProject(ctx, projectID, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	// No scanner configured
+	if primary == nil {
+		return nil, nil
+	}
+
+	registrations := []*scanner.Registration{primary}
+
+	m, err := bc.proMetaMgr.Get(ctx, projectID, proAdditionalScannersMetaKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "api controller: get project scanners")
+	}
+
+	opts := newOptions(options...)
+	for _, id := range strings.Split(m[proAdditionalScannersMetaKey], ",") {
+		if len(id) == 0 || id == primary.UUID {
+			continue
+		}
+
+		r, err := bc.manager.Get(ctx, id)
+		if err != nil {
+			return nil, errors.Wrap(err, "api controller: get project scanners")
+		}
+
+		if r == nil {
+			// Might be deleted by the admin, just skip it
+			log.G(ctx).Warningf("additional scanner %s of project %d not found", id, projectID)
+			continue
+		}
+
+		if opts.Ping {
+			bc.fillHealth(ctx, r)
+		}
+
+		registrations = append(registrations, r)
+	}
+
+	return registrations, nil
+}
+
+// fillHealth pings the registration and fills in the health status and metadata
+func (bc *basicController) fillHealth(ctx context.Context, registration *scanner.Registration) {
+	// Get metadata of the configured registration
+	meta, err := bc.Ping(ctx, registration)
+	if err != nil {
+		// Not blocked, just logged it
+		log.Error(errors.Wrap(err, "api controller: get project scanner"))
+		registration.Health = StatusUnhealthy
+	} else {
+		registration.Health = StatusHealthy
+		// Fill in some metadata
+		registration.Adapter = meta.Scanner.Name
+		registration.Vendor = meta.Scanner.Vendor
+		registration.Version = meta.Scanner.Version
+
+		registration.Metadata = meta
+	}
 }
 
 // Ping ...
@@ -332,6 +444,43 @@ func (bc *basicController) Ping(ctx context.Context, registration *scanner.Regis
 	return meta, nil
 }
 
+// CheckDBUpdates ...
+func (bc *basicController) CheckDBUpdates(ctx context.Context) ([]*scanner.Registration, error) {
+	l, err := bc.manager.List(ctx, q.New(q.KeyWords{"disabled": false}))
+	if err != nil {
+		return nil, errors.Wrap(err, "api controller: check db updates")
+	}
+
+	var updated []*scanner.Registration
+	for _, r := range l {
+		meta, err := bc.Ping(ctx, r)
+		if err != nil {
+			// Not blocked, just logged it
+			log.G(ctx).Warningf("failed to check the vulnerability database of scanner %s, error: %v", r.Name, err)
+			continue
+		}
+
+		updatedAt := meta.GetDBUpdatedAt()
+		// The scanner doesn't report the update time of the database or it's not updated
+		if updatedAt == nil || !updatedAt.After(r.DBUpdatedAt) {
+			continue
+		}
+
+		// Only record the update time for the first check, otherwise all the artifacts are rescanned
+		// once the feature is enabled
+		if !r.DBUpdatedAt.IsZero() {
+			updated = append(updated, r)
+		}
+
+		r.DBUpdatedAt = *updatedAt
+		if err := bc.manager.Update(ctx, r, "db_updated_at"); err != nil {
+			return nil, errors.Wrap(err, "api controller: check db updates")
+		}
+	}
+
+	return updated, nil
+}
+
 // GetMetadata ...
 func (bc *basicController) GetMetadata(ctx context.Context, registrationUUID string) (*v1.ScannerAdapterMetadata, error) {
 	if len(registrationUUID) == 0 {
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -231,6 +232,51 @@ func (suite *ControllerTestSuite) TestGetRegistrationByProject() {
 	assert.Equal(suite.T(), "forUT", r.Name)
 }
 
+// TestSetRegistrationsByProject tests SetRegistrationsByProject
+func (suite *ControllerTestSuite) TestSetRegistrationsByProject() {
+	var pid int64 = 1
+	suite.mMgr.On("Get", mock.Anything, "uuid").Return(&scanner.Registration{UUID: "uuid"}, nil)
+	suite.mMgr.On("Get", mock.Anything, "uuid2").Return(&scanner.Registration{UUID: "uuid2"}, nil)
+	suite.mMgr.On("Get", mock.Anything, "uuid3").Return(&scanner.Registration{UUID: "uuid3"}, nil)
+	suite.mMgr.On("Get", mock.Anything, "missing").Return(nil, nil)
+
+	suite.mMeta.On("Get", mock.Anything, pid, proScannerMetaKey).Return(map[string]string{}, nil)
+	suite.mMeta.On("Add", mock.Anything, pid, map[string]string{proScannerMetaKey: "uuid"}).Return(nil)
+	suite.mMeta.On("Get", mock.Anything, pid, proAdditionalScannersMetaKey).Return(map[string]string{}, nil)
+	suite.mMeta.On("Add", mock.Anything, pid, map[string]string{proAdditionalScannersMetaKey: "uuid2,uuid3"}).Return(nil)
+
+	// the duplicated one is ignored
+	err := suite.c.SetRegistrationsByProject(context.TODO(), pid, []string{"uuid", "uuid2", "uuid", "uuid3"})
+	suite.Require().NoError(err)
+	suite.mMeta.AssertExpectations(suite.T())
+
+	// scanner not found
+	err = suite.c.SetRegistrationsByProject(context.TODO(), pid, []string{"uuid", "missing"})
+	suite.Error(err)
+
+	// no scanner
+	err = suite.c.SetRegistrationsByProject(context.TODO(), pid, nil)
+	suite.Error(err)
+}
+
+// TestGetRegistrationsByProject tests GetRegistrationsByProject
+func (suite *ControllerTestSuite) TestGetRegistrationsByProject() {
+	var pid int64 = 1
+	suite.sample.UUID = "uuid"
+
+	suite.mMeta.On("Get", mock.Anything, pid, proScannerMetaKey).Return(map[string]string{proScannerMetaKey: "uuid"}, nil)
+	suite.mMeta.On("Get", mock.Anything, pid, proAdditionalScannersMetaKey).Return(map[string]string{proAdditionalScannersMetaKey: "uuid,uuid2,deleted"}, nil)
+	suite.mMgr.On("Get", mock.Anything, "uuid").Return(suite.sample, nil)
+	suite.mMgr.On("Get", mock.Anything, "uuid2").Return(&scanner.Registration{UUID: "uuid2", Name: "another"}, nil)
+	suite.mMgr.On("Get", mock.Anything, "deleted").Return(nil, nil)
+
+	l, err := suite.c.GetRegistrationsByProject(context.TODO(), pid)
+	suite.Require().NoError(err)
+	suite.Require().Len(l, 2)
+	suite.Equal("forUT", l[0].Name)
+	suite.Equal("another", l[1].Name)
+}
+
 // TestGetRegistrationByProjectWhenPingError tests GetRegistrationByProject
 func (suite *ControllerTestSuite) TestGetRegistrationByProjectWhenPingError() {
 	m := make(map[string]string, 1)
@@ -256,6 +302,41 @@ func (suite *ControllerTestSuite) TestGetRegistrationByProjectWhenPingError() {
 	assert.Equal(suite.T(), "unhealthy", r.Health)
 }
 
+// TestCheckDBUpdates tests CheckDBUpdates
+func (suite *ControllerTestSuite) TestCheckDBUpdates() {
+	dbUpdatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	m := &v1.ScannerAdapterMetadata{
+		Scanner: &v1.Scanner{Name: "Trivy", Vendor: "Harbor", Version: "0.1.0"},
+		Capabilities: []*v1.ScannerCapability{{
+			ConsumesMimeTypes: []string{v1.MimeTypeDockerArtifact},
+			ProducesMimeTypes: []string{v1.MimeTypeNativeReport},
+		}},
+		Properties: v1.ScannerProperties{
+			v1.PropertyDBUpdatedAt: dbUpdatedAt.Format(time.RFC3339),
+		},
+	}
+	mc := &v1testing.Client{}
+	mc.On("GetMetadata").Return(m, nil)
+	mcp := &v1testing.ClientPool{}
+	mocktesting.OnAnything(mcp, "Get").Return(mc, nil)
+	suite.c.clientPool = mcp
+
+	first := &scanner.Registration{UUID: "first", Name: "first", URL: "https://first.scanner.com"}
+	updated := &scanner.Registration{UUID: "updated", Name: "updated", URL: "https://updated.scanner.com", DBUpdatedAt: dbUpdatedAt.Add(-time.Hour)}
+	latest := &scanner.Registration{UUID: "latest", Name: "latest", URL: "https://latest.scanner.com", DBUpdatedAt: dbUpdatedAt}
+	suite.mMgr.On("List", mock.Anything, mock.Anything).Return([]*scanner.Registration{first, updated, latest}, nil)
+	suite.mMgr.On("Update", mock.Anything, mock.Anything, "db_updated_at").Return(nil)
+
+	l, err := suite.c.CheckDBUpdates(context.TODO())
+	suite.Require().NoError(err)
+	// the update time is only recorded for the first check
+	suite.Require().Len(l, 1)
+	suite.Equal("updated", l[0].UUID)
+	suite.Equal(dbUpdatedAt, first.DBUpdatedAt)
+	suite.Equal(dbUpdatedAt, updated.DBUpdatedAt)
+	suite.mMgr.AssertNumberOfCalls(suite.T(), "Update", 2)
+}
+
 // TestPing ...
 func (suite *ControllerTestSuite) TestPing() {
 	meta, err := suite.c.Ping(context.TODO(), suite.sample)
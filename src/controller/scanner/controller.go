@@ -131,6 +131,30 @@ type Controller interface {
 	//     error                 : non nil error if any errors occurred
 	GetRegistrationByProject(ctx context.Context, projectID int64, options ...Option) (*scanner.Registration, error)
 
+	// SetRegistrationsByProject sets the scanners for the given project, the first one is the primary scanner of
+	// the project and the others are the additional scanners whose reports are merged with the primary one.
+	//
+	//  Arguments:
+	//    ctx context.Context : the context.Context for this method
+	//    projectID int64  : the ID of the given project
+	//    scannerIDs []string : the UUIDs of the scanners
+	//
+	//  Returns:
+	//    error : non nil error if any errors occurred
+	SetRegistrationsByProject(ctx context.Context, projectID int64, scannerIDs []string) error
+
+	// GetRegistrationsByProject returns all the scanner registrations of the given project,
+	// the first one is the registration returned by GetRegistrationByProject and followed by the additional ones.
+	//
+	//   Arguments:
+	//     ctx context.Context : the context.Context for this method
+	//     projectID int64 : the ID of the given project
+	//
+	//   Returns:
+	//     []*scanner.Registration : the scanner registrations of the project
+	//     error                   : non nil error if any errors occurred
+	GetRegistrationsByProject(ctx context.Context, projectID int64, options ...Option) ([]*scanner.Registration, error)
+
 	// Ping pings Scanner Adapter to test EndpointURL and Authorization settings.
 	// The implementation is supposed to call the GetMetadata method on scanner.Client.
 	// Returns `nil` if connection succeeded, a non `nil` error otherwise.
@@ -155,6 +179,17 @@ type Controller interface {
 	//    error                      : non nil error if any errors occurred
 	GetMetadata(ctx context.Context, registrationUUID string) (*v1.ScannerAdapterMetadata, error)
 
+	// CheckDBUpdates checks the vulnerability database of the enabled scanners and returns the ones
+	// whose database is updated since the last check. The update time reported by the scanner is recorded.
+	//
+	//  Arguments:
+	//    ctx context.Context : the context for this method
+	//
+	//  Returns:
+	//    []*scanner.Registration : the scanners whose vulnerability database is updated
+	//    error                   : non nil error if any errors occurred
+	CheckDBUpdates(ctx context.Context) ([]*scanner.Registration, error)
+
 	// RetrieveCap retrieve scanner capabilities
 	RetrieveCap(ctx context.Context, r *scanner.Registration) error
 }
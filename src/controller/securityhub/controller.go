@@ -16,8 +16,13 @@ package securityhub
 
 import (
 	"context"
+	"time"
 
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom/component"
+	componentModel "github.com/goharbor/harbor/src/pkg/scan/sbom/component/model"
 	"github.com/goharbor/harbor/src/pkg/scan/scanner"
 	"github.com/goharbor/harbor/src/pkg/securityhub"
 	secHubModel "github.com/goharbor/harbor/src/pkg/securityhub/model"
@@ -66,20 +71,51 @@ type Controller interface {
 	ListVuls(ctx context.Context, scannerUUID string, projectID int64, withTag bool, query *q.Query) ([]*secHubModel.VulnerabilityItem, error)
 	// CountVuls get all vulnerability count by query
 	CountVuls(ctx context.Context, scannerUUID string, projectID int64, tuneCount bool, query *q.Query) (int64, error)
+	// SearchComponents searches the artifacts containing the components indexed from their SBOMs
+	SearchComponents(ctx context.Context, criteria *componentModel.Criteria, withTag bool, pageNumber, pageSize int64) (int64, []*componentModel.Item, error)
+	// TakeSnapshot persists the current security summary of each project and the whole system,
+	// the snapshot taken on the same day is replaced
+	TakeSnapshot(ctx context.Context) error
+	// ListTrends returns the snapshots of the specified project taken in the time range ordered by time,
+	// the project ID 0 returns the system-wide snapshots
+	ListTrends(ctx context.Context, projectID int64, from, to time.Time) ([]*secHubModel.Snapshot, error)
+	// CreateRemediation starts tracking the remediation of the finding, the due time is derived from the
+	// severity SLA if not specified
+	CreateRemediation(ctx context.Context, remediation *secHubModel.Remediation) (int64, error)
+	// GetRemediation returns the remediation specified by ID
+	GetRemediation(ctx context.Context, id int64) (*secHubModel.Remediation, error)
+	// CountRemediations returns the total count of remediations according to the query
+	CountRemediations(ctx context.Context, query *q.Query) (int64, error)
+	// ListRemediations lists the remediations according to the query
+	ListRemediations(ctx context.Context, query *q.Query) ([]*secHubModel.Remediation, error)
+	// UpdateRemediation updates the owner, state, due time and accepted risk of the remediation
+	UpdateRemediation(ctx context.Context, remediation *secHubModel.Remediation) error
+	// DeleteRemediation stops tracking the remediation specified by ID
+	DeleteRemediation(ctx context.Context, id int64) error
+	// ReconcileRemediations moves the state of the remediations of the project according to the latest scan reports,
+	// the remediations of all projects are reconciled if the project ID is 0
+	ReconcileRemediations(ctx context.Context, projectID int64) error
+	// ReconcileExpiredRisks reconciles the remediations of the projects having accepted risks which expire,
+	// so the expired risks are reopened without waiting for a new scan
+	ReconcileExpiredRisks(ctx context.Context) error
 }
 
 type controller struct {
-	scannerMgr scanner.Manager
-	secHubMgr  securityhub.Manager
-	tagMgr     tag.Manager
+	scannerMgr     scanner.Manager
+	secHubMgr      securityhub.Manager
+	remediationMgr securityhub.RemediationManager
+	tagMgr         tag.Manager
+	componentMgr   component.Manager
 }
 
 // NewController ...
 func NewController() Controller {
 	return &controller{
-		scannerMgr: scanner.Mgr,
-		secHubMgr:  securityhub.Mgr,
-		tagMgr:     tag.Mgr,
+		scannerMgr:     scanner.Mgr,
+		secHubMgr:      securityhub.Mgr,
+		remediationMgr: securityhub.RemediationMgr,
+		tagMgr:         tag.Mgr,
+		componentMgr:   component.Mgr,
 	}
 }
 
@@ -141,36 +177,103 @@ func (c *controller) ListVuls(ctx context.Context, scannerUUID string, projectID
 
 func (c *controller) attachTags(ctx context.Context, vuls []*secHubModel.VulnerabilityItem) ([]*secHubModel.VulnerabilityItem, error) {
 	// get all artifact_ids
-	artifactTagMap := make(map[int64][]string, 0)
+	var artifactIDs []int64
 	for _, v := range vuls {
-		artifactTagMap[v.ArtifactID] = make([]string, 0)
+		artifactIDs = append(artifactIDs, v.ArtifactID)
 	}
 
-	// get tags in the artifact list
-	var artifactIDs []any
-	for k := range artifactTagMap {
-		artifactIDs = append(artifactIDs, k)
-	}
-	query := q.New(q.KeyWords{"artifact_id": q.NewOrList(artifactIDs)})
-	tags, err := c.tagMgr.List(ctx, query)
+	artifactTagMap, err := c.listTags(ctx, artifactIDs)
 	if err != nil {
 		return vuls, err
 	}
-	for _, tag := range tags {
-		artifactTagMap[tag.ArtifactID] = append(artifactTagMap[tag.ArtifactID], tag.Name)
-	}
 
-	// attach tags, only show 10 tags
 	for _, v := range vuls {
-		if len(artifactTagMap[v.ArtifactID]) > 10 {
-			v.Tags = artifactTagMap[v.ArtifactID][:10]
-			continue
-		}
 		v.Tags = artifactTagMap[v.ArtifactID]
 	}
 	return vuls, nil
 }
 
+// listTags returns the tags of the artifacts, only 10 tags are returned for each artifact
+func (c *controller) listTags(ctx context.Context, artifactIDs []int64) (map[int64][]string, error) {
+	artifactTagMap := make(map[int64][]string, 0)
+	var ids []any
+	for _, id := range artifactIDs {
+		if _, ok := artifactTagMap[id]; ok {
+			continue
+		}
+		artifactTagMap[id] = make([]string, 0)
+		ids = append(ids, id)
+	}
+
+	// get tags in the artifact list
+	query := q.New(q.KeyWords{"artifact_id": q.NewOrList(ids)})
+	tags, err := c.tagMgr.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		if len(artifactTagMap[tag.ArtifactID]) < 10 {
+			artifactTagMap[tag.ArtifactID] = append(artifactTagMap[tag.ArtifactID], tag.Name)
+		}
+	}
+	return artifactTagMap, nil
+}
+
 func (c *controller) CountVuls(ctx context.Context, scannerUUID string, projectID int64, tuneCount bool, query *q.Query) (int64, error) {
 	return c.secHubMgr.TotalVuls(ctx, scannerUUID, projectID, tuneCount, query)
 }
+
+func (c *controller) SearchComponents(ctx context.Context, criteria *componentModel.Criteria, withTag bool, pageNumber, pageSize int64) (int64, []*componentModel.Item, error) {
+	total, items, err := c.componentMgr.Search(ctx, criteria, pageNumber, pageSize)
+	if err != nil {
+		return 0, nil, err
+	}
+	if withTag && len(items) > 0 {
+		var artifactIDs []int64
+		for _, item := range items {
+			artifactIDs = append(artifactIDs, item.ArtifactID)
+		}
+		tags, err := c.listTags(ctx, artifactIDs)
+		if err != nil {
+			return 0, nil, err
+		}
+		for _, item := range items {
+			item.Tags = tags[item.ArtifactID]
+		}
+	}
+	return total, items, nil
+}
+
+func (c *controller) TakeSnapshot(ctx context.Context) error {
+	scannerUUID, err := c.scannerMgr.DefaultScannerUUID(ctx)
+	if err != nil {
+		return err
+	}
+	if len(scannerUUID) == 0 {
+		log.Debug("skip to take the security summary snapshot as no default scanner is set")
+		return nil
+	}
+
+	snapshots, err := c.secHubMgr.ProjectSummaries(ctx, scannerUUID)
+	if err != nil {
+		return err
+	}
+	system := &secHubModel.Snapshot{}
+	for _, snapshot := range snapshots {
+		system.Add(snapshot)
+	}
+	snapshots = append(snapshots, system)
+
+	// one snapshot per day, the time is truncated to the start of the day in UTC
+	snapshotTime := time.Now().UTC().Truncate(24 * time.Hour)
+	return orm.WithTransaction(func(ctx context.Context) error {
+		return c.secHubMgr.SaveSnapshots(ctx, snapshotTime, snapshots)
+	})(orm.SetTransactionOpNameToContext(ctx, "tx-take-security-snapshot"))
+}
+
+func (c *controller) ListTrends(ctx context.Context, projectID int64, from, to time.Time) ([]*secHubModel.Snapshot, error) {
+	return c.secHubMgr.ListSnapshots(ctx, q.New(q.KeyWords{
+		"project_id":    projectID,
+		"snapshot_time": &q.Range{Min: from, Max: to},
+	}))
+}
@@ -15,16 +15,23 @@
 package securityhub
 
 import (
-	"errors"
 	"testing"
+	"time"
 
+	testifymock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+	componentModel "github.com/goharbor/harbor/src/pkg/scan/sbom/component/model"
 	"github.com/goharbor/harbor/src/pkg/securityhub/model"
 	"github.com/goharbor/harbor/src/pkg/tag/model/tag"
 	htesting "github.com/goharbor/harbor/src/testing"
 	"github.com/goharbor/harbor/src/testing/mock"
+	componentMock "github.com/goharbor/harbor/src/testing/pkg/scan/sbom/component"
 	scannerMock "github.com/goharbor/harbor/src/testing/pkg/scan/scanner"
 	securityMock "github.com/goharbor/harbor/src/testing/pkg/securityhub"
 	tagMock "github.com/goharbor/harbor/src/testing/pkg/tag"
@@ -41,10 +48,12 @@ var sum = &model.Summary{
 
 type ControllerTestSuite struct {
 	htesting.Suite
-	c          *controller
-	scannerMgr *scannerMock.Manager
-	secHubMgr  *securityMock.Manager
-	tagMgr     *tagMock.Manager
+	c              *controller
+	scannerMgr     *scannerMock.Manager
+	secHubMgr      *securityMock.Manager
+	remediationMgr *securityMock.RemediationManager
+	tagMgr         *tagMock.Manager
+	componentMgr   *componentMock.Manager
 }
 
 // TestController is the entry of controller test suite
@@ -55,13 +64,17 @@ func TestController(t *testing.T) {
 // SetupTest prepares env for the controller test suite
 func (suite *ControllerTestSuite) SetupTest() {
 	suite.secHubMgr = &securityMock.Manager{}
+	suite.remediationMgr = &securityMock.RemediationManager{}
 	suite.scannerMgr = &scannerMock.Manager{}
 	suite.tagMgr = &tagMock.Manager{}
+	suite.componentMgr = &componentMock.Manager{}
 
 	suite.c = &controller{
-		secHubMgr:  suite.secHubMgr,
-		scannerMgr: suite.scannerMgr,
-		tagMgr:     suite.tagMgr,
+		secHubMgr:      suite.secHubMgr,
+		remediationMgr: suite.remediationMgr,
+		scannerMgr:     suite.scannerMgr,
+		tagMgr:         suite.tagMgr,
+		componentMgr:   suite.componentMgr,
 	}
 }
 
@@ -195,3 +208,189 @@ func (suite *ControllerTestSuite) TestCountVuls() {
 	suite.NoError(err)
 	suite.Equal(int64(10), count)
 }
+
+func (suite *ControllerTestSuite) TestSearchComponents() {
+	ctx := suite.Context()
+	items := []*componentModel.Item{
+		{Component: componentModel.Component{ArtifactID: 1, Name: "log4j-core", Version: "2.14.1"}},
+		{Component: componentModel.Component{ArtifactID: 2, Name: "log4j-core", Version: "2.14.0"}},
+	}
+	tagList := []*tag.Tag{
+		{ArtifactID: int64(1), Name: "latest"},
+	}
+	criteria := &componentModel.Criteria{Name: "log4j-core", Version: "<2.15.0"}
+	suite.componentMgr.On("Search", ctx, criteria, int64(1), int64(10)).Return(int64(2), items, nil)
+	mock.OnAnything(suite.c.tagMgr, "List").Return(tagList, nil).Once()
+	total, result, err := suite.c.SearchComponents(ctx, criteria, true, 1, 10)
+	suite.NoError(err)
+	suite.Equal(int64(2), total)
+	suite.Require().Len(result, 2)
+	suite.Equal([]string{"latest"}, result[0].Tags)
+	suite.Empty(result[1].Tags)
+}
+
+func (suite *ControllerTestSuite) TestTakeSnapshot() {
+	ctx := suite.Context()
+	mock.OnAnything(suite.scannerMgr, "DefaultScannerUUID").Return("ruuid", nil)
+	snapshots := []*model.Snapshot{
+		{ProjectID: 1, CriticalCnt: 5, HighCnt: 4, FixableCnt: 3, ScannedCnt: 2, TotalArtifactCnt: 3},
+		{ProjectID: 2, CriticalCnt: 1, LowCnt: 6, FixableCnt: 1, ScannedCnt: 1, TotalArtifactCnt: 1},
+	}
+	mock.OnAnything(suite.secHubMgr, "ProjectSummaries").Return(snapshots, nil).Once()
+	var saved []*model.Snapshot
+	mock.OnAnything(suite.secHubMgr, "SaveSnapshots").Run(func(args mock.Arguments) {
+		saved = args.Get(2).([]*model.Snapshot)
+	}).Return(nil).Once()
+
+	suite.NoError(suite.c.TakeSnapshot(ctx))
+	suite.Require().Len(saved, 3)
+	system := saved[2]
+	suite.Equal(int64(0), system.ProjectID)
+	suite.Equal(int64(6), system.CriticalCnt)
+	suite.Equal(int64(4), system.HighCnt)
+	suite.Equal(int64(6), system.LowCnt)
+	suite.Equal(int64(4), system.FixableCnt)
+	suite.Equal(int64(3), system.ScannedCnt)
+	suite.Equal(int64(4), system.TotalArtifactCnt)
+}
+
+func (suite *ControllerTestSuite) TestTakeSnapshotWithoutScanner() {
+	ctx := suite.Context()
+	mock.OnAnything(suite.scannerMgr, "DefaultScannerUUID").Return("", nil)
+	suite.NoError(suite.c.TakeSnapshot(ctx))
+	suite.secHubMgr.AssertNotCalled(suite.T(), "SaveSnapshots", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ControllerTestSuite) TestListTrends() {
+	ctx := suite.Context()
+	to := time.Now()
+	from := to.AddDate(0, 0, -7)
+	snapshots := []*model.Snapshot{{ProjectID: 1, SnapshotTime: from}, {ProjectID: 1, SnapshotTime: to}}
+	suite.secHubMgr.On("ListSnapshots", ctx, mock.AnythingOfType("*q.Query")).Run(func(args mock.Arguments) {
+		query := args.Get(1).(*q.Query)
+		suite.Equal(int64(1), query.Keywords["project_id"])
+		suite.Equal(&q.Range{Min: from, Max: to}, query.Keywords["snapshot_time"])
+	}).Return(snapshots, nil).Once()
+	result, err := suite.c.ListTrends(ctx, 1, from, to)
+	suite.NoError(err)
+	suite.Len(result, 2)
+}
+
+func (suite *ControllerTestSuite) TestCreateRemediation() {
+	ctx := suite.Context()
+	config.InitWithSettings(map[string]any{common.VulnerabilitySLADays: `{"Critical":3}`})
+	mock.OnAnything(suite.scannerMgr, "DefaultScannerUUID").Return("ruuid", nil)
+	findings := []*model.Finding{
+		{CVEID: "CVE-2021-44228", Package: "log4j-api", Severity: "Medium"},
+		{CVEID: "CVE-2021-44228", Package: "log4j-core", Severity: "Critical"},
+	}
+	suite.remediationMgr.On("ListFindings", ctx, "ruuid", int64(1), "CVE-2021-44228").Return(findings, nil)
+	var created *model.Remediation
+	mock.OnAnything(suite.remediationMgr, "Create").Run(func(args mock.Arguments) {
+		created = args.Get(1).(*model.Remediation)
+	}).Return(int64(1), nil).Once()
+
+	id, err := suite.c.CreateRemediation(ctx, &model.Remediation{ProjectID: 1, CVEID: "CVE-2021-44228", Package: "log4j-core", Owner: "alice"})
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), id)
+	suite.Equal(model.RemediationStateOpen, created.State)
+	suite.Equal("Critical", created.Severity)
+	suite.Equal("ruuid", created.ScannerUUID)
+	suite.WithinDuration(time.Now().AddDate(0, 0, 3), created.DueTime, time.Minute)
+
+	// the finding isn't in the project
+	_, err = suite.c.CreateRemediation(ctx, &model.Remediation{ProjectID: 1, CVEID: "CVE-2021-44228", Package: "xstream"})
+	suite.True(errors.IsNotFoundErr(err))
+
+	// the justification is required to accept the risk
+	_, err = suite.c.CreateRemediation(ctx, &model.Remediation{ProjectID: 1, CVEID: "CVE-2021-44228", Package: "log4j-core",
+		State: model.RemediationStateAcceptedRisk, RiskExpiryTime: time.Now().Add(time.Hour)})
+	suite.True(errors.IsErr(err, errors.BadRequestCode))
+}
+
+func (suite *ControllerTestSuite) TestUpdateRemediation() {
+	ctx := suite.Context()
+	remediation := &model.Remediation{ID: 1, State: model.RemediationStateResolved}
+	suite.True(errors.IsErr(suite.c.UpdateRemediation(ctx, remediation), errors.BadRequestCode))
+
+	remediation.State = model.RemediationStateAcceptedRisk
+	remediation.Justification = "not exploitable"
+	remediation.RiskExpiryTime = time.Now().Add(-time.Hour)
+	suite.True(errors.IsErr(suite.c.UpdateRemediation(ctx, remediation), errors.BadRequestCode))
+
+	remediation.RiskExpiryTime = time.Now().AddDate(0, 1, 0)
+	suite.remediationMgr.On("Update", ctx, remediation, "Owner", "State", "DueTime", "Justification", "RiskExpiryTime").Return(nil).Once()
+	suite.NoError(suite.c.UpdateRemediation(ctx, remediation))
+}
+
+func (suite *ControllerTestSuite) TestReconcileRemediations() {
+	ctx := suite.Context()
+	config.InitWithSettings(map[string]any{common.VulnerabilitySLADays: `{"High":30}`})
+	remediations := []*model.Remediation{
+		{ID: 1, ProjectID: 1, CVEID: "CVE-1", Package: "a", ScannerUUID: "ruuid", State: model.RemediationStateOpen},
+		{ID: 2, ProjectID: 1, CVEID: "CVE-2", Package: "b", ScannerUUID: "ruuid", Severity: "High", State: model.RemediationStateResolved},
+		{ID: 3, ProjectID: 1, CVEID: "CVE-3", Package: "c", ScannerUUID: "ruuid", State: model.RemediationStateAcceptedRisk, RiskExpiryTime: time.Now().Add(-time.Hour)},
+		{ID: 4, ProjectID: 1, CVEID: "CVE-4", Package: "d", ScannerUUID: "ruuid", State: model.RemediationStateInProgress},
+	}
+	mock.OnAnything(suite.remediationMgr, "List").Return(remediations, nil).Once()
+	suite.remediationMgr.On("ScanCompleted", ctx, "ruuid", int64(1)).Return(true, nil).Once()
+	findings := []*model.Finding{{CVEID: "CVE-2", Package: "b"}, {CVEID: "CVE-3", Package: "c"}, {CVEID: "CVE-4", Package: "d"}}
+	suite.remediationMgr.On("ListFindings", ctx, "ruuid", int64(1), "CVE-1", "CVE-2", "CVE-3", "CVE-4").Return(findings, nil).Once()
+	suite.remediationMgr.On("Update", ctx, remediations[0], "State", "ResolvedTime").Return(nil).Once()
+	suite.remediationMgr.On("Update", ctx, remediations[1], "State", "ResolvedTime", "DueTime").Return(nil).Once()
+	suite.remediationMgr.On("Update", ctx, remediations[2], "State").Return(nil).Once()
+
+	suite.NoError(suite.c.ReconcileRemediations(ctx, 1))
+	suite.remediationMgr.AssertExpectations(suite.T())
+	suite.Equal(model.RemediationStateResolved, remediations[0].State)
+	suite.False(remediations[0].ResolvedTime.IsZero())
+	suite.Equal(model.RemediationStateOpen, remediations[1].State)
+	suite.WithinDuration(time.Now().AddDate(0, 0, 30), remediations[1].DueTime, time.Minute)
+	suite.Equal(model.RemediationStateOpen, remediations[2].State)
+	suite.Equal(model.RemediationStateInProgress, remediations[3].State)
+}
+
+func (suite *ControllerTestSuite) TestReconcileExpiredRisks() {
+	ctx := suite.Context()
+	expired := []*model.Remediation{
+		{ID: 1, ProjectID: 1, CVEID: "CVE-1", Package: "a", ScannerUUID: "ruuid", State: model.RemediationStateAcceptedRisk, RiskExpiryTime: time.Now().Add(-time.Hour)},
+		{ID: 2, ProjectID: 1, CVEID: "CVE-2", Package: "b", ScannerUUID: "ruuid", State: model.RemediationStateAcceptedRisk, RiskExpiryTime: time.Now().Add(-time.Hour)},
+	}
+	suite.remediationMgr.On("List", ctx, testifymock.MatchedBy(func(query *q.Query) bool {
+		r, ok := query.Keywords["risk_expiry_time"].(*q.Range)
+		return ok && r.Max != nil && query.Keywords["state"] == model.RemediationStateAcceptedRisk
+	})).Return(expired, nil).Once()
+	// the remediations of the project are reconciled once
+	suite.remediationMgr.On("List", ctx, testifymock.MatchedBy(func(query *q.Query) bool {
+		return query.Keywords["project_id"] == int64(1)
+	})).Return(expired, nil).Once()
+	suite.remediationMgr.On("ScanCompleted", ctx, "ruuid", int64(1)).Return(true, nil).Once()
+	findings := []*model.Finding{{CVEID: "CVE-1", Package: "a"}, {CVEID: "CVE-2", Package: "b"}}
+	suite.remediationMgr.On("ListFindings", ctx, "ruuid", int64(1), "CVE-1", "CVE-2").Return(findings, nil).Once()
+	suite.remediationMgr.On("Update", ctx, expired[0], "State").Return(nil).Once()
+	suite.remediationMgr.On("Update", ctx, expired[1], "State").Return(nil).Once()
+
+	suite.NoError(suite.c.ReconcileExpiredRisks(ctx))
+	suite.remediationMgr.AssertExpectations(suite.T())
+	suite.Equal(model.RemediationStateOpen, expired[0].State)
+	suite.Equal(model.RemediationStateOpen, expired[1].State)
+}
+
+func (suite *ControllerTestSuite) TestReconcileRemediationsScanInProgress() {
+	ctx := suite.Context()
+	remediations := []*model.Remediation{
+		{ID: 1, ProjectID: 1, CVEID: "CVE-1", Package: "a", ScannerUUID: "ruuid", State: model.RemediationStateOpen},
+		{ID: 2, ProjectID: 1, CVEID: "CVE-2", Package: "b", ScannerUUID: "ruuid", State: model.RemediationStateResolved},
+	}
+	mock.OnAnything(suite.remediationMgr, "List").Return(remediations, nil).Once()
+	// the findings of the reports in progress are missing
+	suite.remediationMgr.On("ScanCompleted", ctx, "ruuid", int64(1)).Return(false, nil).Once()
+	findings := []*model.Finding{{CVEID: "CVE-2", Package: "b"}}
+	suite.remediationMgr.On("ListFindings", ctx, "ruuid", int64(1), "CVE-1", "CVE-2").Return(findings, nil).Once()
+	suite.remediationMgr.On("Update", ctx, remediations[1], "State", "ResolvedTime", "DueTime").Return(nil).Once()
+
+	suite.NoError(suite.c.ReconcileRemediations(ctx, 1))
+	suite.remediationMgr.AssertExpectations(suite.T())
+	suite.Equal(model.RemediationStateOpen, remediations[0].State)
+	suite.Equal(model.RemediationStateOpen, remediations[1].State)
+}
@@ -0,0 +1,216 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securityhub
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	secHubModel "github.com/goharbor/harbor/src/pkg/securityhub/model"
+)
+
+func (c *controller) CreateRemediation(ctx context.Context, remediation *secHubModel.Remediation) (int64, error) {
+	if len(remediation.State) == 0 {
+		remediation.State = secHubModel.RemediationStateOpen
+	}
+	now := time.Now()
+	if err := validateRemediation(remediation, now); err != nil {
+		return 0, err
+	}
+
+	scannerUUID, err := c.scannerMgr.DefaultScannerUUID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(scannerUUID) == 0 {
+		return 0, errors.PreconditionFailedError(nil).WithMessage("no default scanner is set")
+	}
+	findings, err := c.remediationMgr.ListFindings(ctx, scannerUUID, remediation.ProjectID, remediation.CVEID)
+	if err != nil {
+		return 0, err
+	}
+	var finding *secHubModel.Finding
+	for _, f := range findings {
+		if f.Package == remediation.Package {
+			finding = f
+			break
+		}
+	}
+	if finding == nil {
+		return 0, errors.NotFoundError(nil).WithMessagef("%s is not found in package %s of project %d",
+			remediation.CVEID, remediation.Package, remediation.ProjectID)
+	}
+
+	remediation.Severity = finding.Severity
+	// the remediation is reconciled against the reports of the scanner finding it
+	remediation.ScannerUUID = scannerUUID
+	if remediation.DueTime.IsZero() {
+		remediation.DueTime = secHubModel.DueTime(config.VulnerabilitySLADays(ctx), finding.Severity, now)
+	}
+	return c.remediationMgr.Create(ctx, remediation)
+}
+
+func (c *controller) GetRemediation(ctx context.Context, id int64) (*secHubModel.Remediation, error) {
+	return c.remediationMgr.Get(ctx, id)
+}
+
+func (c *controller) CountRemediations(ctx context.Context, query *q.Query) (int64, error) {
+	return c.remediationMgr.Count(ctx, query)
+}
+
+func (c *controller) ListRemediations(ctx context.Context, query *q.Query) ([]*secHubModel.Remediation, error) {
+	return c.remediationMgr.List(ctx, query)
+}
+
+func (c *controller) UpdateRemediation(ctx context.Context, remediation *secHubModel.Remediation) error {
+	if err := validateRemediation(remediation, time.Now()); err != nil {
+		return err
+	}
+	if remediation.State != secHubModel.RemediationStateAcceptedRisk {
+		remediation.RiskExpiryTime = time.Time{}
+	}
+	return c.remediationMgr.Update(ctx, remediation, "Owner", "State", "DueTime", "Justification", "RiskExpiryTime")
+}
+
+func (c *controller) DeleteRemediation(ctx context.Context, id int64) error {
+	return c.remediationMgr.Delete(ctx, id)
+}
+
+func (c *controller) ReconcileRemediations(ctx context.Context, projectID int64) error {
+	query := q.New(q.KeyWords{})
+	if projectID > 0 {
+		query.Keywords["project_id"] = projectID
+	}
+	remediations, err := c.remediationMgr.List(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	// group the remediations by project and the scanner finding them to query the findings of each group once
+	type group struct {
+		projectID   int64
+		scannerUUID string
+	}
+	groups := map[group][]*secHubModel.Remediation{}
+	for _, r := range remediations {
+		g := group{projectID: r.ProjectID, scannerUUID: r.ScannerUUID}
+		groups[g] = append(groups[g], r)
+	}
+	slaDays := config.VulnerabilitySLADays(ctx)
+	for g, rs := range groups {
+		if err := c.reconcileProjectRemediations(ctx, g.scannerUUID, g.projectID, rs, slaDays); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *controller) ReconcileExpiredRisks(ctx context.Context) error {
+	expired, err := c.remediationMgr.List(ctx, q.New(q.KeyWords{
+		"state":            secHubModel.RemediationStateAcceptedRisk,
+		"risk_expiry_time": &q.Range{Max: time.Now()},
+	}))
+	if err != nil {
+		return err
+	}
+
+	projects := map[int64]struct{}{}
+	for _, r := range expired {
+		if _, ok := projects[r.ProjectID]; ok {
+			continue
+		}
+		projects[r.ProjectID] = struct{}{}
+		if err := c.ReconcileRemediations(ctx, r.ProjectID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileProjectRemediations moves the state of the remediations according to the findings of the completed reports
+// of the scanner in the project: the remediations whose findings disappear are resolved, the resolved ones whose
+// findings reappear and the accepted risks which expire are reopened. The remediations are only resolved when all
+// the reports of the scanner in the project are completed, as the findings of the reports in progress are missing.
+func (c *controller) reconcileProjectRemediations(ctx context.Context, scannerUUID string, projectID int64,
+	remediations []*secHubModel.Remediation, slaDays map[string]int) error {
+	completed, err := c.remediationMgr.ScanCompleted(ctx, scannerUUID, projectID)
+	if err != nil {
+		return err
+	}
+
+	var cveIDs []string
+	seen := map[string]struct{}{}
+	for _, r := range remediations {
+		if _, ok := seen[r.CVEID]; !ok {
+			seen[r.CVEID] = struct{}{}
+			cveIDs = append(cveIDs, r.CVEID)
+		}
+	}
+	findings, err := c.remediationMgr.ListFindings(ctx, scannerUUID, projectID, cveIDs...)
+	if err != nil {
+		return err
+	}
+	present := map[string]struct{}{}
+	for _, f := range findings {
+		present[f.Key()] = struct{}{}
+	}
+
+	now := time.Now()
+	for _, r := range remediations {
+		_, found := present[(&secHubModel.Finding{CVEID: r.CVEID, Package: r.Package}).Key()]
+		var props []string
+		switch {
+		case !found && completed && r.State != secHubModel.RemediationStateResolved:
+			r.State = secHubModel.RemediationStateResolved
+			r.ResolvedTime = now
+			props = []string{"State", "ResolvedTime"}
+		case found && r.State == secHubModel.RemediationStateResolved:
+			r.State = secHubModel.RemediationStateOpen
+			r.ResolvedTime = time.Time{}
+			r.DueTime = secHubModel.DueTime(slaDays, r.Severity, now)
+			props = []string{"State", "ResolvedTime", "DueTime"}
+		case found && r.RiskExpired(now):
+			r.State = secHubModel.RemediationStateOpen
+			props = []string{"State"}
+		default:
+			continue
+		}
+		log.Debugf("the state of the remediation %d of %s in project %d changes to %s", r.ID, r.CVEID, projectID, r.State)
+		if err := c.remediationMgr.Update(ctx, r, props...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateRemediation(remediation *secHubModel.Remediation, now time.Time) error {
+	if !secHubModel.IsValidRemediationState(remediation.State) {
+		return errors.BadRequestError(nil).WithMessagef("invalid remediation state: %s", remediation.State)
+	}
+	if remediation.State == secHubModel.RemediationStateAcceptedRisk {
+		if len(strings.TrimSpace(remediation.Justification)) == 0 {
+			return errors.BadRequestError(nil).WithMessage("the justification is required to accept the risk")
+		}
+		if !remediation.RiskExpiryTime.After(now) {
+			return errors.BadRequestError(nil).WithMessage("the expiry time of the accepted risk must be in the future")
+		}
+	}
+	return nil
+}
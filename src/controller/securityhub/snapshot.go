@@ -0,0 +1,86 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securityhub
+
+import (
+	"context"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/gtask"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/scheduler"
+)
+
+const (
+	// SnapshotCallback the scheduler callback name of the security summary snapshot
+	SnapshotCallback = "securityHubSnapshot"
+	// systemVendorID represents the id for system job.
+	systemVendorID = -1
+
+	cronTypeCustom = "Custom"
+	// run for every day
+	snapshotCron = "0 0 0 * * *"
+	// the interval to reopen the expired accepted risks
+	expiredRiskInterval = time.Hour
+)
+
+var sched = scheduler.Sched
+
+func init() {
+	if err := scheduler.RegisterCallbackFunc(SnapshotCallback, snapshotCallback); err != nil {
+		log.Fatalf("failed to register the callback for the security summary snapshot, error %v", err)
+	}
+	gtask.DefaultPool().AddTask(reconcileExpiredRisks, expiredRiskInterval)
+}
+
+func reconcileExpiredRisks(ctx context.Context) {
+	if err := Ctl.ReconcileExpiredRisks(ctx); err != nil {
+		log.Errorf("failed to reconcile the remediations with the expired accepted risks, error: %v", err)
+	}
+}
+
+func snapshotCallback(ctx context.Context, _ string) error {
+	return Ctl.TakeSnapshot(ctx)
+}
+
+// ScheduleSnapshotJob schedules the system job to take the daily snapshot of the security summary.
+func ScheduleSnapshotJob(ctx context.Context) error {
+	schedules, err := sched.ListSchedules(ctx, q.New(q.KeyWords{"vendor_type": job.SecurityHubSnapshotVendorType}))
+	if err != nil {
+		return err
+	}
+
+	if len(schedules) > 0 {
+		// unschedule the job if the cron changed
+		if schedules[0].CRON == snapshotCron {
+			log.Debug("skip to schedule the security summary snapshot job because the old one existed and cron not changed")
+			return nil
+		}
+
+		if err = sched.UnScheduleByID(ctx, schedules[0].ID); err != nil {
+			return err
+		}
+	}
+
+	scheduleID, err := sched.Schedule(ctx, job.SecurityHubSnapshotVendorType, systemVendorID, cronTypeCustom, snapshotCron, SnapshotCallback, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("scheduled the security summary snapshot job, id: %d", scheduleID)
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/signing"
+	"github.com/goharbor/harbor/src/pkg/task"
+)
+
+func init() {
+	if err := task.RegisterCheckInProcessor(job.ArtifactSigningVendorType, signingCheckIn); err != nil {
+		log.Fatalf("failed to register the checkin processor for the signing job, error %v", err)
+	}
+}
+
+// signingCheckIn records the signature checked in by the signing job
+func signingCheckIn(ctx context.Context, t *task.Task, sc *job.StatusChange) error {
+	if sc.CheckIn == "" {
+		return nil
+	}
+	signature := &signing.CheckIn{}
+	if err := json.Unmarshal([]byte(sc.CheckIn), signature); err != nil {
+		log.G(ctx).Errorf("failed to resolve checkin of signing task %d: %v", t.ID, err)
+		return err
+	}
+	repository, _ := t.ExtraAttrs[extraAttrRepository].(string)
+	digest, _ := t.ExtraAttrs[extraAttrDigest].(string)
+	if err := Ctl.RecordSignature(ctx, repository, digest, signature); err != nil {
+		log.G(ctx).Errorf("failed to record the signature %s of %s@%s: %v", signature.Digest, repository, digest, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/accessory"
+	"github.com/goharbor/harbor/src/pkg/signing"
+	"github.com/goharbor/harbor/src/pkg/task"
+)
+
+const (
+	// the extra attributes of the signing task
+	extraAttrRepository = "repository"
+	extraAttrDigest     = "digest"
+	extraAttrFormat     = "format"
+)
+
+// Ctl is the global signing controller instance
+var Ctl = NewController()
+
+// Controller signs the artifacts with the signing keys of the projects on the server side
+type Controller interface {
+	// Sign launches the job to sign the artifact with the signing key of its project in the format
+	Sign(ctx context.Context, art *artifact.Artifact, format string) (err error)
+	// RecordSignature records the signature pushed by the signing job as the accessory of the artifact
+	RecordSignature(ctx context.Context, repository, digest string, signature *signing.CheckIn) (err error)
+}
+
+// NewController creates an instance of the default controller
+func NewController() Controller {
+	return &controller{
+		execMgr: task.ExecMgr,
+		taskMgr: task.Mgr,
+		keyMgr:  signing.Mgr,
+		artCtl:  artifact.Ctl,
+		accMgr:  accessory.Mgr,
+		extURL:  config.ExtURL,
+	}
+}
+
+type controller struct {
+	execMgr task.ExecutionManager
+	taskMgr task.Manager
+	keyMgr  signing.Manager
+	artCtl  artifact.Controller
+	accMgr  accessory.Manager
+	extURL  func() (string, error)
+}
+
+func (c *controller) Sign(ctx context.Context, art *artifact.Artifact, format string) error {
+	if !signing.IsValidFormat(format) {
+		return errors.BadRequestError(nil).WithMessagef("invalid signing format %s", format)
+	}
+	// fail fast if no signing key configured for the project
+	if _, err := c.keyMgr.Get(ctx, art.ProjectID); err != nil {
+		return err
+	}
+
+	dockerReference := art.RepositoryName
+	if host, err := c.extURL(); err == nil && len(host) > 0 {
+		dockerReference = fmt.Sprintf("%s/%s", host, art.RepositoryName)
+	}
+
+	execID, err := c.execMgr.Create(ctx, job.ArtifactSigningVendorType, art.ID, task.ExecutionTriggerEvent, map[string]any{
+		extraAttrFormat: format,
+	})
+	if err != nil {
+		return err
+	}
+	j := &task.Job{
+		Name: job.ArtifactSigningVendorType,
+		Parameters: job.Parameters{
+			signing.ParamProjectID:       art.ProjectID,
+			signing.ParamRepository:      art.RepositoryName,
+			signing.ParamDigest:          art.Digest,
+			signing.ParamMediaType:       art.ManifestMediaType,
+			signing.ParamSize:            art.Size,
+			signing.ParamFormat:          format,
+			signing.ParamDockerReference: dockerReference,
+		},
+		Metadata: &job.Metadata{
+			JobKind: job.KindGeneric,
+		},
+	}
+	if _, err = c.taskMgr.Create(ctx, execID, j, map[string]any{
+		extraAttrRepository: art.RepositoryName,
+		extraAttrDigest:     art.Digest,
+		extraAttrFormat:     format,
+	}); err != nil {
+		if er := c.execMgr.MarkError(ctx, execID, err.Error()); er != nil {
+			log.G(ctx).Errorf("failed to mark the signing execution %d as error: %v", execID, er)
+		}
+		return err
+	}
+	return nil
+}
+
+func (c *controller) RecordSignature(ctx context.Context, repository, digest string, signature *signing.CheckIn) error {
+	subject, err := c.artCtl.GetByReference(ctx, repository, digest, nil)
+	if err != nil {
+		return err
+	}
+	var tags []string
+	if len(signature.Reference) > 0 {
+		tags = append(tags, signature.Reference)
+	}
+	_, id, err := c.artCtl.Ensure(ctx, repository, signature.Digest, &artifact.ArtOption{Tags: tags})
+	if err != nil {
+		return err
+	}
+	art, err := c.artCtl.Get(ctx, id, nil)
+	if err != nil {
+		return err
+	}
+	return c.accMgr.Ensure(ctx, subject.Digest, repository, subject.ID, art.ID, art.Size, art.Digest, signature.Type)
+}
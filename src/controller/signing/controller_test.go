@@ -0,0 +1,132 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	accessorymodel "github.com/goharbor/harbor/src/pkg/accessory/model"
+	pkgartifact "github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/pkg/signing"
+	"github.com/goharbor/harbor/src/pkg/signing/model"
+	"github.com/goharbor/harbor/src/pkg/task"
+	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
+	"github.com/goharbor/harbor/src/testing/mock"
+	accessorytesting "github.com/goharbor/harbor/src/testing/pkg/accessory"
+	signingtesting "github.com/goharbor/harbor/src/testing/pkg/signing"
+	tasktesting "github.com/goharbor/harbor/src/testing/pkg/task"
+)
+
+type controllerTestSuite struct {
+	suite.Suite
+	ctl     *controller
+	execMgr *tasktesting.ExecutionManager
+	taskMgr *tasktesting.Manager
+	keyMgr  *signingtesting.Manager
+	artCtl  *artifacttesting.Controller
+	accMgr  *accessorytesting.Manager
+	art     *artifact.Artifact
+}
+
+func (c *controllerTestSuite) SetupTest() {
+	c.execMgr = &tasktesting.ExecutionManager{}
+	c.taskMgr = &tasktesting.Manager{}
+	c.keyMgr = &signingtesting.Manager{}
+	c.artCtl = &artifacttesting.Controller{}
+	c.accMgr = &accessorytesting.Manager{}
+	c.ctl = &controller{
+		execMgr: c.execMgr,
+		taskMgr: c.taskMgr,
+		keyMgr:  c.keyMgr,
+		artCtl:  c.artCtl,
+		accMgr:  c.accMgr,
+		extURL:  func() (string, error) { return "harbor.example.com", nil },
+	}
+	c.art = &artifact.Artifact{Artifact: pkgartifact.Artifact{
+		ID:                1,
+		ProjectID:         1,
+		RepositoryName:    "library/hello",
+		Digest:            "sha256:digest",
+		ManifestMediaType: "application/vnd.oci.image.manifest.v1+json",
+		Size:              1024,
+	}}
+}
+
+func (c *controllerTestSuite) TestSign() {
+	c.keyMgr.On("Get", mock.Anything, int64(1)).Return(&model.Key{ProjectID: 1}, nil)
+	c.execMgr.On("Create", mock.Anything, job.ArtifactSigningVendorType, int64(1), task.ExecutionTriggerEvent, mock.Anything).Return(int64(1), nil)
+	var params job.Parameters
+	c.taskMgr.On("Create", mock.Anything, int64(1), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		params = args.Get(2).(*task.Job).Parameters
+	}).Return(int64(1), nil)
+
+	c.Require().Nil(c.ctl.Sign(context.TODO(), c.art, signing.FormatCosign))
+	c.Equal("harbor.example.com/library/hello", params[signing.ParamDockerReference])
+	c.Equal(signing.FormatCosign, params[signing.ParamFormat])
+	c.Equal("sha256:digest", params[signing.ParamDigest])
+	c.execMgr.AssertExpectations(c.T())
+	c.taskMgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestSignFailed() {
+	// invalid format
+	err := c.ctl.Sign(context.TODO(), c.art, "gpg")
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// no key
+	c.keyMgr.On("Get", mock.Anything, int64(1)).Return(nil, errors.NotFoundError(nil)).Once()
+	err = c.ctl.Sign(context.TODO(), c.art, signing.FormatNotation)
+	c.True(errors.IsNotFoundErr(err))
+	c.execMgr.AssertNotCalled(c.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// failed to create task
+	c.keyMgr.On("Get", mock.Anything, int64(1)).Return(&model.Key{ProjectID: 1}, nil)
+	c.execMgr.On("Create", mock.Anything, job.ArtifactSigningVendorType, int64(1), task.ExecutionTriggerEvent, mock.Anything).Return(int64(1), nil)
+	c.taskMgr.On("Create", mock.Anything, int64(1), mock.Anything, mock.Anything).Return(int64(0), fmt.Errorf("error"))
+	c.execMgr.On("MarkError", mock.Anything, int64(1), "error").Return(nil)
+	c.NotNil(c.ctl.Sign(context.TODO(), c.art, signing.FormatNotation))
+	c.execMgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestRecordSignature() {
+	c.artCtl.On("GetByReference", mock.Anything, "library/hello", "sha256:digest", mock.Anything).Return(c.art, nil)
+	c.artCtl.On("Ensure", mock.Anything, "library/hello", "sha256:signature", &artifact.ArtOption{Tags: []string{"sha256-digest.sig"}}).Return(true, int64(2), nil)
+	c.artCtl.On("Get", mock.Anything, int64(2), mock.Anything).Return(&artifact.Artifact{Artifact: pkgartifact.Artifact{
+		ID:     2,
+		Digest: "sha256:signature",
+		Size:   512,
+	}}, nil)
+	c.accMgr.On("Ensure", mock.Anything, "sha256:digest", "library/hello", int64(1), int64(2), int64(512), "sha256:signature", accessorymodel.TypeCosignSignature).Return(nil)
+
+	err := c.ctl.RecordSignature(context.TODO(), "library/hello", "sha256:digest", &signing.CheckIn{
+		Digest:    "sha256:signature",
+		Type:      accessorymodel.TypeCosignSignature,
+		Reference: "sha256-digest.sig",
+	})
+	c.Require().Nil(err)
+	c.artCtl.AssertExpectations(c.T())
+	c.accMgr.AssertExpectations(c.T())
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &controllerTestSuite{})
+}
@@ -31,6 +31,8 @@ import (
 const (
 	cronTypeDaily = "Daily"
 	cronSpec      = "0 0 0 * * *"
+	// cronJitter spreads the daily cleanup of the instances sharing the same database over an hour
+	cronJitter = 3600
 )
 
 var (
@@ -138,7 +140,8 @@ func scheduleSystemArtifactCleanJob(ctx context.Context) error {
 		log.Debugf("Export data cleanup job already scheduled with ID : %v.", schedule.ID)
 		return nil
 	}
-	scheduleID, err := sched.Schedule(ctx, job.SystemArtifactCleanupVendorType, 0, cronTypeDaily, cronSpec, SystemArtifactCleanupCallback, nil, nil)
+	scheduleID, err := sched.ScheduleWithOptions(ctx, job.SystemArtifactCleanupVendorType, 0, cronTypeDaily, cronSpec,
+		&job.ScheduleOptions{Jitter: cronJitter}, SystemArtifactCleanupCallback, nil, nil)
 	if err != nil {
 		log.Errorf("Encountered error when scheduling scan data export cleanup job : %v", err)
 		return err
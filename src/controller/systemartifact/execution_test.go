@@ -138,16 +138,16 @@ func (suite *SystemArtifactCleanupTestSuite) TestScheduleCleanupJobNoPreviousSch
 	}
 
 	var extraAttrs map[string]any
-	suite.sched.On("Schedule", mock.Anything,
-		job.SystemArtifactCleanupVendorType, int64(0), cronTypeDaily, cronSpec, SystemArtifactCleanupCallback, nil, extraAttrs).Return(int64(1), nil)
+	suite.sched.On("ScheduleWithOptions", mock.Anything,
+		job.SystemArtifactCleanupVendorType, int64(0), cronTypeDaily, cronSpec, &job.ScheduleOptions{Jitter: cronJitter}, SystemArtifactCleanupCallback, nil, extraAttrs).Return(int64(1), nil)
 	suite.sched.On("ListSchedules", mock.Anything, mock.Anything).Return(make([]*scheduler2.Schedule, 0), nil)
 	sched = suite.sched
 	ctx := context.TODO()
 
 	ScheduleCleanupTask(ctx)
 
-	suite.sched.AssertCalled(suite.T(), "Schedule", mock.Anything,
-		job.SystemArtifactCleanupVendorType, int64(0), cronTypeDaily, cronSpec, SystemArtifactCleanupCallback, nil, extraAttrs)
+	suite.sched.AssertCalled(suite.T(), "ScheduleWithOptions", mock.Anything,
+		job.SystemArtifactCleanupVendorType, int64(0), cronTypeDaily, cronSpec, &job.ScheduleOptions{Jitter: cronJitter}, SystemArtifactCleanupCallback, nil, extraAttrs)
 }
 
 func (suite *SystemArtifactCleanupTestSuite) TestScheduleCleanupJobPreviousSchedule() {
@@ -164,8 +164,8 @@ func (suite *SystemArtifactCleanupTestSuite) TestScheduleCleanupJobPreviousSched
 	}
 
 	var extraAttrs map[string]any
-	suite.sched.On("Schedule", mock.Anything,
-		job.SystemArtifactCleanupVendorType, int64(0), cronTypeDaily, cronSpec, SystemArtifactCleanupCallback, nil, extraAttrs).Return(int64(1), nil)
+	suite.sched.On("ScheduleWithOptions", mock.Anything,
+		job.SystemArtifactCleanupVendorType, int64(0), cronTypeDaily, cronSpec, &job.ScheduleOptions{Jitter: cronJitter}, SystemArtifactCleanupCallback, nil, extraAttrs).Return(int64(1), nil)
 
 	existingSchedule := scheduler2.Schedule{ID: int64(10)}
 	suite.sched.On("ListSchedules", mock.Anything, mock.Anything).Return([]*scheduler2.Schedule{&existingSchedule}, nil)
@@ -174,8 +174,8 @@ func (suite *SystemArtifactCleanupTestSuite) TestScheduleCleanupJobPreviousSched
 
 	ScheduleCleanupTask(ctx)
 
-	suite.sched.AssertNotCalled(suite.T(), "Schedule", mock.Anything,
-		job.SystemArtifactCleanupVendorType, int64(0), cronTypeDaily, cronSpec, SystemArtifactCleanupCallback, nil, extraAttrs)
+	suite.sched.AssertNotCalled(suite.T(), "ScheduleWithOptions", mock.Anything,
+		job.SystemArtifactCleanupVendorType, int64(0), cronTypeDaily, cronSpec, &job.ScheduleOptions{Jitter: cronJitter}, SystemArtifactCleanupCallback, nil, extraAttrs)
 }
 
 func (suite *SystemArtifactCleanupTestSuite) TestScheduleCleanupJobPreviousScheduleError() {
@@ -191,8 +191,8 @@ func (suite *SystemArtifactCleanupTestSuite) TestScheduleCleanupJobPreviousSched
 		makeCtx:           func() context.Context { return orm.NewContext(nil, &ormtesting.FakeOrmer{}) },
 	}
 
-	suite.sched.On("Schedule", mock.Anything,
-		job.SystemArtifactCleanupVendorType, int64(0), cronTypeDaily, cronSpec, SystemArtifactCleanupCallback, nil, mock.Anything).Return(int64(1), nil)
+	suite.sched.On("ScheduleWithOptions", mock.Anything,
+		job.SystemArtifactCleanupVendorType, int64(0), cronTypeDaily, cronSpec, &job.ScheduleOptions{Jitter: cronJitter}, SystemArtifactCleanupCallback, nil, mock.Anything).Return(int64(1), nil)
 
 	suite.sched.On("ListSchedules", mock.Anything, mock.Anything).Return(nil, errors.New("test error"))
 	sched = suite.sched
@@ -203,8 +203,8 @@ func (suite *SystemArtifactCleanupTestSuite) TestScheduleCleanupJobPreviousSched
 	extraAttributesMatcher := testifymock.MatchedBy(func(attrs map[string]any) bool {
 		return len(attrs) == 0
 	})
-	suite.sched.AssertNotCalled(suite.T(), "Schedule", mock.Anything,
-		job.SystemArtifactCleanupVendorType, int64(0), cronTypeDaily, cronSpec, SystemArtifactCleanupCallback, nil, extraAttributesMatcher)
+	suite.sched.AssertNotCalled(suite.T(), "ScheduleWithOptions", mock.Anything,
+		job.SystemArtifactCleanupVendorType, int64(0), cronTypeDaily, cronSpec, &job.ScheduleOptions{Jitter: cronJitter}, SystemArtifactCleanupCallback, nil, extraAttributesMatcher)
 }
 
 func (suite *SystemArtifactCleanupTestSuite) TearDownSuite() {
@@ -16,14 +16,29 @@ package task
 
 import (
 	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
 
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/task"
+	"github.com/goharbor/harbor/src/pkg/task/dao"
+)
+
+const (
+	// exportPageSize is the count of the executions read in one batch when exporting.
+	exportPageSize = 100
+	// MaxExportedExecutions caps the count of the executions in one export.
+	MaxExportedExecutions = 100000
 )
 
 var (
 	// ExecutionCtl is a global execution controller.
 	ExecutionCtl = NewExecutionController()
+
+	executionCSVHeader = []string{"id", "vendor_type", "vendor_id", "status", "trigger", "start_time", "end_time",
+		"duration_seconds", "task_count", "success_task_count", "error_task_count", "stopped_task_count", "status_message"}
 )
 
 // ExecutionController manages the execution.
@@ -38,6 +53,11 @@ type ExecutionController interface {
 	List(ctx context.Context, query *q.Query) (executions []*task.Execution, err error)
 	// Count counts total.
 	Count(ctx context.Context, query *q.Query) (int64, error)
+	// Stats returns the statistics of the executions across all the vendor types and of each vendor type.
+	Stats(ctx context.Context, query *q.Query) (stats []*dao.ExecutionStats, err error)
+	// Export writes the executions matching the query into the writer as CSV, ordered by ID descending.
+	// At most MaxExportedExecutions executions are exported.
+	Export(ctx context.Context, query *q.Query, w io.Writer) (err error)
 }
 
 // executionController defines the execution controller.
@@ -76,3 +96,72 @@ func (ec *executionController) List(ctx context.Context, query *q.Query) (execut
 func (ec *executionController) Count(ctx context.Context, query *q.Query) (int64, error) {
 	return ec.mgr.Count(ctx, query)
 }
+
+// Stats returns the statistics of the executions across all the vendor types and of each vendor type.
+func (ec *executionController) Stats(ctx context.Context, query *q.Query) ([]*dao.ExecutionStats, error) {
+	return ec.mgr.Stats(ctx, query)
+}
+
+// Export writes the executions matching the query into the writer as CSV, ordered by ID descending.
+// The executions are read page by page after the last ID read, so the executions created during
+// the export don't shift the pages.
+func (ec *executionController) Export(ctx context.Context, query *q.Query, w io.Writer) error {
+	query = q.MustClone(query)
+	query.PageNumber, query.PageSize = 1, exportPageSize
+	query.Sorts = []*q.Sort{q.NewSort("id", true)}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(executionCSVHeader); err != nil {
+		return err
+	}
+	for exported := 0; exported < MaxExportedExecutions; {
+		executions, err := ec.mgr.List(ctx, query)
+		if err != nil {
+			return err
+		}
+		for _, execution := range executions[:min(len(executions), MaxExportedExecutions-exported)] {
+			if err = writer.Write(executionCSVRecord(execution)); err != nil {
+				return err
+			}
+			exported++
+		}
+		writer.Flush()
+		if err = writer.Error(); err != nil {
+			return err
+		}
+		if len(executions) < exportPageSize {
+			break
+		}
+		query.Keywords["id"] = &q.Range{Max: executions[len(executions)-1].ID - 1}
+	}
+	return nil
+}
+
+func executionCSVRecord(execution *task.Execution) []string {
+	var (
+		endTime, duration string
+		metrics           = execution.Metrics
+	)
+	if !execution.EndTime.IsZero() && !execution.EndTime.Before(execution.StartTime) {
+		endTime = execution.EndTime.Format(time.RFC3339)
+		duration = strconv.FormatFloat(execution.EndTime.Sub(execution.StartTime).Seconds(), 'f', 3, 64)
+	}
+	if metrics == nil {
+		metrics = &dao.Metrics{}
+	}
+	return []string{
+		strconv.FormatInt(execution.ID, 10),
+		execution.VendorType,
+		strconv.FormatInt(execution.VendorID, 10),
+		execution.Status,
+		execution.Trigger,
+		execution.StartTime.Format(time.RFC3339),
+		endTime,
+		duration,
+		strconv.FormatInt(metrics.TaskCount, 10),
+		strconv.FormatInt(metrics.SuccessTaskCount, 10),
+		strconv.FormatInt(metrics.ErrorTaskCount, 10),
+		strconv.FormatInt(metrics.StoppedTaskCount, 10),
+		execution.StatusMessage,
+	}
+}
@@ -15,12 +15,16 @@
 package task
 
 import (
+	"bytes"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
 	"github.com/goharbor/harbor/src/lib/q"
 	model "github.com/goharbor/harbor/src/pkg/task"
+	"github.com/goharbor/harbor/src/pkg/task/dao"
 	"github.com/goharbor/harbor/src/testing/mock"
 	"github.com/goharbor/harbor/src/testing/pkg/task"
 )
@@ -84,3 +88,52 @@ func (ec *executionControllerTestSuite) TestList() {
 	ec.NoError(err)
 	ec.Len(es, 2)
 }
+
+// TestStats tests stats.
+func (ec *executionControllerTestSuite) TestStats() {
+	ec.mgr.On("Stats", mock.Anything, mock.Anything).Return([]*dao.ExecutionStats{
+		{Total: 2},
+		{VendorType: "REPLICATION", Total: 2},
+	}, nil)
+	stats, err := ec.ctl.Stats(nil, nil)
+	ec.NoError(err)
+	ec.Len(stats, 2)
+}
+
+// TestExport tests export.
+func (ec *executionControllerTestSuite) TestExport() {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	page := make([]*model.Execution, exportPageSize)
+	for i := range page {
+		page[i] = &model.Execution{ID: int64(i + 1), VendorType: "REPLICATION", Status: "Success", StartTime: start}
+	}
+	ec.mgr.On("List", mock.Anything, mock.Anything).Return(page, nil).Once()
+	ec.mgr.On("List", mock.Anything, mock.Anything).Return([]*model.Execution{
+		{
+			ID:            101,
+			VendorType:    "GARBAGE_COLLECTION",
+			Status:        "Error",
+			StatusMessage: "failed, retry later",
+			Trigger:       "SCHEDULE",
+			StartTime:     start,
+			EndTime:       start.Add(90 * time.Second),
+			Metrics:       &dao.Metrics{TaskCount: 2, SuccessTaskCount: 1, ErrorTaskCount: 1},
+		},
+	}, nil).Once()
+
+	buf := &bytes.Buffer{}
+	err := ec.ctl.Export(nil, q.New(q.KeyWords{"status": "Error"}), buf)
+	ec.Require().NoError(err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	ec.Require().Len(lines, exportPageSize+2)
+	ec.Equal(strings.Join(executionCSVHeader, ","), lines[0])
+	ec.Equal("1,REPLICATION,0,Success,,2026-01-01T00:00:00Z,,,0,0,0,0,", lines[1])
+	ec.Equal(`101,GARBAGE_COLLECTION,0,Error,SCHEDULE,2026-01-01T00:00:00Z,2026-01-01T00:01:30Z,90.000,2,1,1,0,"failed, retry later"`, lines[exportPageSize+1])
+	ec.mgr.AssertNumberOfCalls(ec.T(), "List", 2)
+	// the next page is read after the last ID
+	query := ec.mgr.Calls[len(ec.mgr.Calls)-1].Arguments.Get(1).(*q.Query)
+	ec.Equal(&q.Range{Max: int64(exportPageSize - 1)}, query.Keywords["id"])
+	ec.Equal("Error", query.Keywords["status"])
+	ec.Equal([]*q.Sort{q.NewSort("id", true)}, query.Sorts)
+}
@@ -36,6 +36,8 @@ import (
 	_ "github.com/goharbor/harbor/src/controller/event/handler"
 	"github.com/goharbor/harbor/src/controller/health"
 	"github.com/goharbor/harbor/src/controller/registry"
+	scanCtl "github.com/goharbor/harbor/src/controller/scan"
+	"github.com/goharbor/harbor/src/controller/securityhub"
 	"github.com/goharbor/harbor/src/controller/systemartifact"
 	"github.com/goharbor/harbor/src/controller/task"
 	"github.com/goharbor/harbor/src/core/api"
@@ -59,12 +61,14 @@ import (
 	"github.com/goharbor/harbor/src/lib/retry"
 	tracelib "github.com/goharbor/harbor/src/lib/trace"
 	"github.com/goharbor/harbor/src/migration"
+	_ "github.com/goharbor/harbor/src/pkg/accessory/model/attestation"
 	_ "github.com/goharbor/harbor/src/pkg/accessory/model/base"
 	_ "github.com/goharbor/harbor/src/pkg/accessory/model/cosign"
 	_ "github.com/goharbor/harbor/src/pkg/accessory/model/notation"
 	_ "github.com/goharbor/harbor/src/pkg/accessory/model/nydus"
 	_ "github.com/goharbor/harbor/src/pkg/accessory/model/sbom"
 	_ "github.com/goharbor/harbor/src/pkg/accessory/model/subject"
+	_ "github.com/goharbor/harbor/src/pkg/accessory/model/vex"
 	"github.com/goharbor/harbor/src/pkg/audit"
 	_ "github.com/goharbor/harbor/src/pkg/auditext/event/config"
 	_ "github.com/goharbor/harbor/src/pkg/auditext/event/login"
@@ -323,6 +327,18 @@ func main() {
 		}, options...); err != nil {
 			log.Errorf("failed to schedule system execution sweep job, error: %v", err)
 		}
+		// schedule the vulnerability database update checking job
+		if err := retry.Retry(func() error {
+			return scanCtl.ScheduleDBUpdateCheck(ctx)
+		}, options...); err != nil {
+			log.Errorf("failed to schedule the vulnerability database update checking job, error: %v", err)
+		}
+		// schedule the security summary snapshot job
+		if err := retry.Retry(func() error {
+			return securityhub.ScheduleSnapshotJob(ctx)
+		}, options...); err != nil {
+			log.Errorf("failed to schedule the security summary snapshot job, error: %v", err)
+		}
 	}()
 	web.RunWithMiddleWares("", middlewares.MiddleWares()...)
 }
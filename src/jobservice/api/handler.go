@@ -15,6 +15,7 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -22,6 +23,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
@@ -38,8 +40,14 @@ import (
 const (
 	totalHeaderKey = "Total-Count"
 	nextCursorKey  = "Next-Cursor"
+
+	// logFollowWriteTimeout is the timeout of writing one event when following the job log
+	logFollowWriteTimeout = 15 * time.Second
 )
 
+// logFollowInterval is the interval of checking the new log data when following the job log
+var logFollowInterval = time.Second
+
 // Handler defines approaches to handle the http requests.
 type Handler interface {
 	// HandleLaunchJobReq is used to handle the job submission request.
@@ -65,6 +73,15 @@ type Handler interface {
 
 	// HandleGetConfigReq is used to handle the request of getting configure
 	HandleGetConfigReq(w http.ResponseWriter, req *http.Request)
+
+	// HandleLaunchDAGReq is used to handle the DAG submission request.
+	HandleLaunchDAGReq(w http.ResponseWriter, req *http.Request)
+
+	// HandleGetDAGReq is used to handle the DAG query request.
+	HandleGetDAGReq(w http.ResponseWriter, req *http.Request)
+
+	// HandleDAGActionReq is used to handle the DAG action requests (stop/retry).
+	HandleDAGActionReq(w http.ResponseWriter, req *http.Request)
 }
 
 // DefaultHandler is the default request handler which implements the Handler interface.
@@ -203,6 +220,11 @@ func (dh *DefaultHandler) HandleJobLogReq(w http.ResponseWriter, req *http.Reque
 		return
 	}
 
+	if follow, _ := strconv.ParseBool(req.URL.Query().Get("follow")); follow {
+		dh.followJobLog(w, req, jobID)
+		return
+	}
+
 	logData, err := dh.controller.GetJobLogData(jobID)
 	if err != nil {
 		code := http.StatusInternalServerError
@@ -223,6 +245,106 @@ func (dh *DefaultHandler) HandleJobLogReq(w http.ResponseWriter, req *http.Reque
 	writeDate(w, logData)
 }
 
+// followJobLog streams the log of the job as Server-Sent Events until the job is done or the client is gone.
+// The id of each event is the log offset which can be sent back with the 'Last-Event-ID' header to resume.
+func (dh *DefaultHandler) followJobLog(w http.ResponseWriter, req *http.Request, jobID string) {
+	var offset int64
+	lastEventID := req.Header.Get("Last-Event-ID")
+	if len(lastEventID) == 0 {
+		lastEventID = req.URL.Query().Get("offset")
+	}
+	if len(lastEventID) > 0 {
+		v, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || v < 0 {
+			dh.handleError(w, req, http.StatusBadRequest, errors.Errorf("invalid log offset: %s", lastEventID))
+			return
+		}
+		offset = v
+	}
+
+	// Make sure the job exists before starting the stream
+	if _, err := dh.controller.GetJob(jobID); err != nil {
+		code := http.StatusInternalServerError
+		if errs.IsObjectNotFoundError(err) {
+			code = http.StatusNotFound
+		} else {
+			err = errs.GetJobLogError(err)
+		}
+		dh.handleError(w, req, code, err)
+		return
+	}
+
+	dh.log(req, http.StatusOK, "")
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	send := func(event string, data []byte) bool {
+		// The stream lives longer than the write timeout of the server
+		_ = rc.SetWriteDeadline(time.Now().Add(logFollowWriteTimeout))
+		if err := writeEvent(w, event, offset, data); err != nil {
+			logger.Debugf("Stop following the log of job %s: %s", jobID, err)
+			return false
+		}
+		if err := rc.Flush(); err != nil {
+			logger.Debugf("Stop following the log of job %s: %s", jobID, err)
+			return false
+		}
+		return true
+	}
+
+	ticker := time.NewTicker(logFollowInterval)
+	defer ticker.Stop()
+
+	for {
+		stats, err := dh.controller.GetJob(jobID)
+		if err != nil {
+			send("error", []byte(err.Error()))
+			return
+		}
+		done := job.Status(stats.Info.Status).Final()
+
+		data, next, err := dh.controller.TailJobLogData(jobID, offset)
+		if err != nil {
+			// The log may not be there until the job is started
+			if !errs.IsObjectNotFoundError(err) || done {
+				send("error", []byte(err.Error()))
+				return
+			}
+			next = offset
+		}
+
+		// Hold the incomplete line back until the rest of it is written
+		if i := bytes.LastIndexByte(data, '\n'); !done && i >= 0 && i+1 < len(data) {
+			next -= int64(len(data) - i - 1)
+			data = data[:i+1]
+		}
+		offset = next
+
+		if len(data) > 0 && !send("", data) {
+			return
+		}
+
+		if done {
+			if len(data) == 0 {
+				send("end", []byte(stats.Info.Status))
+				return
+			}
+			// Drain the rest of the log
+			continue
+		}
+
+		select {
+		case <-req.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // HandlePeriodicExecutions is implementation of method defined in interface 'Handler'
 func (dh *DefaultHandler) HandlePeriodicExecutions(w http.ResponseWriter, req *http.Request) {
 	// Get param
@@ -307,9 +429,112 @@ func (dh *DefaultHandler) HandleGetConfigReq(w http.ResponseWriter, req *http.Re
 	}
 	dh.handleJSONData(w, req, http.StatusOK, &job.Config{
 		RedisPoolConfig: config.DefaultConfig.PoolConfig.RedisPoolCfg,
+		Priorities:      job.PrioritiesFromConfig(config.DefaultConfig.PriorityConfig),
 	})
 }
 
+// HandleLaunchDAGReq is implementation of method defined in interface 'Handler'
+func (dh *DefaultHandler) HandleLaunchDAGReq(w http.ResponseWriter, req *http.Request) {
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		dh.handleError(w, req, http.StatusInternalServerError, errs.ReadRequestBodyError(err))
+		return
+	}
+
+	// unmarshal data
+	dagReq := &job.DAGRequest{}
+	if err = json.Unmarshal(data, dagReq); err != nil {
+		dh.handleError(w, req, http.StatusInternalServerError, errs.HandleJSONDataError(err))
+		return
+	}
+
+	dag, err := dh.controller.LaunchDAG(dagReq)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errs.IsBadRequestError(err) {
+			code = http.StatusBadRequest
+		} else {
+			err = errs.LaunchDAGError(err)
+		}
+
+		dh.handleError(w, req, code, err)
+		return
+	}
+
+	dh.handleJSONData(w, req, http.StatusAccepted, dag)
+}
+
+// HandleGetDAGReq is implementation of method defined in interface 'Handler'
+func (dh *DefaultHandler) HandleGetDAGReq(w http.ResponseWriter, req *http.Request) {
+	vars := mux.Vars(req)
+	dagID := vars["dag_id"]
+
+	dag, err := dh.controller.GetDAG(dagID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errs.IsObjectNotFoundError(err) {
+			code = http.StatusNotFound
+		} else if errs.IsBadRequestError(err) {
+			code = http.StatusBadRequest
+		} else {
+			err = errs.GetDAGError(err)
+		}
+		dh.handleError(w, req, code, err)
+		return
+	}
+
+	dh.handleJSONData(w, req, http.StatusOK, dag)
+}
+
+// HandleDAGActionReq is implementation of method defined in interface 'Handler'
+func (dh *DefaultHandler) HandleDAGActionReq(w http.ResponseWriter, req *http.Request) {
+	vars := mux.Vars(req)
+	dagID := vars["dag_id"]
+
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		dh.handleError(w, req, http.StatusInternalServerError, errs.ReadRequestBodyError(err))
+		return
+	}
+
+	// unmarshal data
+	actionReq := &job.ActionRequest{}
+	if err = json.Unmarshal(data, actionReq); err != nil {
+		dh.handleError(w, req, http.StatusInternalServerError, errs.HandleJSONDataError(err))
+		return
+	}
+
+	cmd := job.OPCommand(actionReq.Action)
+	switch {
+	case cmd.IsStop():
+		err = dh.controller.StopDAG(dagID)
+	case cmd.IsRetry():
+		err = dh.controller.RetryDAG(dagID)
+	default:
+		dh.handleError(w, req, http.StatusNotImplemented, errs.UnknownActionNameError(errors.Errorf("command: %s", actionReq.Action)))
+		return
+	}
+
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errs.IsObjectNotFoundError(err) {
+			code = http.StatusNotFound
+		} else if errs.IsBadRequestError(err) {
+			code = http.StatusBadRequest
+		} else if errs.IsStatusMismatchError(err) {
+			code = http.StatusConflict
+		} else {
+			err = errs.DAGActionError(err)
+		}
+		dh.handleError(w, req, code, err)
+		return
+	}
+
+	dh.log(req, http.StatusNoContent, string(data))
+
+	w.WriteHeader(http.StatusNoContent) // only header, no content returned
+}
+
 func extractQuery(req *http.Request) *query.Parameter {
 	q := &query.Parameter{
 		PageNumber: 1,
@@ -363,6 +588,22 @@ func extractQuery(req *http.Request) *query.Parameter {
 	return q
 }
 
+// writeEvent writes a Server-Sent Event, each line of the data is put into a 'data' field
+func writeEvent(w io.Writer, event string, id int64, data []byte) error {
+	var buf bytes.Buffer
+	if len(event) > 0 {
+		fmt.Fprintf(&buf, "event: %s\n", event)
+	}
+	fmt.Fprintf(&buf, "id: %d\n", id)
+	for line := range strings.SplitSeq(strings.TrimSuffix(string(data), "\n"), "\n") {
+		fmt.Fprintf(&buf, "data: %s\n", strings.TrimSuffix(line, "\r"))
+	}
+	buf.WriteString("\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
 func writeDate(w http.ResponseWriter, bytes []byte) {
 	if _, err := w.Write(bytes); err != nil {
 		logger.Errorf("writer write error: %s", err)
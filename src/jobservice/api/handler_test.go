@@ -195,6 +195,64 @@ func (suite *APIHandlerTestSuite) TestJobActionSucceed() {
 	assert.Equal(suite.T(), 204, code, "expected 204 no content but got %d", code)
 }
 
+// TestLaunchDAG ...
+func (suite *APIHandlerTestSuite) TestLaunchDAG() {
+	fc := &fakeController{}
+	fc.On("LaunchDAG", mock.Anything).Return(nil, errs.BadRequestError("cycle detected in the DAG")).Once()
+	fc.On("LaunchDAG", mock.Anything).Return(&job.DAG{ID: "fake_dag_ID", Status: job.RunningStatus.String()}, nil)
+	suite.controller = fc
+
+	dagReq := &job.DAGRequest{
+		Nodes: []*job.DAGNodeRequest{
+			{Name: "a", Job: createJobReq().Job},
+			{Name: "b", Job: createJobReq().Job, DependsOn: []string{"a"}},
+		},
+	}
+	data, _ := json.Marshal(dagReq)
+	_, code := suite.postReq(fmt.Sprintf("%s/%s", suite.APIAddr, "dags"), data)
+	assert.Equal(suite.T(), 400, code, "expected 400 bad request but got %d", code)
+
+	res, code := suite.postReq(fmt.Sprintf("%s/%s", suite.APIAddr, "dags"), data)
+	require.Equal(suite.T(), 202, code, "expected 202 accepted but got %d", code)
+	dag := &job.DAG{}
+	require.NoError(suite.T(), json.Unmarshal(res, dag))
+	assert.Equal(suite.T(), "fake_dag_ID", dag.ID)
+}
+
+// TestGetDAG ...
+func (suite *APIHandlerTestSuite) TestGetDAG() {
+	fc := &fakeController{}
+	fc.On("GetDAG", "fake_dag_ID_not").Return(nil, errs.NoObjectFoundError("fake_dag_ID_not"))
+	fc.On("GetDAG", "fake_dag_ID").Return(&job.DAG{ID: "fake_dag_ID", Status: job.RunningStatus.String()}, nil)
+	suite.controller = fc
+
+	_, code := suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "dags/fake_dag_ID_not"))
+	assert.Equal(suite.T(), 404, code, "expected 404 not found but got %d", code)
+
+	_, code = suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "dags/fake_dag_ID"))
+	assert.Equal(suite.T(), 200, code, "expected 200 ok but got %d", code)
+}
+
+// TestDAGAction ...
+func (suite *APIHandlerTestSuite) TestDAGAction() {
+	fc := &fakeController{}
+	fc.On("StopDAG", "fake_dag_ID").Return(nil)
+	fc.On("RetryDAG", "fake_dag_ID").Return(errs.StatusMismatchError("Running", "Running"))
+	suite.controller = fc
+
+	data, _ := json.Marshal(createJobActionReq("not-support"))
+	_, code := suite.postReq(fmt.Sprintf("%s/%s", suite.APIAddr, "dags/fake_dag_ID"), data)
+	assert.Equal(suite.T(), 501, code, "expected 501 not implemented but got %d", code)
+
+	data, _ = json.Marshal(createJobActionReq("stop"))
+	_, code = suite.postReq(fmt.Sprintf("%s/%s", suite.APIAddr, "dags/fake_dag_ID"), data)
+	assert.Equal(suite.T(), 204, code, "expected 204 no content but got %d", code)
+
+	data, _ = json.Marshal(createJobActionReq("retry"))
+	_, code = suite.postReq(fmt.Sprintf("%s/%s", suite.APIAddr, "dags/fake_dag_ID"), data)
+	assert.Equal(suite.T(), 409, code, "expected 409 conflict but got %d", code)
+}
+
 // TestCheckStatus ...
 func (suite *APIHandlerTestSuite) TestCheckStatus() {
 	statsRes := &worker.Stats{
@@ -241,6 +299,35 @@ func (suite *APIHandlerTestSuite) TestGetJobLog() {
 	assert.Equal(suite.T(), "hello log", string(resData))
 }
 
+// TestFollowJobLog ...
+func (suite *APIHandlerTestSuite) TestFollowJobLog() {
+	fc := &fakeController{}
+	fc.On("GetJob", "fake_job_ID").Return(createJobStats("sample", "Generic", ""), nil).Twice()
+	fc.On("GetJob", "fake_job_ID").Return(&job.Stats{
+		Info: &job.StatsInfo{
+			JobID:  "fake_job_ID",
+			Status: job.SuccessStatus.String(),
+		},
+	}, nil)
+	fc.On("TailJobLogData", "fake_job_ID", int64(0)).Return([]byte("line 1\nline 2\nline"), int64(18), nil)
+	fc.On("TailJobLogData", "fake_job_ID", int64(14)).Return([]byte("line 3\n"), int64(21), nil).Once()
+	fc.On("TailJobLogData", "fake_job_ID", int64(21)).Return([]byte{}, int64(21), nil)
+	suite.controller = fc
+	logFollowInterval = 10 * time.Millisecond
+
+	resData, code := suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs/fake_job_ID/log?follow=true"))
+	require.Equal(suite.T(), 200, code, "expected 200 ok but got %d", code)
+	assert.Equal(suite.T(), "id: 14\ndata: line 1\ndata: line 2\n\nid: 21\ndata: line 3\n\nevent: end\nid: 21\ndata: Success\n\n", string(resData))
+}
+
+// TestFollowJobLogInvalidOffset ...
+func (suite *APIHandlerTestSuite) TestFollowJobLogInvalidOffset() {
+	suite.controller = &fakeController{}
+
+	_, code := suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs/fake_job_ID/log?follow=true&offset=-1"))
+	assert.Equal(suite.T(), 400, code, "expected 400 bad request but got %d", code)
+}
+
 // TestGetPeriodicExecutionsWithoutQuery ...
 func (suite *APIHandlerTestSuite) TestGetPeriodicExecutionsWithoutQuery() {
 	q := &query.Parameter{
@@ -403,6 +490,10 @@ func (suite *APIHandlerTestSuite) GetJobLogData(jobID string) ([]byte, error) {
 	return suite.controller.GetJobLogData(jobID)
 }
 
+func (suite *APIHandlerTestSuite) TailJobLogData(jobID string, offset int64) ([]byte, int64, error) {
+	return suite.controller.TailJobLogData(jobID, offset)
+}
+
 func (suite *APIHandlerTestSuite) GetPeriodicExecutions(periodicJobID string, query *query.Parameter) ([]*job.Stats, int64, error) {
 	return suite.controller.GetPeriodicExecutions(periodicJobID, query)
 }
@@ -411,6 +502,26 @@ func (suite *APIHandlerTestSuite) GetJobs(query *query.Parameter) ([]*job.Stats,
 	return suite.controller.GetJobs(query)
 }
 
+func (suite *APIHandlerTestSuite) LaunchDAG(req *job.DAGRequest) (*job.DAG, error) {
+	return suite.controller.LaunchDAG(req)
+}
+
+func (suite *APIHandlerTestSuite) GetDAG(dagID string) (*job.DAG, error) {
+	return suite.controller.GetDAG(dagID)
+}
+
+func (suite *APIHandlerTestSuite) StopDAG(dagID string) error {
+	return suite.controller.StopDAG(dagID)
+}
+
+func (suite *APIHandlerTestSuite) RetryDAG(dagID string) error {
+	return suite.controller.RetryDAG(dagID)
+}
+
+func (suite *APIHandlerTestSuite) AdvanceDAG(dagID string) error {
+	return suite.controller.AdvanceDAG(dagID)
+}
+
 type fakeController struct {
 	mock.Mock
 }
@@ -461,6 +572,15 @@ func (fc *fakeController) GetJobLogData(jobID string) ([]byte, error) {
 	return args.Get(0).([]byte), nil
 }
 
+func (fc *fakeController) TailJobLogData(jobID string, offset int64) ([]byte, int64, error) {
+	args := fc.Called(jobID, offset)
+	if args.Error(2) != nil {
+		return nil, 0, args.Error(2)
+	}
+
+	return args.Get(0).([]byte), args.Get(1).(int64), nil
+}
+
 func (fc *fakeController) GetPeriodicExecutions(periodicJobID string, query *query.Parameter) ([]*job.Stats, int64, error) {
 	args := fc.Called(periodicJobID, query)
 	if args.Error(2) != nil {
@@ -479,6 +599,39 @@ func (fc *fakeController) GetJobs(query *query.Parameter) ([]*job.Stats, int64,
 	return args.Get(0).([]*job.Stats), args.Get(1).(int64), nil
 }
 
+func (fc *fakeController) LaunchDAG(req *job.DAGRequest) (*job.DAG, error) {
+	args := fc.Called(req)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*job.DAG), nil
+}
+
+func (fc *fakeController) GetDAG(dagID string) (*job.DAG, error) {
+	args := fc.Called(dagID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*job.DAG), nil
+}
+
+func (fc *fakeController) StopDAG(dagID string) error {
+	args := fc.Called(dagID)
+	return args.Error(0)
+}
+
+func (fc *fakeController) RetryDAG(dagID string) error {
+	args := fc.Called(dagID)
+	return args.Error(0)
+}
+
+func (fc *fakeController) AdvanceDAG(dagID string) error {
+	args := fc.Called(dagID)
+	return args.Error(0)
+}
+
 func createJobStats(name, kind, cron string) *job.Stats {
 	now := time.Now()
 	params := make(job.Parameters)
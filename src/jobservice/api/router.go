@@ -102,4 +102,8 @@ func (br *BaseRouter) registerRoutes() {
 	subRouter.HandleFunc("/stats", br.handler.HandleCheckStatusReq).Methods(http.MethodGet)
 	subRouter.HandleFunc("/config", br.handler.HandleGetConfigReq).Methods(http.MethodGet)
 	subRouter.HandleFunc("/jobs/{job_id}/executions", br.handler.HandlePeriodicExecutions).Methods(http.MethodGet)
+	// The DAGs are not launched by core yet, the jobs of the DAG nodes are not tracked as executions
+	subRouter.HandleFunc("/dags", br.handler.HandleLaunchDAGReq).Methods(http.MethodPost)
+	subRouter.HandleFunc("/dags/{dag_id}", br.handler.HandleGetDAGReq).Methods(http.MethodGet)
+	subRouter.HandleFunc("/dags/{dag_id}", br.handler.HandleDAGActionReq).Methods(http.MethodPost)
 }
@@ -106,3 +106,40 @@ func KeyInProgressQueue(namespace string, jobType string, workerPoolID string) s
 func KeyWorkerPools(namespace string) string {
 	return KeyNamespacePrefix(namespace) + "worker_pools"
 }
+
+// KeyJobPriorities returns the key of the job priorities adjusted at runtime
+func KeyJobPriorities(namespace string) string {
+	return KeyNamespacePrefix(namespace) + "job_priorities"
+}
+
+// KeyTenantRunningJobs returns the key of the running jobs of the specified tenant
+func KeyTenantRunningJobs(namespace string, tenant string) string {
+	return fmt.Sprintf("%stenants:%s:running", KeyNamespacePrefix(namespace), tenant)
+}
+
+// KeyRateLimitRunningJobs returns the key of the running jobs limited by the rate limit of the specified scope,
+// the scope is the vendor type or the remote endpoint
+func KeyRateLimitRunningJobs(namespace string, scope string, name string) string {
+	return fmt.Sprintf("%srate_limits:%s:%s:running", KeyNamespacePrefix(namespace), scope, name)
+}
+
+// KeyRateLimitStartedJobs returns the key of the count of the jobs started in the specified second
+// limited by the rate limit of the specified scope
+func KeyRateLimitStartedJobs(namespace string, scope string, name string, second int64) string {
+	return fmt.Sprintf("%srate_limits:%s:%s:started:%d", KeyNamespacePrefix(namespace), scope, name, second)
+}
+
+// KeyDAG returns the key of the specified DAG
+func KeyDAG(namespace string, dagID string) string {
+	return fmt.Sprintf("%sdags:%s", KeyNamespacePrefix(namespace), dagID)
+}
+
+// KeyDAGLock returns the key of the lock for updating the specified DAG
+func KeyDAGLock(namespace string, dagID string) string {
+	return fmt.Sprintf("%s:lock", KeyDAG(namespace, dagID))
+}
+
+// KeyRunningDAGs returns the key of the set of the unfinished DAGs
+func KeyRunningDAGs(namespace string) string {
+	return fmt.Sprintf("%s%s", KeyNamespacePrefix(namespace), "dags_running")
+}
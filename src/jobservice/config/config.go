@@ -57,6 +57,9 @@ const (
 
 	// redis protocol schema
 	redisSchema = "redis://"
+
+	// the max job priority accepted by the worker pool
+	maxJobPriority = 100000
 )
 
 // DefaultConfig is the default configuration reference
@@ -90,6 +93,12 @@ type Configuration struct {
 
 	// MaxLogSizeReturnedMB is the max size of log returned by job log API
 	MaxLogSizeReturnedMB int `yaml:"max_retrieve_size_mb,omitempty"`
+
+	// Job priority configurations
+	PriorityConfig *PriorityConfig `yaml:"priority,omitempty"`
+
+	// Rate limits of the jobs per vendor type and per remote endpoint
+	RateLimitConfig *RateLimitConfig `yaml:"rate_limit,omitempty"`
 }
 
 // HTTPSConfig keeps additional configurations when using https protocol
@@ -140,6 +149,34 @@ type LoggerConfig struct {
 	Sweeper  *LogSweeperConfig  `yaml:"sweeper"`
 }
 
+// PriorityConfig keeps the job priorities and the fair queuing settings of the tenants (projects)
+type PriorityConfig struct {
+	// Default priority of the job types not listed in VendorTypes
+	Default uint `yaml:"default"`
+	// Priorities of the job types
+	VendorTypes map[string]uint `yaml:"vendor_types,omitempty"`
+	// Weights of the tenants when sharing the running slots
+	Tenants map[string]uint `yaml:"tenants,omitempty"`
+	// Max number of the running jobs of one tenant with the default weight, 0 means no limit
+	MaxRunningPerTenant uint `yaml:"max_running_per_tenant"`
+}
+
+// RateLimitConfig keeps the rate limits of the jobs so that the large executions do not hammer the downstream services
+type RateLimitConfig struct {
+	// Limits of the jobs of the vendor types
+	VendorTypes map[string]*RateLimit `yaml:"vendor_types,omitempty"`
+	// Limits of the jobs calling the remote endpoints, the key is the host (with port if any) of the endpoint
+	Endpoints map[string]*RateLimit `yaml:"endpoints,omitempty"`
+}
+
+// RateLimit keeps the limits of the jobs sharing the same vendor type or remote endpoint, 0 means no limit
+type RateLimit struct {
+	// Max number of the running jobs
+	MaxConcurrency uint `yaml:"max_concurrency"`
+	// Max number of the jobs started in one second
+	MaxPerSecond uint `yaml:"max_per_second"`
+}
+
 type ReaperConfig struct {
 	MaxUpdateHour   int `yaml:"max_update_hours"`
 	MaxDanglingHour int `yaml:"max_dangling_hours"`
@@ -368,6 +405,40 @@ func (c *Configuration) validate() error {
 		return errors.New("missing logger config of job")
 	}
 
+	// Job priorities
+	if c.PriorityConfig != nil {
+		if c.PriorityConfig.Default > maxJobPriority {
+			return fmt.Errorf("default job priority should be less or equal %d", maxJobPriority)
+		}
+		for t, p := range c.PriorityConfig.VendorTypes {
+			if p == 0 || p > maxJobPriority {
+				return fmt.Errorf("priority of job type %s should be between 1 and %d", t, maxJobPriority)
+			}
+		}
+		for t, p := range c.PriorityConfig.Tenants {
+			if p == 0 || p > maxJobPriority {
+				return fmt.Errorf("priority of tenant %s should be between 1 and %d", t, maxJobPriority)
+			}
+		}
+	}
+
+	// Job rate limits
+	if c.RateLimitConfig != nil {
+		for t, l := range c.RateLimitConfig.VendorTypes {
+			if l == nil {
+				return fmt.Errorf("missing rate limit of job type %s", t)
+			}
+		}
+		for e, l := range c.RateLimitConfig.Endpoints {
+			if utils.IsEmptyStr(e) {
+				return errors.New("empty endpoint in rate limits")
+			}
+			if l == nil {
+				return fmt.Errorf("missing rate limit of endpoint %s", e)
+			}
+		}
+	}
+
 	return nil // valid
 }
 
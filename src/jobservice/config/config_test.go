@@ -82,6 +82,35 @@ func (suite *ConfigurationTestSuite) TestConfigLoadingWithEnv() {
 	assert.Equal(suite.T(), "core_url", GetCoreURL(), "expect core url 'core_url' but got '%s'", GetCoreURL())
 }
 
+// TestInvalidPriorityConfig ...
+func (suite *ConfigurationTestSuite) TestInvalidPriorityConfig() {
+	cfg := &Configuration{}
+	err := cfg.Load("../config_test.yml", false)
+	require.NoError(suite.T(), err)
+
+	cfg.PriorityConfig.VendorTypes["IMAGE_SCAN"] = 200000
+	assert.Error(suite.T(), cfg.validate(), "expect error for the out of range priority")
+
+	cfg.PriorityConfig.VendorTypes["IMAGE_SCAN"] = 2000
+	cfg.PriorityConfig.Tenants["library"] = 0
+	assert.Error(suite.T(), cfg.validate(), "expect error for the zero tenant priority")
+}
+
+// TestInvalidRateLimitConfig ...
+func (suite *ConfigurationTestSuite) TestInvalidRateLimitConfig() {
+	cfg := &Configuration{}
+	err := cfg.Load("../config_test.yml", false)
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), cfg.validate())
+
+	cfg.RateLimitConfig.Endpoints[""] = &RateLimit{MaxConcurrency: 1}
+	assert.Error(suite.T(), cfg.validate(), "expect error for the empty endpoint")
+
+	delete(cfg.RateLimitConfig.Endpoints, "")
+	cfg.RateLimitConfig.VendorTypes["WEBHOOK"] = nil
+	assert.Error(suite.T(), cfg.validate(), "expect error for the missing rate limit")
+}
+
 // TestDefaultConfig ...
 func (suite *ConfigurationTestSuite) TestDefaultConfig() {
 	err := DefaultConfig.Load("../config_test.yml", true)
@@ -94,6 +123,17 @@ func (suite *ConfigurationTestSuite) TestDefaultConfig() {
 	require.Equal(suite.T(), 168, maxDangling, "expect max dangling time to be 24 but got %d", maxDangling)
 
 	assert.Equal(suite.T(), 10, DefaultConfig.MaxLogSizeReturnedMB, "expect max log size returned 10MB but got %d", DefaultConfig.MaxLogSizeReturnedMB)
+
+	require.NotNil(suite.T(), DefaultConfig.PriorityConfig, "expect non nil priority config")
+	assert.Equal(suite.T(), uint(1000), DefaultConfig.PriorityConfig.Default)
+	assert.Equal(suite.T(), uint(2000), DefaultConfig.PriorityConfig.VendorTypes["IMAGE_SCAN"])
+	assert.Equal(suite.T(), uint(2000), DefaultConfig.PriorityConfig.Tenants["library"])
+	assert.Equal(suite.T(), uint(10), DefaultConfig.PriorityConfig.MaxRunningPerTenant)
+
+	require.NotNil(suite.T(), DefaultConfig.RateLimitConfig, "expect non nil rate limit config")
+	assert.Equal(suite.T(), uint(20), DefaultConfig.RateLimitConfig.VendorTypes["REPLICATION"].MaxConcurrency)
+	assert.Equal(suite.T(), uint(5), DefaultConfig.RateLimitConfig.Endpoints["registry.example.com"].MaxConcurrency)
+	assert.Equal(suite.T(), uint(20), DefaultConfig.RateLimitConfig.Endpoints["hooks.example.com"].MaxPerSecond)
 	redisURL := DefaultConfig.PoolConfig.RedisPoolCfg.RedisURL
 	assert.Equal(suite.T(), "redis://localhost:6379", redisURL, "expect redisURL '%s' but got '%s'", "redis://localhost:6379", redisURL)
 
@@ -46,7 +46,12 @@ func NewController(backendWorker worker.Interface, mgr mgt.Manager) Interface {
 }
 
 // LaunchJob is implementation of same method in core interface.
-func (bc *basicController) LaunchJob(req *job.Request) (res *job.Stats, err error) {
+func (bc *basicController) LaunchJob(req *job.Request) (*job.Stats, error) {
+	return bc.launchJob(req, "", "")
+}
+
+// launchJob launches the job, the job is linked to the DAG node if the DAG ID is not empty.
+func (bc *basicController) launchJob(req *job.Request, dagID, dagNode string) (res *job.Stats, err error) {
 	if err := validJobReq(req); err != nil {
 		return nil, errs.BadRequestError(err)
 	}
@@ -77,6 +82,7 @@ func (bc *basicController) LaunchJob(req *job.Request) (res *job.Stats, err erro
 			req.Job.Name,
 			req.Job.Parameters,
 			req.Job.Metadata.Cron,
+			req.Job.Metadata.ScheduleOptions,
 			req.Job.Metadata.IsUnique,
 			req.Job.StatusHook,
 		)
@@ -91,6 +97,11 @@ func (bc *basicController) LaunchJob(req *job.Request) (res *job.Stats, err erro
 
 	// Save job stats
 	if err == nil {
+		// Keep the tenant for the fair queuing of the jobs
+		res.Info.Tenant = req.Job.Metadata.Tenant
+		res.Info.ExecutionID = req.Job.Metadata.ExecutionID
+		res.Info.DAGID = dagID
+		res.Info.DAGNode = dagNode
 		if err := bc.manager.SaveJob(res); err != nil {
 			return nil, err
 		}
@@ -135,6 +146,15 @@ func (bc *basicController) GetJobLogData(jobID string) ([]byte, error) {
 	return logger.Retrieve(jobID)
 }
 
+// TailJobLogData is implementation of same method in core interface.
+func (bc *basicController) TailJobLogData(jobID string, offset int64) ([]byte, int64, error) {
+	if utils.IsEmptyStr(jobID) {
+		return nil, 0, errs.BadRequestError(errors.New("empty job ID"))
+	}
+
+	return logger.Tail(jobID, offset)
+}
+
 // CheckStatus is implementation of same method in core interface.
 func (bc *basicController) CheckStatus() (*worker.Stats, error) {
 	return bc.backendWorker.Stats()
@@ -203,6 +223,9 @@ func validJobReq(req *job.Request) error {
 		if _, err := comUtils.CronParser().Parse(req.Job.Metadata.Cron); err != nil {
 			return fmt.Errorf("'cron_spec' is not correctly set: %s: %s", req.Job.Metadata.Cron, err)
 		}
+		if err := req.Job.Metadata.ScheduleOptions.Validate(); err != nil {
+			return fmt.Errorf("'schedule_options' is not correctly set: %s", err)
+		}
 	}
 
 	return nil
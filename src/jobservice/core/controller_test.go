@@ -103,7 +103,7 @@ func (suite *ControllerTestSuite) TestLaunchScheduledJob() {
 func (suite *ControllerTestSuite) TestLaunchPeriodicJob() {
 	req := createJobReq("Periodic")
 
-	suite.worker.On("PeriodicallyEnqueue", job.SampleJob, suite.params, "5 * * * * *", true, req.Job.StatusHook).Return(suite.res, nil)
+	suite.worker.On("PeriodicallyEnqueue", job.SampleJob, suite.params, "5 * * * * *", req.Job.Metadata.ScheduleOptions, true, req.Job.StatusHook).Return(suite.res, nil)
 
 	res, err := suite.ctl.LaunchJob(req)
 	require.Nil(suite.T(), err, "launch periodic job: nil error expected but got %s", err)
@@ -166,6 +166,11 @@ func (suite *ControllerTestSuite) TestInvalidChecks() {
 	req.Job.Metadata.Cron = "x x x x x x"
 	_, err = suite.ctl.LaunchJob(req)
 	assert.NotNil(suite.T(), err, "invalid job name: error expected but got nil")
+
+	req.Job.Metadata.Cron = "5 * * * * *"
+	req.Job.Metadata.ScheduleOptions = &job.ScheduleOptions{Timezone: "Mars/Olympus_Mons"}
+	_, err = suite.ctl.LaunchJob(req)
+	assert.NotNil(suite.T(), err, "invalid schedule options: error expected but got nil")
 }
 
 // TestGetScheduledJobs ...
@@ -247,8 +252,8 @@ func (suite *ControllerTestSuite) Schedule(jobName string, params job.Parameters
 	return suite.worker.Schedule(jobName, params, runAfterSeconds, isUnique, webHook)
 }
 
-func (suite *ControllerTestSuite) PeriodicallyEnqueue(jobName string, params job.Parameters, cronSetting string, isUnique bool, webHook string) (*job.Stats, error) {
-	return suite.worker.PeriodicallyEnqueue(jobName, params, cronSetting, isUnique, webHook)
+func (suite *ControllerTestSuite) PeriodicallyEnqueue(jobName string, params job.Parameters, cronSetting string, options *job.ScheduleOptions, isUnique bool, webHook string) (*job.Stats, error) {
+	return suite.worker.PeriodicallyEnqueue(jobName, params, cronSetting, options, isUnique, webHook)
 }
 
 func (suite *ControllerTestSuite) Stats() (*worker.Stats, error) {
@@ -292,6 +297,22 @@ func (suite *ControllerTestSuite) SaveJob(j *job.Stats) error {
 	return suite.manager.SaveJob(j)
 }
 
+func (suite *ControllerTestSuite) SaveDAG(dag *job.DAG) error {
+	return suite.manager.SaveDAG(dag)
+}
+
+func (suite *ControllerTestSuite) GetDAG(dagID string) (*job.DAG, error) {
+	return suite.manager.GetDAG(dagID)
+}
+
+func (suite *ControllerTestSuite) LockDAG(dagID string) (func(), error) {
+	return suite.manager.LockDAG(dagID)
+}
+
+func (suite *ControllerTestSuite) GetRunningDAGs() ([]string, error) {
+	return suite.manager.GetRunningDAGs()
+}
+
 // fake worker
 type fakeWorker struct {
 	mock.Mock
@@ -327,8 +348,8 @@ func (f *fakeWorker) Schedule(jobName string, params job.Parameters, runAfterSec
 	return args.Get(0).(*job.Stats), nil
 }
 
-func (f *fakeWorker) PeriodicallyEnqueue(jobName string, params job.Parameters, cronSetting string, isUnique bool, webHook string) (*job.Stats, error) {
-	args := f.Called(jobName, params, cronSetting, isUnique, webHook)
+func (f *fakeWorker) PeriodicallyEnqueue(jobName string, params job.Parameters, cronSetting string, options *job.ScheduleOptions, isUnique bool, webHook string) (*job.Stats, error) {
+	args := f.Called(jobName, params, cronSetting, options, isUnique, webHook)
 	if args.Error(1) != nil {
 		return nil, args.Error(1)
 	}
@@ -411,3 +432,35 @@ func (fm *fakeManager) SaveJob(j *job.Stats) error {
 	args := fm.Called(j)
 	return args.Error(0)
 }
+
+func (fm *fakeManager) SaveDAG(dag *job.DAG) error {
+	args := fm.Called(dag)
+	return args.Error(0)
+}
+
+func (fm *fakeManager) GetDAG(dagID string) (*job.DAG, error) {
+	args := fm.Called(dagID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*job.DAG), nil
+}
+
+func (fm *fakeManager) LockDAG(dagID string) (func(), error) {
+	args := fm.Called(dagID)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(func()), nil
+}
+
+func (fm *fakeManager) GetRunningDAGs() ([]string, error) {
+	args := fm.Called()
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]string), nil
+}
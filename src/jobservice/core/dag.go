@@ -0,0 +1,248 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/errs"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+// LaunchDAG is implementation of same method in core interface.
+func (bc *basicController) LaunchDAG(req *job.DAGRequest) (*job.DAG, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errs.BadRequestError(err)
+	}
+
+	now := time.Now().Unix()
+	d := &job.DAG{
+		ID:           utils.MakeIdentifier(),
+		Status:       job.PendingStatus.String(),
+		Nodes:        make([]*job.DAGNode, 0, len(req.Nodes)),
+		CreationTime: now,
+	}
+
+	for _, n := range req.Nodes {
+		// Validate the jobs in advance to avoid the DAG failing halfway
+		jobType, isKnownJob := bc.backendWorker.IsKnownJob(n.Job.Name)
+		if !isKnownJob {
+			return nil, errs.BadRequestError(errors.Errorf("job with name '%s' of DAG node %s is unknown", n.Job.Name, n.Name))
+		}
+		if err := bc.backendWorker.ValidateJobParameters(jobType, n.Job.Parameters); err != nil {
+			return nil, errs.BadRequestError(errors.Wrapf(err, "DAG node %s", n.Name))
+		}
+
+		if n.Job.Metadata == nil {
+			n.Job.Metadata = &job.Metadata{}
+		}
+		n.Job.Metadata.JobKind = job.KindGeneric
+
+		d.Nodes = append(d.Nodes, &job.DAGNode{
+			Name:      n.Name,
+			Job:       n.Job,
+			DependsOn: n.DependsOn,
+			Status:    job.PendingStatus.String(),
+		})
+	}
+
+	// Hold the lock until the DAG is saved, the status changes of the launched jobs
+	// are handled after that
+	unlock, err := bc.manager.LockDAG(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	bc.advanceDAG(d)
+	if err := bc.saveDAG(d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// GetDAG is implementation of same method in core interface.
+func (bc *basicController) GetDAG(dagID string) (*job.DAG, error) {
+	if utils.IsEmptyStr(dagID) {
+		return nil, errs.BadRequestError(errors.New("empty DAG ID"))
+	}
+
+	return bc.manager.GetDAG(dagID)
+}
+
+// StopDAG is implementation of same method in core interface.
+func (bc *basicController) StopDAG(dagID string) error {
+	if utils.IsEmptyStr(dagID) {
+		return errs.BadRequestError(errors.New("empty DAG ID"))
+	}
+
+	return bc.updateDAG(dagID, func(d *job.DAG) error {
+		// Nothing to stop
+		if job.Status(d.Status).Final() {
+			return nil
+		}
+
+		for _, n := range d.Nodes {
+			if n.Final() {
+				continue
+			}
+
+			if !utils.IsEmptyStr(n.JobID) {
+				// Stopping one job should not block the others
+				if err := bc.backendWorker.StopJob(n.JobID); err != nil {
+					logger.Errorf("Failed to stop job %s of DAG node %s:%s: %s", n.JobID, dagID, n.Name, err)
+				}
+			}
+			n.Status = job.StoppedStatus.String()
+		}
+		d.Status = job.StoppedStatus.String()
+
+		return nil
+	})
+}
+
+// RetryDAG is implementation of same method in core interface.
+func (bc *basicController) RetryDAG(dagID string) error {
+	if utils.IsEmptyStr(dagID) {
+		return errs.BadRequestError(errors.New("empty DAG ID"))
+	}
+
+	return bc.updateDAG(dagID, func(d *job.DAG) error {
+		if d.Status != job.ErrorStatus.String() && d.Status != job.StoppedStatus.String() {
+			return errs.StatusMismatchError(d.Status, job.RunningStatus.String())
+		}
+
+		// The nodes not succeeded are launched again
+		for _, n := range d.Nodes {
+			if n.Status == job.ErrorStatus.String() || n.Status == job.StoppedStatus.String() {
+				n.JobID = ""
+				n.Error = ""
+				n.Status = job.PendingStatus.String()
+			}
+		}
+		d.Status = job.RunningStatus.String()
+		bc.advanceDAG(d)
+
+		return nil
+	})
+}
+
+// AdvanceDAG is implementation of same method in core interface.
+func (bc *basicController) AdvanceDAG(dagID string) error {
+	if utils.IsEmptyStr(dagID) {
+		return errs.BadRequestError(errors.New("empty DAG ID"))
+	}
+
+	return bc.updateDAG(dagID, func(d *job.DAG) error {
+		// The finished DAG can only be changed by retrying
+		if !job.Status(d.Status).Final() {
+			bc.advanceDAG(d)
+		}
+
+		return nil
+	})
+}
+
+// updateDAG loads the DAG under the lock, applies the change and saves it back.
+func (bc *basicController) updateDAG(dagID string, change func(d *job.DAG) error) error {
+	unlock, err := bc.manager.LockDAG(dagID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	d, err := bc.manager.GetDAG(dagID)
+	if err != nil {
+		return err
+	}
+
+	if err := change(d); err != nil {
+		return err
+	}
+
+	return bc.saveDAG(d)
+}
+
+// advanceDAG syncs the status of the launched jobs and launches the nodes
+// whose dependencies all succeed. The node fails only when its job has
+// failed the max times, the nodes depending on the failed or stopped ones
+// are stopped. The nodes are kept in topological order, so
+// one pass is enough.
+func (bc *basicController) advanceDAG(d *job.DAG) {
+	for _, n := range d.Nodes {
+		if n.Final() {
+			continue
+		}
+
+		if !utils.IsEmptyStr(n.JobID) {
+			stats, err := bc.manager.GetJob(n.JobID)
+			if err != nil {
+				// Keep the current status and try again next time
+				logger.Errorf("Failed to get job %s of DAG node %s:%s: %s", n.JobID, d.ID, n.Name, err)
+				continue
+			}
+			n.Status = stats.Info.Status
+			// The failed job is retried until it has failed the max times
+			if n.Status == job.ErrorStatus.String() && stats.Info.Fails < stats.Info.MaxFails {
+				n.Status = job.RunningStatus.String()
+			}
+
+			continue
+		}
+
+		ready := true
+		for _, name := range n.DependsOn {
+			dep := d.Node(name)
+			if dep.Status == job.SuccessStatus.String() {
+				continue
+			}
+
+			ready = false
+			if dep.Status == job.ErrorStatus.String() || dep.Status == job.StoppedStatus.String() {
+				n.Status = job.StoppedStatus.String()
+				n.Error = fmt.Sprintf("dependency %s is %s", dep.Name, dep.Status)
+				break
+			}
+		}
+
+		if !ready {
+			continue
+		}
+
+		res, err := bc.launchJob(&job.Request{Job: n.Job}, d.ID, n.Name)
+		if err != nil {
+			n.Status = job.ErrorStatus.String()
+			n.Error = err.Error()
+			logger.Errorf("Failed to launch job of DAG node %s:%s: %s", d.ID, n.Name, err)
+
+			continue
+		}
+		n.JobID = res.Info.JobID
+		n.Status = res.Info.Status
+	}
+
+	d.Refresh()
+}
+
+func (bc *basicController) saveDAG(d *job.DAG) error {
+	d.UpdateTime = time.Now().Unix()
+
+	return bc.manager.SaveDAG(d)
+}
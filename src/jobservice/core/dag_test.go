@@ -0,0 +1,213 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/jobservice/errs"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/job/impl/sample"
+)
+
+// DAGTestSuite tests the DAG functions of core controller
+type DAGTestSuite struct {
+	suite.Suite
+
+	manager *fakeManager
+	worker  *fakeWorker
+	ctl     Interface
+
+	dag *job.DAG
+}
+
+// TestDAGTestSuite is suite entry for 'go test'
+func TestDAGTestSuite(t *testing.T) {
+	suite.Run(t, new(DAGTestSuite))
+}
+
+// SetupTest prepares for each test case
+func (suite *DAGTestSuite) SetupTest() {
+	suite.dag = nil
+
+	suite.worker = &fakeWorker{}
+	suite.worker.On("IsKnownJob", job.SampleJob).Return((*sample.Job)(nil), true)
+	suite.worker.On("ValidateJobParameters", (*sample.Job)(nil), mock.Anything).Return(nil)
+
+	suite.manager = &fakeManager{}
+	suite.manager.On("LockDAG", mock.Anything).Return(func() {}, nil)
+	suite.manager.On("SaveJob", mock.Anything).Return(nil)
+	suite.manager.On("SaveDAG", mock.Anything).Run(func(args mock.Arguments) {
+		suite.dag = args.Get(0).(*job.DAG)
+	}).Return(nil)
+
+	suite.ctl = NewController(suite.worker, suite.manager)
+}
+
+// TestLaunchDAG ...
+func (suite *DAGTestSuite) TestLaunchDAG() {
+	suite.expectEnqueue("job-a")
+
+	d, err := suite.ctl.LaunchDAG(createDAGReq())
+	suite.Require().NoError(err)
+	suite.Equal(job.RunningStatus.String(), d.Status)
+	suite.Equal("job-a", d.Node("a").JobID)
+	suite.Empty(d.Node("b").JobID)
+	suite.Equal(job.PendingStatus.String(), d.Node("b").Status)
+	suite.Same(d, suite.dag)
+	suite.worker.AssertNumberOfCalls(suite.T(), "Enqueue", 1)
+}
+
+// TestLaunchInvalidDAG ...
+func (suite *DAGTestSuite) TestLaunchInvalidDAG() {
+	req := createDAGReq()
+	req.Nodes[0].DependsOn = []string{"b"}
+
+	_, err := suite.ctl.LaunchDAG(req)
+	suite.True(errs.IsBadRequestError(err))
+}
+
+// TestAdvanceDAG ...
+func (suite *DAGTestSuite) TestAdvanceDAG() {
+	suite.storeDAG(job.RunningStatus, "job-a", job.RunningStatus, job.PendingStatus)
+	suite.expectJobStatus("job-a", job.SuccessStatus)
+	suite.expectEnqueue("job-b")
+
+	suite.Require().NoError(suite.ctl.AdvanceDAG(suite.dag.ID))
+	suite.Equal(job.SuccessStatus.String(), suite.dag.Node("a").Status)
+	suite.Equal("job-b", suite.dag.Node("b").JobID)
+	suite.Equal(job.RunningStatus.String(), suite.dag.Status)
+
+	suite.manager.On("GetDAG", "dag").Return(suite.dag, nil).Once()
+	suite.expectJobStatus("job-b", job.SuccessStatus)
+	suite.Require().NoError(suite.ctl.AdvanceDAG(suite.dag.ID))
+	suite.Equal(job.SuccessStatus.String(), suite.dag.Status)
+}
+
+// TestAdvanceFailedDAG ...
+func (suite *DAGTestSuite) TestAdvanceFailedDAG() {
+	suite.storeDAG(job.RunningStatus, "job-a", job.RunningStatus, job.PendingStatus)
+	suite.expectJobStatus("job-a", job.ErrorStatus)
+
+	suite.Require().NoError(suite.ctl.AdvanceDAG(suite.dag.ID))
+	suite.Equal(job.StoppedStatus.String(), suite.dag.Node("b").Status)
+	suite.NotEmpty(suite.dag.Node("b").Error)
+	suite.Equal(job.ErrorStatus.String(), suite.dag.Status)
+	suite.worker.AssertNotCalled(suite.T(), "Enqueue", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestAdvanceRetryingDAG ...
+func (suite *DAGTestSuite) TestAdvanceRetryingDAG() {
+	suite.storeDAG(job.RunningStatus, "job-a", job.RunningStatus, job.PendingStatus)
+	suite.manager.On("GetJob", "job-a").Return(&job.Stats{
+		Info: &job.StatsInfo{
+			JobID:    "job-a",
+			Status:   job.ErrorStatus.String(),
+			Fails:    1,
+			MaxFails: 3,
+		},
+	}, nil).Once()
+
+	suite.Require().NoError(suite.ctl.AdvanceDAG(suite.dag.ID))
+	suite.Equal(job.RunningStatus.String(), suite.dag.Node("a").Status)
+	suite.Equal(job.PendingStatus.String(), suite.dag.Node("b").Status)
+	suite.Equal(job.RunningStatus.String(), suite.dag.Status)
+	suite.worker.AssertNotCalled(suite.T(), "Enqueue", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestStopDAG ...
+func (suite *DAGTestSuite) TestStopDAG() {
+	suite.storeDAG(job.RunningStatus, "job-a", job.RunningStatus, job.PendingStatus)
+	suite.worker.On("StopJob", "job-a").Return(nil)
+
+	suite.Require().NoError(suite.ctl.StopDAG(suite.dag.ID))
+	suite.Equal(job.StoppedStatus.String(), suite.dag.Status)
+	for _, n := range suite.dag.Nodes {
+		suite.Equal(job.StoppedStatus.String(), n.Status)
+	}
+	suite.worker.AssertCalled(suite.T(), "StopJob", "job-a")
+}
+
+// TestRetryDAG ...
+func (suite *DAGTestSuite) TestRetryDAG() {
+	suite.storeDAG(job.RunningStatus, "job-a", job.RunningStatus, job.PendingStatus)
+	err := suite.ctl.RetryDAG(suite.dag.ID)
+	suite.True(errs.IsStatusMismatchError(err))
+
+	suite.storeDAG(job.ErrorStatus, "job-a", job.ErrorStatus, job.StoppedStatus)
+	suite.expectEnqueue("job-a2")
+
+	suite.Require().NoError(suite.ctl.RetryDAG(suite.dag.ID))
+	suite.Equal(job.RunningStatus.String(), suite.dag.Status)
+	suite.Equal("job-a2", suite.dag.Node("a").JobID)
+	suite.Equal(job.PendingStatus.String(), suite.dag.Node("b").Status)
+	suite.Empty(suite.dag.Node("b").JobID)
+}
+
+func (suite *DAGTestSuite) storeDAG(status job.Status, jobID string, statusA, statusB job.Status) {
+	req := createDAGReq()
+	for _, n := range req.Nodes {
+		n.Job.Metadata = &job.Metadata{JobKind: job.KindGeneric}
+	}
+	suite.dag = &job.DAG{
+		ID:     "dag",
+		Status: status.String(),
+		Nodes: []*job.DAGNode{
+			{Name: "a", Job: req.Nodes[0].Job, JobID: jobID, Status: statusA.String()},
+			{Name: "b", Job: req.Nodes[1].Job, DependsOn: []string{"a"}, Status: statusB.String()},
+		},
+	}
+	suite.manager.On("GetDAG", "dag").Return(suite.dag, nil).Once()
+}
+
+func (suite *DAGTestSuite) expectEnqueue(jobID string) {
+	suite.worker.On("Enqueue", job.SampleJob, mock.Anything, false, "").Return(&job.Stats{
+		Info: &job.StatsInfo{
+			JobID:  jobID,
+			Status: job.PendingStatus.String(),
+		},
+	}, nil).Once()
+}
+
+func (suite *DAGTestSuite) expectJobStatus(jobID string, status job.Status) {
+	suite.manager.On("GetJob", jobID).Return(&job.Stats{
+		Info: &job.StatsInfo{
+			JobID:  jobID,
+			Status: status.String(),
+		},
+	}, nil).Once()
+}
+
+func createDAGReq() *job.DAGRequest {
+	params := make(job.Parameters)
+	params["name"] = "testing:v1"
+
+	return &job.DAGRequest{
+		Nodes: []*job.DAGNodeRequest{
+			{
+				Name: "a",
+				Job:  &job.RequestBody{Name: job.SampleJob, Parameters: params},
+			},
+			{
+				Name:      "b",
+				Job:       &job.RequestBody{Name: job.SampleJob, Parameters: params},
+				DependsOn: []string{"a"},
+			},
+		},
+	}
+}
@@ -63,6 +63,10 @@ type Interface interface {
 	// GetJobLogData is used to return the log text data for the specified job if exists
 	GetJobLogData(jobID string) ([]byte, error)
 
+	// TailJobLogData is used to return the log text data of the specified job appended after the offset.
+	// The offset of the end of the returned data is also returned.
+	TailJobLogData(jobID string, offset int64) ([]byte, int64, error)
+
 	// Get the periodic executions for the specified periodic job.
 	// Pagination by query is supported.
 	// The total number is also returned.
@@ -75,4 +79,51 @@ type Interface interface {
 	// For other cases, query the jobs with cursor, not standard pagination. The int64 is next cursor.
 	// The total number is also returned.
 	GetJobs(query *query.Parameter) ([]*job.Stats, int64, error)
+
+	// LaunchDAG is used to handle the DAG submission request.
+	// The jobs of the DAG nodes are launched once all the jobs they depend on succeed.
+	// The DAG is only tracked by jobservice, no execution or task records are created in core for it,
+	// the status of the node jobs is reported to the status hooks of their own requests.
+	//
+	// req	*job.DAGRequest : DAG request contains the nodes and the dependencies between them.
+	//
+	// Returns:
+	//	*job.DAG : The DAG with ID returned if it's successfully launched.
+	//  error    : Error returned if failed to launch the specified DAG.
+	LaunchDAG(req *job.DAGRequest) (*job.DAG, error)
+
+	// GetDAG is used to handle the DAG query request.
+	//
+	// dagID	string: ID of DAG.
+	//
+	// Returns:
+	//	*job.DAG : The DAG with the status of the nodes if it exists.
+	//  error    : Error returned if failed to get the specified DAG.
+	GetDAG(dagID string) (*job.DAG, error)
+
+	// StopDAG is used to stop the running jobs of the DAG and cancel the pending ones.
+	//
+	// dagID	string: ID of DAG.
+	//
+	// Return:
+	//  error   : Error returned if failed to stop the specified DAG.
+	StopDAG(dagID string) error
+
+	// RetryDAG is used to relaunch the failed or stopped nodes of the DAG.
+	// The succeeded nodes are not run again.
+	//
+	// dagID	string: ID of DAG.
+	//
+	// Return:
+	//  error   : Error returned if failed to retry the specified DAG.
+	RetryDAG(dagID string) error
+
+	// AdvanceDAG refreshes the status of the DAG nodes and launches the nodes
+	// whose dependencies are all satisfied.
+	//
+	// dagID	string: ID of DAG.
+	//
+	// Return:
+	//  error   : Error returned if failed to advance the specified DAG.
+	AdvanceDAG(dagID string) error
 }
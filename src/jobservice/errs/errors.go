@@ -57,6 +57,12 @@ const (
 	GetPeriodicExecutionErrorCode
 	// StatusMismatchErrorCode is code for the error of mismatching status
 	StatusMismatchErrorCode
+	// LaunchDAGErrorCode is code for the error of launching DAG
+	LaunchDAGErrorCode
+	// GetDAGErrorCode is code for the error of getting DAG
+	GetDAGErrorCode
+	// DAGActionErrorCode is code for the error of stopping or retrying DAG
+	DAGActionErrorCode
 )
 
 // baseError ...
@@ -153,6 +159,21 @@ func GetPeriodicExecutionError(err error) error {
 	return New(GetPeriodicExecutionErrorCode, "failed to get periodic executions", err.Error())
 }
 
+// LaunchDAGError is error wrapper for the error of launching DAG failed.
+func LaunchDAGError(err error) error {
+	return New(LaunchDAGErrorCode, "launch DAG failed with error", err.Error())
+}
+
+// GetDAGError is error wrapper for the error of getting DAG failed.
+func GetDAGError(err error) error {
+	return New(GetDAGErrorCode, "get DAG failed with error", err.Error())
+}
+
+// DAGActionError is error wrapper for the error of stopping or retrying DAG failed.
+func DAGActionError(err error) error {
+	return New(DAGActionErrorCode, "DAG action failed with error", err.Error())
+}
+
 // objectNotFound is designed for the case of no object found
 type objectNotFoundError struct {
 	baseError
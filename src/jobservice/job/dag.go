@@ -0,0 +1,166 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+// DAGRequest is the request of launching the jobs depending on each other as one DAG.
+type DAGRequest struct {
+	Nodes []*DAGNodeRequest `json:"nodes"`
+}
+
+// DAGNodeRequest is the request of one node in the DAG.
+type DAGNodeRequest struct {
+	// Name of the node, unique in the DAG
+	Name string `json:"name"`
+	// The job of the node, only generic job is supported
+	Job *RequestBody `json:"job"`
+	// The names of the nodes this node depends on,
+	// the job of the node is launched after all the jobs of them succeed
+	DependsOn []string `json:"depends_on,omitempty"`
+}
+
+// DAG keeps the jobs depending on each other, they are launched, tracked and stopped as one unit
+// inside jobservice. The IDs of the node jobs are assigned when they are launched and change when
+// the nodes are retried, so the node jobs can't be bound to the task records of core in advance.
+type DAG struct {
+	ID           string     `json:"id"`
+	Status       string     `json:"status"`
+	Nodes        []*DAGNode `json:"nodes"`
+	CreationTime int64      `json:"creation_time"`
+	UpdateTime   int64      `json:"update_time"`
+}
+
+// DAGNode is the node of DAG
+type DAGNode struct {
+	Name      string       `json:"name"`
+	Job       *RequestBody `json:"job"`
+	DependsOn []string     `json:"depends_on,omitempty"`
+	// ID of the launched job, empty if the job is not launched yet
+	JobID  string `json:"job_id,omitempty"`
+	Status string `json:"status"`
+	// Error message if the job can not be launched
+	Error string `json:"error,omitempty"`
+}
+
+// Validate the DAG request and sort the nodes in topological order.
+func (r *DAGRequest) Validate() error {
+	if r == nil || len(r.Nodes) == 0 {
+		return errors.New("no nodes in the DAG")
+	}
+
+	nodes := make(map[string]*DAGNodeRequest, len(r.Nodes))
+	for _, n := range r.Nodes {
+		if n == nil || utils.IsEmptyStr(n.Name) {
+			return errors.New("name of the DAG node must be specified")
+		}
+		if _, ok := nodes[n.Name]; ok {
+			return errors.Errorf("duplicated DAG node %s", n.Name)
+		}
+		if n.Job == nil || utils.IsEmptyStr(n.Job.Name) {
+			return errors.Errorf("job of the DAG node %s must be specified", n.Name)
+		}
+		if n.Job.Metadata != nil && n.Job.Metadata.JobKind != KindGeneric {
+			return errors.Errorf("only %s job is supported in the DAG node %s", KindGeneric, n.Name)
+		}
+		nodes[n.Name] = n
+	}
+
+	for _, n := range r.Nodes {
+		for _, d := range n.DependsOn {
+			if _, ok := nodes[d]; !ok {
+				return errors.Errorf("DAG node %s depends on the unknown node %s", n.Name, d)
+			}
+		}
+	}
+
+	// Kahn's algorithm, the nodes left are in cycles
+	indegree := make(map[string]int, len(r.Nodes))
+	dependents := make(map[string][]string, len(r.Nodes))
+	for _, n := range r.Nodes {
+		indegree[n.Name] += len(n.DependsOn)
+		for _, d := range n.DependsOn {
+			dependents[d] = append(dependents[d], n.Name)
+		}
+	}
+	sorted := make([]*DAGNodeRequest, 0, len(r.Nodes))
+	for _, n := range r.Nodes {
+		if indegree[n.Name] == 0 {
+			sorted = append(sorted, n)
+		}
+	}
+	for i := 0; i < len(sorted); i++ {
+		for _, d := range dependents[sorted[i].Name] {
+			indegree[d]--
+			if indegree[d] == 0 {
+				sorted = append(sorted, nodes[d])
+			}
+		}
+	}
+	if len(sorted) != len(r.Nodes) {
+		return errors.New("cycle detected in the DAG")
+	}
+	r.Nodes = sorted
+
+	return nil
+}
+
+// Final returns true if the node will not change any more.
+func (n *DAGNode) Final() bool {
+	return Status(n.Status).Final()
+}
+
+// Refresh the DAG status based on the status of the nodes.
+//
+// The DAG is stopped if it has been stopped, failed if any node fails or
+// is stopped, succeeded if all the nodes succeed, otherwise it's running.
+func (d *DAG) Refresh() {
+	if d.Status == StoppedStatus.String() {
+		return
+	}
+
+	var done, failed int
+	for _, n := range d.Nodes {
+		switch Status(n.Status) {
+		case SuccessStatus:
+			done++
+		case ErrorStatus, StoppedStatus:
+			failed++
+		}
+	}
+
+	switch {
+	case done == len(d.Nodes):
+		d.Status = SuccessStatus.String()
+	case done+failed == len(d.Nodes):
+		d.Status = ErrorStatus.String()
+	default:
+		d.Status = RunningStatus.String()
+	}
+}
+
+// Node returns the node with the given name.
+func (d *DAG) Node(name string) *DAGNode {
+	for _, n := range d.Nodes {
+		if n.Name == name {
+			return n
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// DAGTestSuite is test suite for DAG.
+type DAGTestSuite struct {
+	suite.Suite
+}
+
+// TestDAG is entry point of DAGTestSuite.
+func TestDAG(t *testing.T) {
+	suite.Run(t, &DAGTestSuite{})
+}
+
+// TestValidate ...
+func (suite *DAGTestSuite) TestValidate() {
+	req := &DAGRequest{
+		Nodes: []*DAGNodeRequest{
+			dagNodeReq("c", "a", "b"),
+			dagNodeReq("b", "a"),
+			dagNodeReq("a"),
+		},
+	}
+	suite.Require().NoError(req.Validate())
+	names := make([]string, 0, len(req.Nodes))
+	for _, n := range req.Nodes {
+		names = append(names, n.Name)
+	}
+	suite.Equal([]string{"a", "b", "c"}, names)
+
+	suite.Error((&DAGRequest{}).Validate())
+	suite.Error((&DAGRequest{Nodes: []*DAGNodeRequest{dagNodeReq("a"), dagNodeReq("a")}}).Validate())
+	suite.Error((&DAGRequest{Nodes: []*DAGNodeRequest{dagNodeReq("a", "x")}}).Validate())
+	suite.Error((&DAGRequest{Nodes: []*DAGNodeRequest{dagNodeReq("a", "b"), dagNodeReq("b", "a")}}).Validate())
+
+	periodic := dagNodeReq("a")
+	periodic.Job.Metadata = &Metadata{JobKind: KindPeriodic}
+	suite.Error((&DAGRequest{Nodes: []*DAGNodeRequest{periodic}}).Validate())
+}
+
+// TestRefresh ...
+func (suite *DAGTestSuite) TestRefresh() {
+	d := &DAG{
+		Nodes: []*DAGNode{
+			{Name: "a", Status: SuccessStatus.String()},
+			{Name: "b", Status: RunningStatus.String()},
+		},
+	}
+	d.Refresh()
+	suite.Equal(RunningStatus.String(), d.Status)
+
+	d.Node("b").Status = ErrorStatus.String()
+	d.Refresh()
+	suite.Equal(ErrorStatus.String(), d.Status)
+
+	d.Node("b").Status = SuccessStatus.String()
+	d.Refresh()
+	suite.Equal(SuccessStatus.String(), d.Status)
+
+	d.Status = StoppedStatus.String()
+	d.Refresh()
+	suite.Equal(StoppedStatus.String(), d.Status)
+}
+
+func dagNodeReq(name string, dependsOn ...string) *DAGNodeRequest {
+	return &DAGNodeRequest{
+		Name:      name,
+		Job:       &RequestBody{Name: SampleJob},
+		DependsOn: dependsOn,
+	}
+}
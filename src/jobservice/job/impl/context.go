@@ -132,7 +132,7 @@ func (c *Context) Build(tracker job.Tracker) (job.Context, error) {
 	// Set loggers for job
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	lg, err := createLoggers(tracker.Job().Info.JobID)
+	lg, err := createLoggers(tracker.Job().Info)
 	if err != nil {
 		return nil, err
 	}
@@ -182,18 +182,30 @@ func (c *Context) Tracker() job.Tracker {
 }
 
 // create loggers based on the configurations.
-func createLoggers(jobID string) (logger.Interface, error) {
+func createLoggers(info *job.StatsInfo) (logger.Interface, error) {
+	jobID := info.JobID
 	// Init job loggers here
 	lOptions := make([]logger.Option, 0)
 	for _, lc := range config.DefaultConfig.JobLoggerConfigs {
 		// For running job, the depth should be 5
-		if lc.Name == logger.NameFile || lc.Name == logger.NameStdOutput || lc.Name == logger.NameDB {
+		if lc.Name == logger.NameFile || lc.Name == logger.NameStdOutput || lc.Name == logger.NameDB || lc.Name == logger.NameHTTP {
 			if lc.Settings == nil {
 				lc.Settings = map[string]any{}
 			}
 			lc.Settings["depth"] = 5
 		}
-		if lc.Name == logger.NameFile || lc.Name == logger.NameDB {
+		if lc.Name == logger.NameHTTP {
+			// Append the job ID and the labels of the shipped log lines
+			hSettings := map[string]any{}
+			maps.Copy(hSettings, lc.Settings)
+			hSettings["key"] = jobID
+			hSettings["vendor_type"] = info.JobName
+			hSettings["project"] = info.Tenant
+			if info.ExecutionID > 0 {
+				hSettings["execution_id"] = fmt.Sprintf("%d", info.ExecutionID)
+			}
+			lOptions = append(lOptions, logger.BackendOption(lc.Name, lc.Level, hSettings))
+		} else if lc.Name == logger.NameFile || lc.Name == logger.NameDB {
 			// Need extra param
 			fSettings := map[string]any{}
 			maps.Copy(fSettings, lc.Settings)
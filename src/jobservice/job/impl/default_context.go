@@ -66,7 +66,7 @@ func (dc *DefaultContext) Build(t job.Tracker) (job.Context, error) {
 	}
 
 	// Set loggers for job
-	lg, err := createLoggers(t.Job().Info.JobID)
+	lg, err := createLoggers(t.Job().Info)
 	if err != nil {
 		return nil, err
 	}
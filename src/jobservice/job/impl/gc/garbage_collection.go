@@ -15,14 +15,17 @@
 package gc
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/url"
 	"os"
 	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/opencontainers/go-digest"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/goharbor/harbor/src/common/registryctl"
@@ -39,6 +42,8 @@ import (
 	"github.com/goharbor/harbor/src/pkg/blob"
 	blobModels "github.com/goharbor/harbor/src/pkg/blob/models"
 	"github.com/goharbor/harbor/src/pkg/registry/interceptor/readonly"
+	"github.com/goharbor/harbor/src/pkg/systemartifact"
+	sysartifactmodel "github.com/goharbor/harbor/src/pkg/systemartifact/model"
 	"github.com/goharbor/harbor/src/registryctl/client"
 )
 
@@ -53,6 +58,8 @@ const (
 	dialWriteTimeout      = 10 * time.Second
 	blobPrefix            = "blobs::*"
 	repoPrefix            = "repository::*"
+	// the default grace period of the online GC, the blobs created in it are never swept.
+	defaultGracePeriod = 2 * time.Hour
 )
 
 // GarbageCollector is the struct to run registry's garbage collection
@@ -61,6 +68,7 @@ type GarbageCollector struct {
 	artrashMgr        artifactrash.Manager
 	blobMgr           blob.Manager
 	registryCtlClient client.Client
+	sysArtifactMgr    systemartifact.Manager
 	logger            logger.Interface
 	redisURL          string
 	deleteUntagged    bool
@@ -74,6 +82,19 @@ type GarbageCollector struct {
 	deleteSet       []*blobModels.Blob
 	timeWindowHours int64
 	workers         int
+	// the IDs of the projects which the GC is scoped to, the GC runs over the entire registry if it's empty.
+	projectIDs []int64
+	// holds the digests of the blobs which are unassociated from the scoped projects by mark,
+	// only these blobs can be GC candidates when the GC is scoped to projects.
+	scopedBlobs map[string]struct{}
+	// online GC doesn't require the registry to stop accepting pushes, the blobs created in the grace period are never swept,
+	// and each candidate is re-checked for the references just before deleting it.
+	online      bool
+	gracePeriod time.Duration
+	// collects the owners of the GC candidates for the dry-run report
+	report *reportBuilder
+	// the count of the sweep tasks, the sweep is split into the shards by the digest prefix and run by the sweep jobs if it's greater than 1.
+	shards int
 }
 
 // MaxFails implements the interface in job/Interface
@@ -101,6 +122,8 @@ func (gc *GarbageCollector) init(ctx job.Context, params job.Parameters) error {
 	gc.logger = ctx.GetLogger()
 	gc.deleteSet = make([]*blobModels.Blob, 0)
 	gc.trashedArts = make(map[string][]model.ArtifactTrash, 0)
+	gc.scopedBlobs = make(map[string]struct{})
+	gc.report = newReportBuilder()
 
 	// UT will use the mock client, ctl and mgr
 	if os.Getenv("UTTEST") != "true" {
@@ -108,6 +131,7 @@ func (gc *GarbageCollector) init(ctx job.Context, params job.Parameters) error {
 		gc.artCtl = artifact.Ctl
 		gc.artrashMgr = artifactrash.NewManager()
 		gc.blobMgr = blob.NewManager()
+		gc.sysArtifactMgr = systemartifact.Mgr
 	}
 	if err := gc.registryCtlClient.Health(); err != nil {
 		gc.logger.Errorf("failed to start gc as registry controller is unreachable: %v", err)
@@ -166,8 +190,29 @@ func (gc *GarbageCollector) parseParams(params job.Parameters) {
 		}
 	}
 
-	gc.logger.Infof("Garbage Collection parameters: [delete_untagged: %t, delete_tag: %t, dry_run: %t, time_window: %d, workers: %d]",
-		gc.deleteUntagged, gc.deleteTag, gc.dryRun, gc.timeWindowHours, gc.workers)
+	// project ids: default is empty, the GC runs over the entire registry.
+	gc.projectIDs = parseProjectIDs(params["project_ids"])
+
+	// online: default is false.
+	gc.online = false
+	if online, ok := params["online"].(bool); ok {
+		gc.online = online
+	}
+
+	// grace period: default is 2 hours, it's in minutes and only used by the online GC.
+	gc.gracePeriod = defaultGracePeriod
+	if gracePeriod, ok := params["grace_period"].(float64); ok && gracePeriod >= 0 {
+		gc.gracePeriod = time.Duration(gracePeriod) * time.Minute
+	}
+
+	// shards: default is 1, the sweep is done in this job.
+	gc.shards = 1
+	if shards, ok := params["shards"].(float64); ok && int(shards) > 1 {
+		gc.shards = int(shards)
+	}
+
+	gc.logger.Infof("Garbage Collection parameters: [delete_untagged: %t, delete_tag: %t, dry_run: %t, time_window: %d, workers: %d, project_ids: %v, online: %t, grace_period: %s, shards: %d]",
+		gc.deleteUntagged, gc.deleteTag, gc.dryRun, gc.timeWindowHours, gc.workers, gc.projectIDs, gc.online, gc.gracePeriod, gc.shards)
 }
 
 // Run implements the interface in job/Interface
@@ -188,6 +233,17 @@ func (gc *GarbageCollector) Run(ctx job.Context, params job.Parameters) error {
 		return err
 	}
 
+	// the candidates are swept by the sweep jobs in parallel, the core creates the sweep tasks when this job checks in
+	// before it finishes, so the execution keeps running until all the shards are swept
+	if !gc.dryRun && gc.shards > 1 {
+		gc.logger.Infof("the sweep is split into %d shards, each shard is swept by a sweep job.", gc.shards)
+		if err := checkinGCRes(ctx, &gcResult{Marked: true}); err != nil {
+			return err
+		}
+		gc.logger.Infof("success to run gc mark in job.")
+		return nil
+	}
+
 	// sweep
 	if !gc.dryRun {
 		if err := gc.sweep(ctx); err != nil {
@@ -237,7 +293,9 @@ func (gc *GarbageCollector) mark(ctx job.Context) error {
 		blobs = append(blobs, orphanBlobs...)
 	}
 	if len(blobs) == 0 {
-		if err := saveGCRes(ctx, int64(0), int64(0), int64(0)); err != nil {
+		if gc.dryRun {
+			gc.saveDryRunRes(ctx, int64(0), int64(0), int64(0))
+		} else if err := saveGCRes(ctx, int64(0), int64(0), int64(0)); err != nil {
 			gc.logger.Errorf("failed to save the garbage collection results, errMsg=%v", err)
 		}
 		gc.logger.Info("no need to execute GC as there is no non referenced artifacts.")
@@ -281,9 +339,7 @@ func (gc *GarbageCollector) mark(ctx job.Context) error {
 	gc.logger.Infof("The GC could free up %d MB space, the size is a rough estimation.", makeSize/1024/1024)
 
 	if gc.dryRun {
-		if err := saveGCRes(ctx, makeSize, int64(blobCt), int64(mfCt)); err != nil {
-			gc.logger.Errorf("failed to save the garbage collection results, errMsg=%v", err)
-		}
+		gc.saveDryRunRes(ctx, makeSize, int64(blobCt), int64(mfCt))
 	}
 	return nil
 }
@@ -324,8 +380,8 @@ func (gc *GarbageCollector) sweep(ctx job.Context) error {
 
 				localIndex := atomic.AddInt64(&index, 1)
 				// set the status firstly, if the blob is updated by any HEAD/PUT request, it should be fail and skip.
-				blob.Status = blobModels.StatusDeleting
-				count, err := gc.blobMgr.UpdateBlobStatus(ctx.SystemContext(), blob)
+				// once the blob is in deleting, the HEAD/PUT requests referencing it are rejected until it's deleted.
+				count, err := gc.markDeleting(ctx, blob)
 				if err != nil {
 					gc.logger.Errorf("[%s][%d/%d] failed to mark gc candidate deleting, skip: %s, %s", uid, localIndex, total, blob.Digest, blob.Status)
 					continue
@@ -542,10 +598,15 @@ func (gc *GarbageCollector) deletedArt(ctx job.Context) (map[string][]model.Arti
 	artMap := make(map[string][]model.ArtifactTrash)
 	// handle the optional ones, and the artifact controller will move them into trash.
 	if gc.deleteUntagged {
+		keywords := map[string]any{
+			"Tags": "nil",
+		}
+		// only delete the untagged artifacts of the scoped projects
+		if gc.scoped() {
+			keywords["ProjectID"] = gc.projectIDsOrList()
+		}
 		untaggedArts, err := gc.artCtl.List(ctx.SystemContext(), &q.Query{
-			Keywords: map[string]any{
-				"Tags": "nil",
-			},
+			Keywords: keywords,
 		}, &artifact.Option{WithAccessory: true})
 		if err != nil {
 			return artMap, err
@@ -621,7 +682,11 @@ func (gc *GarbageCollector) deletedArt(ctx job.Context) (map[string][]model.Arti
 // * non dry-run, remove the reference of the untagged blobs
 func (gc *GarbageCollector) markOrSweepUntaggedBlobs(ctx job.Context) ([]*blobModels.Blob, error) {
 	var orphanBlobs []*blobModels.Blob
-	for result := range project.ListAll(ctx.SystemContext(), 50, nil, project.Metadata(false)) {
+	var projectQuery *q.Query
+	if gc.scoped() {
+		projectQuery = q.New(q.KeyWords{"project_id": gc.projectIDsOrList()})
+	}
+	for result := range project.ListAll(ctx.SystemContext(), 50, projectQuery, project.Metadata(false)) {
 		if gc.shouldStop(ctx) {
 			return nil, errGcStop
 		}
@@ -669,7 +734,18 @@ func (gc *GarbageCollector) markOrSweepUntaggedBlobs(ctx job.Context) ([]*blobMo
 					break
 				}
 				orphanBlobs = append(orphanBlobs, unassociated...)
+				gc.recordScopedBlobs(unassociated)
+				gc.reportBuilder().recordProject(p.Name, unassociated)
 			} else {
+				// record the blobs which will be unassociated from the scoped project before cleaning up
+				if gc.scoped() {
+					unassociated, err := gc.blobMgr.FindBlobsShouldUnassociatedWithProject(ctx.SystemContext(), p.ProjectID, blobs)
+					if err != nil {
+						gc.logger.Errorf("failed to find untagged blobs of project: %d, %v", p.ProjectID, err)
+						break
+					}
+					gc.recordScopedBlobs(unassociated)
+				}
 				if err := gc.blobMgr.CleanupAssociationsForProject(ctx.SystemContext(), p.ProjectID, blobs); err != nil {
 					gc.logger.Errorf("failed to clean untagged blobs of project: %d, %v", p.ProjectID, err)
 					break
@@ -694,16 +770,48 @@ func (gc *GarbageCollector) uselessBlobs(ctx job.Context) ([]*blobModels.Blob, e
 		return blobs, err
 	}
 
+	// For the scoped GC, only the useless blobs which were referenced by the scoped projects are deleted,
+	// so the blobs still referenced by any other project are kept, and the orphan blobs left by the other
+	// projects are left to the registry-wide GC.
+	if gc.scoped() {
+		scopedBlobs := make([]*blobModels.Blob, 0, len(blobs))
+		for _, blob := range blobs {
+			if _, exist := gc.scopedBlobs[blob.Digest]; exist {
+				scopedBlobs = append(scopedBlobs, blob)
+			}
+		}
+		gc.logger.Infof("%d of %d useless blobs are referenced only by the scoped projects", len(scopedBlobs), len(blobs))
+		blobs = scopedBlobs
+	}
+
+	// For online GC, the blobs created or touched(e.g. checked by HEAD before pushing the manifest) in the grace period
+	// may be referenced by the manifest being pushed, never sweep them.
+	if gc.online {
+		graceTime := time.Now().Add(-gc.gracePeriod)
+		candidates := make([]*blobModels.Blob, 0, len(blobs))
+		for _, blob := range blobs {
+			if blob.UpdateTime.After(graceTime) {
+				continue
+			}
+			candidates = append(candidates, blob)
+		}
+		gc.logger.Infof("%d of %d useless blobs are out of the grace period %s", len(candidates), len(blobs), gc.gracePeriod)
+		blobs = candidates
+	}
+
 	// For dryRun, it needs to append the blobs that are associated with untagged artifact.
 	// Do it since the it doesn't remove the untagged artifact in dry run mode. All the blobs of untagged artifact are referenced by project,
 	// so they cannot get by the above UselessBlobs method.
 	// In dryRun mode, trashedArts only contains the mock deletion artifact.
 	if gc.dryRun {
-		for artDigest := range gc.trashedArts {
+		for artDigest, arts := range gc.trashedArts {
 			artBlobs, err := gc.blobMgr.GetByArt(ctx.SystemContext(), artDigest)
 			if err != nil {
 				return blobs, err
 			}
+			if len(arts) > 0 {
+				gc.reportBuilder().recordRepository(arts[0].RepositoryName, artBlobs)
+			}
 			blobs = append(blobs, artBlobs...)
 		}
 	}
@@ -711,6 +819,92 @@ func (gc *GarbageCollector) uselessBlobs(ctx job.Context) ([]*blobModels.Blob, e
 	return blobs, err
 }
 
+// markDeleting marks the GC candidate as deleting, for online GC, the blob is re-checked in the same statement,
+// and it's skipped if it's referenced by any project again or touched in the grace period.
+func (gc *GarbageCollector) markDeleting(ctx job.Context, blob *blobModels.Blob) (int64, error) {
+	if gc.online {
+		return gc.blobMgr.MarkDeleting(ctx.SystemContext(), blob, time.Now().Add(-gc.gracePeriod))
+	}
+	blob.Status = blobModels.StatusDeleting
+	return gc.blobMgr.UpdateBlobStatus(ctx.SystemContext(), blob)
+}
+
+func (gc *GarbageCollector) reportBuilder() *reportBuilder {
+	if gc.report == nil {
+		gc.report = newReportBuilder()
+	}
+	return gc.report
+}
+
+// saveDryRunRes saves the estimated results of the dry-run along with the report breaking down the reclaimable space
+func (gc *GarbageCollector) saveDryRunRes(ctx job.Context, makeSize, blobs, manifests int64) {
+	res := &gcResult{
+		SweepSize: makeSize,
+		Blobs:     blobs,
+		Manifests: manifests,
+	}
+	report := gc.reportBuilder().build(gc.deleteSet, gc.trashedArts)
+	repository, dgt, err := gc.uploadReport(ctx, report)
+	if err != nil {
+		gc.logger.Errorf("failed to upload the garbage collection dry-run report, errMsg=%v", err)
+	} else {
+		gc.logger.Infof("the garbage collection dry-run report is saved, repository: %s, digest: %s", repository, dgt)
+		res.ReportRepository = repository
+		res.ReportDigest = dgt
+	}
+	if err := checkinGCRes(ctx, res); err != nil {
+		gc.logger.Errorf("failed to save the garbage collection results, errMsg=%v", err)
+	}
+}
+
+// uploadReport saves the dry-run report as a system artifact, returns the repository and digest of it
+func (gc *GarbageCollector) uploadReport(ctx job.Context, report *Report) (string, string, error) {
+	if gc.sysArtifactMgr == nil {
+		return "", "", errors.New("no system artifact manager to save the report")
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		return "", "", err
+	}
+	record := &sysartifactmodel.SystemArtifact{
+		Repository: fmt.Sprintf("gc_dry_run_report_%s", uuid.New().String()),
+		Digest:     digest.FromBytes(data).String(),
+		Size:       int64(len(data)),
+		Vendor:     ReportVendor,
+		Type:       reportType,
+	}
+	if _, err := gc.sysArtifactMgr.Create(ctx.SystemContext(), record, bytes.NewReader(data)); err != nil {
+		return "", "", err
+	}
+	return record.Repository, record.Digest, nil
+}
+
+// scoped returns true if the GC is scoped to the specified projects
+func (gc *GarbageCollector) scoped() bool {
+	return len(gc.projectIDs) > 0
+}
+
+func (gc *GarbageCollector) projectIDsOrList() *q.OrList {
+	var ids []any
+	for _, id := range gc.projectIDs {
+		ids = append(ids, id)
+	}
+	return q.NewOrList(ids)
+}
+
+// recordScopedBlobs records the blobs unassociated from the scoped projects as the candidates of the scoped GC
+func (gc *GarbageCollector) recordScopedBlobs(blobs []*blobModels.Blob) {
+	if !gc.scoped() {
+		return
+	}
+	if gc.scopedBlobs == nil {
+		gc.scopedBlobs = make(map[string]struct{})
+	}
+	for _, blob := range blobs {
+		gc.scopedBlobs[blob.Digest] = struct{}{}
+	}
+}
+
 // markDeleteFailed set the blob status to StatusDeleteFailed
 func (gc *GarbageCollector) markDeleteFailed(ctx job.Context, blob *blobModels.Blob) error {
 	blob.Status = blobModels.StatusDeleteFailed
@@ -733,16 +927,26 @@ func (gc *GarbageCollector) shouldStop(ctx job.Context) bool {
 	return false
 }
 
+// gcResult is the result of GC checked in to the core
+type gcResult struct {
+	SweepSize        int64  `json:"freed_space"`
+	Blobs            int64  `json:"purged_blobs"`
+	Manifests        int64  `json:"purged_manifests"`
+	ReportRepository string `json:"report_repository,omitempty"`
+	ReportDigest     string `json:"report_digest,omitempty"`
+	// the candidates are marked and ready to be swept by the sweep jobs
+	Marked bool `json:"marked,omitempty"`
+}
+
 func saveGCRes(ctx job.Context, sweepSize, blobs, manifests int64) error {
-	gcObj := struct {
-		SweepSize int64 `json:"freed_space"`
-		Blobs     int64 `json:"purged_blobs"`
-		Manifests int64 `json:"purged_manifests"`
-	}{
+	return checkinGCRes(ctx, &gcResult{
 		SweepSize: sweepSize,
 		Blobs:     blobs,
 		Manifests: manifests,
-	}
+	})
+}
+
+func checkinGCRes(ctx job.Context, gcObj *gcResult) error {
 	c, err := json.Marshal(gcObj)
 	if err != nil {
 		return err
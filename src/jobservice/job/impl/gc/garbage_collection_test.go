@@ -16,6 +16,7 @@ package gc
 
 import (
 	"testing"
+	"time"
 
 	"github.com/docker/distribution/manifest/schema2"
 	"github.com/stretchr/testify/suite"
@@ -36,6 +37,7 @@ import (
 	"github.com/goharbor/harbor/src/testing/mock"
 	trashtesting "github.com/goharbor/harbor/src/testing/pkg/artifactrash"
 	"github.com/goharbor/harbor/src/testing/pkg/blob"
+	systemartifacttesting "github.com/goharbor/harbor/src/testing/pkg/systemartifact"
 	"github.com/goharbor/harbor/src/testing/registryctl"
 )
 
@@ -170,6 +172,32 @@ func (suite *gcTestSuite) TestInit() {
 	suite.True(gc.deleteUntagged)
 	suite.True(gc.deleteTag)
 	suite.Equal(3, gc.workers)
+	suite.Empty(gc.projectIDs)
+
+	params = map[string]any{
+		"redis_url_reg": "redis url",
+		"project_ids":   []any{float64(1), float64(2)},
+	}
+	suite.Nil(gc.init(ctx, params))
+	suite.Equal([]int64{1, 2}, gc.projectIDs)
+	suite.False(gc.online)
+
+	params = map[string]any{
+		"redis_url_reg": "redis url",
+		"online":        true,
+		"grace_period":  float64(30),
+	}
+	suite.Nil(gc.init(ctx, params))
+	suite.True(gc.online)
+	suite.Equal(30*time.Minute, gc.gracePeriod)
+	suite.Equal(1, gc.shards)
+
+	params = map[string]any{
+		"redis_url_reg": "redis url",
+		"shards":        float64(4),
+	}
+	suite.Nil(gc.init(ctx, params))
+	suite.Equal(4, gc.shards)
 
 	params = map[string]any{
 		"delete_untagged": "unsupported",
@@ -369,6 +397,111 @@ func (suite *gcTestSuite) TestMark() {
 	suite.Nil(gc.mark(ctx))
 }
 
+func (suite *gcTestSuite) TestMarkScoped() {
+	ctx := &mockjobservice.MockJobContext{}
+	logger := &mockjobservice.MockJobLogger{}
+	ctx.On("GetLogger").Return(logger)
+	ctx.On("OPCommand").Return(job.NilCommand, false)
+
+	mock.OnAnything(suite.projectCtl, "List").Return([]*proModels.Project{
+		{
+			ProjectID: 1234,
+			Name:      "test GC",
+		},
+	}, nil)
+
+	scoped := suite.DigestString()
+	mock.OnAnything(suite.blobMgr, "List").Return([]*pkg_blob.Blob{
+		{
+			ID:     1,
+			Digest: scoped,
+			Size:   1234,
+		},
+	}, nil)
+	mock.OnAnything(suite.blobMgr, "FindBlobsShouldUnassociatedWithProject").Return([]*pkg_blob.Blob{
+		{
+			ID:     1,
+			Digest: scoped,
+			Size:   1234,
+		},
+	}, nil)
+	mock.OnAnything(suite.blobMgr, "CleanupAssociationsForProject").Return(nil)
+	mock.OnAnything(suite.blobMgr, "UselessBlobs").Return([]*pkg_blob.Blob{
+		{
+			ID:          1,
+			Digest:      scoped,
+			ContentType: schema2.MediaTypeLayer,
+		},
+		{
+			ID:          2,
+			Digest:      suite.DigestString(),
+			ContentType: schema2.MediaTypeLayer,
+		},
+	}, nil)
+
+	gc := &GarbageCollector{
+		artCtl:     suite.artifactCtl,
+		artrashMgr: suite.artrashMgr,
+		blobMgr:    suite.blobMgr,
+		projectIDs: []int64{1234},
+		logger:     logger,
+	}
+
+	_, err := gc.markOrSweepUntaggedBlobs(ctx)
+	suite.Nil(err)
+	blobs, err := gc.uselessBlobs(ctx)
+	suite.Nil(err)
+	// only the blob unassociated from the scoped project is the candidate
+	suite.Len(blobs, 1)
+	suite.Equal(scoped, blobs[0].Digest)
+}
+
+func (suite *gcTestSuite) TestMarkDryRunReport() {
+	ctx := &mockjobservice.MockJobContext{}
+	logger := &mockjobservice.MockJobLogger{}
+	ctx.On("GetLogger").Return(logger)
+	ctx.On("OPCommand").Return(job.NilCommand, false)
+
+	mock.OnAnything(suite.projectCtl, "List").Return([]*proModels.Project{
+		{
+			ProjectID: 1234,
+			Name:      "library",
+		},
+	}, nil)
+	blobs := []*pkg_blob.Blob{
+		{
+			ID:          1,
+			Digest:      suite.DigestString(),
+			Size:        1024,
+			ContentType: schema2.MediaTypeLayer,
+		},
+	}
+	mock.OnAnything(suite.blobMgr, "List").Return(blobs, nil)
+	mock.OnAnything(suite.blobMgr, "FindBlobsShouldUnassociatedWithProject").Return(blobs, nil)
+	mock.OnAnything(suite.blobMgr, "UselessBlobs").Return([]*pkg_blob.Blob{}, nil)
+
+	sysArtifactMgr := &systemartifacttesting.Manager{}
+	sysArtifactMgr.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+	var checkin string
+	ctx.On("Checkin", mock.Anything).Run(func(args mock.Arguments) {
+		checkin = args.String(0)
+	}).Return(nil)
+
+	gc := &GarbageCollector{
+		artCtl:         suite.artifactCtl,
+		artrashMgr:     suite.artrashMgr,
+		blobMgr:        suite.blobMgr,
+		sysArtifactMgr: sysArtifactMgr,
+		dryRun:         true,
+		logger:         logger,
+	}
+
+	suite.Nil(gc.mark(ctx))
+	sysArtifactMgr.AssertNumberOfCalls(suite.T(), "Create", 1)
+	suite.Contains(checkin, `"freed_space":1024`)
+	suite.Contains(checkin, `"report_repository":"gc_dry_run_report_`)
+}
+
 func (suite *gcTestSuite) TestSweep() {
 	ctx := &mockjobservice.MockJobContext{}
 	logger := &mockjobservice.MockJobLogger{}
@@ -398,6 +531,87 @@ func (suite *gcTestSuite) TestSweep() {
 	suite.Nil(gc.sweep(ctx))
 }
 
+func (suite *gcTestSuite) TestSweepOnline() {
+	ctx := &mockjobservice.MockJobContext{}
+	logger := &mockjobservice.MockJobLogger{}
+	ctx.On("GetLogger").Return(logger)
+	ctx.On("OPCommand").Return(job.NilCommand, false)
+	mock.OnAnything(ctx, "Checkin").Return(nil)
+
+	unreferenced := &pkg_blob.Blob{
+		ID:          1,
+		Digest:      suite.DigestString(),
+		ContentType: schema2.MediaTypeLayer,
+	}
+	referenced := &pkg_blob.Blob{
+		ID:          2,
+		Digest:      suite.DigestString(),
+		ContentType: schema2.MediaTypeLayer,
+	}
+	suite.blobMgr.On("MarkDeleting", mock.Anything, unreferenced, mock.Anything).Return(int64(1), nil)
+	// the blob is referenced again after mark, it should be skipped
+	suite.blobMgr.On("MarkDeleting", mock.Anything, referenced, mock.Anything).Return(int64(0), nil)
+	suite.blobMgr.On("Delete", mock.Anything, int64(1)).Return(nil)
+
+	gc := &GarbageCollector{
+		artCtl:            suite.artifactCtl,
+		artrashMgr:        suite.artrashMgr,
+		blobMgr:           suite.blobMgr,
+		registryCtlClient: suite.registryCtlClient,
+		deleteSet:         []*pkg_blob.Blob{unreferenced, referenced},
+		workers:           1,
+		online:            true,
+		gracePeriod:       time.Hour,
+	}
+
+	suite.registryCtlClient.On("DeleteBlob", unreferenced.Digest).Return(nil)
+	suite.Nil(gc.sweep(ctx))
+	suite.registryCtlClient.AssertNumberOfCalls(suite.T(), "DeleteBlob", 1)
+	suite.blobMgr.AssertNotCalled(suite.T(), "UpdateBlobStatus", mock.Anything, mock.Anything)
+}
+
+func (suite *gcTestSuite) TestUselessBlobsOnline() {
+	ctx := &mockjobservice.MockJobContext{}
+	logger := &mockjobservice.MockJobLogger{}
+	ctx.On("GetLogger").Return(logger)
+
+	old := suite.DigestString()
+	mock.OnAnything(suite.blobMgr, "UselessBlobs").Return([]*pkg_blob.Blob{
+		{
+			ID:           1,
+			Digest:       old,
+			CreationTime: time.Now().Add(-3 * time.Hour),
+			UpdateTime:   time.Now().Add(-3 * time.Hour),
+		},
+		{
+			ID:           2,
+			Digest:       suite.DigestString(),
+			CreationTime: time.Now().Add(-time.Minute),
+			UpdateTime:   time.Now().Add(-time.Minute),
+		},
+		{
+			// the old blob checked by HEAD before pushing the manifest
+			ID:           3,
+			Digest:       suite.DigestString(),
+			CreationTime: time.Now().Add(-3 * time.Hour),
+			UpdateTime:   time.Now().Add(-time.Minute),
+		},
+	}, nil)
+
+	gc := &GarbageCollector{
+		blobMgr:     suite.blobMgr,
+		logger:      logger,
+		online:      true,
+		gracePeriod: 2 * time.Hour,
+	}
+
+	blobs, err := gc.uselessBlobs(ctx)
+	suite.Nil(err)
+	// the blob created or touched in the grace period is never swept
+	suite.Len(blobs, 1)
+	suite.Equal(old, blobs[0].Digest)
+}
+
 func (suite *gcTestSuite) TestSaveRes() {
 	ctx := &mockjobservice.MockJobContext{}
 	logger := &mockjobservice.MockJobLogger{}
@@ -0,0 +1,200 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/pkg/artifactrash/model"
+	blobModels "github.com/goharbor/harbor/src/pkg/blob/models"
+)
+
+const (
+	// ReportVendor is the vendor of the system artifacts holding the GC dry-run reports
+	ReportVendor = "garbage_collection"
+	// ReportRepositoryKey is the key of the execution extra attribute holding the repository of the dry-run report
+	ReportRepositoryKey = "report_repository"
+	// ReportDigestKey is the key of the execution extra attribute holding the digest of the dry-run report
+	ReportDigestKey = "report_digest"
+
+	reportType = "DryRunReport"
+)
+
+// Usage is the reclaimable space of a set of blobs
+type Usage struct {
+	ReclaimableSize int64 `json:"reclaimable_size"`
+	BlobCount       int64 `json:"blob_count"`
+}
+
+func (u *Usage) add(blob *blobModels.Blob) {
+	u.BlobCount++
+	// the foreign layer is not in the storage, do not count its size
+	if !blob.IsForeignLayer() {
+		u.ReclaimableSize += blob.Size
+	}
+}
+
+// RepositoryUsage is the reclaimable space of a repository
+type RepositoryUsage struct {
+	Usage
+	Name string `json:"name"`
+}
+
+// ProjectUsage is the reclaimable space of a project and its repositories
+type ProjectUsage struct {
+	Usage
+	Name         string             `json:"name"`
+	Repositories []*RepositoryUsage `json:"repositories"`
+}
+
+// UntaggedArtifact is the untagged artifact which would be deleted by GC
+type UntaggedArtifact struct {
+	Repository string `json:"repository"`
+	Digest     string `json:"digest"`
+	MediaType  string `json:"media_type"`
+}
+
+// Report is the dry-run report of GC, it breaks down the reclaimable space by project and repository
+type Report struct {
+	Usage
+	CreationTime      time.Time           `json:"creation_time"`
+	ManifestCount     int64               `json:"manifest_count"`
+	Projects          []*ProjectUsage     `json:"projects"`
+	UntaggedArtifacts []*UntaggedArtifact `json:"untagged_artifacts"`
+	// the blobs which are not referenced by any project before the GC, they cannot be attributed to any project
+	Unattributed Usage `json:"unattributed"`
+}
+
+// reportBuilder collects the owners of the GC candidates in the mark phase of dry-run
+type reportBuilder struct {
+	// blob digest -> project name
+	projects map[string]string
+	// blob digest -> repository name
+	repositories map[string]string
+}
+
+func newReportBuilder() *reportBuilder {
+	return &reportBuilder{
+		projects:     make(map[string]string),
+		repositories: make(map[string]string),
+	}
+}
+
+// recordProject records the blobs which would be unassociated from the project
+func (r *reportBuilder) recordProject(project string, blobs []*blobModels.Blob) {
+	for _, blob := range blobs {
+		if _, exist := r.projects[blob.Digest]; !exist {
+			r.projects[blob.Digest] = project
+		}
+	}
+}
+
+// recordRepository records the blobs which would be released by deleting the artifact of the repository
+func (r *reportBuilder) recordRepository(repository string, blobs []*blobModels.Blob) {
+	for _, blob := range blobs {
+		if _, exist := r.repositories[blob.Digest]; !exist {
+			r.repositories[blob.Digest] = repository
+		}
+	}
+}
+
+// build builds the report for the GC candidates and the artifacts which would be deleted
+func (r *reportBuilder) build(blobs []*blobModels.Blob, trashedArts map[string][]model.ArtifactTrash) *Report {
+	report := &Report{
+		CreationTime:      time.Now(),
+		Projects:          []*ProjectUsage{},
+		UntaggedArtifacts: []*UntaggedArtifact{},
+	}
+
+	projects := make(map[string]*ProjectUsage)
+	repositories := make(map[string]*RepositoryUsage)
+	counted := make(map[string]struct{})
+	for _, blob := range blobs {
+		// the candidates may be duplicated as a blob can be found by both the project and the artifact
+		if _, exist := counted[blob.Digest]; exist {
+			continue
+		}
+		counted[blob.Digest] = struct{}{}
+
+		report.add(blob)
+		if blob.IsManifest() {
+			report.ManifestCount++
+		}
+
+		repository := r.repositories[blob.Digest]
+		projectName := r.projects[blob.Digest]
+		if repository != "" {
+			projectName = projectOfRepository(repository)
+		}
+		if projectName == "" {
+			report.Unattributed.add(blob)
+			continue
+		}
+
+		p, exist := projects[projectName]
+		if !exist {
+			p = &ProjectUsage{Name: projectName, Repositories: []*RepositoryUsage{}}
+			projects[projectName] = p
+			report.Projects = append(report.Projects, p)
+		}
+		p.add(blob)
+
+		if repository == "" {
+			continue
+		}
+		repo, exist := repositories[repository]
+		if !exist {
+			repo = &RepositoryUsage{Name: repository}
+			repositories[repository] = repo
+			p.Repositories = append(p.Repositories, repo)
+		}
+		repo.add(blob)
+	}
+
+	// sort by the reclaimable size, so the repositories consuming the most storage are listed first
+	sort.SliceStable(report.Projects, func(i, j int) bool {
+		return report.Projects[i].ReclaimableSize > report.Projects[j].ReclaimableSize
+	})
+	for _, p := range report.Projects {
+		sort.SliceStable(p.Repositories, func(i, j int) bool {
+			return p.Repositories[i].ReclaimableSize > p.Repositories[j].ReclaimableSize
+		})
+	}
+
+	for _, arts := range trashedArts {
+		for _, art := range arts {
+			report.UntaggedArtifacts = append(report.UntaggedArtifacts, &UntaggedArtifact{
+				Repository: art.RepositoryName,
+				Digest:     art.Digest,
+				MediaType:  art.ManifestMediaType,
+			})
+		}
+	}
+	sort.SliceStable(report.UntaggedArtifacts, func(i, j int) bool {
+		if report.UntaggedArtifacts[i].Repository != report.UntaggedArtifacts[j].Repository {
+			return report.UntaggedArtifacts[i].Repository < report.UntaggedArtifacts[j].Repository
+		}
+		return report.UntaggedArtifacts[i].Digest < report.UntaggedArtifacts[j].Digest
+	})
+
+	return report
+}
+
+// projectOfRepository returns the project name of the repository, e.g. "library" for "library/hello-world"
+func projectOfRepository(repository string) string {
+	return strings.SplitN(repository, "/", 2)[0]
+}
@@ -0,0 +1,79 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"testing"
+
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/pkg/artifactrash/model"
+	blobModels "github.com/goharbor/harbor/src/pkg/blob/models"
+)
+
+func TestBuildReport(t *testing.T) {
+	manifest := &blobModels.Blob{Digest: "sha256:manifest", ContentType: schema2.MediaTypeManifest, Size: 10}
+	layer := &blobModels.Blob{Digest: "sha256:layer", ContentType: schema2.MediaTypeLayer, Size: 100}
+	orphan := &blobModels.Blob{Digest: "sha256:orphan", ContentType: schema2.MediaTypeLayer, Size: 1000}
+	foreign := &blobModels.Blob{Digest: "sha256:foreign", ContentType: schema2.MediaTypeForeignLayer, Size: 10000}
+	unreferenced := &blobModels.Blob{Digest: "sha256:unreferenced", ContentType: schema2.MediaTypeLayer, Size: 1}
+
+	builder := newReportBuilder()
+	builder.recordRepository("library/hello-world", []*blobModels.Blob{manifest, layer})
+	builder.recordProject("library", []*blobModels.Blob{layer, orphan})
+	builder.recordProject("test", []*blobModels.Blob{foreign})
+
+	trashedArts := map[string][]model.ArtifactTrash{
+		"sha256:manifest": {
+			{
+				RepositoryName:    "library/hello-world",
+				Digest:            "sha256:manifest",
+				ManifestMediaType: schema2.MediaTypeManifest,
+			},
+		},
+	}
+	// the layer is duplicated, it should be counted once
+	report := builder.build([]*blobModels.Blob{manifest, layer, orphan, foreign, unreferenced, layer}, trashedArts)
+
+	assert.Equal(t, int64(5), report.BlobCount)
+	assert.Equal(t, int64(1), report.ManifestCount)
+	assert.Equal(t, int64(1111), report.ReclaimableSize)
+
+	assert.Len(t, report.Projects, 2)
+	assert.Equal(t, "library", report.Projects[0].Name)
+	assert.Equal(t, int64(1110), report.Projects[0].ReclaimableSize)
+	assert.Equal(t, int64(3), report.Projects[0].BlobCount)
+	assert.Len(t, report.Projects[0].Repositories, 1)
+	assert.Equal(t, "library/hello-world", report.Projects[0].Repositories[0].Name)
+	assert.Equal(t, int64(110), report.Projects[0].Repositories[0].ReclaimableSize)
+	assert.Equal(t, int64(2), report.Projects[0].Repositories[0].BlobCount)
+	// the size of the foreign layer isn't counted
+	assert.Equal(t, "test", report.Projects[1].Name)
+	assert.Equal(t, int64(0), report.Projects[1].ReclaimableSize)
+	assert.Equal(t, int64(1), report.Projects[1].BlobCount)
+
+	assert.Equal(t, int64(1), report.Unattributed.BlobCount)
+	assert.Equal(t, int64(1), report.Unattributed.ReclaimableSize)
+
+	assert.Len(t, report.UntaggedArtifacts, 1)
+	assert.Equal(t, "library/hello-world", report.UntaggedArtifacts[0].Repository)
+}
+
+func TestProjectOfRepository(t *testing.T) {
+	assert.Equal(t, "library", projectOfRepository("library/hello-world"))
+	assert.Equal(t, "library", projectOfRepository("library/a/b"))
+	assert.Equal(t, "library", projectOfRepository("library"))
+}
@@ -0,0 +1,189 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	blobModels "github.com/goharbor/harbor/src/pkg/blob/models"
+)
+
+// MaxShards is the max count of the shards the sweep can be split into
+const MaxShards = 256
+
+// Sweeper is the job to sweep a shard of the GC candidates marked by the GarbageCollector.
+// The candidates are partitioned by the digest prefix, so the shards can be swept by the jobservice workers in parallel.
+type Sweeper struct {
+	GarbageCollector
+	shard int
+	// only the candidates marked after this time are swept, the ones left by the previous GC are skipped.
+	markTime time.Time
+}
+
+// MaxFails implements the interface in job/Interface
+func (s *Sweeper) MaxFails() uint {
+	return 1
+}
+
+// MaxCurrency implements the interface in job/Interface, no limit for the sweep jobs
+func (s *Sweeper) MaxCurrency() uint {
+	return 0
+}
+
+// ShouldRetry implements the interface in job/Interface
+func (s *Sweeper) ShouldRetry() bool {
+	return false
+}
+
+// Validate implements the interface in job/Interface
+func (s *Sweeper) Validate(params job.Parameters) error {
+	shards, ok := params["shards"].(float64)
+	if !ok || shards < 1 || shards > MaxShards {
+		return errors.New(nil).WithMessagef("invalid shards: %v", params["shards"])
+	}
+	shard, ok := params["shard"].(float64)
+	if !ok || shard < 0 || shard >= shards {
+		return errors.New(nil).WithMessagef("invalid shard: %v", params["shard"])
+	}
+	return nil
+}
+
+// Run implements the interface in job/Interface
+func (s *Sweeper) Run(ctx job.Context, params job.Parameters) error {
+	if err := s.init(ctx, params); err != nil {
+		return err
+	}
+	// the untagged artifacts are deleted by the mark job
+	s.deleteUntagged = false
+	s.dryRun = false
+	if shard, ok := params["shard"].(float64); ok {
+		s.shard = int(shard)
+	}
+	if markTime, ok := params["mark_time"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, markTime); err == nil {
+			s.markTime = t
+		}
+	}
+
+	s.logger.Infof("start to sweep the shard %d/%d of gc candidates.", s.shard+1, s.shards)
+
+	// the repositories of the deleted artifacts are required when to delete the manifests
+	arts, err := s.deletedArt(ctx)
+	if err != nil {
+		s.logger.Errorf("failed to get deleted Artifacts in gc sweep job, with error: %v", err)
+		return err
+	}
+	s.trashedArts = arts
+
+	candidates, err := s.candidates(ctx)
+	if err != nil {
+		if err == errGcStop {
+			s.logger.Info("received the stop signal, quit GC sweep job.")
+			return nil
+		}
+		s.logger.Errorf("failed to get the gc candidates of the shard %d, error: %v", s.shard, err)
+		return err
+	}
+	s.deleteSet = candidates
+	s.logger.Infof("%d gc candidates in the shard %d/%d", len(candidates), s.shard+1, s.shards)
+	if len(candidates) == 0 {
+		// check in the empty result, so the shard is counted as swept
+		if err := saveGCRes(ctx, int64(0), int64(0), int64(0)); err != nil {
+			s.logger.Errorf("failed to save the garbage collection results, errMsg=%v", err)
+		}
+		return nil
+	}
+
+	if err := s.sweep(ctx); err != nil {
+		if err == errGcStop {
+			s.logger.Info("received the stop signal, quit GC sweep job after cleaning up the cache.")
+			return s.cleanCache(ctx.SystemContext())
+		}
+		s.logger.Errorf("failed to execute GC sweep job, error: %v", err)
+		return err
+	}
+	if err := s.cleanCache(ctx.SystemContext()); err != nil {
+		return err
+	}
+	s.logger.Infof("success to sweep the shard %d/%d of gc candidates.", s.shard+1, s.shards)
+	return nil
+}
+
+// candidates returns the blobs marked as GC candidate in the shard
+func (s *Sweeper) candidates(ctx job.Context) ([]*blobModels.Blob, error) {
+	var candidates []*blobModels.Blob
+	ps := 1000
+	lastBlobID := int64(0)
+	from, to := shardRange(s.shard, s.shards)
+	for {
+		if s.shouldStop(ctx) {
+			return nil, errGcStop
+		}
+		keywords := map[string]any{
+			"status": blobModels.StatusDelete,
+			"id":     &q.Range{Min: lastBlobID + 1},
+		}
+		if !s.markTime.IsZero() {
+			keywords["update_time"] = &q.Range{Min: s.markTime}
+		}
+		// the shard is filtered by the database, so each shard only scans its own candidates
+		if len(from) > 0 {
+			keywords["digest__gte"] = from
+		}
+		if len(to) > 0 {
+			keywords["digest__lt"] = to
+		}
+		blobs, err := s.blobMgr.List(ctx.SystemContext(), &q.Query{
+			Keywords:   keywords,
+			PageNumber: 1,
+			PageSize:   int64(ps),
+			Sorts: []*q.Sort{
+				q.NewSort("id", false),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, blobs...)
+		if len(blobs) < ps {
+			break
+		}
+		lastBlobID = blobs[len(blobs)-1].ID
+	}
+	return candidates, nil
+}
+
+// shardRange returns the range [from, to) of the digests in the shard, the shards are the contiguous ranges of
+// the first byte of the sha256 digest, e.g. for 4 shards, the digests "sha256:00..." ~ "sha256:3f..." are in the
+// shard 0. The empty bound means unbounded, so the digests of other algorithms are covered by the first or last shard.
+func shardRange(shard, shards int) (from string, to string) {
+	if shards <= 1 {
+		return "", ""
+	}
+	// the first byte b belongs to the shard b*shards/256
+	lower := (shard*256 + shards - 1) / shards
+	upper := ((shard+1)*256 + shards - 1) / shards
+	if shard > 0 {
+		from = fmt.Sprintf("sha256:%02x", lower)
+	}
+	if upper < 256 {
+		to = fmt.Sprintf("sha256:%02x", upper)
+	}
+	return from, to
+}
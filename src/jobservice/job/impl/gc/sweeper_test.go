@@ -0,0 +1,82 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/q"
+	pkg_blob "github.com/goharbor/harbor/src/pkg/blob/models"
+	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/blob"
+)
+
+func TestShardRange(t *testing.T) {
+	cases := []struct {
+		shard, shards int
+		from, to      string
+	}{
+		{0, 1, "", ""},
+		{0, 4, "", "sha256:40"},
+		{1, 4, "sha256:40", "sha256:80"},
+		{3, 4, "sha256:c0", ""},
+		{1, 3, "sha256:56", "sha256:ab"},
+		{255, 256, "sha256:ff", ""},
+	}
+	for _, c := range cases {
+		from, to := shardRange(c.shard, c.shards)
+		assert.Equal(t, c.from, from, "shard %d/%d", c.shard, c.shards)
+		assert.Equal(t, c.to, to, "shard %d/%d", c.shard, c.shards)
+	}
+}
+
+func TestSweeperValidate(t *testing.T) {
+	s := &Sweeper{}
+	assert.Nil(t, s.Validate(job.Parameters{"shards": float64(4), "shard": float64(3)}))
+	assert.NotNil(t, s.Validate(job.Parameters{"shards": float64(4), "shard": float64(4)}))
+	assert.NotNil(t, s.Validate(job.Parameters{"shards": float64(0), "shard": float64(0)}))
+	assert.NotNil(t, s.Validate(job.Parameters{"shards": float64(MaxShards + 1), "shard": float64(0)}))
+	assert.NotNil(t, s.Validate(job.Parameters{"shard": float64(0)}))
+	assert.Equal(t, uint(0), s.MaxCurrency())
+}
+
+func TestSweeperCandidates(t *testing.T) {
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("OPCommand").Return(job.NilCommand, false)
+
+	blobMgr := &blob.Manager{}
+	mock.OnAnything(blobMgr, "List").Return([]*pkg_blob.Blob{
+		{ID: 1, Digest: "sha256:00ab"},
+		{ID: 3, Digest: "sha256:7fab"},
+	}, nil)
+
+	s := &Sweeper{
+		GarbageCollector: GarbageCollector{
+			blobMgr: blobMgr,
+			shards:  2,
+		},
+		shard: 0,
+	}
+	candidates, err := s.candidates(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, candidates, 2)
+	query := blobMgr.Calls[0].Arguments.Get(1).(*q.Query)
+	assert.Equal(t, "sha256:80", query.Keywords["digest__lt"])
+	assert.NotContains(t, query.Keywords, "digest__gte")
+}
@@ -16,6 +16,7 @@ package gc
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/goharbor/harbor/src/lib/cache"
 	"github.com/goharbor/harbor/src/lib/errors"
@@ -74,3 +75,35 @@ func divide(a, b int) (int, error) {
 	}
 	return quotient, nil
 }
+
+// parseProjectIDs parses the project IDs from the job parameter, the invalid ones are ignored
+func parseProjectIDs(v any) []int64 {
+	var ids []int64
+	switch items := v.(type) {
+	case []int64:
+		ids = append(ids, items...)
+	case []any:
+		for _, item := range items {
+			switch id := item.(type) {
+			case float64:
+				ids = append(ids, int64(id))
+			case int64:
+				ids = append(ids, id)
+			case int:
+				ids = append(ids, int64(id))
+			case json.Number:
+				if n, err := id.Int64(); err == nil {
+					ids = append(ids, n)
+				}
+			}
+		}
+	}
+
+	var result []int64
+	for _, id := range ids {
+		if id > 0 {
+			result = append(result, id)
+		}
+	}
+	return result
+}
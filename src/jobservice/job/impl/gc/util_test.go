@@ -2,6 +2,7 @@ package gc
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -52,6 +53,14 @@ func TestDivide(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestParseProjectIDs(t *testing.T) {
+	assert.Nil(t, parseProjectIDs(nil))
+	assert.Nil(t, parseProjectIDs("1,2"))
+	assert.Equal(t, []int64{1, 2}, parseProjectIDs([]int64{1, 2}))
+	assert.Equal(t, []int64{1, 2, 3}, parseProjectIDs([]any{float64(1), json.Number("2"), 3}))
+	assert.Equal(t, []int64{2}, parseProjectIDs([]any{float64(0), json.Number("x"), "1", float64(2), -1}))
+}
+
 func TestDelKeys(t *testing.T) {
 	// get redis client
 	c, err := cache.New("redis", cache.Address("redis://127.0.0.1:6379"))
@@ -16,6 +16,7 @@ package notification
 
 import (
 	"net/http"
+	"net/url"
 	"os"
 	"strconv"
 	"time"
@@ -73,3 +74,16 @@ func init() {
 		Timeout:   timeout,
 	}
 }
+
+// endpointOf returns the host of the address in the job parameters
+func endpointOf(params map[string]any) string {
+	address, ok := params["address"].(string)
+	if !ok {
+		return ""
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
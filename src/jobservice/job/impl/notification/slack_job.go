@@ -59,6 +59,11 @@ func (sj *SlackJob) ShouldRetry() bool {
 	return true
 }
 
+// Endpoint returns the host of the slack address
+func (sj *SlackJob) Endpoint(params job.Parameters) string {
+	return endpointOf(params)
+}
+
 // Validate implements the interface in job/Interface
 func (sj *SlackJob) Validate(params job.Parameters) error {
 	if params == nil {
@@ -59,6 +59,11 @@ func (wj *WebhookJob) ShouldRetry() bool {
 	return true
 }
 
+// Endpoint returns the host of the webhook address
+func (wj *WebhookJob) Endpoint(params job.Parameters) string {
+	return endpointOf(params)
+}
+
 // Validate implements the interface in job/Interface
 func (wj *WebhookJob) Validate(_ job.Parameters) error {
 	return nil
@@ -82,3 +82,9 @@ func TestRun(t *testing.T) {
 	// test incorrect webhook response
 	assert.NotNil(t, rep.Run(ctx, paramsWrong))
 }
+
+func TestEndpoint(t *testing.T) {
+	rep := &WebhookJob{}
+	assert.Equal(t, "hooks.example.com:8080", rep.Endpoint(map[string]any{"address": "https://hooks.example.com:8080/harbor"}))
+	assert.Empty(t, rep.Endpoint(map[string]any{}))
+}
@@ -17,6 +17,7 @@ package replication
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 
 	"github.com/goharbor/harbor/src/controller/replication/transfer"
 	// import chart transfer
@@ -79,6 +80,27 @@ func (r *Replication) Validate(_ job.Parameters) error {
 	return nil
 }
 
+// Endpoint returns the host of the remote registry, it is the source registry for the pull-based
+// replication and the destination registry for the push-based one
+func (r *Replication) Endpoint(params job.Parameters) string {
+	res := &model.Resource{}
+	// the local registry has the ID 0
+	if err := parseParam(params, "src_resource", res); err != nil || res.Registry == nil || res.Registry.ID == 0 {
+		res = &model.Resource{}
+		if err = parseParam(params, "dst_resource", res); err != nil {
+			return ""
+		}
+	}
+	if res.Registry == nil {
+		return ""
+	}
+	u, err := url.Parse(res.Registry.URL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
 // Run gets the corresponding transfer according to the resource type
 // and calls its function to do the real work
 func (r *Replication) Run(ctx job.Context, params job.Parameters) error {
@@ -109,7 +131,18 @@ func (r *Replication) Run(ctx job.Context, params job.Parameters) error {
 		return err
 	}
 
-	return trans.Transfer(src, dst, opts)
+	err = trans.Transfer(src, dst, opts)
+	// report the bytes transferred even if the transfer fails
+	if c, ok := trans.(transfer.Counter); ok && c.Transferred() > 0 {
+		checkIn, er := json.Marshal(&transfer.CheckIn{BytesTransferred: c.Transferred()})
+		if er == nil {
+			er = ctx.Checkin(string(checkIn))
+		}
+		if er != nil {
+			logger.Warningf("failed to check in the bytes transferred: %v", er)
+		}
+	}
+	return err
 }
 
 func parseParams(params map[string]any) (*model.Resource, *model.Resource, *transfer.Options, error) {
@@ -88,3 +88,23 @@ func TestRun(t *testing.T) {
 	require.Nil(t, rep.Run(&impl.Context{}, params))
 	assert.True(t, transferred)
 }
+
+func TestEndpoint(t *testing.T) {
+	rep := &Replication{}
+	// push-based
+	params := map[string]any{
+		"src_resource": `{"registry":{"id":0,"url":"http://core:8080"}}`,
+		"dst_resource": `{"registry":{"id":1,"url":"https://registry.example.com"}}`,
+	}
+	assert.Equal(t, "registry.example.com", rep.Endpoint(params))
+
+	// pull-based
+	params = map[string]any{
+		"src_resource": `{"registry":{"id":2,"url":"https://hub.example.com:5000"}}`,
+		"dst_resource": `{"registry":{"id":0,"url":"http://core:8080"}}`,
+	}
+	assert.Equal(t, "hub.example.com:5000", rep.Endpoint(params))
+
+	// invalid parameters
+	assert.Empty(t, rep.Endpoint(map[string]any{}))
+}
@@ -23,7 +23,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/gocarina/gocsv"
 	"github.com/opencontainers/go-digest"
 
 	"github.com/goharbor/harbor/src/jobservice/job"
@@ -94,13 +93,22 @@ func (sde *ScanDataExport) Run(ctx job.Context, params job.Parameters) error {
 	logger := ctx.GetLogger()
 	logger.Infof("Scan data export job started in mode : %v", mode)
 	sde.init()
-	fileName := fmt.Sprintf("%s/scandata_export_%s.csv", sde.scanDataExportDirPath, params[export.JobID])
+	format, err := sde.extractFormat(params)
+	if err != nil {
+		return err
+	}
+
+	formatInfo, err := export.GetFormat(format)
+	if err != nil {
+		return err
+	}
+	fileName := fmt.Sprintf("%s/scandata_export_%s.%s", sde.scanDataExportDirPath, params[export.JobID], formatInfo.Extension)
 
-	// ensure that CSV files are cleared post the completion of the Run.
-	defer sde.cleanupCsvFile(ctx, fileName, params)
-	err := sde.writeCsvFile(ctx, params, fileName)
+	// ensure that export files are cleared post the completion of the Run.
+	defer sde.cleanupExportFile(ctx, fileName, params)
+	err = sde.writeExportFile(ctx, params, fileName, format)
 	if err != nil {
-		logger.Errorf("error when writing data to CSV: %v", err)
+		logger.Errorf("error when writing data to %s: %v", format, err)
 		return err
 	}
 
@@ -118,14 +126,14 @@ func (sde *ScanDataExport) Run(ctx job.Context, params job.Parameters) error {
 		return err
 	}
 	baseFileName := filepath.Base(fileName)
-	repositoryName := strings.TrimSuffix(baseFileName, filepath.Ext(baseFileName))
-	logger.Infof("Creating repository for CSV file with blob : %s", repositoryName)
+	repositoryName := strings.TrimSuffix(baseFileName, "."+formatInfo.Extension)
+	logger.Infof("Creating repository for export file with blob : %s", repositoryName)
 	stat, err := os.Stat(fileName)
 	if err != nil {
 		logger.Errorf("Error when fetching file size: %v", err)
 		return err
 	}
-	logger.Infof("Export Job Id = %s. CSV file size: %d", params[export.JobID], stat.Size())
+	logger.Infof("Export Job Id = %s. Export file size: %d", params[export.JobID], stat.Size())
 	// earlier return and update status message if the file size is 0, unnecessary to push a empty system artifact.
 	if stat.Size() == 0 {
 		extra := map[string]any{
@@ -136,11 +144,11 @@ func (sde *ScanDataExport) Run(ctx job.Context, params job.Parameters) error {
 			logger.Errorf("Export Job Id = %s. Error when updating the exec extra attributes 'status_message' to 'No vulnerabilities found or matched': %v", params[export.JobID], updateErr)
 		}
 
-		logger.Infof("Export Job Id = %s. Exported CSV file is empty, skip to push system artifact, exit job", params[export.JobID])
+		logger.Infof("Export Job Id = %s. Exported file is empty, skip to push system artifact, exit job", params[export.JobID])
 		return nil
 	}
 
-	csvExportArtifactRecord := model.SystemArtifact{Repository: repositoryName, Digest: hash.String(), Size: stat.Size(), Type: "ScanData_CSV", Vendor: strings.ToLower(export.Vendor)}
+	csvExportArtifactRecord := model.SystemArtifact{Repository: repositoryName, Digest: hash.String(), Size: stat.Size(), Type: "ScanData_" + strings.ToUpper(format), Vendor: strings.ToLower(export.Vendor)}
 	artID, err := sde.sysArtifactMgr.Create(ctx.SystemContext(), &csvExportArtifactRecord, csvFile)
 	if err != nil {
 		logger.Errorf(
@@ -179,16 +187,21 @@ func (sde *ScanDataExport) updateExecAttributes(ctx job.Context, params job.Para
 	return sde.execMgr.UpdateExtraAttrs(ctx.SystemContext(), execID, attrsToUpdate)
 }
 
-func (sde *ScanDataExport) writeCsvFile(ctx job.Context, params job.Parameters, fileName string) error {
+func (sde *ScanDataExport) writeExportFile(ctx job.Context, params job.Parameters, fileName, format string) error {
 	logger := ctx.GetLogger()
-	csvFile, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_APPEND, os.ModePerm)
+	exportFile, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_APPEND, os.ModePerm)
 	if err != nil {
-		logger.Errorf("Failed to create CSV export file %s. Error : %v", fileName, err)
+		logger.Errorf("Failed to create export file %s. Error : %v", fileName, err)
 		return err
 	}
-	defer csvFile.Close()
+	defer exportFile.Close()
 
-	logger.Infof("Created CSV export file %s", csvFile.Name())
+	logger.Infof("Created export file %s", exportFile.Name())
+
+	writer, err := export.NewWriter(format, exportFile)
+	if err != nil {
+		return err
+	}
 
 	systemContext := ctx.SystemContext()
 	var exportParams export.Params
@@ -274,14 +287,8 @@ func (sde *ScanDataExport) writeCsvFile(ctx job.Context, params job.Parameters,
 			}
 			logger.Infof("Export Group Id = %d, Job Id = %s, Page Number = %d, Page Size = %d Num Records = %d", groupID, params[export.JobID], exportParams.PageNumber, exportParams.PageSize, len(data))
 
-			// for the first page write the CSV with the headers
-			if exportParams.PageNumber == 1 && groupID == 0 {
-				err = gocsv.Marshal(data, csvFile)
-			} else {
-				err = gocsv.MarshalWithoutHeaders(data, csvFile)
-			}
-			if err != nil {
-				return nil
+			if err := writer.Write(data); err != nil {
+				return err
 			}
 
 			exportParams.PageNumber = exportParams.PageNumber + 1
@@ -291,7 +298,9 @@ func (sde *ScanDataExport) writeCsvFile(ctx job.Context, params job.Parameters,
 			}
 		}
 	}
-	return nil
+
+	// complete the document of the format
+	return writer.Close()
 }
 
 func (sde *ScanDataExport) extractCriteria(params job.Parameters) (*export.Request, error) {
@@ -325,6 +334,24 @@ func (sde *ScanDataExport) extractCriteria(params job.Parameters) (*export.Reque
 	return sterilize(criteria), nil
 }
 
+// extractFormat returns the format of the export, the CSV is returned if no format specified
+func (sde *ScanDataExport) extractFormat(params job.Parameters) (string, error) {
+	if _, ok := params[export.JobRequest]; !ok {
+		return export.FormatCSV, nil
+	}
+
+	criteria, err := sde.extractCriteria(params)
+	if err != nil {
+		return "", err
+	}
+
+	if criteria.Format == "" {
+		return export.FormatCSV, nil
+	}
+
+	return strings.ToLower(criteria.Format), nil
+}
+
 func (sde *ScanDataExport) calculateFileHash(fileName string) (digest.Digest, error) {
 	return sde.digestCalculator.Calculate(fileName)
 }
@@ -363,15 +390,15 @@ func (sde *ScanDataExport) init() {
 	}
 }
 
-func (sde *ScanDataExport) cleanupCsvFile(ctx job.Context, fileName string, params job.Parameters) {
+func (sde *ScanDataExport) cleanupExportFile(ctx job.Context, fileName string, params job.Parameters) {
 	logger := ctx.GetLogger()
 	if _, err := os.Stat(fileName); os.IsNotExist(err) {
-		logger.Infof("Export Job Id = %s, CSV Export File = %s does not exist. Nothing to do", params[export.JobID], fileName)
+		logger.Infof("Export Job Id = %s, Export File = %s does not exist. Nothing to do", params[export.JobID], fileName)
 		return
 	}
 	err := os.Remove(fileName)
 	if err != nil {
-		logger.Errorf("Export Job Id = %s, CSV Export File = %s could not deleted. Error = %v", params[export.JobID], fileName, err)
+		logger.Errorf("Export Job Id = %s, Export File = %s could not deleted. Error = %v", params[export.JobID], fileName, err)
 		return
 	}
 }
@@ -52,3 +52,11 @@ type Interface interface {
 	//
 	Run(ctx Context, params Parameters) error
 }
+
+// EndpointProvider is an optional interface implemented by the jobs calling the remote services.
+// The worker enforces the rate limits of the endpoint on the jobs if it is configured.
+type EndpointProvider interface {
+	// Endpoint returns the host (with port if any) of the remote service called by the job
+	// with the given parameters, empty string means no remote service is called.
+	Endpoint(params Parameters) string
+}
@@ -28,6 +28,8 @@ const (
 	SBOMJobVendorType = "SBOM"
 	// GarbageCollectionVendorType job name
 	GarbageCollectionVendorType = "GARBAGE_COLLECTION"
+	// GarbageCollectionSweepVendorType : the name of the job which sweeps a shard of the GC candidates
+	GarbageCollectionSweepVendorType = "GARBAGE_COLLECTION_SWEEP"
 	// ReplicationVendorType : the name of the replication job in job service
 	ReplicationVendorType = "REPLICATION"
 	// WebhookJobVendorType : the name of the webhook job in job service
@@ -48,8 +50,14 @@ const (
 	ExecSweepVendorType = "EXECUTION_SWEEP"
 	// ScanAllVendorType: the name of the scan all job
 	ScanAllVendorType = "SCAN_ALL"
+	// ScanOnDBUpdateVendorType: the name of the job which rescans the artifacts when the vulnerability database of the scanner updates
+	ScanOnDBUpdateVendorType = "SCAN_ON_DB_UPDATE"
+	// SecurityHubSnapshotVendorType: the name of the job which takes the daily snapshot of the security summary
+	SecurityHubSnapshotVendorType = "SECURITY_HUB_SNAPSHOT"
 	// AuditLogsGDPRCompliantVendorType : the name of the job which makes audit logs table GDPR-compliant
 	AuditLogsGDPRCompliantVendorType = "AUDIT_LOGS_GDPR_COMPLIANT"
+	// ArtifactSigningVendorType : the name of the job which signs the artifact with the signing key of the project
+	ArtifactSigningVendorType = "ARTIFACT_SIGNING"
 )
 
 var (
@@ -58,6 +66,7 @@ var (
 		ImageScanJobVendorType:          lib.GetEnvInt64("IMAGE_SCAN_EXECUTION_RETENTION_COUNT", 1),
 		SBOMJobVendorType:               lib.GetEnvInt64("SBOM_EXECUTION_RETENTION_COUNT", 1),
 		ScanAllVendorType:               lib.GetEnvInt64("SCAN_ALL_EXECUTION_RETENTION_COUNT", 1),
+		ScanOnDBUpdateVendorType:        lib.GetEnvInt64("SCAN_ON_DB_UPDATE_EXECUTION_RETENTION_COUNT", 10),
 		PurgeAuditVendorType:            lib.GetEnvInt64("PURGE_AUDIT_EXECUTION_RETENTION_COUNT", 10),
 		ExecSweepVendorType:             lib.GetEnvInt64("EXECUTION_SWEEP_EXECUTION_RETENTION_COUNT", 10),
 		GarbageCollectionVendorType:     lib.GetEnvInt64("GARBAGE_COLLECTION_EXECUTION_RETENTION_COUNT", 50),
@@ -68,6 +77,7 @@ var (
 		SystemArtifactCleanupVendorType: lib.GetEnvInt64("SYSTEM_ARTIFACT_CLEANUP_EXECUTION_RETENTION_COUNT", 50),
 		P2PPreheatVendorType:            lib.GetEnvInt64("P2P_PREHEAT_EXECUTION_RETENTION_COUNT", 50),
 		RetentionVendorType:             lib.GetEnvInt64("RETENTION_EXECUTION_RETENTION_COUNT", 50),
+		ArtifactSigningVendorType:       lib.GetEnvInt64("ARTIFACT_SIGNING_EXECUTION_RETENTION_COUNT", 1),
 	}
 )
 
@@ -45,6 +45,10 @@ type Metadata struct {
 	ScheduleDelay uint64 `json:"schedule_delay,omitempty"`
 	Cron          string `json:"cron_spec,omitempty"`
 	IsUnique      bool   `json:"unique"`
+	Tenant        string `json:"tenant,omitempty"`       // The tenant (e.g. project) of the job for fair queuing
+	ExecutionID   int64  `json:"execution_id,omitempty"` // The execution the job is submitted for, used to label the job log
+	// The timezone, jitter and blackout windows of the periodic job
+	ScheduleOptions *ScheduleOptions `json:"schedule_options,omitempty"`
 }
 
 // Stats keeps the result of job launching.
@@ -66,6 +70,7 @@ type StatsInfo struct {
 	RunAt         int64      `json:"run_at,omitempty"`
 	CheckIn       string     `json:"check_in,omitempty"`
 	CheckInAt     int64      `json:"check_in_at,omitempty"`
+	HeartbeatAt   int64      `json:"heartbeat_at,omitempty"` // The last time the running job reported it is alive
 	DieAt         int64      `json:"die_at,omitempty"`
 	WebHookURL    string     `json:"web_hook_url,omitempty"`
 	UpstreamJobID string     `json:"upstream_job_id,omitempty"`   // Ref the upstream job if existing
@@ -73,6 +78,12 @@ type StatsInfo struct {
 	Parameters    Parameters `json:"parameters,omitempty"`
 	Revision      int64      `json:"revision,omitempty"` // For differentiating the each retry of the same job
 	HookAck       *ACK       `json:"ack,omitempty"`
+	Tenant        string     `json:"tenant,omitempty"`       // The tenant of the job for fair queuing
+	DAGID         string     `json:"dag_id,omitempty"`       // The DAG the job belongs to
+	DAGNode       string     `json:"dag_node,omitempty"`     // The node of the DAG the job is launched for
+	ExecutionID   int64      `json:"execution_id,omitempty"` // The execution the job is submitted for
+	Fails         int64      `json:"fails,omitempty"`        // The times the job has failed
+	MaxFails      int64      `json:"max_fails,omitempty"`    // The times the job can fail before it's not retried
 }
 
 // ACK is the acknowledge of hook event
@@ -159,4 +170,5 @@ func (st *Stats) Validate() error {
 // Config job service config
 type Config struct {
 	RedisPoolConfig *config.RedisPoolConfig `json:"redis_pool_config"`
+	Priorities      *Priorities             `json:"priorities,omitempty"`
 }
@@ -17,6 +17,8 @@ package job
 const (
 	// StopCommand is const for stop command
 	StopCommand OPCommand = "stop"
+	// RetryCommand is const for retry command
+	RetryCommand OPCommand = "retry"
 	// NilCommand is const for a nil command
 	NilCommand OPCommand = "nil"
 )
@@ -28,3 +30,8 @@ type OPCommand string
 func (oc OPCommand) IsStop() bool {
 	return oc == "stop"
 }
+
+// IsRetry return if the op command is retry
+func (oc OPCommand) IsRetry() bool {
+	return oc == RetryCommand
+}
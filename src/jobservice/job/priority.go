@@ -14,10 +14,22 @@
 
 package job
 
+import (
+	"sync"
+
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
 const (
 	defaultPriority uint = 1000
+	// MaxPriority is the max priority value accepted by the worker pool
+	MaxPriority uint = 100000
 )
 
+// global sampler which can be reconfigured via SetPriorities
+var sampler = &configurableSampler{}
+
 // PrioritySampler define the job priority generation method
 type PrioritySampler interface {
 	// Priority for the given job.
@@ -52,7 +64,122 @@ func (ps *defaultSampler) For(job string) uint {
 	}
 }
 
-// Priority returns the default job priority sampler implementation.
+// Priorities keeps the configurable job priorities and the fair share settings of the tenants.
+type Priorities struct {
+	// Default priority of the job types not listed in VendorTypes
+	Default uint `json:"default,omitempty"`
+	// VendorTypes keeps the priorities of the job types
+	VendorTypes map[string]uint `json:"vendor_types,omitempty"`
+	// Tenants keeps the weights of the tenants (projects) when sharing the running slots,
+	// the tenants not listed here have the weight of the default priority
+	Tenants map[string]uint `json:"tenants,omitempty"`
+	// MaxRunningPerTenant is the max number of the running jobs of one tenant with the default weight,
+	// 0 means no limit
+	MaxRunningPerTenant uint `json:"max_running_per_tenant,omitempty"`
+}
+
+// PrioritiesFromConfig converts the priority configurations of job service to Priorities.
+func PrioritiesFromConfig(cfg *config.PriorityConfig) *Priorities {
+	if cfg == nil {
+		return nil
+	}
+
+	return &Priorities{
+		Default:             cfg.Default,
+		VendorTypes:         cfg.VendorTypes,
+		Tenants:             cfg.Tenants,
+		MaxRunningPerTenant: cfg.MaxRunningPerTenant,
+	}
+}
+
+// Validate the priorities
+func (p *Priorities) Validate() error {
+	if p.Default > MaxPriority {
+		return errors.Errorf("default priority should be less or equal %d", MaxPriority)
+	}
+
+	for t, v := range p.VendorTypes {
+		if v == 0 || v > MaxPriority {
+			return errors.Errorf("priority of job type %s should be between 1 and %d", t, MaxPriority)
+		}
+	}
+
+	for t, v := range p.Tenants {
+		if v == 0 || v > MaxPriority {
+			return errors.Errorf("priority of tenant %s should be between 1 and %d", t, MaxPriority)
+		}
+	}
+
+	return nil
+}
+
+// TenantLimit returns the max number of the running jobs of the given tenant.
+// The limit is scaled by the weight of the tenant, 0 means no limit.
+func (p *Priorities) TenantLimit(tenant string) uint {
+	if p == nil || p.MaxRunningPerTenant == 0 || len(tenant) == 0 {
+		return 0
+	}
+
+	weight, ok := p.Tenants[tenant]
+	if !ok || weight == 0 {
+		return p.MaxRunningPerTenant
+	}
+
+	base := p.Default
+	if base == 0 {
+		base = defaultPriority
+	}
+	// Round up to give each tenant one slot at least
+	return (p.MaxRunningPerTenant*weight + base - 1) / base
+}
+
+// configurableSampler is the PrioritySampler which priorities can be changed at runtime.
+// The built-in priorities of defaultSampler are used for the job types without configured priority.
+type configurableSampler struct {
+	lock       sync.RWMutex
+	priorities *Priorities
+}
+
+// For the given job
+func (cs *configurableSampler) For(job string) uint {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	if cs.priorities != nil {
+		if p, ok := cs.priorities.VendorTypes[job]; ok && p > 0 {
+			return p
+		}
+	}
+
+	if p := (&defaultSampler{}).For(job); p != defaultPriority {
+		return p
+	}
+
+	if cs.priorities != nil && cs.priorities.Default > 0 {
+		return cs.priorities.Default
+	}
+
+	return defaultPriority
+}
+
+// Priority returns the job priority sampler implementation.
 func Priority() PrioritySampler {
-	return &defaultSampler{}
+	return sampler
+}
+
+// SetPriorities replaces the priorities used by the job priority sampler.
+// Nil priorities restores the built-in ones.
+func SetPriorities(p *Priorities) {
+	sampler.lock.Lock()
+	defer sampler.lock.Unlock()
+
+	sampler.priorities = p
+}
+
+// CurrentPriorities returns the priorities used by the job priority sampler.
+func CurrentPriorities() *Priorities {
+	sampler.lock.RLock()
+	defer sampler.lock.RUnlock()
+
+	return sampler.priorities
 }
@@ -51,3 +51,55 @@ func (suite *PrioritySamplerSuite) Test() {
 	p4 := suite.sampler.For(SlackJobVendorType)
 	suite.Equal((uint)(1), p4, "Job priority for %s", SlackJobVendorType)
 }
+
+// TestConfigurableSampler tests the priorities set at runtime
+func (suite *PrioritySamplerSuite) TestConfigurableSampler() {
+	defer SetPriorities(nil)
+
+	// Built-in priorities
+	suite.Equal(defaultPriority, Priority().For(ReplicationVendorType))
+	suite.Equal((uint)(1), Priority().For(SampleJob))
+
+	SetPriorities(&Priorities{
+		Default: 500,
+		VendorTypes: map[string]uint{
+			ImageScanJobVendorType: 2000,
+			SampleJob:              10,
+		},
+	})
+	suite.Equal((uint)(2000), Priority().For(ImageScanJobVendorType))
+	suite.Equal((uint)(10), Priority().For(SampleJob))
+	suite.Equal((uint)(500), Priority().For(ReplicationVendorType))
+	suite.Equal((uint)(1), Priority().For(SlackJobVendorType))
+}
+
+// TestTenantLimit tests the running limit of the tenants
+func (suite *PrioritySamplerSuite) TestTenantLimit() {
+	var p *Priorities
+	suite.Equal((uint)(0), p.TenantLimit("library"))
+
+	p = &Priorities{
+		Tenants: map[string]uint{
+			"library": 2000,
+			"low":     100,
+		},
+	}
+	suite.Equal((uint)(0), p.TenantLimit("library"), "no limit when fair queuing is disabled")
+
+	p.MaxRunningPerTenant = 10
+	suite.Equal((uint)(0), p.TenantLimit(""), "no limit for the jobs without tenant")
+	suite.Equal((uint)(10), p.TenantLimit("other"))
+	suite.Equal((uint)(20), p.TenantLimit("library"))
+	suite.Equal((uint)(1), p.TenantLimit("low"))
+
+	p.Default = 4000
+	suite.Equal((uint)(5), p.TenantLimit("library"))
+}
+
+// TestValidate tests the validation of priorities
+func (suite *PrioritySamplerSuite) TestValidate() {
+	suite.NoError((&Priorities{}).Validate())
+	suite.Error((&Priorities{Default: MaxPriority + 1}).Validate())
+	suite.Error((&Priorities{VendorTypes: map[string]uint{ImageScanJobVendorType: 0}}).Validate())
+	suite.Error((&Priorities{Tenants: map[string]uint{"library": MaxPriority + 1}}).Validate())
+}
@@ -0,0 +1,176 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"hash/fnv"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+const (
+	// MaxScheduleJitter is the max random delay in seconds applied to the scheduled time
+	MaxScheduleJitter int64 = 24 * 3600
+	// maxBlackoutSkips is the max number of the continuous scheduled times skipped by the blackout windows
+	maxBlackoutSkips = 1000
+)
+
+// ScheduleOptions customizes how the cron of a periodic job is evaluated.
+type ScheduleOptions struct {
+	// IANA name of the time zone the cron is evaluated in, the local time zone of the server if empty
+	Timezone string `json:"timezone,omitempty"`
+	// Max random delay in seconds added to each scheduled time to spread the load
+	Jitter int64 `json:"jitter,omitempty"`
+	// The scheduled times falling into any of the windows are skipped
+	Blackouts []*BlackoutWindow `json:"blackout_windows,omitempty"`
+}
+
+// BlackoutWindow is a recurring period in which no job is scheduled.
+// The window starts at each time matched by the cron and lasts for the duration.
+type BlackoutWindow struct {
+	// Cron of the window start, evaluated in the time zone of the schedule
+	Cron string `json:"cron"`
+	// Duration of the window in minutes
+	Duration int64 `json:"duration"`
+}
+
+// Validate the schedule options.
+func (o *ScheduleOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+
+	if _, err := time.LoadLocation(o.Timezone); err != nil {
+		return errors.Errorf("invalid timezone %s: %v", o.Timezone, err)
+	}
+
+	if o.Jitter < 0 || o.Jitter > MaxScheduleJitter {
+		return errors.Errorf("jitter must be between 0 and %d seconds", MaxScheduleJitter)
+	}
+
+	for _, w := range o.Blackouts {
+		if w == nil {
+			return errors.New("empty blackout window")
+		}
+		if _, err := utils.CronParser().Parse(w.Cron); err != nil {
+			return errors.Errorf("invalid cron %s of blackout window: %v", w.Cron, err)
+		}
+		if w.Duration <= 0 {
+			return errors.Errorf("duration of blackout window %s must be positive", w.Cron)
+		}
+	}
+
+	return nil
+}
+
+// Location returns the time zone the cron is evaluated in, the cron without the
+// time zone specified is evaluated in the local time zone as the other schedules.
+func (o *ScheduleOptions) Location() *time.Location {
+	if o == nil || o.Timezone == "" {
+		return time.Local
+	}
+
+	loc, err := time.LoadLocation(o.Timezone)
+	if err != nil {
+		// The options should be already validated
+		return time.Local
+	}
+
+	return loc
+}
+
+// Next returns the next scheduled time after t which is not in any blackout window.
+// The zero time is returned if no such time can be found.
+func (o *ScheduleOptions) Next(schedule cron.Schedule, t time.Time) time.Time {
+	next := schedule.Next(t.In(o.Location()))
+	for i := 0; i < maxBlackoutSkips && !next.IsZero(); i++ {
+		if !o.InBlackout(next) {
+			return next
+		}
+		next = schedule.Next(next)
+	}
+
+	return time.Time{}
+}
+
+// NextRun returns the next scheduled time after t and the time the job runs at, which is the
+// scheduled time delayed by the jitter of the specified schedule. The scheduled times whose
+// run times fall into any blackout window are skipped, the zero times are returned if no
+// such time can be found.
+func (o *ScheduleOptions) NextRun(scheduleID string, schedule cron.Schedule, t time.Time) (time.Time, time.Time) {
+	next := schedule.Next(t.In(o.Location()))
+	for i := 0; i < maxBlackoutSkips && !next.IsZero(); i++ {
+		run := next.Add(o.JitterOf(scheduleID, next))
+		if !o.InBlackout(run) {
+			return next, run
+		}
+		next = schedule.Next(next)
+	}
+
+	return time.Time{}, time.Time{}
+}
+
+// InBlackout returns true if t falls into any of the blackout windows.
+func (o *ScheduleOptions) InBlackout(t time.Time) bool {
+	if o == nil {
+		return false
+	}
+
+	t = t.In(o.Location())
+	for _, w := range o.Blackouts {
+		schedule, err := utils.CronParser().Parse(w.Cron)
+		if err != nil {
+			continue
+		}
+
+		// The window covers t if it starts in (t-duration, t]
+		start := schedule.Next(t.Add(-time.Duration(w.Duration) * time.Minute))
+		if !start.IsZero() && !start.After(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// JitterOf returns the delay applied to the scheduled time t of the specified schedule.
+// The delay is derived from the schedule ID and t instead of being random, so every
+// node computes the same time for the same execution.
+func (o *ScheduleOptions) JitterOf(scheduleID string, t time.Time) time.Duration {
+	if o == nil || o.Jitter <= 0 {
+		return 0
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(scheduleID))
+	_, _ = h.Write([]byte(t.UTC().Format(time.RFC3339)))
+
+	return time.Duration(h.Sum64()%uint64(o.Jitter+1)) * time.Second
+}
+
+// NextSchedule returns the next scheduled time of the cron with the options after t.
+// The zero time is returned if the cron is invalid.
+func NextSchedule(cronSpec string, options *ScheduleOptions, t time.Time) time.Time {
+	schedule, err := utils.CronParser().Parse(cronSpec)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return options.Next(schedule, t)
+}
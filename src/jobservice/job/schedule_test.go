@@ -0,0 +1,117 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common/utils"
+)
+
+// ScheduleOptionsTestSuite is test suite for ScheduleOptions.
+type ScheduleOptionsTestSuite struct {
+	suite.Suite
+}
+
+// TestScheduleOptions is entry point of ScheduleOptionsTestSuite.
+func TestScheduleOptions(t *testing.T) {
+	suite.Run(t, &ScheduleOptionsTestSuite{})
+}
+
+// TestValidate ...
+func (suite *ScheduleOptionsTestSuite) TestValidate() {
+	var o *ScheduleOptions
+	suite.NoError(o.Validate())
+
+	suite.NoError((&ScheduleOptions{
+		Timezone:  "Asia/Shanghai",
+		Jitter:    600,
+		Blackouts: []*BlackoutWindow{{Cron: "0 0 0 28 * *", Duration: 4 * 24 * 60}},
+	}).Validate())
+
+	suite.Error((&ScheduleOptions{Timezone: "Nowhere/Nothing"}).Validate())
+	suite.Error((&ScheduleOptions{Jitter: -1}).Validate())
+	suite.Error((&ScheduleOptions{Jitter: MaxScheduleJitter + 1}).Validate())
+	suite.Error((&ScheduleOptions{Blackouts: []*BlackoutWindow{{Cron: "x", Duration: 1}}}).Validate())
+	suite.Error((&ScheduleOptions{Blackouts: []*BlackoutWindow{{Cron: "0 0 0 * * *"}}}).Validate())
+}
+
+// TestTimezone ...
+func (suite *ScheduleOptionsTestSuite) TestTimezone() {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// The local time zone is used by default
+	var o *ScheduleOptions
+	local := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	suite.Equal(time.Date(2024, 1, 1, 2, 0, 0, 0, time.Local), NextSchedule("0 0 2 * * *", o, local))
+	suite.Equal(time.Local, (&ScheduleOptions{}).Location())
+
+	o = &ScheduleOptions{Timezone: "Asia/Shanghai"}
+	// 02:00 in UTC+8 is 18:00 in UTC of the previous day
+	suite.Equal(time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC), NextSchedule("0 0 2 * * *", o, now).UTC())
+}
+
+// TestBlackout ...
+func (suite *ScheduleOptionsTestSuite) TestBlackout() {
+	// No runs from the 28th to the end of the month
+	o := &ScheduleOptions{
+		Timezone:  "UTC",
+		Blackouts: []*BlackoutWindow{{Cron: "0 0 0 28 * *", Duration: 4 * 24 * 60}},
+	}
+
+	suite.True(o.InBlackout(time.Date(2024, 1, 28, 0, 0, 0, 0, time.UTC)))
+	suite.True(o.InBlackout(time.Date(2024, 1, 31, 23, 0, 0, 0, time.UTC)))
+	suite.False(o.InBlackout(time.Date(2024, 1, 27, 23, 59, 59, 0, time.UTC)))
+	suite.False(o.InBlackout(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)))
+
+	next := NextSchedule("0 0 2 * * *", o, time.Date(2024, 1, 27, 12, 0, 0, 0, time.UTC))
+	suite.Equal(time.Date(2024, 2, 1, 2, 0, 0, 0, time.UTC), next.UTC())
+
+	// Always in blackout
+	o.Blackouts = []*BlackoutWindow{{Cron: "0 0 * * * *", Duration: 60}}
+	suite.True(NextSchedule("0 0 2 * * *", o, time.Now()).IsZero())
+}
+
+// TestJitter ...
+func (suite *ScheduleOptionsTestSuite) TestJitter() {
+	t := time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)
+
+	var o *ScheduleOptions
+	suite.Equal(time.Duration(0), o.JitterOf("id", t))
+
+	o = &ScheduleOptions{Jitter: 600}
+	j := o.JitterOf("id", t)
+	suite.True(j >= 0 && j <= 600*time.Second)
+	suite.Equal(j, o.JitterOf("id", t), "jitter should be stable for the same execution")
+
+	schedule, err := utils.CronParser().Parse("0 0 2 * * *")
+	suite.Require().NoError(err)
+	suite.Equal(t, o.Next(schedule, t.Add(-time.Hour)).UTC(), "jitter should not change the scheduled time")
+
+	next, run := o.NextRun("id", schedule, t.Add(-time.Hour))
+	suite.Equal(t, next.UTC())
+	suite.Equal(t.Add(j), run.UTC())
+
+	// The run delayed into the blackout window is skipped
+	o.Timezone = "UTC"
+	o.Blackouts = []*BlackoutWindow{{Cron: "0 0 2 1 1 *", Duration: 60}}
+	o.Jitter = 60
+	next, run = o.NextRun("id", schedule, t.Add(-time.Hour))
+	suite.Equal(t.AddDate(0, 0, 1), next.UTC())
+	suite.False(o.InBlackout(run))
+}
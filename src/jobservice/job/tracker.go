@@ -78,6 +78,10 @@ type Tracker interface {
 	// Check in message
 	CheckIn(message string) error
 
+	// Heartbeat reports the running job is still alive.
+	// It does not touch the update time which is used by the reaper.
+	Heartbeat() error
+
 	// Update status with retry enabled
 	UpdateStatusWithRetry(targetStatus Status) error
 
@@ -244,6 +248,23 @@ func (bt *basicTracker) CheckIn(message string) error {
 	return nil
 }
 
+// Heartbeat records the alive timestamp of the running job
+func (bt *basicTracker) Heartbeat() error {
+	conn := bt.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	now := time.Now().Unix()
+	key := rds.KeyJobStats(bt.namespace, bt.jobID)
+	if err := rds.HmSet(conn, key, "heartbeat_at", now); err != nil {
+		return errors.Wrap(err, "heartbeat")
+	}
+	bt.jobStats.Info.HeartbeatAt = now
+
+	return nil
+}
+
 // Run job
 // Either one is failed, the final return will be marked as failed.
 func (bt *basicTracker) Run() error {
@@ -331,6 +352,18 @@ func (bt *basicTracker) Save() (err error) {
 		args = append(args, "upstream_job_id", stats.Info.UpstreamJobID)
 	}
 
+	if !utils.IsEmptyStr(stats.Info.Tenant) {
+		args = append(args, "tenant", stats.Info.Tenant)
+	}
+
+	if !utils.IsEmptyStr(stats.Info.DAGID) {
+		args = append(args, "dag_id", stats.Info.DAGID, "dag_node", stats.Info.DAGNode)
+	}
+
+	if stats.Info.ExecutionID > 0 {
+		args = append(args, "execution_id", stats.Info.ExecutionID)
+	}
+
 	if len(stats.Info.Parameters) > 0 {
 		if bytes, err := json.Marshal(&stats.Info.Parameters); err == nil {
 			args = append(args, "parameters", string(bytes))
@@ -466,8 +499,9 @@ func (bt *basicTracker) refresh(targetStatus Status, checkIn ...string) {
 
 // FireHookEvent fires the hook event
 func (bt *basicTracker) fireHookEvent(status Status, checkIn ...string) error {
-	// Check if hook URL is registered
-	if utils.IsEmptyStr(bt.jobStats.Info.WebHookURL) {
+	// Check if hook URL is registered.
+	// The status change of the job in DAG is always fired to drive the DAG forward.
+	if utils.IsEmptyStr(bt.jobStats.Info.WebHookURL) && utils.IsEmptyStr(bt.jobStats.Info.DAGID) {
 		// Do nothing
 		return nil
 	}
@@ -571,6 +605,8 @@ func (bt *basicTracker) retrieve() error {
 			res.Info.RunAt = parseInt64(value)
 		case "check_in_at":
 			res.Info.CheckInAt = parseInt64(value)
+		case "heartbeat_at":
+			res.Info.HeartbeatAt = parseInt64(value)
 		case "check_in":
 			res.Info.CheckIn = "" // never read checkin placeholder data
 		case "cron_spec":
@@ -583,6 +619,18 @@ func (bt *basicTracker) retrieve() error {
 			res.Info.UpstreamJobID = value
 		case "numeric_policy_id":
 			res.Info.NumericPID = parseInt64(value)
+		case "tenant":
+			res.Info.Tenant = value
+		case "dag_id":
+			res.Info.DAGID = value
+		case "dag_node":
+			res.Info.DAGNode = value
+		case "execution_id":
+			res.Info.ExecutionID = parseInt64(value)
+		case "fails":
+			res.Info.Fails = parseInt64(value)
+		case "max_fails":
+			res.Info.MaxFails = parseInt64(value)
 		case "parameters":
 			params := make(Parameters)
 			if err := json.Unmarshal([]byte(value), &params); err == nil {
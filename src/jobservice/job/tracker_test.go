@@ -114,6 +114,16 @@ func (suite *TrackerTestSuite) TestTracker() {
 	err = tracker.CheckIn("check in2")
 	assert.Nil(suite.T(), err, "check in2: nil error expected but got %s", err)
 
+	err = tracker.Load()
+	assert.Nil(suite.T(), err, "load before heartbeat: nil error expected but got %s", err)
+	updateTime := tracker.Job().Info.UpdateTime
+	err = tracker.Heartbeat()
+	assert.Nil(suite.T(), err, "heartbeat: nil error expected but got %s", err)
+	err = tracker.Load()
+	assert.Nil(suite.T(), err, "load after heartbeat: nil error expected but got %s", err)
+	assert.NotEqual(suite.T(), int64(0), tracker.Job().Info.HeartbeatAt, "heartbeat: expect heartbeat time set")
+	assert.Equal(suite.T(), updateTime, tracker.Job().Info.UpdateTime, "heartbeat: expect update time unchanged")
+
 	err = tracker.Succeed()
 	assert.Nil(suite.T(), err, "succeed: nil error expected but got %s", err)
 	// same status is allowed to update
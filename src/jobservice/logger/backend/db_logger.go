@@ -15,8 +15,9 @@
 package backend
 
 import (
-	"bufio"
 	"bytes"
+	"sync"
+	"time"
 
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
@@ -24,49 +25,122 @@ import (
 	"github.com/goharbor/harbor/src/pkg/joblog/models"
 )
 
+// dbLogFlushInterval is how often the log of the running job is written into DB,
+// so it can be followed from any jobservice instance before the job is done
+const dbLogFlushInterval = 5 * time.Second
+
+// syncBuffer is a bytes buffer safe for concurrent writing and reading
+type syncBuffer struct {
+	lock   sync.Mutex
+	buffer bytes.Buffer
+}
+
+// Write implements io.Writer
+func (sb *syncBuffer) Write(p []byte) (int, error) {
+	sb.lock.Lock()
+	defer sb.lock.Unlock()
+
+	return sb.buffer.Write(p)
+}
+
+// From returns the buffered data from the offset as string
+func (sb *syncBuffer) From(offset int) string {
+	sb.lock.Lock()
+	defer sb.lock.Unlock()
+
+	return string(sb.buffer.Bytes()[offset:])
+}
+
 // DBLogger is an implementation of logger.Interface.
 // It outputs logs to PGSql.
 type DBLogger struct {
 	backendLogger *log.Logger
-	bw            *bufio.Writer
-	buffer        *bytes.Buffer
+	buffer        *syncBuffer
 	key           string
+	// flushed is the size of the log data written into DB
+	flushed int
+	done    chan struct{}
+	stopped chan struct{}
 }
 
 // NewDBLogger crates a new DB logger
 // nil might be returned
 func NewDBLogger(key string, level string, depth int) (*DBLogger, error) {
-	buffer := bytes.NewBuffer(make([]byte, 0))
-	bw := bufio.NewWriter(buffer)
+	buffer := &syncBuffer{}
 	logLevel := parseLevel(level)
 
-	backendLogger := log.New(bw, log.NewTextFormatter(), logLevel, depth)
+	backendLogger := log.New(buffer, log.NewTextFormatter(), logLevel, depth)
 
-	return &DBLogger{
+	dbl := &DBLogger{
 		backendLogger: backendLogger,
-		bw:            bw,
 		buffer:        buffer,
 		key:           key,
-	}, nil
+		done:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+	}
+	go dbl.loop()
+
+	return dbl, nil
 }
 
-// Close the opened io stream and flush data into DB
-// Implements logger.Closer interface
-func (dbl *DBLogger) Close() error {
-	err := dbl.bw.Flush()
-	if err != nil {
-		return err
+// loop writes the log data into DB periodically until the logger is closed
+func (dbl *DBLogger) loop() {
+	defer close(dbl.stopped)
+
+	ticker := time.NewTicker(dbLogFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := dbl.flush(); err != nil {
+				log.Errorf("failed to write the log of job %s into DB: %v", dbl.key, err)
+			}
+		case <-dbl.done:
+			return
+		}
 	}
+}
 
-	jobLog := models.JobLog{
-		UUID:    dbl.key,
-		Content: dbl.buffer.String(),
+// flush writes the new lines of the log data into DB, only the new lines are appended so
+// the writes don't grow with the size of the log
+func (dbl *DBLogger) flush() error {
+	content := dbl.buffer.From(dbl.flushed)
+	if len(content) == 0 {
+		return nil
 	}
 
-	_, err = joblog.Mgr.Create(orm.Context(), &jobLog)
+	var err error
+	if dbl.flushed == 0 {
+		// the first write replaces the log left by the previous run of the job
+		_, err = joblog.Mgr.Create(orm.Context(), &models.JobLog{
+			UUID:    dbl.key,
+			Content: content,
+		})
+	} else {
+		err = joblog.Mgr.Append(orm.Context(), dbl.key, content)
+	}
 	if err != nil {
 		return err
 	}
+	dbl.flushed += len(content)
+	return nil
+}
+
+// Close the opened io stream and flush data into DB
+// Implements logger.Closer interface
+func (dbl *DBLogger) Close() error {
+	close(dbl.done)
+	<-dbl.stopped
+
+	if err := dbl.flush(); err != nil {
+		return err
+	}
+	if dbl.flushed == 0 {
+		// keep the log record of the job even if it logs nothing
+		_, err := joblog.Mgr.Create(orm.Context(), &models.JobLog{UUID: dbl.key})
+		return err
+	}
 	return nil
 }
 
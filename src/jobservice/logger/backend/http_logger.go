@@ -0,0 +1,208 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/logger/sink"
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+const (
+	// DefaultHTTPBatchSize is the default number of the log lines shipped in one request
+	DefaultHTTPBatchSize = 100
+	// DefaultHTTPFlushInterval is the default max time the log lines are kept before shipped
+	DefaultHTTPFlushInterval = 5 * time.Second
+	// maxHTTPPendingEntries caps the log lines kept in memory when the log store is unavailable,
+	// the oldest ones are dropped when it's exceeded
+	maxHTTPPendingEntries = 10000
+)
+
+// HTTPLogger is an implementation of logger.Interface.
+// It batches the log lines and ships them with the labels to a remote log store over HTTP.
+type HTTPLogger struct {
+	backendLogger *log.Logger
+	sink          sink.Interface
+	labels        map[string]string
+	batchSize     int
+
+	lock    sync.Mutex
+	pending []*sink.Entry
+	seq     int64
+
+	flushChan chan struct{}
+	closeChan chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewHTTPLogger creates a new HTTP logger shipping the log lines to the sink
+func NewHTTPLogger(s sink.Interface, labels map[string]string, level string, depth int, batchSize int, flushInterval time.Duration) *HTTPLogger {
+	if batchSize <= 0 {
+		batchSize = DefaultHTTPBatchSize
+	}
+	if flushInterval <= 0 {
+		flushInterval = DefaultHTTPFlushInterval
+	}
+
+	hl := &HTTPLogger{
+		sink:      s,
+		labels:    labels,
+		batchSize: batchSize,
+		flushChan: make(chan struct{}, 1),
+		closeChan: make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	hl.backendLogger = log.New(hl, log.NewTextFormatter(), parseLevel(level), depth)
+
+	go hl.loop(flushInterval)
+
+	return hl
+}
+
+// Write implements io.Writer, each call is a formatted log line
+func (hl *HTTPLogger) Write(p []byte) (int, error) {
+	hl.lock.Lock()
+	defer hl.lock.Unlock()
+
+	hl.seq++
+	hl.pending = append(hl.pending, &sink.Entry{
+		Time: time.Now(),
+		Seq:  hl.seq,
+		Line: strings.TrimRight(string(p), "\n"),
+	})
+	if len(hl.pending) > maxHTTPPendingEntries {
+		hl.pending = hl.pending[len(hl.pending)-maxHTTPPendingEntries:]
+	}
+
+	if len(hl.pending) >= hl.batchSize {
+		select {
+		case hl.flushChan <- struct{}{}:
+		default:
+		}
+	}
+
+	return len(p), nil
+}
+
+// Close ships the remaining log lines and stops the logger
+// Implements logger.Closer interface
+func (hl *HTTPLogger) Close() error {
+	hl.closeOnce.Do(func() {
+		close(hl.closeChan)
+	})
+	<-hl.done
+
+	return hl.flush()
+}
+
+func (hl *HTTPLogger) loop(interval time.Duration) {
+	defer close(hl.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-hl.flushChan:
+		case <-ticker.C:
+		case <-hl.closeChan:
+			return
+		}
+
+		if err := hl.flush(); err != nil {
+			log.Warningf("failed to ship the log of job %s: %v", hl.labels[sink.LabelJobID], err)
+		}
+	}
+}
+
+// flush ships the pending log lines, they're kept for the next round if failed
+func (hl *HTTPLogger) flush() error {
+	hl.lock.Lock()
+	batch := hl.pending
+	hl.pending = nil
+	hl.lock.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	if err := hl.sink.Push(context.Background(), hl.labels, batch); err != nil {
+		hl.lock.Lock()
+		hl.pending = append(batch, hl.pending...)
+		if len(hl.pending) > maxHTTPPendingEntries {
+			hl.pending = hl.pending[len(hl.pending)-maxHTTPPendingEntries:]
+		}
+		hl.lock.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// Debug ...
+func (hl *HTTPLogger) Debug(v ...any) {
+	hl.backendLogger.Debug(v...)
+}
+
+// Debugf with format
+func (hl *HTTPLogger) Debugf(format string, v ...any) {
+	hl.backendLogger.Debugf(format, v...)
+}
+
+// Info ...
+func (hl *HTTPLogger) Info(v ...any) {
+	hl.backendLogger.Info(v...)
+}
+
+// Infof for logging info with format
+func (hl *HTTPLogger) Infof(format string, v ...any) {
+	hl.backendLogger.Infof(format, v...)
+}
+
+// Warning ...
+func (hl *HTTPLogger) Warning(v ...any) {
+	hl.backendLogger.Warning(v...)
+}
+
+// Warningf for warning with format
+func (hl *HTTPLogger) Warningf(format string, v ...any) {
+	hl.backendLogger.Warningf(format, v...)
+}
+
+// Error for logging error
+func (hl *HTTPLogger) Error(v ...any) {
+	hl.backendLogger.Error(v...)
+}
+
+// Errorf for logging error with format
+func (hl *HTTPLogger) Errorf(format string, v ...any) {
+	hl.backendLogger.Errorf(format, v...)
+}
+
+// Fatal error
+func (hl *HTTPLogger) Fatal(v ...any) {
+	hl.backendLogger.Fatal(v...)
+}
+
+// Fatalf error
+func (hl *HTTPLogger) Fatalf(format string, v ...any) {
+	hl.backendLogger.Fatalf(format, v...)
+}
@@ -0,0 +1,128 @@
+package backend
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/jobservice/logger/sink"
+)
+
+// fakeSink keeps the pushed log entries in memory
+type fakeSink struct {
+	lock    sync.Mutex
+	labels  map[string]string
+	entries []*sink.Entry
+	pushes  int
+	failing bool
+}
+
+func (f *fakeSink) Push(_ context.Context, labels map[string]string, entries []*sink.Entry) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.failing {
+		return http.ErrServerClosed
+	}
+	f.pushes++
+	f.labels = labels
+	f.entries = append(f.entries, entries...)
+	return nil
+}
+
+func (f *fakeSink) Fetch(_ context.Context, _ string, _ *sink.Entry) ([]*sink.Entry, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.entries, nil
+}
+
+// Test HTTP logger
+func TestHTTPLogger(t *testing.T) {
+	s := &fakeSink{}
+	labels := map[string]string{sink.LabelJobID: "fake_job_ID", sink.LabelVendorType: "REPLICATION"}
+	l := NewHTTPLogger(s, labels, "DEBUG", 4, 2, time.Hour)
+
+	l.Debug("TestHTTPLogger")
+	l.Infof("%s", "TestHTTPLogger")
+	// the batch size is reached, the lines are shipped in the background
+	assert.Eventually(t, func() bool {
+		entries, _ := s.Fetch(context.TODO(), "fake_job_ID", nil)
+		return len(entries) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	l.Warning("TestHTTPLogger")
+	l.Errorf("%s", "TestHTTPLogger")
+	l.Error("TestHTTPLogger")
+	require.Nil(t, l.Close())
+
+	entries, _ := s.Fetch(context.TODO(), "fake_job_ID", nil)
+	require.Len(t, entries, 5)
+	assert.Equal(t, labels, s.labels)
+	for i, e := range entries {
+		assert.Equal(t, int64(i+1), e.Seq)
+		assert.Contains(t, e.Line, "TestHTTPLogger")
+		assert.False(t, strings.HasSuffix(e.Line, "\n"))
+	}
+	assert.Contains(t, entries[0].Line, "[DEBUG]")
+}
+
+// Test the log lines are kept when the log store is unavailable
+func TestHTTPLoggerRetry(t *testing.T) {
+	s := &fakeSink{failing: true}
+	l := NewHTTPLogger(s, map[string]string{sink.LabelJobID: "fake_job_ID"}, "INFO", 4, 100, time.Hour)
+
+	l.Info("first")
+	require.NotNil(t, l.flush())
+
+	s.lock.Lock()
+	s.failing = false
+	s.lock.Unlock()
+
+	l.Info("second")
+	require.Nil(t, l.Close())
+
+	entries, _ := s.Fetch(context.TODO(), "fake_job_ID", nil)
+	require.Len(t, entries, 2)
+	assert.Contains(t, entries[0].Line, "first")
+	assert.Contains(t, entries[1].Line, "second")
+	assert.Equal(t, 1, s.pushes)
+}
+
+// Test HTTP logger shipping the log to the Loki push API
+func TestHTTPLoggerLoki(t *testing.T) {
+	var (
+		lock   sync.Mutex
+		bodies []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		lock.Lock()
+		bodies = append(bodies, r.Method+" "+r.URL.Path+" "+string(body))
+		lock.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	s, err := sink.New(&sink.Options{Type: sink.TypeLoki, Endpoint: server.URL})
+	require.Nil(t, err)
+
+	l := NewHTTPLogger(s, map[string]string{sink.LabelJobID: "fake_job_ID", sink.LabelProject: "library"}, "INFO", 4, 0, 0)
+	l.Info("TestHTTPLoggerLoki")
+	require.Nil(t, l.Close())
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Len(t, bodies, 1)
+	assert.Contains(t, bodies[0], "POST /loki/api/v1/push")
+	assert.Contains(t, bodies[0], `"project":"library"`)
+	assert.Contains(t, bodies[0], "TestHTTPLoggerLoki")
+}
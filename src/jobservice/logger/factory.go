@@ -17,8 +17,10 @@ package logger
 import (
 	"errors"
 	"path"
+	"time"
 
 	"github.com/goharbor/harbor/src/jobservice/logger/backend"
+	"github.com/goharbor/harbor/src/jobservice/logger/sink"
 )
 
 // Factory creates a new logger based on the settings.
@@ -100,3 +102,54 @@ func DBFactory(options ...OptionItem) (Interface, error) {
 
 	return backend.NewDBLogger(key, level, depth)
 }
+
+// HTTPFactory is factory of the HTTP logger shipping the log to a remote log store
+func HTTPFactory(options ...OptionItem) (Interface, error) {
+	var (
+		level, key                      string
+		depth, batchSize, flushInterval int
+		sinkOptions                     = &sink.Options{}
+		labels                          = map[string]string{}
+	)
+	for _, op := range options {
+		switch op.Field() {
+		case "level":
+			level = op.String()
+		case "key":
+			key = op.String()
+		case "depth":
+			depth = op.Int()
+		case "batch_size":
+			batchSize = op.Int()
+		case "flush_interval":
+			flushInterval = op.Int()
+		case "type":
+			sinkOptions.Type = op.String()
+		case "endpoint":
+			sinkOptions.Endpoint = op.String()
+		case "index":
+			sinkOptions.Index = op.String()
+		case "username":
+			sinkOptions.Username = op.String()
+		case "password":
+			sinkOptions.Password = op.String()
+		case sink.LabelVendorType, sink.LabelExecutionID, sink.LabelProject:
+			if v := op.String(); len(v) > 0 {
+				labels[op.Field()] = v
+			}
+		default:
+		}
+	}
+
+	if len(key) == 0 {
+		return nil, errors.New("missing key option of the http logger")
+	}
+	labels[sink.LabelJobID] = key
+
+	s, err := sink.New(sinkOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return backend.NewHTTPLogger(s, labels, level, depth, batchSize, time.Duration(flushInterval)*time.Second), nil
+}
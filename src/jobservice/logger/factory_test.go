@@ -73,3 +73,42 @@ func TestDBFactoryErr1(t *testing.T) {
 	_, err := DBFactory(ois...)
 	require.NotNil(t, err)
 }
+
+// TestHTTPFactory
+func TestHTTPFactory(t *testing.T) {
+	ois := make([]OptionItem, 0)
+	ois = append(ois, OptionItem{"level", "DEBUG"})
+	ois = append(ois, OptionItem{"key", "key_http_logger_unit_test"})
+	ois = append(ois, OptionItem{"depth", 5})
+	ois = append(ois, OptionItem{"type", "loki"})
+	ois = append(ois, OptionItem{"endpoint", "http://127.0.0.1:3100"})
+	ois = append(ois, OptionItem{"vendor_type", "REPLICATION"})
+	ois = append(ois, OptionItem{"batch_size", 10})
+	ois = append(ois, OptionItem{"flush_interval", 1})
+
+	l, err := HTTPFactory(ois...)
+	require.Nil(t, err)
+	require.Equal(t, NameHTTP, GetLoggerName(l))
+}
+
+// TestHTTPFactoryErr1
+func TestHTTPFactoryErr1(t *testing.T) {
+	ois := make([]OptionItem, 0)
+	ois = append(ois, OptionItem{"level", "DEBUG"})
+	ois = append(ois, OptionItem{"type", "loki"})
+	ois = append(ois, OptionItem{"endpoint", "http://127.0.0.1:3100"})
+
+	_, err := HTTPFactory(ois...)
+	require.NotNil(t, err)
+}
+
+// TestHTTPFactoryErr2
+func TestHTTPFactoryErr2(t *testing.T) {
+	ois := make([]OptionItem, 0)
+	ois = append(ois, OptionItem{"level", "DEBUG"})
+	ois = append(ois, OptionItem{"key", "key_http_logger_unit_test"})
+	ois = append(ois, OptionItem{"type", "unknown"})
+
+	_, err := HTTPFactory(ois...)
+	require.NotNil(t, err)
+}
@@ -24,3 +24,36 @@ type Interface interface {
 	// otherwise, a non nil error is returned
 	Retrieve(logID string) ([]byte, error)
 }
+
+// Tailer is implemented by the getters supporting reading the log data incrementally,
+// which is used to follow the log of the running jobs
+type Tailer interface {
+	// Tail reads the log data appended after the offset
+	//
+	// logID string : the id of the log entry
+	// offset int64 : the position to read from, the tail of the log within the size limit is read if it's 0
+	//
+	// If succeed, the log data and the offset of its end will be returned
+	// otherwise, a non nil error is returned
+	Tail(logID string, offset int64) ([]byte, int64, error)
+}
+
+// maxTailChunkSize is the max size of the log data returned by one Tail call
+const maxTailChunkSize int64 = 1024 * 1024
+
+// tailRange returns the range of the log data with the size to read after the offset
+func tailRange(size, offset int64) (int64, int64) {
+	if offset <= 0 {
+		// Start from the tail within the size limit as Retrieve does
+		offset = 0
+		if limit := logSizeLimit(); limit > 0 && size > limit {
+			offset = size - limit
+		}
+	}
+	if offset > size {
+		// The log has been truncated or replaced, nothing can be read after the offset
+		return size, size
+	}
+
+	return offset, min(size, offset+maxTailChunkSize)
+}
@@ -56,3 +56,18 @@ func (dbg *DBGetter) Retrieve(logID string) ([]byte, error) {
 	}
 	return buf, nil
 }
+
+// Tail implements @Tailer.Tail
+func (dbg *DBGetter) Tail(logID string, offset int64) ([]byte, int64, error) {
+	if len(logID) == 0 {
+		return nil, 0, errors.New("empty log identify")
+	}
+
+	jobLog, err := joblog.Mgr.Get(orm.Context(), logID)
+	if err != nil {
+		return nil, 0, errs.NoObjectFoundError(fmt.Sprintf("log entity: %s", logID))
+	}
+
+	from, to := tailRange(int64(len(jobLog.Content)), offset)
+	return []byte(jobLog.Content[from:to]), to, nil
+}
@@ -18,6 +18,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"strings"
@@ -58,6 +59,39 @@ func (fg *FileGetter) Retrieve(logID string) ([]byte, error) {
 	return tailLogFile(fPath, logSizeLimit())
 }
 
+// Tail implements @Tailer.Tail
+func (fg *FileGetter) Tail(logID string, offset int64) ([]byte, int64, error) {
+	if err := isValidLogID(logID); err != nil {
+		return nil, 0, err
+	}
+
+	fPath := path.Join(fg.baseDir, fmt.Sprintf("%s.log", logID))
+
+	if !utils.FileExists(fPath) {
+		return nil, 0, errs.NoObjectFoundError(logID)
+	}
+
+	fi, err := os.Open(fPath)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer fi.Close()
+
+	fInfo, err := fi.Stat()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	from, to := tailRange(fInfo.Size(), offset)
+	buf := make([]byte, to-from)
+	n, err := fi.ReadAt(buf, from)
+	if err != nil && err != io.EOF {
+		return nil, 0, err
+	}
+
+	return buf[:n], from + int64(n), nil
+}
+
 func isValidLogID(id string) error {
 	lid := id
 	segment := strings.LastIndex(lid, "@")
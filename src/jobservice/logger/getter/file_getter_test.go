@@ -45,6 +45,57 @@ func TestLogDataGetter(t *testing.T) {
 	}
 }
 
+// Test tailing the log data
+func TestLogDataTail(t *testing.T) {
+	fakeLog := path.Join(os.TempDir(), newLogFileName)
+	if err := os.WriteFile(fakeLog, []byte("hello"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.Remove(fakeLog); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	fg := NewFileGetter(os.TempDir())
+	if _, _, err := fg.Tail(nonExistFileID, 0); !errs.IsObjectNotFoundError(err) {
+		t.Errorf("expect object not found error but got %v", err)
+	}
+
+	data, offset, err := fg.Tail(newLogFileID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "hello" || offset != 5 {
+		t.Errorf("expect reading 'hello' till offset 5 but got '%s' till offset %d", data, offset)
+	}
+
+	f, err := os.OpenFile(fakeLog, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.WriteString(" world"); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+
+	data, offset, err = fg.Tail(newLogFileID, offset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != " world" || offset != 11 {
+		t.Errorf("expect reading ' world' till offset 11 but got '%s' till offset %d", data, offset)
+	}
+
+	data, offset, err = fg.Tail(newLogFileID, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 0 || offset != 11 {
+		t.Errorf("expect reading nothing till offset 11 but got '%s' till offset %d", data, offset)
+	}
+}
+
 func Test_tailLogFile(t *testing.T) {
 	type args struct {
 		filename string
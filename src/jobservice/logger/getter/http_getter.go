@@ -0,0 +1,169 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package getter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/errs"
+	"github.com/goharbor/harbor/src/jobservice/logger/sink"
+)
+
+// tailCursorTTL is how long the position of a followed log is kept after it's read last time
+const tailCursorTTL = 10 * time.Minute
+
+// HTTPGetter is responsible for retrieving the log data shipped to a remote log store
+type HTTPGetter struct {
+	sink sink.Interface
+
+	lock sync.Mutex
+	// cursors are the positions of the followed logs keyed by the log ID,
+	// so the following Tail calls only fetch the lines shipped after the position
+	cursors map[string]*tailCursor
+}
+
+// tailCursor is where the last Tail call of a log stopped
+type tailCursor struct {
+	// offset is the end of the log data returned by the last Tail call
+	offset int64
+	// last is the last log entry fetched
+	last *sink.Entry
+	// pending is the log data fetched but not returned yet as it's over the chunk size
+	pending  string
+	accessed time.Time
+}
+
+// NewHTTPGetter is constructor of HTTPGetter
+func NewHTTPGetter(s sink.Interface) *HTTPGetter {
+	return &HTTPGetter{
+		sink:    s,
+		cursors: make(map[string]*tailCursor),
+	}
+}
+
+// Retrieve implements @Interface.Retrieve
+func (hg *HTTPGetter) Retrieve(logID string) ([]byte, error) {
+	entries, err := hg.fetch(logID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errs.NoObjectFoundError(fmt.Sprintf("log entity: %s", logID))
+	}
+
+	content := join(entries)
+	sz := int64(len(content))
+	sizeLimit := logSizeLimit()
+	if sizeLimit > 0 && sz > sizeLimit {
+		content = content[sz-sizeLimit:]
+	}
+
+	return []byte(content), nil
+}
+
+// Tail implements @Tailer.Tail
+func (hg *HTTPGetter) Tail(logID string, offset int64) ([]byte, int64, error) {
+	cursor := hg.cursor(logID, offset)
+	if cursor == nil {
+		// Read the whole log when it's not followed yet or the offset is not where the last call stopped
+		entries, err := hg.fetch(logID, nil)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(entries) == 0 {
+			return nil, 0, errs.NoObjectFoundError(fmt.Sprintf("log entity: %s", logID))
+		}
+
+		content := join(entries)
+		from, to := tailRange(int64(len(content)), offset)
+		hg.save(logID, &tailCursor{
+			offset:  to,
+			last:    entries[len(entries)-1],
+			pending: content[to:],
+		})
+		return []byte(content[from:to]), to, nil
+	}
+
+	entries, err := hg.fetch(logID, cursor.last)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	content := cursor.pending + join(entries)
+	n := min(int64(len(content)), maxTailChunkSize)
+	next := &tailCursor{
+		offset:  offset + n,
+		last:    cursor.last,
+		pending: content[n:],
+	}
+	if len(entries) > 0 {
+		next.last = entries[len(entries)-1]
+	}
+	hg.save(logID, next)
+
+	return []byte(content[:n]), next.offset, nil
+}
+
+// cursor returns the position of the log if the last Tail call stopped at the offset
+func (hg *HTTPGetter) cursor(logID string, offset int64) *tailCursor {
+	hg.lock.Lock()
+	defer hg.lock.Unlock()
+
+	c, ok := hg.cursors[logID]
+	if !ok || offset <= 0 || c.offset != offset {
+		return nil
+	}
+	return c
+}
+
+// save keeps the position of the log and drops the ones not followed anymore
+func (hg *HTTPGetter) save(logID string, c *tailCursor) {
+	hg.lock.Lock()
+	defer hg.lock.Unlock()
+
+	now := time.Now()
+	for id, cur := range hg.cursors {
+		if now.Sub(cur.accessed) > tailCursorTTL {
+			delete(hg.cursors, id)
+		}
+	}
+	c.accessed = now
+	hg.cursors[logID] = c
+}
+
+// fetch reads the log lines of the job after the given entry
+func (hg *HTTPGetter) fetch(logID string, after *sink.Entry) ([]*sink.Entry, error) {
+	if len(logID) == 0 {
+		return nil, errors.New("empty log identify")
+	}
+
+	return hg.sink.Fetch(context.Background(), logID, after)
+}
+
+// join joins the log lines as the log content
+func join(entries []*sink.Entry) string {
+	var b strings.Builder
+	for _, e := range entries {
+		b.WriteString(e.Line)
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
@@ -0,0 +1,88 @@
+package getter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/errs"
+	"github.com/goharbor/harbor/src/jobservice/logger/sink"
+)
+
+// fakeSink keeps the log entries in memory
+type fakeSink struct {
+	entries map[string][]*sink.Entry
+}
+
+func (f *fakeSink) Push(_ context.Context, labels map[string]string, entries []*sink.Entry) error {
+	jobID := labels[sink.LabelJobID]
+	f.entries[jobID] = append(f.entries[jobID], entries...)
+	return nil
+}
+
+func (f *fakeSink) Fetch(_ context.Context, jobID string, after *sink.Entry) ([]*sink.Entry, error) {
+	entries := make([]*sink.Entry, 0)
+	for _, e := range f.entries[jobID] {
+		if e.After(after) {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// TestHTTPGetter tests retrieving the log data from the log store
+func TestHTTPGetter(t *testing.T) {
+	s := &fakeSink{entries: map[string][]*sink.Entry{}}
+	hg := NewHTTPGetter(s)
+
+	if _, err := hg.Retrieve(nonExistFileID); !errs.IsObjectNotFoundError(err) {
+		t.Errorf("expect object not found error but got %v", err)
+	}
+
+	labels := map[string]string{sink.LabelJobID: newLogFileID}
+	if err := s.Push(context.TODO(), labels, []*sink.Entry{
+		{Time: time.Now(), Seq: 1, Line: "hello"},
+		{Time: time.Now(), Seq: 2, Line: "world"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := hg.Retrieve(newLogFileID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "hello\nworld\n" {
+		t.Errorf("expect reading 'hello\\nworld\\n' but got '%s'", data)
+	}
+
+	data, offset, err := hg.Tail(newLogFileID, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "world\n" || offset != 12 {
+		t.Errorf("expect reading 'world\\n' till offset 12 but got '%s' till offset %d", data, offset)
+	}
+
+	// Only the lines after the last tailed one are fetched
+	if err := s.Push(context.TODO(), labels, []*sink.Entry{{Time: time.Now(), Seq: 3, Line: "again"}}); err != nil {
+		t.Fatal(err)
+	}
+	data, offset, err = hg.Tail(newLogFileID, offset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "again\n" || offset != 18 {
+		t.Errorf("expect reading 'again\\n' till offset 18 but got '%s' till offset %d", data, offset)
+	}
+	data, offset, err = hg.Tail(newLogFileID, offset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 0 || offset != 18 {
+		t.Errorf("expect reading nothing till offset 18 but got '%s' till offset %d", data, offset)
+	}
+
+	if _, _, err := hg.Tail(nonExistFileID, 0); !errs.IsObjectNotFoundError(err) {
+		t.Errorf("expect object not found error but got %v", err)
+	}
+}
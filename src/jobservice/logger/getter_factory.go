@@ -18,6 +18,7 @@ import (
 	"errors"
 
 	"github.com/goharbor/harbor/src/jobservice/logger/getter"
+	"github.com/goharbor/harbor/src/jobservice/logger/sink"
 )
 
 // GetterFactory is responsible for creating a log data getter based on the options
@@ -44,3 +45,30 @@ func FileGetterFactory(options ...OptionItem) (getter.Interface, error) {
 func DBGetterFactory(_ ...OptionItem) (getter.Interface, error) {
 	return getter.NewDBGetter(), nil
 }
+
+// HTTPGetterFactory creates a getter for the HTTP logger
+func HTTPGetterFactory(options ...OptionItem) (getter.Interface, error) {
+	sinkOptions := &sink.Options{}
+	for _, op := range options {
+		switch op.Field() {
+		case "type":
+			sinkOptions.Type = op.String()
+		case "endpoint":
+			sinkOptions.Endpoint = op.String()
+		case "index":
+			sinkOptions.Index = op.String()
+		case "username":
+			sinkOptions.Username = op.String()
+		case "password":
+			sinkOptions.Password = op.String()
+		default:
+		}
+	}
+
+	s, err := sink.New(sinkOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return getter.NewHTTPGetter(s), nil
+}
@@ -33,3 +33,22 @@ func TestDBGetterFactory(t *testing.T) {
 	_, err := DBGetterFactory(ois...)
 	require.Nil(t, err)
 }
+
+// TestHTTPGetterFactory
+func TestHTTPGetterFactory(t *testing.T) {
+	ois := make([]OptionItem, 0)
+	ois = append(ois, OptionItem{"type", "elasticsearch"})
+	ois = append(ois, OptionItem{"endpoint", "http://127.0.0.1:9200"})
+
+	_, err := HTTPGetterFactory(ois...)
+	require.Nil(t, err)
+}
+
+// TestHTTPGetterFactoryErr1
+func TestHTTPGetterFactoryErr1(t *testing.T) {
+	ois := make([]OptionItem, 0)
+	ois = append(ois, OptionItem{"type", "elasticsearch"})
+
+	_, err := HTTPGetterFactory(ois...)
+	require.NotNil(t, err)
+}
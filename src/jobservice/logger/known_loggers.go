@@ -29,6 +29,8 @@ const (
 	NameStdOutput = "STD_OUTPUT"
 	// NameDB is the unique name of the DB logger.
 	NameDB = "DB"
+	// NameHTTP is the unique name of the HTTP logger shipping the log to a remote log store.
+	NameHTTP = "HTTP"
 )
 
 // Declaration is used to declare a supported logger.
@@ -52,6 +54,8 @@ var knownLoggers = map[string]*Declaration{
 	NameStdOutput: {StdFactory, nil, nil, true},
 	// DB logger
 	NameDB: {DBFactory, DBSweeperFactory, DBGetterFactory, false},
+	// HTTP logger, the retention of the log is managed by the log store
+	NameHTTP: {HTTPFactory, nil, HTTPGetterFactory, false},
 }
 
 // IsKnownLogger checks if the logger is supported with name.
@@ -107,6 +111,8 @@ func GetLoggerName(l Interface) string {
 		name = NameStdOutput
 	case *backend.FileLogger:
 		name = NameFile
+	case *backend.HTTPLogger:
+		name = NameHTTP
 	default:
 		name = reflect.TypeOf(l).String()
 	}
@@ -29,3 +29,18 @@ func Retrieve(logID string) ([]byte, error) {
 
 	return val.(getter.Interface).Retrieve(logID)
 }
+
+// Tail is wrapper func for getter.Tailer.Tail
+func Tail(logID string, offset int64) ([]byte, int64, error) {
+	val, ok := singletons.Load(systemKeyLogDataGetter)
+	if !ok {
+		return nil, 0, errors.New("no log data getter is configured")
+	}
+
+	tailer, ok := val.(getter.Tailer)
+	if !ok {
+		return nil, 0, errors.New("the configured log data getter does not support tailing")
+	}
+
+	return tailer.Tail(logID, offset)
+}
@@ -0,0 +1,175 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultIndex = "harbor-job-log"
+	// esPageSize is the number of the log lines read by one search
+	esPageSize = 1000
+
+	esFieldTimestamp = "@timestamp"
+	esFieldSeq       = "seq"
+	esFieldMessage   = "message"
+)
+
+// elasticsearch indexes each log line as a document carrying the labels as fields
+type elasticsearch struct {
+	*client
+	index string
+}
+
+type esBulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+type esSearchResponse struct {
+	Hits struct {
+		Hits []struct {
+			Source map[string]any `json:"_source"`
+			Sort   []any          `json:"sort"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// Push implements @Interface.Push
+func (es *elasticsearch) Push(ctx context.Context, labels map[string]string, entries []*Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	action, err := json.Marshal(map[string]any{"index": map[string]string{"_index": es.index}})
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	for _, e := range entries {
+		doc := make(map[string]any, len(labels)+3)
+		for k, v := range labels {
+			doc[k] = v
+		}
+		doc[esFieldTimestamp] = e.Time.UTC().Format(time.RFC3339Nano)
+		doc[esFieldSeq] = e.Seq
+		doc[esFieldMessage] = e.Line
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		body.Write(action)
+		body.WriteByte('\n')
+		body.Write(data)
+		body.WriteByte('\n')
+	}
+
+	resp := &esBulkResponse{}
+	if err := es.do(ctx, http.MethodPost, "/_bulk", "application/x-ndjson", body.Bytes(), resp); err != nil {
+		return err
+	}
+	if resp.Errors {
+		for _, item := range resp.Items {
+			for _, result := range item {
+				if result.Error != nil {
+					return fmt.Errorf("failed to index the log line: %s: %s", result.Error.Type, result.Error.Reason)
+				}
+			}
+		}
+		return fmt.Errorf("failed to index the log lines")
+	}
+
+	return nil
+}
+
+// Fetch implements @Interface.Fetch
+func (es *elasticsearch) Fetch(ctx context.Context, jobID string, after *Entry) ([]*Entry, error) {
+	match := map[string]any{
+		// The job ID is matched exactly no matter it's mapped as a keyword or as a text with a keyword sub-field
+		"should": []any{
+			map[string]any{"term": map[string]any{LabelJobID: jobID}},
+			map[string]any{"term": map[string]any{LabelJobID + ".keyword": jobID}},
+		},
+		"minimum_should_match": 1,
+	}
+	if after != nil {
+		// The timestamp is indexed in milliseconds, the lines in the same millisecond are read again and skipped
+		match["filter"] = []any{
+			map[string]any{"range": map[string]any{esFieldTimestamp: map[string]string{
+				"gte": after.Time.UTC().Truncate(time.Millisecond).Format(time.RFC3339Nano),
+			}}},
+		}
+	}
+	query := map[string]any{
+		"size":  esPageSize,
+		"query": map[string]any{"bool": match},
+		"sort": []any{
+			map[string]string{esFieldTimestamp: "asc"},
+			map[string]string{esFieldSeq: "asc"},
+		},
+	}
+
+	entries := make([]*Entry, 0)
+	for {
+		body, err := json.Marshal(query)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &esSearchResponse{}
+		if err := es.do(ctx, http.MethodPost, fmt.Sprintf("/%s/_search", url.PathEscape(es.index)), "application/json", body, resp); err != nil {
+			if err == errNotFound {
+				return entries, nil
+			}
+			return nil, err
+		}
+
+		for _, hit := range resp.Hits.Hits {
+			e := &Entry{}
+			if v, ok := hit.Source[esFieldTimestamp].(string); ok {
+				if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+					e.Time = t
+				}
+			}
+			if v, ok := hit.Source[esFieldSeq].(float64); ok {
+				e.Seq = int64(v)
+			}
+			e.Line, _ = hit.Source[esFieldMessage].(string)
+			if e.After(after) {
+				entries = append(entries, e)
+			}
+		}
+
+		if len(resp.Hits.Hits) < esPageSize {
+			return entries, nil
+		}
+		// Continue after the last returned line
+		query["search_after"] = resp.Hits.Hits[len(resp.Hits.Hits)-1].Sort
+	}
+}
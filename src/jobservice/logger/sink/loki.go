@@ -0,0 +1,228 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	lokiPushPath  = "/loki/api/v1/push"
+	lokiQueryPath = "/loki/api/v1/query_range"
+	// lokiQueryLimit is the max entries returned by one query, which is the default limit of Loki
+	lokiQueryLimit = 5000
+	// lokiQueryRange is how long ago the log of a job is searched from,
+	// it's within the default max query length of Loki
+	lokiQueryRange = 30 * 24 * time.Hour
+
+	// lokiLabelService is the static label selecting all the job log streams
+	lokiLabelService = "service_name"
+	lokiService      = "harbor-jobservice"
+	// lokiMetadataSeq is the structured metadata carrying the sequence number of the line
+	lokiMetadataSeq = "seq"
+)
+
+// lokiStreamLabels are the labels kept as the stream labels, they're bounded so the number of
+// the streams doesn't grow with the jobs. The others, e.g. the job ID, are sent as the structured metadata.
+var lokiStreamLabels = map[string]bool{
+	LabelVendorType: true,
+	LabelProject:    true,
+}
+
+// loki pushes the logs to the Loki push API, the streams are labeled by the job type and
+// the job ID of each line is attached as the structured metadata
+type loki struct {
+	*client
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values []*lokiValue      `json:"values"`
+}
+
+// lokiValue is the timestamp in nanoseconds, the log line and the structured metadata of the line
+type lokiValue struct {
+	Timestamp string
+	Line      string
+	Metadata  map[string]string
+}
+
+// MarshalJSON encodes the value as the array accepted by the push API
+func (v *lokiValue) MarshalJSON() ([]byte, error) {
+	if len(v.Metadata) == 0 {
+		return json.Marshal([]any{v.Timestamp, v.Line})
+	}
+	return json.Marshal([]any{v.Timestamp, v.Line, v.Metadata})
+}
+
+// UnmarshalJSON decodes the value returned by the query API, the structured metadata is either
+// a flat object or categorized under "structuredMetadata" per the response encoding flags
+func (v *lokiValue) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) < 2 {
+		return fmt.Errorf("invalid log value: %s", string(data))
+	}
+	if err := json.Unmarshal(raw[0], &v.Timestamp); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw[1], &v.Line); err != nil {
+		return err
+	}
+	if len(raw) < 3 {
+		return nil
+	}
+
+	categorized := struct {
+		StructuredMetadata map[string]string `json:"structuredMetadata"`
+	}{}
+	if err := json.Unmarshal(raw[2], &categorized); err == nil && categorized.StructuredMetadata != nil {
+		v.Metadata = categorized.StructuredMetadata
+		return nil
+	}
+	return json.Unmarshal(raw[2], &v.Metadata)
+}
+
+type lokiPushRequest struct {
+	Streams []*lokiStream `json:"streams"`
+}
+
+type lokiQueryResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Result []*lokiStream `json:"result"`
+	} `json:"data"`
+}
+
+// Push implements @Interface.Push
+func (l *loki) Push(ctx context.Context, labels map[string]string, entries []*Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	stream := &lokiStream{
+		Stream: map[string]string{lokiLabelService: lokiService},
+		Values: make([]*lokiValue, 0, len(entries)),
+	}
+	metadata := make(map[string]string)
+	for k, v := range labels {
+		if lokiStreamLabels[k] {
+			stream.Stream[k] = v
+		} else {
+			metadata[k] = v
+		}
+	}
+	for _, e := range entries {
+		md := make(map[string]string, len(metadata)+1)
+		for k, v := range metadata {
+			md[k] = v
+		}
+		md[lokiMetadataSeq] = strconv.FormatInt(e.Seq, 10)
+		stream.Values = append(stream.Values, &lokiValue{
+			Timestamp: strconv.FormatInt(e.Time.UnixNano(), 10),
+			Line:      e.Line,
+			Metadata:  md,
+		})
+	}
+	body, err := json.Marshal(&lokiPushRequest{Streams: []*lokiStream{stream}})
+	if err != nil {
+		return err
+	}
+
+	return l.do(ctx, http.MethodPost, lokiPushPath, "application/json", body, nil)
+}
+
+// Fetch implements @Interface.Fetch
+func (l *loki) Fetch(ctx context.Context, jobID string, after *Entry) ([]*Entry, error) {
+	query := fmt.Sprintf("{%s=%s} | %s=%s", lokiLabelService, strconv.Quote(lokiService), LabelJobID, strconv.Quote(jobID))
+	end := time.Now()
+	start := end.Add(-lokiQueryRange)
+	if after != nil {
+		// The lines at the same timestamp as the given one are read again and skipped by the sequence number
+		start = after.Time
+	}
+
+	entries := make([]*Entry, 0)
+	for {
+		params := url.Values{}
+		params.Set("query", query)
+		params.Set("start", strconv.FormatInt(start.UnixNano(), 10))
+		params.Set("end", strconv.FormatInt(end.UnixNano(), 10))
+		params.Set("limit", strconv.Itoa(lokiQueryLimit))
+		params.Set("direction", "forward")
+
+		resp := &lokiQueryResponse{}
+		if err := l.do(ctx, http.MethodGet, lokiQueryPath+"?"+params.Encode(), "", nil, resp); err != nil {
+			if err == errNotFound {
+				return entries, nil
+			}
+			return nil, err
+		}
+
+		batch := make([]*Entry, 0)
+		for _, s := range resp.Data.Result {
+			for _, v := range s.Values {
+				ns, err := strconv.ParseInt(v.Timestamp, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid timestamp %s of the log line: %v", v.Timestamp, err)
+				}
+				e := &Entry{Time: time.Unix(0, ns), Line: v.Line}
+				// The structured metadata is returned as the stream labels unless it's categorized
+				seq, ok := v.Metadata[lokiMetadataSeq]
+				if !ok {
+					seq = s.Stream[lokiMetadataSeq]
+				}
+				if len(seq) > 0 {
+					if e.Seq, err = strconv.ParseInt(seq, 10, 64); err != nil {
+						return nil, fmt.Errorf("invalid sequence number %s of the log line: %v", seq, err)
+					}
+				}
+				batch = append(batch, e)
+			}
+		}
+		// The lines of the different streams are returned separately
+		sort.SliceStable(batch, func(i, j int) bool {
+			return batch[j].After(batch[i])
+		})
+
+		last := after
+		for _, e := range batch {
+			if e.After(last) {
+				entries = append(entries, e)
+				last = e
+			}
+		}
+
+		if len(batch) < lokiQueryLimit {
+			return entries, nil
+		}
+		if last == after {
+			// The whole page is taken by the lines at the same timestamp, no way to move on
+			return nil, fmt.Errorf("more than %d log lines of the job %s at %s", lokiQueryLimit, jobID, start.Format(time.RFC3339Nano))
+		}
+		// Continue from the timestamp of the last returned line, the lines after it at the same timestamp are kept
+		start = last.Time
+		after = last
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sink ships the job logs to the remote log stores over HTTP and reads them back.
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	commonhttp "github.com/goharbor/harbor/src/common/http"
+)
+
+const (
+	// TypeLoki is the type of the sink pushing the logs to the Loki push API
+	TypeLoki = "loki"
+	// TypeElasticsearch is the type of the sink indexing the logs with the Elasticsearch bulk API
+	TypeElasticsearch = "elasticsearch"
+
+	// LabelJobID is the label of the job ID
+	LabelJobID = "job_id"
+	// LabelVendorType is the label of the job type, e.g. REPLICATION
+	LabelVendorType = "vendor_type"
+	// LabelExecutionID is the label of the execution the job is submitted for
+	LabelExecutionID = "execution_id"
+	// LabelProject is the label of the project the job works on
+	LabelProject = "project"
+
+	defaultTimeout = 30 * time.Second
+	// maxErrorBodySize is the max size of the response body kept in the error message
+	maxErrorBodySize = 1024
+)
+
+// errNotFound is returned when the log store responds 404, e.g. the index is not created yet
+var errNotFound = errors.New("not found")
+
+// Entry is a line of the job log
+type Entry struct {
+	Time time.Time
+	// Seq is the sequence number of the line in the log, it keeps the order of the lines with the same timestamp
+	Seq  int64
+	Line string
+}
+
+// After checks whether the entry is after the given one in the log, it's always true if the given one is nil
+func (e *Entry) After(o *Entry) bool {
+	if o == nil {
+		return true
+	}
+	if !e.Time.Equal(o.Time) {
+		return e.Time.After(o.Time)
+	}
+	return e.Seq > o.Seq
+}
+
+// Interface defines the operations of a remote log store
+type Interface interface {
+	// Push ships the log entries with the labels, the job ID label is required
+	Push(ctx context.Context, labels map[string]string, entries []*Entry) error
+	// Fetch reads the log entries of the job back in order,
+	// only the ones after the given entry are read if it's not nil
+	Fetch(ctx context.Context, jobID string, after *Entry) ([]*Entry, error)
+}
+
+// Options of the sink
+type Options struct {
+	// Type of the sink, loki or elasticsearch
+	Type string
+	// Endpoint is the base URL of the log store, e.g. http://loki:3100
+	Endpoint string
+	// Index is the Elasticsearch index the logs are written into
+	Index string
+	// Username and Password for the basic authentication if set
+	Username string
+	Password string
+	// Timeout of each request
+	Timeout time.Duration
+}
+
+// New creates a sink per the options
+func New(opts *Options) (Interface, error) {
+	if opts == nil || len(opts.Endpoint) == 0 {
+		return nil, fmt.Errorf("missing endpoint of the log sink")
+	}
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	c := &client{
+		endpoint: strings.TrimRight(opts.Endpoint, "/"),
+		username: opts.Username,
+		password: opts.Password,
+		client: &http.Client{
+			Transport: commonhttp.GetHTTPTransport(),
+			Timeout:   timeout,
+		},
+	}
+
+	switch strings.ToLower(opts.Type) {
+	case TypeLoki:
+		return &loki{client: c}, nil
+	case TypeElasticsearch:
+		index := opts.Index
+		if len(index) == 0 {
+			index = defaultIndex
+		}
+		return &elasticsearch{client: c, index: index}, nil
+	default:
+		return nil, fmt.Errorf("unknown log sink type: %s", opts.Type)
+	}
+}
+
+// client is the http client shared by the sinks
+type client struct {
+	endpoint string
+	username string
+	password string
+	client   *http.Client
+}
+
+// do sends the request and decodes the JSON response into out if it's not nil.
+// errNotFound is returned if the status code is 404.
+func (c *client) do(ctx context.Context, method, path, contentType string, body []byte, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if len(contentType) > 0 {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if len(c.username) > 0 {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return fmt.Errorf("%s %s: unexpected status code %d: %s", method, path, resp.StatusCode, string(data))
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
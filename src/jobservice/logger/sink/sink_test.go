@@ -0,0 +1,225 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// SinkTestSuite tests the sinks against the stub log stores
+type SinkTestSuite struct {
+	suite.Suite
+
+	lock    sync.Mutex
+	streams []*lokiStream
+	docs    []map[string]any
+	server  *httptest.Server
+}
+
+// TestSinkTestSuite is entry of go test
+func TestSinkTestSuite(t *testing.T) {
+	suite.Run(t, new(SinkTestSuite))
+}
+
+// SetupTest starts the stub server serving both the Loki and Elasticsearch APIs
+func (suite *SinkTestSuite) SetupTest() {
+	suite.streams = nil
+	suite.docs = nil
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+lokiPushPath, func(w http.ResponseWriter, r *http.Request) {
+		req := &lokiPushRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		suite.lock.Lock()
+		suite.streams = append(suite.streams, req.Streams...)
+		suite.lock.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET "+lokiQueryPath, func(w http.ResponseWriter, r *http.Request) {
+		resp := &lokiQueryResponse{Status: "success"}
+		start, _ := strconv.ParseInt(r.URL.Query().Get("start"), 10, 64)
+		suite.lock.Lock()
+		for _, s := range suite.streams {
+			result := &lokiStream{Stream: s.Stream}
+			for _, v := range s.Values {
+				ts, _ := strconv.ParseInt(v.Timestamp, 10, 64)
+				if ts >= start && r.URL.Query().Get("query") == `{service_name="harbor-jobservice"} | job_id="`+v.Metadata[LabelJobID]+`"` {
+					result.Values = append(result.Values, v)
+				}
+			}
+			if len(result.Values) > 0 {
+				resp.Data.Result = append(resp.Data.Result, result)
+			}
+		}
+		suite.lock.Unlock()
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+	mux.HandleFunc("POST /_bulk", func(w http.ResponseWriter, r *http.Request) {
+		scanner := bufio.NewScanner(r.Body)
+		for i := 0; scanner.Scan(); i++ {
+			// Skip the action lines
+			if i%2 == 0 {
+				continue
+			}
+			doc := map[string]any{}
+			if err := json.Unmarshal(scanner.Bytes(), &doc); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			suite.lock.Lock()
+			suite.docs = append(suite.docs, doc)
+			suite.lock.Unlock()
+		}
+		_, _ = w.Write([]byte(`{"errors":false,"items":[]}`))
+	})
+	mux.HandleFunc("POST /missing/_search", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("POST /harbor-job-log/_search", func(w http.ResponseWriter, r *http.Request) {
+		query := map[string]any{}
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := json.Marshal(query["query"])
+		resp := &esSearchResponse{}
+		suite.lock.Lock()
+		for _, doc := range suite.docs {
+			if strings.Contains(string(data), `"`+doc[LabelJobID].(string)+`"`) {
+				resp.Hits.Hits = append(resp.Hits.Hits, struct {
+					Source map[string]any `json:"_source"`
+					Sort   []any          `json:"sort"`
+				}{Source: doc})
+			}
+		}
+		suite.lock.Unlock()
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+	suite.server = httptest.NewServer(mux)
+}
+
+// TearDownTest stops the stub server
+func (suite *SinkTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+// TestNew tests creating the sinks
+func (suite *SinkTestSuite) TestNew() {
+	_, err := New(&Options{Type: TypeLoki})
+	suite.Error(err)
+
+	_, err = New(&Options{Type: "unknown", Endpoint: suite.server.URL})
+	suite.Error(err)
+
+	s, err := New(&Options{Type: TypeElasticsearch, Endpoint: suite.server.URL + "/"})
+	suite.Require().NoError(err)
+	suite.Equal(defaultIndex, s.(*elasticsearch).index)
+	suite.Equal(suite.server.URL, s.(*elasticsearch).endpoint)
+}
+
+// TestLoki tests pushing and fetching the logs with Loki
+func (suite *SinkTestSuite) TestLoki() {
+	s, err := New(&Options{Type: TypeLoki, Endpoint: suite.server.URL})
+	suite.Require().NoError(err)
+
+	suite.pushAndFetch(s)
+	suite.Require().Len(suite.streams, 2)
+	suite.Equal("REPLICATION", suite.streams[0].Stream[LabelVendorType])
+	// The job IDs are not the stream labels
+	suite.NotContains(suite.streams[0].Stream, LabelJobID)
+	suite.NotContains(suite.streams[0].Stream, LabelExecutionID)
+	suite.Equal("fake_job_ID", suite.streams[0].Values[0].Metadata[LabelJobID])
+	suite.Equal("1", suite.streams[0].Values[0].Metadata[LabelExecutionID])
+}
+
+// TestElasticsearch tests pushing and fetching the logs with Elasticsearch
+func (suite *SinkTestSuite) TestElasticsearch() {
+	s, err := New(&Options{Type: TypeElasticsearch, Endpoint: suite.server.URL})
+	suite.Require().NoError(err)
+
+	suite.pushAndFetch(s)
+	suite.Require().Len(suite.docs, 3)
+	suite.Equal("REPLICATION", suite.docs[0][LabelVendorType])
+	suite.Equal("first line", suite.docs[0][esFieldMessage])
+
+	// The index is not created yet
+	s, err = New(&Options{Type: TypeElasticsearch, Endpoint: suite.server.URL, Index: "missing"})
+	suite.Require().NoError(err)
+	entries, err := s.Fetch(context.TODO(), "fake_job_ID", nil)
+	suite.Require().NoError(err)
+	suite.Empty(entries)
+}
+
+// TestPushError tests the error returned by the log store
+func (suite *SinkTestSuite) TestPushError() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte("rate limited"))
+	}))
+	defer server.Close()
+
+	s, err := New(&Options{Type: TypeLoki, Endpoint: server.URL})
+	suite.Require().NoError(err)
+	err = s.Push(context.TODO(), map[string]string{LabelJobID: "fake_job_ID"}, []*Entry{{Time: time.Now(), Line: "line"}})
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "rate limited")
+}
+
+func (suite *SinkTestSuite) pushAndFetch(s Interface) {
+	now := time.Now()
+	labels := map[string]string{
+		LabelJobID:       "fake_job_ID",
+		LabelVendorType:  "REPLICATION",
+		LabelExecutionID: "1",
+		LabelProject:     "library",
+	}
+	err := s.Push(context.TODO(), labels, []*Entry{
+		{Time: now, Seq: 1, Line: "first line"},
+		{Time: now, Seq: 2, Line: "second line"},
+	})
+	suite.Require().NoError(err)
+	err = s.Push(context.TODO(), map[string]string{LabelJobID: "another_job_ID"}, []*Entry{
+		{Time: now, Seq: 1, Line: "another line"},
+	})
+	suite.Require().NoError(err)
+
+	entries, err := s.Fetch(context.TODO(), "fake_job_ID", nil)
+	suite.Require().NoError(err)
+	suite.Require().Len(entries, 2)
+	suite.Equal("first line", entries[0].Line)
+	suite.Equal("second line", entries[1].Line)
+	suite.Equal(int64(2), entries[1].Seq)
+	suite.Equal(now.UnixNano(), entries[0].Time.UnixNano())
+
+	// The lines at the same timestamp after the given one are kept
+	entries, err = s.Fetch(context.TODO(), "fake_job_ID", entries[0])
+	suite.Require().NoError(err)
+	suite.Require().Len(entries, 1)
+	suite.Equal("second line", entries[0].Line)
+}
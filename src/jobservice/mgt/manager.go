@@ -16,9 +16,11 @@ package mgt
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gocraft/work"
 	"github.com/gomodule/redigo/redis"
@@ -33,6 +35,13 @@ import (
 	"github.com/goharbor/harbor/src/lib/errors"
 )
 
+const (
+	dagExpireSeconds     = 7 * 24 * 3600
+	dagLockExpireSeconds = 30
+	dagLockRetries       = 50
+	dagLockRetryInterval = 200 * time.Millisecond
+)
+
 // Manager defines the related operations to handle the management of job stats.
 type Manager interface {
 	// Get the stats data of all kinds of jobs.
@@ -88,6 +97,42 @@ type Manager interface {
 	// Returns:
 	//   Non nil error if any issues meet
 	SaveJob(job *job.Stats) error
+
+	// Save the DAG
+	//
+	// Arguments:
+	//   dag *job.DAG: the saving DAG
+	//
+	// Returns:
+	//   Non nil error if any issues meet
+	SaveDAG(dag *job.DAG) error
+
+	// Get the specified DAG
+	//
+	// Arguments:
+	//   dagID string: ID of the DAG
+	//
+	// Returns:
+	//   The DAG
+	//   Non nil error if any issues meet
+	GetDAG(dagID string) (*job.DAG, error)
+
+	// Lock the specified DAG to avoid concurrent updating
+	//
+	// Arguments:
+	//   dagID string: ID of the DAG
+	//
+	// Returns:
+	//   The function to unlock the DAG
+	//   Non nil error if any issues meet
+	LockDAG(dagID string) (func(), error)
+
+	// Get the IDs of the unfinished DAGs
+	//
+	// Returns:
+	//   The IDs of the DAGs
+	//   Non nil error if any issues meet
+	GetRunningDAGs() ([]string, error)
 }
 
 // basicManager is the default implementation of @manager,
@@ -313,6 +358,122 @@ func (bm *basicManager) SaveJob(j *job.Stats) error {
 	return t.Save()
 }
 
+// SaveDAG is implementation of Manager.SaveDAG
+func (bm *basicManager) SaveDAG(dag *job.DAG) error {
+	if dag == nil {
+		return errs.BadRequestError("nil saving DAG")
+	}
+
+	data, err := json.Marshal(dag)
+	if err != nil {
+		return errors.Wrap(err, "save DAG")
+	}
+
+	conn := bm.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// The finished DAG is kept for a while for querying
+	cmds := [][]any{{"MULTI"}}
+	if job.Status(dag.Status).Final() {
+		cmds = append(cmds,
+			[]any{"SET", rds.KeyDAG(bm.namespace, dag.ID), data, "EX", dagExpireSeconds},
+			[]any{"SREM", rds.KeyRunningDAGs(bm.namespace), dag.ID},
+		)
+	} else {
+		cmds = append(cmds,
+			[]any{"SET", rds.KeyDAG(bm.namespace, dag.ID), data},
+			[]any{"SADD", rds.KeyRunningDAGs(bm.namespace), dag.ID},
+		)
+	}
+	for _, cmd := range cmds {
+		if err := conn.Send(cmd[0].(string), cmd[1:]...); err != nil {
+			return errors.Wrap(err, "save DAG")
+		}
+	}
+
+	if _, err := conn.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "save DAG")
+	}
+
+	return nil
+}
+
+// GetDAG is implementation of Manager.GetDAG
+func (bm *basicManager) GetDAG(dagID string) (*job.DAG, error) {
+	if utils.IsEmptyStr(dagID) {
+		return nil, errs.BadRequestError("empty DAG ID")
+	}
+
+	conn := bm.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	data, err := redis.Bytes(conn.Do("GET", rds.KeyDAG(bm.namespace, dagID)))
+	if err != nil {
+		if errors.Is(err, redis.ErrNil) {
+			return nil, errs.NoObjectFoundError(fmt.Sprintf("DAG %s", dagID))
+		}
+
+		return nil, errors.Wrap(err, "get DAG")
+	}
+
+	dag := &job.DAG{}
+	if err := json.Unmarshal(data, dag); err != nil {
+		return nil, errors.Wrap(err, "get DAG")
+	}
+
+	return dag, nil
+}
+
+// LockDAG is implementation of Manager.LockDAG
+func (bm *basicManager) LockDAG(dagID string) (func(), error) {
+	lockKey := rds.KeyDAGLock(bm.namespace, dagID)
+	lockerID := utils.MakeIdentifier()
+
+	conn := bm.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	var err error
+	for i := 0; i < dagLockRetries; i++ {
+		if err = rds.AcquireLock(conn, lockKey, lockerID, dagLockExpireSeconds); err == nil {
+			return func() {
+				c := bm.pool.Get()
+				defer func() {
+					_ = c.Close()
+				}()
+
+				if er := rds.ReleaseLock(c, lockKey, lockerID); er != nil {
+					logger.Errorf("Failed to release the lock of DAG %s: %s", dagID, er)
+				}
+			}, nil
+		}
+
+		<-time.After(dagLockRetryInterval)
+	}
+
+	return nil, errors.Wrap(err, "lock DAG")
+}
+
+// GetRunningDAGs is implementation of Manager.GetRunningDAGs
+func (bm *basicManager) GetRunningDAGs() ([]string, error) {
+	conn := bm.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	ids, err := redis.Strings(conn.Do("SMEMBERS", rds.KeyRunningDAGs(bm.namespace)))
+	if err != nil {
+		return nil, errors.Wrap(err, "get running DAGs")
+	}
+
+	return ids, nil
+}
+
 // queryExecutions queries periodic executions by status
 func queryExecutions(conn redis.Conn, dataKey string, q *query.Parameter) ([]string, int64, error) {
 	total, err := redis.Int64(conn.Do("ZCOUNT", dataKey, 0, "+inf"))
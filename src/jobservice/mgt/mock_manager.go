@@ -15,6 +15,36 @@ type MockManager struct {
 	mock.Mock
 }
 
+// GetDAG provides a mock function with given fields: dagID
+func (_m *MockManager) GetDAG(dagID string) (*job.DAG, error) {
+	ret := _m.Called(dagID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDAG")
+	}
+
+	var r0 *job.DAG
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*job.DAG, error)); ok {
+		return rf(dagID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *job.DAG); ok {
+		r0 = rf(dagID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*job.DAG)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(dagID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetJob provides a mock function with given fields: jobID
 func (_m *MockManager) GetJob(jobID string) (*job.Stats, error) {
 	ret := _m.Called(jobID)
@@ -119,6 +149,36 @@ func (_m *MockManager) GetPeriodicExecution(pID string, q *query.Parameter) ([]*
 	return r0, r1, r2
 }
 
+// GetRunningDAGs provides a mock function with no fields
+func (_m *MockManager) GetRunningDAGs() ([]string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRunningDAGs")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetScheduledJobs provides a mock function with given fields: q
 func (_m *MockManager) GetScheduledJobs(q *query.Parameter) ([]*job.Stats, int64, error) {
 	ret := _m.Called(q)
@@ -156,6 +216,54 @@ func (_m *MockManager) GetScheduledJobs(q *query.Parameter) ([]*job.Stats, int64
 	return r0, r1, r2
 }
 
+// LockDAG provides a mock function with given fields: dagID
+func (_m *MockManager) LockDAG(dagID string) (func(), error) {
+	ret := _m.Called(dagID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockDAG")
+	}
+
+	var r0 func()
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (func(), error)); ok {
+		return rf(dagID)
+	}
+	if rf, ok := ret.Get(0).(func(string) func()); ok {
+		r0 = rf(dagID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(dagID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveDAG provides a mock function with given fields: dag
+func (_m *MockManager) SaveDAG(dag *job.DAG) error {
+	ret := _m.Called(dag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDAG")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*job.DAG) error); ok {
+		r0 = rf(dag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveJob provides a mock function with given fields: _a0
 func (_m *MockManager) SaveJob(_a0 *job.Stats) error {
 	ret := _m.Called(_a0)
@@ -167,8 +167,9 @@ func (e *enqueuer) scheduleNextJobs(p *Policy, conn redis.Conn) {
 		e.lastEnqueueErr = err
 		logger.Errorf("Invalid corn spec in periodic policy %s %s: %s", lib.TrimLineBreaks(p.JobName), p.ID, err)
 	} else {
-		for t := schedule.Next(nowTime); t.Before(horizon); t = schedule.Next(t) {
-			epoch := t.Unix()
+		// The jitter is applied and the runs falling into the blackout windows are skipped based on the options
+		for t, run := p.Options.NextRun(p.ID, schedule, nowTime); !t.IsZero() && t.Before(horizon); t, run = p.Options.NextRun(p.ID, schedule, t) {
+			epoch := run.Unix()
 
 			// Clone parameters
 			// Add extra argument for job running too.
@@ -24,6 +24,7 @@ import (
 	comUtils "github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/jobservice/common/rds"
 	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/logger"
 )
 
@@ -31,12 +32,14 @@ import (
 type Policy struct {
 	// Policy can be treated as job template of periodic job.
 	// The info of policy will be copied into the scheduled job executions for the periodic job.
-	ID            string         `json:"id"`
-	JobName       string         `json:"job_name"`
-	CronSpec      string         `json:"cron_spec"`
-	JobParameters map[string]any `json:"job_params,omitempty"`
-	WebHookURL    string         `json:"web_hook_url,omitempty"`
-	NumericID     int64          `json:"numeric_id,omitempty"`
+	ID       string `json:"id"`
+	JobName  string `json:"job_name"`
+	CronSpec string `json:"cron_spec"`
+	// The timezone, jitter and blackout windows applied to the cron
+	Options       *job.ScheduleOptions `json:"options,omitempty"`
+	JobParameters map[string]any       `json:"job_params,omitempty"`
+	WebHookURL    string               `json:"web_hook_url,omitempty"`
+	NumericID     int64                `json:"numeric_id,omitempty"`
 }
 
 // Serialize the policy to raw data.
@@ -69,6 +72,10 @@ func (p *Policy) Validate() error {
 		return err
 	}
 
+	if err := p.Options.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -37,7 +37,11 @@ import (
 
 const (
 	maxTrackRetries = 6
-	tracerName      = "goharbor/harbor/src/jobservice/runner/redis"
+	// heartbeatInterval is the interval of reporting the running job is alive
+	heartbeatInterval = time.Minute
+	tracerName        = "goharbor/harbor/src/jobservice/runner/redis"
+	// noRetryFails is big enough to cancel the retry of the job
+	noRetryFails = 10000000000
 )
 
 // RedisJob is a job wrapper to wrap the job.Interface to the style which can be recognized by the redis worker.
@@ -45,6 +49,7 @@ type RedisJob struct {
 	job     any            // the real job implementation
 	context *env.Context   // context
 	ctl     lcm.Controller // life cycle controller
+	limiter TenantLimiter  // limit the running jobs of the tenants
 }
 
 // NewRedisJob is constructor of RedisJob
@@ -56,8 +61,20 @@ func NewRedisJob(job any, ctx *env.Context, ctl lcm.Controller) *RedisJob {
 	}
 }
 
+// WithTenantLimiter sets the limiter to share the running slots fairly among the tenants.
+func (rj *RedisJob) WithTenantLimiter(limiter TenantLimiter) *RedisJob {
+	rj.limiter = limiter
+	return rj
+}
+
 // Run the job
-func (rj *RedisJob) Run(j *work.Job) (err error) {
+func (rj *RedisJob) Run(j *work.Job) error {
+	return rj.RunWithHeartbeat(j)
+}
+
+// RunWithHeartbeat runs the job and calls the beats along with the heartbeat of the job,
+// e.g. to keep the running slots taken by the job alive.
+func (rj *RedisJob) RunWithHeartbeat(j *work.Job, beats ...func()) (err error) {
 	_, span := tracelib.StartTrace(context.Background(), tracerName, "run-job")
 	defer span.End()
 
@@ -99,7 +116,7 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 			// else
 			// Exit and never try.
 			// Directly return without retry again as we have no way to restore the stats again.
-			j.Fails = 10000000000 // never retry
+			j.Fails = noRetryFails // never retry
 		}
 
 		// Log error and exit
@@ -114,6 +131,34 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 		return
 	}
 
+	// Defer the job if its tenant has used up the share of the running slots.
+	// The job will be put back by the worker pool shortly without consuming a failure.
+	if tenant, limit := rj.tenantLimit(tracker); limit > 0 {
+		acquired, er := rj.limiter.Acquire(tenant, jID, limit)
+		switch {
+		case er != nil:
+			// Do not block the job if the limiter is not working
+			logger.Errorf("Failed to acquire the running slot of tenant %s for job %s:%s: %s", tenant, j.Name, j.ID, er)
+		case !acquired:
+			logger.Debugf("Job %s:%s is deferred as tenant %s has %d running jobs", j.Name, j.ID, tenant, limit)
+			span.AddEvent("deferred by the tenant limit")
+			j.Fails--
+			return ErrTenantThrottled
+		default:
+			defer func() {
+				if er := rj.limiter.Release(tenant, jID); er != nil {
+					logger.Errorf("Failed to release the running slot of tenant %s for job %s:%s: %s", tenant, j.Name, j.ID, er)
+				}
+			}()
+			// Keep the slot alive with the heartbeat of the job
+			beats = append(beats, func() {
+				if er := rj.limiter.Refresh(tenant, jID); er != nil {
+					logger.Errorf("Failed to refresh the running slot of tenant %s for job %s:%s: %s", tenant, j.Name, j.ID, er)
+				}
+			})
+		}
+	}
+
 	// Defer to switch status
 	defer func() {
 		// Switch job status based on the returned error.
@@ -124,6 +169,11 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 			metric.JobserviceTotalTask.WithLabelValues(j.Name, "fail").Inc()
 			metric.JobservieTaskProcessTimeSummary.WithLabelValues(j.Name, "fail").Observe(time.Since(now).Seconds())
 			tracelib.RecordError(span, err, "job failed with err")
+			// Record the fails before the status change is fired, the DAG waits for the job
+			// being retried until it has failed the max times.
+			if er := tracker.Update("fails", j.Fails+1, "max_fails", rj.maxFails(runningJob, j)); er != nil {
+				logger.Errorf("Error occurred when recording the fails of job %s:%s: %s", j.Name, j.ID, er)
+			}
 			if er := tracker.Fail(); er != nil {
 				logger.Errorf("Error occurred when marking the status of job %s:%s to failure: %s", j.Name, j.ID, er)
 				span.RecordError(err)
@@ -227,6 +277,9 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 		tracelib.RecordError(span, err, "failed set status to run")
 		return
 	}
+	// Report the job is alive until it exits
+	stopHeartbeat := heartbeat(tracker, heartbeatInterval, beats...)
+	defer stopHeartbeat()
 	// Run the job
 	err = runningJob.Run(execContext, j.Args)
 	// Add error context
@@ -247,15 +300,73 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 	return
 }
 
+// tenantLimit returns the tenant of the job and the max running jobs of the tenant, 0 means no limit.
+func (rj *RedisJob) tenantLimit(tracker job.Tracker) (string, uint) {
+	if rj.limiter == nil {
+		return "", 0
+	}
+
+	info := tracker.Job().Info
+	// Only limit the jobs that are going to run
+	switch job.Status(info.Status) {
+	case job.PendingStatus, job.ScheduledStatus, job.RunningStatus, job.ErrorStatus:
+	default:
+		return "", 0
+	}
+
+	return info.Tenant, job.CurrentPriorities().TenantLimit(info.Tenant)
+}
+
+// maxFails returns the times the job can fail, the job is not retried any more if the retry is canceled.
+func (rj *RedisJob) maxFails(j job.Interface, wj *work.Job) int64 {
+	if wj.Fails >= noRetryFails {
+		return wj.Fails + 1
+	}
+	if j == nil {
+		j = Wrap(rj.job)
+	}
+
+	return int64(j.MaxFails())
+}
+
 func (rj *RedisJob) retry(j job.Interface, wj *work.Job) {
 	if !j.ShouldRetry() {
 		// Cancel retry immediately
 		// Make it big enough to avoid retrying
-		wj.Fails = 10000000000
+		wj.Fails = noRetryFails
 		return
 	}
 }
 
+// heartbeat reports the job is alive periodically and calls the beats along with it, call the returned function to stop it
+func heartbeat(tracker job.Tracker, interval time.Duration, beats ...func()) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := tracker.Heartbeat(); err != nil {
+				// Just log it
+				logger.Errorf("failed to report the heartbeat of job %s: %v", tracker.Job().Info.JobID, err)
+			}
+			for _, beat := range beats {
+				beat()
+			}
+
+			select {
+			case <-ticker.C:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+	}
+}
+
 func isPeriodicJobExecution(j *work.Job) (string, bool) {
 	epoch, ok := j.Args[period.PeriodicExecutionMark]
 	return fmt.Sprintf("%s@%s", j.ID, epoch), ok
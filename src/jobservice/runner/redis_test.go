@@ -190,6 +190,51 @@ func (suite *RedisRunnerTestSuite) TestJobWrapperStopped() {
 	require.NoError(suite.T(), err)
 }
 
+// TestJobWrapperTenantThrottled tests job runner deferring the job of the busy tenant
+func (suite *RedisRunnerTestSuite) TestJobWrapperTenantThrottled() {
+	j := &work.Job{
+		ID:         "FAKE-j",
+		Name:       "fakeParentJob",
+		EnqueuedAt: time.Now().Add(5 * time.Minute).Unix(),
+	}
+
+	t, err := suite.lcmCtl.Track("FAKE-j")
+	require.NoError(suite.T(), err)
+	err = t.Update("tenant", "library")
+	require.NoError(suite.T(), err)
+	defer func() {
+		_ = t.Update("tenant", "")
+	}()
+
+	job.SetPriorities(&job.Priorities{MaxRunningPerTenant: 1})
+	defer job.SetPriorities(nil)
+
+	redisJob := NewRedisJob((*fakeParentJob)(nil), suite.envContext, suite.lcmCtl).
+		WithTenantLimiter(&fakeTenantLimiter{})
+	err = redisJob.Run(j)
+	require.ErrorIs(suite.T(), err, ErrTenantThrottled)
+	// The deferral does not consume a failure
+	assert.Equal(suite.T(), int64(-1), j.Fails)
+
+	status, err := t.Status()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), job.PendingStatus, status)
+}
+
+type fakeTenantLimiter struct{}
+
+func (l *fakeTenantLimiter) Acquire(_ string, _ string, _ uint) (bool, error) {
+	return false, nil
+}
+
+func (l *fakeTenantLimiter) Release(_ string, _ string) error {
+	return nil
+}
+
+func (l *fakeTenantLimiter) Refresh(_ string, _ string) error {
+	return nil
+}
+
 type fakeParentJob struct {
 }
 
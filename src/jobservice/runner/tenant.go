@@ -0,0 +1,139 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+// ErrTenantThrottled is returned when the job is deferred as its tenant has used up the running slots,
+// the worker pool puts the deferred job back shortly without the backoff of the failed jobs
+var ErrTenantThrottled = errors.New("tenant has too many running jobs, deferred")
+
+// SlotStaleWindow is the time after which the running slot not refreshed by the heartbeat of the job is treated
+// as stale, e.g. left by a crashed worker pool
+const SlotStaleWindow = 3 * heartbeatInterval
+
+// Remove the stale slots left by the crashed worker pools, then take one slot if the limit is not reached
+// KEYS[1]: the running jobs of the tenant
+// ARGV[1]: the job ID, ARGV[2]: the limit, ARGV[3]: now, ARGV[4]: the stale before, ARGV[5]: the expiration of the key
+var acquireScript = redis.NewScript(1, `
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[4])
+if redis.call('ZSCORE', KEYS[1], ARGV[1]) then
+  return 1
+end
+if redis.call('ZCARD', KEYS[1]) < tonumber(ARGV[2]) then
+  redis.call('ZADD', KEYS[1], ARGV[3], ARGV[1])
+  redis.call('EXPIRE', KEYS[1], ARGV[5])
+  return 1
+end
+return 0
+`)
+
+// TenantLimiter limits the number of the running jobs of each tenant so that the jobs
+// of one tenant cannot occupy all the workers and starve the jobs of the other tenants.
+type TenantLimiter interface {
+	// Acquire a running slot for the job of the tenant.
+	// Returns false if the tenant already has limit running jobs.
+	Acquire(tenant string, jobID string, limit uint) (bool, error)
+	// Release the running slot of the job.
+	Release(tenant string, jobID string) error
+	// Refresh the running slot of the job to keep it from being treated as stale.
+	Refresh(tenant string, jobID string) error
+}
+
+// redisTenantLimiter is the TenantLimiter based on redis sorted sets
+type redisTenantLimiter struct {
+	namespace string
+	pool      *redis.Pool
+}
+
+// NewTenantLimiter is constructor of TenantLimiter
+func NewTenantLimiter(namespace string, pool *redis.Pool) TenantLimiter {
+	return &redisTenantLimiter{
+		namespace: namespace,
+		pool:      pool,
+	}
+}
+
+// Acquire implements TenantLimiter.
+func (rl *redisTenantLimiter) Acquire(tenant string, jobID string, limit uint) (bool, error) {
+	conn := rl.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// The slot of the job which has no heartbeat in the stale window is treated as stale
+	ttl := SlotStaleWindow
+	now := time.Now()
+	ok, err := redis.Int(acquireScript.Do(
+		conn,
+		rds.KeyTenantRunningJobs(rl.namespace, tenant),
+		jobID,
+		limit,
+		now.Unix(),
+		now.Add(-ttl).Unix(),
+		int64(ttl.Seconds()),
+	))
+	if err != nil {
+		return false, errors.Wrap(err, "acquire tenant running slot")
+	}
+
+	return ok == 1, nil
+}
+
+// Release implements TenantLimiter.
+func (rl *redisTenantLimiter) Release(tenant string, jobID string) error {
+	conn := rl.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	if _, err := conn.Do("ZREM", rds.KeyTenantRunningJobs(rl.namespace, tenant), jobID); err != nil {
+		return errors.Wrap(err, "release tenant running slot")
+	}
+
+	return nil
+}
+
+// Refresh implements TenantLimiter.
+func (rl *redisTenantLimiter) Refresh(tenant string, jobID string) error {
+	conn := rl.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	key := rds.KeyTenantRunningJobs(rl.namespace, tenant)
+	// Only refresh the existing slot, the slot removed as stale is not taken again
+	if err := conn.Send("MULTI"); err != nil {
+		return errors.Wrap(err, "refresh tenant running slot")
+	}
+	if err := conn.Send("ZADD", key, "XX", time.Now().Unix(), jobID); err != nil {
+		return errors.Wrap(err, "refresh tenant running slot")
+	}
+	if err := conn.Send("EXPIRE", key, int64(SlotStaleWindow.Seconds())); err != nil {
+		return errors.Wrap(err, "refresh tenant running slot")
+	}
+	if _, err := conn.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "refresh tenant running slot")
+	}
+
+	return nil
+}
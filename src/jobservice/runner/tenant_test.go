@@ -0,0 +1,118 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
+	"github.com/goharbor/harbor/src/jobservice/tests"
+)
+
+// TenantLimiterTestSuite tests functions of tenant limiter
+type TenantLimiterTestSuite struct {
+	suite.Suite
+
+	namespace string
+	pool      *redis.Pool
+	limiter   TenantLimiter
+}
+
+// TestTenantLimiterTestSuite is entry of go test
+func TestTenantLimiterTestSuite(t *testing.T) {
+	suite.Run(t, new(TenantLimiterTestSuite))
+}
+
+// SetupSuite prepares test suite
+func (suite *TenantLimiterTestSuite) SetupSuite() {
+	suite.namespace = tests.GiveMeTestNamespace()
+	suite.pool = tests.GiveMeRedisPool()
+	suite.limiter = NewTenantLimiter(suite.namespace, suite.pool)
+}
+
+// TearDownSuite clears the test suite
+func (suite *TenantLimiterTestSuite) TearDownSuite() {
+	conn := suite.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_ = tests.ClearAll(suite.namespace, conn)
+}
+
+// TestAcquireAndRelease tests acquiring and releasing the running slots
+func (suite *TenantLimiterTestSuite) TestAcquireAndRelease() {
+	ok, err := suite.limiter.Acquire("library", "job-1", 2)
+	suite.Require().NoError(err)
+	suite.True(ok)
+
+	// Acquire again by the same job
+	ok, err = suite.limiter.Acquire("library", "job-1", 2)
+	suite.Require().NoError(err)
+	suite.True(ok)
+
+	ok, err = suite.limiter.Acquire("library", "job-2", 2)
+	suite.Require().NoError(err)
+	suite.True(ok)
+
+	ok, err = suite.limiter.Acquire("library", "job-3", 2)
+	suite.Require().NoError(err)
+	suite.False(ok, "expect no slot left for tenant library")
+
+	// The other tenant is not influenced
+	ok, err = suite.limiter.Acquire("other", "job-4", 2)
+	suite.Require().NoError(err)
+	suite.True(ok)
+
+	suite.Require().NoError(suite.limiter.Release("library", "job-1"))
+	ok, err = suite.limiter.Acquire("library", "job-3", 2)
+	suite.Require().NoError(err)
+	suite.True(ok)
+}
+
+// TestStaleSlots tests the slots not refreshed by the heartbeat are reclaimed
+func (suite *TenantLimiterTestSuite) TestStaleSlots() {
+	conn := suite.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	key := rds.KeyTenantRunningJobs(suite.namespace, "stale")
+	stale := time.Now().Add(-SlotStaleWindow - time.Minute).Unix()
+	_, err := conn.Do("ZADD", key, stale, "job-1")
+	suite.Require().NoError(err)
+	_, err = conn.Do("ZADD", key, stale, "job-2")
+	suite.Require().NoError(err)
+
+	// The refreshed slot is kept
+	suite.Require().NoError(suite.limiter.Refresh("stale", "job-1"))
+	ok, err := suite.limiter.Acquire("stale", "job-3", 2)
+	suite.Require().NoError(err)
+	suite.True(ok)
+	ok, err = suite.limiter.Acquire("stale", "job-4", 2)
+	suite.Require().NoError(err)
+	suite.False(ok, "expect the refreshed slot is kept")
+
+	// The released slot is not taken again by the refresh
+	suite.Require().NoError(suite.limiter.Release("stale", "job-3"))
+	suite.Require().NoError(suite.limiter.Refresh("stale", "job-3"))
+	n, err := redis.Int(conn.Do("ZCARD", key))
+	suite.Require().NoError(err)
+	suite.Equal(1, n)
+}
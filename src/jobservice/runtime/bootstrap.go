@@ -60,6 +60,7 @@ import (
 	"github.com/goharbor/harbor/src/pkg/retention"
 	"github.com/goharbor/harbor/src/pkg/scan"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
+	"github.com/goharbor/harbor/src/pkg/signing"
 	"github.com/goharbor/harbor/src/pkg/task"
 )
 
@@ -67,6 +68,8 @@ const (
 	dialConnectionTimeout = 30 * time.Second
 	dialReadTimeout       = 10 * time.Second
 	dialWriteTimeout      = 10 * time.Second
+	// dagResumeInterval is the interval of advancing the unfinished DAGs
+	dagResumeInterval = 5 * time.Minute
 )
 
 // JobService ...
@@ -118,7 +121,10 @@ func (bs *Bootstrap) LoadAndRun(ctx context.Context, cancel context.CancelFunc)
 		backendWorker worker.Interface
 		manager       mgt.Manager
 		syncWorker    *sync2.Worker
+		ctl           core.Interface
 	)
+	// Closed when the controller is ready for driving the DAGs
+	ctlReady := make(chan struct{})
 	if cfg.PoolConfig.Backend == config.JobServicePoolBackendRedis {
 		// Number of workers
 		workerNum := cfg.PoolConfig.WorkerCount
@@ -156,6 +162,16 @@ func (bs *Bootstrap) LoadAndRun(ctx context.Context, cancel context.CancelFunc)
 				msg = fmt.Sprintf("%s, check_in=%s", msg, cData)
 			}
 
+			// Launch the following jobs of the DAG once the job is done
+			if !utils.IsEmptyStr(change.Metadata.DAGID) && job.Status(change.Status).Final() {
+				go advanceDAG(ctlReady, &ctl, change.Metadata.DAGID)
+			}
+
+			// The job in DAG may have no hook registered
+			if utils.IsEmptyStr(URL) {
+				return nil
+			}
+
 			evt := &hook.Event{
 				URL:       URL,
 				Timestamp: change.Metadata.UpdateTime, // use update timestamp to avoid duplicated resending.
@@ -221,7 +237,10 @@ func (bs *Bootstrap) LoadAndRun(ctx context.Context, cancel context.CancelFunc)
 	}
 
 	// Initialize controller
-	ctl := core.NewController(backendWorker, manager)
+	ctl = core.NewController(backendWorker, manager)
+	close(ctlReady)
+	// Resume the DAGs left unfinished by the last run, and the ones missing the status changes periodically
+	go resumeDAGs(ctx, manager, ctl, dagResumeInterval)
 	// Initialize Prometheus backend
 	go bs.createMetricServer(cfg)
 	// Start the API server
@@ -272,6 +291,41 @@ func (bs *Bootstrap) LoadAndRun(ctx context.Context, cancel context.CancelFunc)
 	return
 }
 
+// advanceDAG advances the specified DAG after the controller is ready.
+func advanceDAG(ready <-chan struct{}, ctl *core.Interface, dagID string) {
+	<-ready
+
+	if err := (*ctl).AdvanceDAG(dagID); err != nil {
+		logger.Errorf("Failed to advance DAG %s: %s", dagID, err)
+	}
+}
+
+// resumeDAGs advances all the unfinished DAGs at the start and then every interval
+// until the context is done, in case any status change is missed.
+func resumeDAGs(ctx context.Context, manager mgt.Manager, ctl core.Interface, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		ids, err := manager.GetRunningDAGs()
+		if err != nil {
+			logger.Errorf("Failed to get the running DAGs: %s", err)
+		}
+
+		for _, id := range ids {
+			if err := ctl.AdvanceDAG(id); err != nil {
+				logger.Errorf("Failed to advance DAG %s: %s", id, err)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func (bs *Bootstrap) createMetricServer(cfg *config.Configuration) {
 	if cfg.Metric != nil && cfg.Metric.Enabled {
 		metric.RegisterJobServiceCollectors()
@@ -316,16 +370,18 @@ func (bs *Bootstrap) loadAndRunRedisWorkerPool(
 			// Only for debugging and testing purpose
 			job.SampleJob: (*sample.Job)(nil),
 			// Functional jobs
-			job.ImageScanJobVendorType:      (*scan.Job)(nil),
-			job.PurgeAuditVendorType:        (*purge.Job)(nil),
-			job.GarbageCollectionVendorType: (*gc.GarbageCollector)(nil),
-			job.ReplicationVendorType:       (*replication.Replication)(nil),
-			job.RetentionVendorType:         (*retention.Job)(nil),
-			scheduler.JobNameScheduler:      (*scheduler.PeriodicJob)(nil),
-			job.WebhookJobVendorType:        (*notification.WebhookJob)(nil),
-			job.SlackJobVendorType:          (*notification.SlackJob)(nil),
-			job.P2PPreheatVendorType:        (*preheat.Job)(nil),
-			job.ScanDataExportVendorType:    (*scandataexport.ScanDataExport)(nil),
+			job.ImageScanJobVendorType:           (*scan.Job)(nil),
+			job.PurgeAuditVendorType:             (*purge.Job)(nil),
+			job.GarbageCollectionVendorType:      (*gc.GarbageCollector)(nil),
+			job.GarbageCollectionSweepVendorType: (*gc.Sweeper)(nil),
+			job.ReplicationVendorType:            (*replication.Replication)(nil),
+			job.RetentionVendorType:              (*retention.Job)(nil),
+			scheduler.JobNameScheduler:           (*scheduler.PeriodicJob)(nil),
+			job.WebhookJobVendorType:             (*notification.WebhookJob)(nil),
+			job.SlackJobVendorType:               (*notification.SlackJob)(nil),
+			job.P2PPreheatVendorType:             (*preheat.Job)(nil),
+			job.ScanDataExportVendorType:         (*scandataexport.ScanDataExport)(nil),
+			job.ArtifactSigningVendorType:        (*signing.Job)(nil),
 			// In v2.2 we migrate the scheduled replication, garbage collection and scan all to
 			// the scheduler mechanism, the following three jobs are kept for the legacy jobs
 			// and they can be removed after several releases
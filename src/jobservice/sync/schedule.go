@@ -248,7 +248,7 @@ func (w *Worker) Run(ctx context.Context) error {
 		p := getPolicy(t.JobID)
 		if p == nil {
 			// Need to restore this missing schedule.
-			if err := w.restore(sch.CRON, t); err != nil {
+			if err := w.restore(sch.CRON, sch.Options, t); err != nil {
 				// Log and skip
 				logger.Error(err)
 				w.lastErr = err
@@ -278,11 +278,12 @@ func (w *Worker) Run(ctx context.Context) error {
 	return w.lastErr
 }
 
-func (w *Worker) restore(cron string, t *task.Task) error {
+func (w *Worker) restore(cron string, options *job.ScheduleOptions, t *task.Task) error {
 	p := &period.Policy{
 		ID:         t.JobID,
 		JobName:    scheduler.JobNameScheduler,
 		CronSpec:   cron,
+		Options:    options,
 		WebHookURL: fmt.Sprintf("%s/service/notifications/tasks/%d", w.internalCoreAddr, t.ID),
 	}
 
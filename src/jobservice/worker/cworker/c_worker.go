@@ -16,6 +16,7 @@ package cworker
 
 import (
 	"fmt"
+	"math/rand"
 	"reflect"
 	"sync"
 	"time"
@@ -24,6 +25,7 @@ import (
 	"github.com/gomodule/redigo/redis"
 
 	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/config"
 	"github.com/goharbor/harbor/src/jobservice/env"
 	"github.com/goharbor/harbor/src/jobservice/errs"
 	"github.com/goharbor/harbor/src/jobservice/job"
@@ -45,23 +47,39 @@ const (
 	workerPoolStatusDead         = "Dead"
 	pingRedisMaxTimes            = 10
 	defaultWorkerCount      uint = 10
+	// deferredJobBackoff is the seconds to wait before putting back the deferred job,
+	// it's the interval of the rate limit tokens which are counted per second
+	deferredJobBackoff int64 = 1
 )
 
 // basicWorker is the worker implementation based on gocraft/work powered by redis.
 type basicWorker struct {
-	namespace string
-	redisPool *redis.Pool
-	pool      *work.WorkerPool
-	enqueuer  *work.Enqueuer
-	client    *work.Client
-	context   *env.Context
-	scheduler period.Scheduler
-	ctl       lcm.Controller
-	reaper    *reaper
+	namespace   string
+	redisPool   *redis.Pool
+	workerCount uint
+	enqueuer    *work.Enqueuer
+	client      *work.Client
+	context     *env.Context
+	scheduler   period.Scheduler
+	ctl         lcm.Controller
+	reaper      *reaper
+	priority    *prioritySyncer
+	limiter     runner.TenantLimiter
+	rates       *rateLimiter
 
 	// key is name of known job
 	// value is the type of known job
 	knownJobs *sync.Map
+
+	// poolLock guards the worker pool which is replaced when the job priorities are adjusted
+	poolLock sync.RWMutex
+	pool     *work.WorkerPool
+	// the priorities of the job types registered to the worker pool
+	priorities map[string]uint
+	started    bool
+	stopped    bool
+	// the replaced worker pool is draining and the new one is waiting to start
+	draining bool
 }
 
 // workerContext ...
@@ -86,16 +104,16 @@ func NewWorker(ctx *env.Context, namespace string, workerCount uint, redisPool *
 		wc = workerCount
 	}
 
-	return &basicWorker{
-		namespace: namespace,
-		redisPool: redisPool,
-		pool:      work.NewWorkerPool(workerContext{}, wc, namespace, redisPool),
-		enqueuer:  work.NewEnqueuer(namespace, redisPool),
-		client:    work.NewClient(namespace, redisPool),
-		scheduler: period.NewScheduler(ctx.SystemContext, namespace, redisPool, ctl),
-		ctl:       ctl,
-		context:   ctx,
-		knownJobs: new(sync.Map),
+	w := &basicWorker{
+		namespace:   namespace,
+		redisPool:   redisPool,
+		workerCount: wc,
+		enqueuer:    work.NewEnqueuer(namespace, redisPool),
+		client:      work.NewClient(namespace, redisPool),
+		scheduler:   period.NewScheduler(ctx.SystemContext, namespace, redisPool, ctl),
+		ctl:         ctl,
+		context:     ctx,
+		knownJobs:   new(sync.Map),
 		reaper: &reaper{
 			context:   ctx.SystemContext,
 			namespace: namespace,
@@ -103,7 +121,19 @@ func NewWorker(ctx *env.Context, namespace string, workerCount uint, redisPool *
 			lcmCtl:    ctl,
 			jobTypes:  make([]string, 0), // Append data later (at the start step)
 		},
+		priority: &prioritySyncer{
+			context:    ctx.SystemContext,
+			namespace:  namespace,
+			pool:       redisPool,
+			configured: job.PrioritiesFromConfig(config.DefaultConfig.PriorityConfig),
+		},
+		limiter: runner.NewTenantLimiter(namespace, redisPool),
+		rates:   newRateLimiter(namespace, redisPool, config.DefaultConfig.RateLimitConfig),
 	}
+	w.priority.onSync = w.applyPriorities
+	w.pool, w.priorities = w.newPool()
+
+	return w
 }
 
 // Start to serve
@@ -135,10 +165,11 @@ func (w *basicWorker) Start() error {
 	w.scheduler.Start()
 
 	// Start the backend worker pool
-	// Add middleware
-	w.pool.Middleware((*workerContext).logJob)
 	// Non blocking call
+	w.poolLock.Lock()
 	w.pool.Start()
+	w.started = true
+	w.poolLock.Unlock()
 	logger.Infof("Basic worker is started")
 
 	// Listen to the system signal
@@ -152,7 +183,12 @@ func (w *basicWorker) Start() error {
 		}()
 
 		<-w.context.SystemContext.Done()
-		w.pool.Stop()
+		// No more worker pool is started to apply the adjusted priorities
+		w.poolLock.Lock()
+		w.stopped = true
+		pool := w.pool
+		w.poolLock.Unlock()
+		pool.Stop()
 	}()
 
 	// Start the reaper
@@ -163,11 +199,17 @@ func (w *basicWorker) Start() error {
 	})
 	w.reaper.start()
 
+	// Keep the job priorities in sync
+	w.priority.start()
+
 	return nil
 }
 
 // GetPoolID returns the worker pool id
 func (w *basicWorker) GetPoolID() string {
+	w.poolLock.RLock()
+	defer w.poolLock.RUnlock()
+
 	v := reflect.ValueOf(*w.pool)
 	return v.FieldByName("workerPoolID").String()
 }
@@ -179,6 +221,13 @@ func (w *basicWorker) RegisterJobs(jobs map[string]any) error {
 		return nil
 	}
 
+	// Load the job priorities before registering, the worker pool is replaced to apply the priorities adjusted later
+	if err := w.priority.sync(); err != nil {
+		// Just log and use the configured priorities
+		logger.Error(err)
+		job.SetPriorities(w.priority.configured)
+	}
+
 	for name, j := range jobs {
 		if err := w.registerJob(name, j); err != nil {
 			return err
@@ -253,11 +302,12 @@ func (w *basicWorker) Schedule(jobName string, params job.Parameters, runAfterSe
 }
 
 // PeriodicallyEnqueue job
-func (w *basicWorker) PeriodicallyEnqueue(jobName string, params job.Parameters, cronSetting string, _ bool, webHook string) (*job.Stats, error) {
+func (w *basicWorker) PeriodicallyEnqueue(jobName string, params job.Parameters, cronSetting string, options *job.ScheduleOptions, _ bool, webHook string) (*job.Stats, error) {
 	p := &period.Policy{
 		ID:            utils.MakeIdentifier(),
 		JobName:       jobName,
 		CronSpec:      cronSetting,
+		Options:       options,
 		JobParameters: params,
 		WebHookURL:    webHook,
 	}
@@ -423,30 +473,158 @@ func (w *basicWorker) registerJob(name string, j any) (err error) {
 		return
 	}
 
+	// Put into the pool
+	w.poolLock.Lock()
+	w.priorities[name] = w.addJob(w.pool, name, j)
+	w.poolLock.Unlock()
+	// Keep the name of registered jobs as known jobs for future validation
+	w.knownJobs.Store(name, j)
+
+	logger.Infof("Register job %s with name %s", reflect.TypeOf(j).String(), name)
+
+	return nil
+}
+
+// addJob adds the job to the worker pool with the current priority of the job type, the priority is returned.
+func (w *basicWorker) addJob(pool *work.WorkerPool, name string, j any) uint {
 	// Wrap job
-	redisJob := runner.NewRedisJob(j, w.context, w.ctl)
+	redisJob := runner.NewRedisJob(j, w.context, w.ctl).WithTenantLimiter(w.limiter)
 	// Get more info from j
 	theJ := runner.Wrap(j)
-	// Put into the pool
-	w.pool.JobWithOptions(
+	priority := job.Priority().For(name)
+	pool.JobWithOptions(
 		name,
 		work.JobOptions{
 			MaxFails:       theJ.MaxFails(),
 			MaxConcurrency: theJ.MaxCurrency(),
-			Priority:       job.Priority().For(name),
+			Priority:       priority,
 			SkipDead:       true,
+			Backoff:        backoff,
 		},
 		// Use generic handler to handle as we do not accept context with this way.
 		func(job *work.Job) error {
-			return redisJob.Run(job)
+			return w.runWithRateLimits(name, theJ, redisJob, job)
 		},
 	)
-	// Keep the name of registered jobs as known jobs for future validation
-	w.knownJobs.Store(name, j)
 
-	logger.Infof("Register job %s with name %s", reflect.TypeOf(j).String(), name)
+	return priority
+}
 
-	return nil
+// newPool creates the worker pool with the known jobs registered with the current priorities.
+func (w *basicWorker) newPool() (*work.WorkerPool, map[string]uint) {
+	pool := work.NewWorkerPool(workerContext{}, w.workerCount, w.namespace, w.redisPool)
+	// Add middleware
+	pool.Middleware((*workerContext).logJob)
+
+	priorities := make(map[string]uint)
+	w.knownJobs.Range(func(name any, j any) bool {
+		priorities[name.(string)] = w.addJob(pool, name.(string), j)
+		return true
+	})
+
+	return pool, priorities
+}
+
+// applyPriorities replaces the worker pool with a new one if the priorities of the job types are adjusted,
+// as the priorities are fixed once the jobs are registered to the worker pool. The new worker pool starts
+// after the replaced one stops fetching jobs and its running jobs complete, so no more than the worker count
+// of jobs run at the same time. The priorities adjusted while draining are applied by the next sync.
+func (w *basicWorker) applyPriorities() {
+	w.poolLock.Lock()
+	defer w.poolLock.Unlock()
+
+	if !w.started || w.stopped || w.draining {
+		return
+	}
+
+	changed := false
+	for name, priority := range w.priorities {
+		if job.Priority().For(name) != priority {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return
+	}
+
+	pool, priorities := w.newPool()
+	replaced := w.pool
+	w.pool, w.priorities = pool, priorities
+	w.draining = true
+	logger.Infof("Worker pool is replaced to apply the adjusted job priorities, waiting for the running jobs to complete")
+
+	w.context.WG.Add(1)
+	go func() {
+		defer w.context.WG.Done()
+
+		replaced.Stop()
+		logger.Infof("Replaced worker pool is stopped")
+
+		w.poolLock.Lock()
+		defer w.poolLock.Unlock()
+
+		w.draining = false
+		// The worker is stopped while draining
+		if w.stopped {
+			return
+		}
+		pool.Start()
+		logger.Infof("New worker pool is started with the adjusted job priorities")
+	}()
+}
+
+// backoff returns the seconds to wait before retrying the failed job. The job deferred by the tenant limits
+// or the rate limits does not fail, it's put back after about one token interval instead of waiting for the
+// exponential backoff of the failed jobs.
+func backoff(j *work.Job) int64 {
+	switch j.LastErr {
+	case runner.ErrTenantThrottled.Error(), errRateLimited.Error():
+		return deferredJobBackoff
+	}
+
+	// The default backoff of the worker pool
+	fails := j.Fails
+	return (fails * fails * fails * fails) + 15 + (rand.Int63n(30) * (fails + 1))
+}
+
+// runWithRateLimits runs the job if the rate limits of its vendor type and remote endpoint are not reached,
+// otherwise the job is put back by the worker pool later without consuming a failure.
+func (w *basicWorker) runWithRateLimits(name string, theJ job.Interface, redisJob *runner.RedisJob, j *work.Job) error {
+	scopes := w.rates.scopes(name, theJ, j.Args)
+	if len(scopes) == 0 {
+		return redisJob.Run(j)
+	}
+
+	jID := j.ID
+	// The executions of the periodic job share the same ID
+	if epoch, ok := j.Args[period.PeriodicExecutionMark]; ok {
+		jID = fmt.Sprintf("%s@%v", j.ID, epoch)
+	}
+	acquired, err := w.rates.acquire(scopes, jID)
+	switch {
+	case err != nil:
+		// Do not block the job if the limiter is not working
+		logger.Errorf("Failed to acquire the rate limit tokens for job %s:%s: %s", j.Name, j.ID, err)
+	case !acquired:
+		logger.Debugf("Job %s:%s is deferred by the rate limits", j.Name, j.ID)
+		j.Fails--
+		return errRateLimited
+	default:
+		defer func() {
+			if er := w.rates.release(scopes, jID); er != nil {
+				logger.Errorf("Failed to release the rate limit tokens for job %s:%s: %s", j.Name, j.ID, er)
+			}
+		}()
+		// Keep the running tokens alive with the heartbeat of the job
+		return redisJob.RunWithHeartbeat(j, func() {
+			if er := w.rates.refresh(scopes, jID); er != nil {
+				logger.Errorf("Failed to refresh the rate limit tokens for job %s:%s: %s", j.Name, j.ID, er)
+			}
+		})
+	}
+
+	return redisJob.Run(j)
 }
 
 // Ping the redis server
@@ -0,0 +1,100 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cworker
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+const prioritySyncInterval = 30 * time.Second
+
+// prioritySyncer keeps the job priorities in sync with the ones adjusted at runtime via the job monitor API.
+// The tenant settings of the fair queuing take effect immediately, while the priorities of the job types
+// are applied by the onSync callback.
+type prioritySyncer struct {
+	context   context.Context
+	namespace string
+	pool      *redis.Pool
+	// the priorities loaded from the configuration file
+	configured *job.Priorities
+	// called after each sync
+	onSync func()
+}
+
+// start the sync loop
+// Non blocking call
+func (ps *prioritySyncer) start() {
+	go func() {
+		defer logger.Info("Priority syncer is stopped")
+
+		tk := time.NewTicker(prioritySyncInterval)
+		defer tk.Stop()
+
+		logger.Info("Priority syncer is started")
+		for {
+			select {
+			case <-tk.C:
+				if err := ps.sync(); err != nil {
+					// Just log
+					logger.Error(err)
+				}
+				if ps.onSync != nil {
+					ps.onSync()
+				}
+			case <-ps.context.Done():
+				return // Terminated
+			}
+		}
+	}()
+}
+
+// sync the priorities adjusted at runtime, fall back to the configured ones if there are no adjustments.
+func (ps *prioritySyncer) sync() error {
+	conn := ps.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	data, err := redis.Bytes(conn.Do("GET", rds.KeyJobPriorities(ps.namespace)))
+	if err != nil {
+		if errors.Is(err, redis.ErrNil) {
+			job.SetPriorities(ps.configured)
+			return nil
+		}
+
+		return errors.Wrap(err, "sync job priorities")
+	}
+
+	p := &job.Priorities{}
+	if err := json.Unmarshal(data, p); err != nil {
+		return errors.Wrap(err, "sync job priorities")
+	}
+	if err := p.Validate(); err != nil {
+		return errors.Wrap(err, "sync job priorities")
+	}
+
+	job.SetPriorities(p)
+
+	return nil
+}
@@ -0,0 +1,194 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cworker
+
+import (
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/runner"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+const (
+	rateLimitScopeVendorType = "vendor_types"
+	rateLimitScopeEndpoint   = "endpoints"
+)
+
+// errRateLimited is returned when the job is deferred as the rate limits of its vendor type or remote endpoint are reached
+var errRateLimited = errors.New("rate limit of the job is reached, deferred")
+
+// Check all the scopes first and take the tokens of all the scopes only if none of the limits is reached,
+// the stale running jobs left by the crashed worker pools are removed before checking.
+// KEYS[2i-1]: the running jobs of the scope i, KEYS[2i]: the count of the jobs started in this second of the scope i
+// ARGV[1]: the job ID, ARGV[2]: now, ARGV[3]: the stale before, ARGV[4]: the expiration of the running jobs key,
+// ARGV[3+2i]: the max concurrency of the scope i, ARGV[4+2i]: the max jobs per second of the scope i
+var acquireRateLimitScript = redis.NewScript(-1, `
+local n = #KEYS / 2
+for i = 1, n do
+  local concurrency = tonumber(ARGV[3 + 2 * i])
+  local rate = tonumber(ARGV[4 + 2 * i])
+  if concurrency > 0 then
+    redis.call('ZREMRANGEBYSCORE', KEYS[2 * i - 1], '-inf', ARGV[3])
+    if not redis.call('ZSCORE', KEYS[2 * i - 1], ARGV[1]) and redis.call('ZCARD', KEYS[2 * i - 1]) >= concurrency then
+      return 0
+    end
+  end
+  if rate > 0 and tonumber(redis.call('GET', KEYS[2 * i]) or '0') >= rate then
+    return 0
+  end
+end
+for i = 1, n do
+  if tonumber(ARGV[3 + 2 * i]) > 0 then
+    redis.call('ZADD', KEYS[2 * i - 1], ARGV[2], ARGV[1])
+    redis.call('EXPIRE', KEYS[2 * i - 1], ARGV[4])
+  end
+  if tonumber(ARGV[4 + 2 * i]) > 0 then
+    redis.call('INCR', KEYS[2 * i])
+    redis.call('EXPIRE', KEYS[2 * i], 2)
+  end
+end
+return 1
+`)
+
+// rateLimitScope is the vendor type or the remote endpoint shared by the jobs limited by the same rate limit
+type rateLimitScope struct {
+	kind  string
+	name  string
+	limit *config.RateLimit
+}
+
+// rateLimiter limits the running jobs and the started jobs per second of each vendor type and
+// remote endpoint with the tokens kept in redis, so that the large executions do not hammer the
+// downstream services.
+type rateLimiter struct {
+	namespace string
+	pool      *redis.Pool
+	limits    *config.RateLimitConfig
+}
+
+// newRateLimiter is constructor of rateLimiter
+func newRateLimiter(namespace string, pool *redis.Pool, limits *config.RateLimitConfig) *rateLimiter {
+	return &rateLimiter{
+		namespace: namespace,
+		pool:      pool,
+		limits:    limits,
+	}
+}
+
+// scopes returns the rate limit scopes of the job, nil means the job is not limited
+func (rl *rateLimiter) scopes(vendorType string, j job.Interface, params job.Parameters) []*rateLimitScope {
+	if rl == nil || rl.limits == nil {
+		return nil
+	}
+
+	var scopes []*rateLimitScope
+	if l, ok := rl.limits.VendorTypes[vendorType]; ok && limited(l) {
+		scopes = append(scopes, &rateLimitScope{kind: rateLimitScopeVendorType, name: vendorType, limit: l})
+	}
+
+	if len(rl.limits.Endpoints) == 0 {
+		return scopes
+	}
+	if ep, ok := j.(job.EndpointProvider); ok {
+		endpoint := ep.Endpoint(params)
+		if l, ok := rl.limits.Endpoints[endpoint]; ok && limited(l) {
+			scopes = append(scopes, &rateLimitScope{kind: rateLimitScopeEndpoint, name: endpoint, limit: l})
+		}
+	}
+
+	return scopes
+}
+
+// acquire the tokens of all the scopes for the job.
+// Returns false if any limit of the scopes is reached.
+func (rl *rateLimiter) acquire(scopes []*rateLimitScope, jobID string) (bool, error) {
+	conn := rl.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// The running job not refreshed by its heartbeat is treated as stale
+	ttl := runner.SlotStaleWindow
+	now := time.Now()
+	keys := make([]any, 0, 2*len(scopes))
+	limits := make([]any, 0, 2*len(scopes))
+	for _, s := range scopes {
+		keys = append(keys,
+			rds.KeyRateLimitRunningJobs(rl.namespace, s.kind, s.name),
+			rds.KeyRateLimitStartedJobs(rl.namespace, s.kind, s.name, now.Unix()),
+		)
+		limits = append(limits, s.limit.MaxConcurrency, s.limit.MaxPerSecond)
+	}
+
+	args := []any{len(keys)}
+	args = append(args, keys...)
+	args = append(args, jobID, now.Unix(), now.Add(-ttl).Unix(), int64(ttl.Seconds()))
+	args = append(args, limits...)
+	ok, err := redis.Int(acquireRateLimitScript.Do(conn, args...))
+	if err != nil {
+		return false, errors.Wrap(err, "acquire rate limit tokens")
+	}
+
+	return ok == 1, nil
+}
+
+// refresh the running tokens of the job to keep them alive, it's called along with the heartbeat of the job.
+func (rl *rateLimiter) refresh(scopes []*rateLimitScope, jobID string) error {
+	conn := rl.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	now := time.Now().Unix()
+	for _, s := range scopes {
+		if s.limit.MaxConcurrency == 0 {
+			continue
+		}
+		// Only update the score of the existing token
+		if _, err := conn.Do("ZADD", rds.KeyRateLimitRunningJobs(rl.namespace, s.kind, s.name), "XX", now, jobID); err != nil {
+			return errors.Wrap(err, "refresh rate limit tokens")
+		}
+	}
+
+	return nil
+}
+
+// release the running tokens of the job.
+func (rl *rateLimiter) release(scopes []*rateLimitScope, jobID string) error {
+	conn := rl.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	for _, s := range scopes {
+		if s.limit.MaxConcurrency == 0 {
+			continue
+		}
+		if _, err := conn.Do("ZREM", rds.KeyRateLimitRunningJobs(rl.namespace, s.kind, s.name), jobID); err != nil {
+			return errors.Wrap(err, "release rate limit tokens")
+		}
+	}
+
+	return nil
+}
+
+func limited(l *config.RateLimit) bool {
+	return l != nil && (l.MaxConcurrency > 0 || l.MaxPerSecond > 0)
+}
@@ -0,0 +1,180 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cworker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/job/impl/sample"
+	"github.com/goharbor/harbor/src/jobservice/runner"
+	"github.com/goharbor/harbor/src/jobservice/tests"
+)
+
+// RateLimiterTestSuite tests functions of rate limiter
+type RateLimiterTestSuite struct {
+	suite.Suite
+
+	namespace string
+	pool      *redis.Pool
+	limiter   *rateLimiter
+}
+
+// TestRateLimiterTestSuite is entry of go test
+func TestRateLimiterTestSuite(t *testing.T) {
+	suite.Run(t, new(RateLimiterTestSuite))
+}
+
+// SetupSuite prepares test suite
+func (suite *RateLimiterTestSuite) SetupSuite() {
+	suite.namespace = tests.GiveMeTestNamespace()
+	suite.pool = tests.GiveMeRedisPool()
+	suite.limiter = newRateLimiter(suite.namespace, suite.pool, &config.RateLimitConfig{
+		VendorTypes: map[string]*config.RateLimit{
+			"REPLICATION": {MaxConcurrency: 2},
+			"WEBHOOK":     {MaxPerSecond: 100},
+			"SCAN":        {},
+		},
+		Endpoints: map[string]*config.RateLimit{
+			"registry.example.com": {MaxConcurrency: 1},
+		},
+	})
+}
+
+// TearDownSuite clears the test suite
+func (suite *RateLimiterTestSuite) TearDownSuite() {
+	conn := suite.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_ = tests.ClearAll(suite.namespace, conn)
+}
+
+// TestScopes tests resolving the rate limit scopes of the jobs
+func (suite *RateLimiterTestSuite) TestScopes() {
+	suite.Len(suite.limiter.scopes("REPLICATION", &sample.Job{}, nil), 1)
+	// zero limits
+	suite.Nil(suite.limiter.scopes("SCAN", &sample.Job{}, nil))
+	// not configured
+	suite.Nil(suite.limiter.scopes("GARBAGE_COLLECTION", &sample.Job{}, nil))
+	// endpoint
+	scopes := suite.limiter.scopes("REPLICATION", &fakeEndpointJob{}, job.Parameters{"address": "registry.example.com"})
+	suite.Require().Len(scopes, 2)
+	suite.Equal(rateLimitScopeEndpoint, scopes[1].kind)
+	suite.Equal("registry.example.com", scopes[1].name)
+	// no limits configured
+	suite.Nil((*rateLimiter)(nil).scopes("REPLICATION", &sample.Job{}, nil))
+}
+
+// TestAcquireAndRelease tests acquiring and releasing the rate limit tokens
+func (suite *RateLimiterTestSuite) TestAcquireAndRelease() {
+	replication := suite.limiter.scopes("REPLICATION", &sample.Job{}, nil)
+	ok, err := suite.limiter.acquire(replication, "job-1")
+	suite.Require().NoError(err)
+	suite.True(ok)
+
+	// Acquire again by the same job
+	ok, err = suite.limiter.acquire(replication, "job-1")
+	suite.Require().NoError(err)
+	suite.True(ok)
+
+	// The endpoint allows only one running job
+	endpoint := suite.limiter.scopes("REPLICATION", &fakeEndpointJob{}, job.Parameters{"address": "registry.example.com"})
+	ok, err = suite.limiter.acquire(endpoint, "job-2")
+	suite.Require().NoError(err)
+	suite.True(ok)
+
+	ok, err = suite.limiter.acquire(endpoint, "job-3")
+	suite.Require().NoError(err)
+	suite.False(ok, "expect no token left for the endpoint")
+
+	// The vendor type allows two running jobs
+	ok, err = suite.limiter.acquire(replication, "job-4")
+	suite.Require().NoError(err)
+	suite.False(ok, "expect no token left for the vendor type")
+
+	suite.Require().NoError(suite.limiter.release(endpoint, "job-2"))
+	ok, err = suite.limiter.acquire(replication, "job-4")
+	suite.Require().NoError(err)
+	suite.True(ok)
+}
+
+// TestMaxPerSecond tests limiting the jobs started in one second
+func (suite *RateLimiterTestSuite) TestMaxPerSecond() {
+	webhook := suite.limiter.scopes("WEBHOOK", &sample.Job{}, nil)
+	suite.limiter.limits.VendorTypes["WEBHOOK"].MaxPerSecond = 1
+	defer func() {
+		suite.limiter.limits.VendorTypes["WEBHOOK"].MaxPerSecond = 100
+	}()
+
+	second := time.Now().Unix()
+	ok, err := suite.limiter.acquire(webhook, "job-5")
+	suite.Require().NoError(err)
+	suite.True(ok)
+
+	ok, err = suite.limiter.acquire(webhook, "job-6")
+	suite.Require().NoError(err)
+	// The tokens are refilled in the next second
+	if time.Now().Unix() == second {
+		suite.False(ok, "expect no token left in this second")
+	}
+}
+
+// TestStaleTokens tests the running tokens not refreshed by the heartbeat are reclaimed
+func (suite *RateLimiterTestSuite) TestStaleTokens() {
+	conn := suite.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	suite.limiter.limits.Endpoints["stale.example.com"] = &config.RateLimit{MaxConcurrency: 1}
+	defer delete(suite.limiter.limits.Endpoints, "stale.example.com")
+	endpoint := suite.limiter.scopes("GARBAGE_COLLECTION", &fakeEndpointJob{}, job.Parameters{"address": "stale.example.com"})
+	suite.Require().Len(endpoint, 1)
+
+	key := rds.KeyRateLimitRunningJobs(suite.namespace, rateLimitScopeEndpoint, "stale.example.com")
+	stale := time.Now().Add(-runner.SlotStaleWindow - time.Minute).Unix()
+	_, err := conn.Do("ZADD", key, stale, "job-7")
+	suite.Require().NoError(err)
+
+	// The refreshed token is kept
+	suite.Require().NoError(suite.limiter.refresh(endpoint, "job-7"))
+	ok, err := suite.limiter.acquire(endpoint, "job-8")
+	suite.Require().NoError(err)
+	suite.False(ok, "expect the refreshed token is kept")
+
+	// The stale token is reclaimed
+	_, err = conn.Do("ZADD", key, stale, "job-7")
+	suite.Require().NoError(err)
+	ok, err = suite.limiter.acquire(endpoint, "job-8")
+	suite.Require().NoError(err)
+	suite.True(ok)
+}
+
+type fakeEndpointJob struct {
+	sample.Job
+}
+
+func (f *fakeEndpointJob) Endpoint(params job.Parameters) string {
+	address, _ := params["address"].(string)
+	return address
+}
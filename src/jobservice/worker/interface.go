@@ -74,7 +74,7 @@ type Interface interface {
 	// Returns:
 	//  models.JobStats: the stats of enqueuing job if succeed
 	//  error          : if failed to enqueue
-	PeriodicallyEnqueue(jobName string, params job.Parameters, cronSetting string, isUnique bool, webHook string) (*job.Stats, error)
+	PeriodicallyEnqueue(jobName string, params job.Parameters, cronSetting string, options *job.ScheduleOptions, isUnique bool, webHook string) (*job.Stats, error)
 
 	// Return the status info of the worker.
 	//
@@ -73,6 +73,7 @@ var (
 		{Name: common.PrimaryAuthMode, Scope: UserScope, Group: BasicGroup, EnvKey: "PRIMARY_AUTH_MODE", DefaultValue: "false", ItemType: &BoolType{}, Description: `Use current auth mode as a primary one`},
 
 		{Name: common.TrivyAdapterURL, Scope: SystemScope, Group: TrivyGroup, EnvKey: "TRIVY_ADAPTER_URL", DefaultValue: "http://trivy-adapter:8080", ItemType: &StringType{}, Editable: false},
+		{Name: common.AdmissionPolicyEngineURL, Scope: SystemScope, Group: BasicGroup, EnvKey: "ADMISSION_POLICY_ENGINE_URL", DefaultValue: "", ItemType: &StringType{}, Editable: false, Description: `The endpoint URL of the OPA server to evaluate the admission policies, the actions governed by the enabled admission policies are rejected when it's empty`},
 
 		{Name: common.CoreURL, Scope: SystemScope, Group: BasicGroup, EnvKey: "CORE_URL", DefaultValue: "http://core:8080", ItemType: &StringType{}, Editable: false},
 		{Name: common.CoreLocalURL, Scope: SystemScope, Group: BasicGroup, EnvKey: "CORE_LOCAL_URL", DefaultValue: "http://127.0.0.1:8080", ItemType: &StringType{}, Editable: false},
@@ -192,11 +193,19 @@ var (
 		{Name: common.AuditLogForwardEndpoint, Scope: UserScope, Group: BasicGroup, EnvKey: "AUDIT_LOG_FORWARD_ENDPOINT", DefaultValue: "", ItemType: &StringType{}, Editable: false, Description: `The endpoint to forward the audit log.`},
 		{Name: common.SkipAuditLogDatabase, Scope: UserScope, Group: BasicGroup, EnvKey: "SKIP_LOG_AUDIT_DATABASE", DefaultValue: "false", ItemType: &BoolType{}, Editable: false, Description: `The option to skip audit log in database`},
 		{Name: common.ScannerSkipUpdatePullTime, Scope: UserScope, Group: BasicGroup, EnvKey: "SCANNER_SKIP_UPDATE_PULL_TIME", DefaultValue: "false", ItemType: &BoolType{}, Editable: false, Description: `The option to skip update pull time for scanner`},
+		{Name: common.ScanOnDBUpdateEnabled, Scope: UserScope, Group: BasicGroup, EnvKey: "SCAN_ON_DB_UPDATE_ENABLED", DefaultValue: "false", ItemType: &BoolType{}, Editable: false, Description: `The option to rescan the recently pulled artifacts when the vulnerability database of the scanner updates`},
+		{Name: common.VulnerabilitySLADays, Scope: UserScope, Group: BasicGroup, EnvKey: "VULNERABILITY_SLA_DAYS", DefaultValue: `{"Critical":7,"High":30,"Medium":90,"Low":180}`, ItemType: &StringToIntMapType{}, Editable: false, Description: `The days to fix the vulnerabilities of each severity, the remediations of the severities not listed have no due time by default`},
+		{Name: common.ScanOnDBUpdatePulledWithinDays, Scope: UserScope, Group: BasicGroup, EnvKey: "SCAN_ON_DB_UPDATE_PULLED_WITHIN_DAYS", DefaultValue: "30", ItemType: &IntType{}, Editable: false, Description: `The artifacts pulled within the days are rescanned when the vulnerability database of the scanner updates`},
 		{Name: common.AuditLogEventsDisabled, Scope: UserScope, Group: BasicGroup, EnvKey: "AUDIT_LOG_EVENTS_DISABLED", DefaultValue: "", ItemType: &StringType{}, Editable: false, Description: `The option to skip audit log for some operations, the key is <operation>_<resource_type> like create_user, delete_user, separated by comma`},
 
 		{Name: common.SessionTimeout, Scope: UserScope, Group: BasicGroup, EnvKey: "SESSION_TIMEOUT", DefaultValue: "60", ItemType: &Int64Type{}, Editable: true, Description: `The session timeout in minutes`},
 
 		{Name: common.ExecutionStatusRefreshIntervalSeconds, Scope: SystemScope, Group: BasicGroup, EnvKey: "EXECUTION_STATUS_REFRESH_INTERVAL_SECONDS", DefaultValue: "30", ItemType: &Int64Type{}, Editable: false, Description: `The interval seconds to refresh the execution status`},
+		{Name: common.TaskReconcileIntervalSeconds, Scope: SystemScope, Group: BasicGroup, EnvKey: "TASK_RECONCILE_INTERVAL_SECONDS", DefaultValue: "300", ItemType: &Int64Type{}, Editable: false, Description: `The interval seconds to reconcile the stuck tasks with jobservice, 0 means disabled`},
+		{Name: common.TaskHeartbeatTimeoutSeconds, Scope: SystemScope, Group: BasicGroup, EnvKey: "TASK_HEARTBEAT_TIMEOUT_SECONDS", DefaultValue: "1800", ItemType: &Int64Type{}, Editable: false, Description: `The seconds after which the running task without heartbeat is marked as error`},
+		{Name: common.TaskMaxRelaunches, Scope: SystemScope, Group: BasicGroup, EnvKey: "TASK_MAX_RELAUNCHES", DefaultValue: "3", ItemType: &Int64Type{}, Editable: false, Description: `The max times that a stuck task of the idempotent vendor type is re-launched`},
+		{Name: common.CosignFulcioRootsPath, Scope: SystemScope, Group: BasicGroup, EnvKey: "COSIGN_FULCIO_ROOTS_PATH", DefaultValue: "/etc/core/cosign/fulcio_roots.pem", ItemType: &StringType{}, Editable: false, Description: `The path of the PEM file holding the Fulcio certificates trusted by the cosign keyless verification`},
+		{Name: common.CosignRekorPublicKeysPath, Scope: SystemScope, Group: BasicGroup, EnvKey: "COSIGN_REKOR_PUBLIC_KEYS_PATH", DefaultValue: "/etc/core/cosign/rekor_public_keys.pem", ItemType: &StringType{}, Editable: false, Description: `The path of the PEM file holding the Rekor public keys trusted by the cosign keyless verification`},
 
 		{Name: common.BannerMessage, Scope: UserScope, Group: BasicGroup, EnvKey: "BANNER_MESSAGE", DefaultValue: "", ItemType: &StringType{}, Editable: true, Description: `The customized banner message for the UI`},
 		{Name: common.QuotaUpdateProvider, Scope: SystemScope, Group: BasicGroup, EnvKey: "QUOTA_UPDATE_PROVIDER", DefaultValue: "db", ItemType: &StringType{}, Editable: false, Description: `The provider for updating quota, 'db' or 'redis' is supported`},
@@ -217,6 +217,29 @@ func (t *StringToStringMapType) get(str string) (any, error) {
 	return result, err
 }
 
+// StringToIntMapType ...
+type StringToIntMapType struct {
+}
+
+func (t *StringToIntMapType) validate(str string) error {
+	result := map[string]int{}
+	if err := json.Unmarshal([]byte(str), &result); err != nil {
+		return err
+	}
+	for k, v := range result {
+		if v < 0 {
+			return fmt.Errorf("the value of %s must not be negative", k)
+		}
+	}
+	return nil
+}
+
+func (t *StringToIntMapType) get(str string) (any, error) {
+	result := map[string]int{}
+	err := json.Unmarshal([]byte(str), &result)
+	return result, err
+}
+
 // QuotaType ...
 type QuotaType struct {
 	Int64Type
@@ -110,6 +110,19 @@ func TestStringToStringMapType_get(t *testing.T) {
 	assert.Equal(t, map[string]string{"sample": "abc", "another": "welcome"}, result)
 }
 
+func TestStringToIntMapType_validate(t *testing.T) {
+	test := &StringToIntMapType{}
+	assert.Nil(t, test.validate(`{"Critical":7, "High":30}`))
+	assert.NotNil(t, test.validate(`{"Critical":"7"}`))
+	assert.NotNil(t, test.validate(`{"Critical":-1}`))
+}
+
+func TestStringToIntMapType_get(t *testing.T) {
+	test := &StringToIntMapType{}
+	result, _ := test.get(`{"Critical":7, "High":30}`)
+	assert.Equal(t, map[string]int{"Critical": 7, "High": 30}, result)
+}
+
 func TestDurationType(t *testing.T) {
 	test := &DurationType{}
 	// test get
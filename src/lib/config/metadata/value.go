@@ -145,6 +145,23 @@ func (c *ConfigureValue) GetStringToStringMap() map[string]string {
 	return result
 }
 
+// GetStringToIntMap - return the string to int map of current value
+func (c *ConfigureValue) GetStringToIntMap() map[string]int {
+	result := map[string]int{}
+	if item, ok := Instance().GetByName(c.Name); ok {
+		val, err := item.ItemType.get(c.Value)
+		if err != nil {
+			log.Errorf("The GetStringToIntMap failed, error: %+v", err)
+			return result
+		}
+		if mapValue, suc := val.(map[string]int); suc {
+			return mapValue
+		}
+	}
+	log.Errorf("GetStringToIntMap failed, current value's metadata is not defined, %+v", c)
+	return result
+}
+
 // GetDuration - return the time.Duration value of current value
 func (c *ConfigureValue) GetDuration() time.Duration {
 	if item, ok := Instance().GetByName(c.Name); ok {
@@ -127,6 +127,31 @@ func GetExecutionStatusRefreshIntervalSeconds() int64 {
 	return DefaultMgr().Get(backgroundCtx, common.ExecutionStatusRefreshIntervalSeconds).GetInt64()
 }
 
+// GetTaskReconcileIntervalSeconds returns the interval seconds for reconciling the stuck tasks.
+func GetTaskReconcileIntervalSeconds() int64 {
+	return DefaultMgr().Get(backgroundCtx, common.TaskReconcileIntervalSeconds).GetInt64()
+}
+
+// GetTaskHeartbeatTimeoutSeconds returns the seconds after which the running task without heartbeat is stuck.
+func GetTaskHeartbeatTimeoutSeconds() int64 {
+	return DefaultMgr().Get(backgroundCtx, common.TaskHeartbeatTimeoutSeconds).GetInt64()
+}
+
+// GetTaskMaxRelaunches returns the max times that a stuck task can be re-launched.
+func GetTaskMaxRelaunches() int64 {
+	return DefaultMgr().Get(backgroundCtx, common.TaskMaxRelaunches).GetInt64()
+}
+
+// GetCosignFulcioRootsPath returns the path of the Fulcio certificates trusted by the cosign keyless verification.
+func GetCosignFulcioRootsPath() string {
+	return DefaultMgr().Get(backgroundCtx, common.CosignFulcioRootsPath).GetString()
+}
+
+// GetCosignRekorPublicKeysPath returns the path of the Rekor public keys trusted by the cosign keyless verification.
+func GetCosignRekorPublicKeysPath() string {
+	return DefaultMgr().Get(backgroundCtx, common.CosignRekorPublicKeysPath).GetString()
+}
+
 // GetQuotaUpdateProvider returns the provider for updating quota.
 func GetQuotaUpdateProvider() string {
 	return DefaultMgr().Get(backgroundCtx, common.QuotaUpdateProvider).GetString()
@@ -205,6 +230,11 @@ func TrivyAdapterURL() string {
 	return DefaultMgr().Get(backgroundCtx, common.TrivyAdapterURL).GetString()
 }
 
+// AdmissionPolicyEngineURL returns the endpoint URL of the OPA server which evaluates the admission policies
+func AdmissionPolicyEngineURL() string {
+	return strings.TrimSuffix(DefaultMgr().Get(backgroundCtx, common.AdmissionPolicyEngineURL).GetString(), "/")
+}
+
 // Metric returns the overall metric settings
 func Metric() *models.Metric {
 	return &models.Metric{
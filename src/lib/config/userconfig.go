@@ -258,6 +258,21 @@ func ScannerSkipUpdatePullTime(ctx context.Context) bool {
 	return DefaultMgr().Get(ctx, common.ScannerSkipUpdatePullTime).GetBool()
 }
 
+// ScanOnDBUpdateEnabled returns whether to rescan the artifacts when the vulnerability database of the scanner updates
+func ScanOnDBUpdateEnabled(ctx context.Context) bool {
+	return DefaultMgr().Get(ctx, common.ScanOnDBUpdateEnabled).GetBool()
+}
+
+// ScanOnDBUpdatePulledWithinDays returns the days within which the pulled artifacts are rescanned when the database updates
+func ScanOnDBUpdatePulledWithinDays(ctx context.Context) int {
+	return DefaultMgr().Get(ctx, common.ScanOnDBUpdatePulledWithinDays).GetInt()
+}
+
+// VulnerabilitySLADays returns the days to fix the vulnerabilities of each severity
+func VulnerabilitySLADays(ctx context.Context) map[string]int {
+	return DefaultMgr().Get(ctx, common.VulnerabilitySLADays).GetStringToIntMap()
+}
+
 // BannerMessage returns the customized banner message
 func BannerMessage(ctx context.Context) string {
 	return DefaultMgr().Get(ctx, common.BannerMessage).GetString()
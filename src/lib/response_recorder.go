@@ -47,6 +47,11 @@ func (r *ResponseRecorder) WriteHeader(statusCode int) {
 	}
 }
 
+// Unwrap returns the underlying writer, so the features like flushing can be reached by http.ResponseController
+func (r *ResponseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 // Success checks whether the status code is >= 200 & <= 399
 func (r *ResponseRecorder) Success() bool {
 	statusCode := r.StatusCode
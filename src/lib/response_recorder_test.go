@@ -52,6 +52,13 @@ func (r *responseRecorderTestSuite) TestSuccess() {
 	r.False(r.recorder.Success())
 }
 
+func (r *responseRecorderTestSuite) TestFlush() {
+	w := httptest.NewRecorder()
+	r.recorder = NewResponseRecorder(w)
+	r.Require().Nil(http.NewResponseController(r.recorder).Flush())
+	r.True(w.Flushed)
+}
+
 func TestResponseRecorder(t *testing.T) {
 	suite.Run(t, &responseRecorderTestSuite{})
 }
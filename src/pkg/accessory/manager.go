@@ -34,6 +34,8 @@ var (
 		model.TypeNotationSignature: icon.DigestOfIconAccNotation,
 		model.TypeNydusAccelerator:  icon.DigestOfIconAccNydus,
 		model.TypeHarborSBOM:        icon.DigestOfIconAccSBOM,
+		model.TypeCycloneDXSBOM:     icon.DigestOfIconAccSBOM,
+		model.TypeSPDXSBOM:          icon.DigestOfIconAccSBOM,
 	}
 )
 
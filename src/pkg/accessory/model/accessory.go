@@ -79,8 +79,28 @@ const (
 
 	// TypeHarborSBOM identifies sbom.harbor
 	TypeHarborSBOM = "sbom.harbor"
+
+	// TypeOpenVEX identifies vex.openvex
+	TypeOpenVEX = "vex.openvex"
+
+	// TypeCycloneDXVEX identifies vex.cyclonedx
+	TypeCycloneDXVEX = "vex.cyclonedx"
+
+	// TypeCycloneDXSBOM identifies sbom.cyclonedx
+	TypeCycloneDXSBOM = "sbom.cyclonedx"
+
+	// TypeSPDXSBOM identifies sbom.spdx
+	TypeSPDXSBOM = "sbom.spdx"
+
+	// TypeInTotoAttestation identifies attestation.intoto
+	TypeInTotoAttestation = "attestation.intoto"
 )
 
+// IsSBOM returns true when the accessory type is SBOM, no matter it's generated by Harbor or pushed by the users
+func IsSBOM(typ string) bool {
+	return typ == TypeHarborSBOM || typ == TypeCycloneDXSBOM || typ == TypeSPDXSBOM
+}
+
 // AccessoryData ...
 type AccessoryData struct {
 	ID                int64     `json:"id"`
@@ -41,6 +41,30 @@ func New(data model.AccessoryData) model.Accessory {
 	}}
 }
 
+// SBOM is the CycloneDX or SPDX SBOM accessory generated outside of Harbor and pushed with the subject field
+type SBOM struct {
+	base.Default
+}
+
+// Kind gives the reference type of accessory.
+func (s *SBOM) Kind() string {
+	return model.RefHard
+}
+
+// IsHard ...
+func (s *SBOM) IsHard() bool {
+	return true
+}
+
+// NewSBOM returns the external sbom accessory
+func NewSBOM(data model.AccessoryData) model.Accessory {
+	return &SBOM{base.Default{
+		Data: data,
+	}}
+}
+
 func init() {
 	model.Register(model.TypeHarborSBOM, New)
+	model.Register(model.TypeCycloneDXSBOM, NewSBOM)
+	model.Register(model.TypeSPDXSBOM, NewSBOM)
 }
@@ -82,6 +82,16 @@ func (suite *SBOMTestSuite) TestDisplay() {
 	suite.False(suite.accessory.Display())
 }
 
+func (suite *SBOMTestSuite) TestExternalSBOM() {
+	for _, typ := range []string{model.TypeCycloneDXSBOM, model.TypeSPDXSBOM} {
+		acc, err := model.New(typ, model.AccessoryData{ArtifactID: 1})
+		suite.Require().Nil(err)
+		suite.Equal(typ, acc.GetData().Type)
+		suite.True(acc.IsHard())
+		suite.True(model.IsSBOM(acc.GetData().Type))
+	}
+}
+
 func TestSBOMTestSuite(t *testing.T) {
 	suite.Run(t, new(SBOMTestSuite))
 }
@@ -0,0 +1,214 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	commonhttp "github.com/goharbor/harbor/src/common/http"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/admission/model"
+)
+
+// the rule queried from the package of each policy, the policy denies the action by
+// defining the "deny" partial set rule whose elements are the reasons, e.g.
+//
+//	package require_signature
+//
+//	deny contains msg if {
+//		count([a | some a in input.artifact.accessories; a.type == "signature.cosign"]) == 0
+//		msg := "the artifact isn't signed by cosign"
+//	}
+const denyRule = "deny"
+
+var denyRuleRegexp = regexp.MustCompile(`(?m)^[ \t]*` + denyRule + `\b`)
+
+var packageRegexp = regexp.MustCompile(`(?m)^[ \t]*package[ \t]+([A-Za-z_][A-Za-z0-9_]*(?:\.[A-Za-z_][A-Za-z0-9_]*)*)[ \t\r]*$`)
+
+// PackageOf returns the package declared in the Rego module
+func PackageOf(module string) (string, error) {
+	matches := packageRegexp.FindStringSubmatch(module)
+	if len(matches) != 2 {
+		return "", errors.BadRequestError(nil).WithMessage("the package declaration is required in the admission policy")
+	}
+	return matches[1], nil
+}
+
+// isolate replaces the package declared in the module of the policy with the one owned by the policy,
+// so the rules of the policies with the same package declaration don't merge in the OPA server, the
+// update time is part of the package so a module uploaded before the policy is updated is never queried
+func isolate(policy *model.Policy) (pkg string, module string, err error) {
+	if _, err := PackageOf(policy.Module); err != nil {
+		return "", "", err
+	}
+
+	pkg = fmt.Sprintf("harbor.admission.policy_%d_%d", policy.ID, policy.UpdateTime.UnixNano())
+	module = packageRegexp.ReplaceAllLiteralString(policy.Module, "package "+pkg)
+	return pkg, module, nil
+}
+
+// Decision is the result of evaluating the admission policies
+type Decision struct {
+	Allowed bool `json:"allowed"`
+	// Reasons are the reasons of denying the action, each one is prefixed by the name of the policy
+	Reasons []string `json:"reasons"`
+}
+
+// Engine evaluates the admission policies against the input
+type Engine interface {
+	// Evaluate the policies against the input, the action is denied when any of the policies denies it
+	Evaluate(ctx context.Context, policies []*model.Policy, input *Input) (*Decision, error)
+}
+
+// NewOPAEngine returns an engine which evaluates the policies by the OPA server listening on the endpoint
+func NewOPAEngine(endpoint string) Engine {
+	return &opaEngine{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client: &http.Client{
+			Transport: commonhttp.GetHTTPTransport(),
+			Timeout:   10 * time.Second,
+		},
+	}
+}
+
+type opaEngine struct {
+	endpoint string
+	client   *http.Client
+}
+
+func (o *opaEngine) Evaluate(ctx context.Context, policies []*model.Policy, input *Input) (*Decision, error) {
+	decision := &Decision{Allowed: true}
+	for _, policy := range policies {
+		pkg, module, err := isolate(policy)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid admission policy %s", policy.Name)
+		}
+
+		reasons, defined, err := o.query(ctx, pkg, input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to evaluate admission policy %s", policy.Name)
+		}
+		// the module isn't loaded in the OPA server, e.g. the server restarted or the policy was updated,
+		// upload it and query again
+		if !defined {
+			if err := o.upload(ctx, policy, module); err != nil {
+				return nil, err
+			}
+			if reasons, defined, err = o.query(ctx, pkg, input); err != nil {
+				return nil, errors.Wrapf(err, "failed to evaluate admission policy %s", policy.Name)
+			}
+		}
+		// fail closed, an undefined result must not admit the action
+		if !defined {
+			return nil, errors.Errorf("the %s rule of admission policy %s is undefined", denyRule, policy.Name)
+		}
+
+		for _, reason := range reasons {
+			decision.Allowed = false
+			decision.Reasons = append(decision.Reasons, fmt.Sprintf("%s: %s", policy.Name, reason))
+		}
+	}
+
+	return decision, nil
+}
+
+// upload the module of the policy to the OPA server by the policy API
+func (o *opaEngine) upload(ctx context.Context, policy *model.Policy, module string) error {
+	url := fmt.Sprintf("%s/v1/policies/harbor/%d", o.endpoint, policy.ID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, strings.NewReader(module))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+
+	if _, err := o.do(req); err != nil {
+		return errors.Wrapf(err, "failed to upload admission policy %s", policy.Name)
+	}
+
+	return nil
+}
+
+// query the deny rule of the package by the data API, returns false when the result is undefined
+func (o *opaEngine) query(ctx context.Context, pkg string, input *Input) ([]string, bool, error) {
+	body, err := json.Marshal(map[string]any{"input": input})
+	if err != nil {
+		return nil, false, err
+	}
+
+	url := fmt.Sprintf("%s/v1/data/%s/%s", o.endpoint, strings.ReplaceAll(pkg, ".", "/"), denyRule)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	data, err := o.do(req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// the result is undefined when the package isn't loaded or the deny rule isn't defined in it
+	result := struct {
+		Result *[]any `json:"result"`
+	}{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, false, errors.Wrap(err, "failed to decode the result of the OPA server")
+	}
+	if result.Result == nil {
+		return nil, false, nil
+	}
+
+	var reasons []string
+	for _, r := range *result.Result {
+		if s, ok := r.(string); ok {
+			reasons = append(reasons, s)
+			continue
+		}
+		b, _ := json.Marshal(r)
+		reasons = append(reasons, string(b))
+	}
+
+	return reasons, true, nil
+}
+
+func (o *opaEngine) do(req *http.Request) ([]byte, error) {
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode == http.StatusBadRequest {
+			return nil, errors.BadRequestError(nil).WithMessagef("OPA server rejected the request: %s", string(data))
+		}
+		return nil, errors.Errorf("unexpected status code %d from OPA server: %s", resp.StatusCode, string(data))
+	}
+
+	return data, nil
+}
@@ -0,0 +1,107 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/pkg/admission/model"
+)
+
+func TestPackageOf(t *testing.T) {
+	pkg, err := PackageOf("# comment\npackage harbor.require_labels\n\nimport rego.v1\n")
+	require.Nil(t, err)
+	assert.Equal(t, "harbor.require_labels", pkg)
+
+	_, err = PackageOf("deny contains msg if { msg := \"denied\" }")
+	assert.NotNil(t, err)
+}
+
+func TestOPAEngineEvaluate(t *testing.T) {
+	now := time.Now()
+	pkg1 := fmt.Sprintf("/v1/data/harbor/admission/policy_1_%d/deny", now.UnixNano())
+	pkg2 := fmt.Sprintf("/v1/data/harbor/admission/policy_2_%d/deny", now.UnixNano())
+
+	// modules loaded in the fake OPA server
+	modules := map[string]string{}
+	uploads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut:
+			uploads++
+			data, _ := io.ReadAll(r.Body)
+			modules[r.URL.Path] = string(data)
+			w.Write([]byte("{}"))
+		case r.Method == http.MethodPost && r.URL.Path == pkg1 && modules["/v1/policies/harbor/1"] != "":
+			body := map[string]*Input{}
+			require.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "pull", body["input"].Action)
+			w.Write([]byte(`{"result": ["the artifact isn't signed"]}`))
+		case r.Method == http.MethodPost && r.URL.Path == pkg2 && modules["/v1/policies/harbor/2"] != "":
+			w.Write([]byte(`{"result": []}`))
+		case r.Method == http.MethodPost:
+			// undefined
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	engine := NewOPAEngine(server.URL + "/")
+	policies := []*model.Policy{
+		{ID: 1, Name: "require-signature", Module: "package require_signature\n\ndeny contains \"denied\"\n", UpdateTime: now},
+		{ID: 2, Name: "noop", Module: "package noop\n\ndeny contains msg if { false; msg := \"never\" }\n", UpdateTime: now},
+	}
+
+	decision, err := engine.Evaluate(context.Background(), policies, &Input{Action: model.ActionPull})
+	require.Nil(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, []string{"require-signature: the artifact isn't signed"}, decision.Reasons)
+	assert.Equal(t, fmt.Sprintf("package harbor.admission.policy_1_%d\n\ndeny contains \"denied\"\n", now.UnixNano()), modules["/v1/policies/harbor/1"])
+	assert.Equal(t, 2, uploads)
+
+	// the loaded policies are not uploaded again
+	_, err = engine.Evaluate(context.Background(), policies, &Input{Action: model.ActionPull})
+	require.Nil(t, err)
+	assert.Equal(t, 2, uploads)
+
+	// the OPA server restarted, the policies are uploaded again
+	clear(modules)
+	decision, err = engine.Evaluate(context.Background(), policies, &Input{Action: model.ActionPull})
+	require.Nil(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 4, uploads)
+
+	// allowed
+	decision, err = engine.Evaluate(context.Background(), policies[1:], &Input{Action: model.ActionPull})
+	require.Nil(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Empty(t, decision.Reasons)
+
+	// the deny rule is still undefined after uploading, fail closed
+	_, err = engine.Evaluate(context.Background(), []*model.Policy{{ID: 3, Name: "unknown", Module: "package unknown\n\ndeny := 1\n"}}, &Input{})
+	assert.NotNil(t, err)
+}
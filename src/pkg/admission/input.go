@@ -0,0 +1,73 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+// Input is the document evaluated by the admission policies, it's available as "input" in the Rego policies
+type Input struct {
+	// Action is the action to admit, "push" or "pull"
+	Action    string     `json:"action"`
+	Principal *Principal `json:"principal"`
+	Project   *Project   `json:"project"`
+	Artifact  *Artifact  `json:"artifact"`
+}
+
+// Principal is the requester of the action
+type Principal struct {
+	// Name is the username of the requester, it's empty for the anonymous requester
+	Name          string `json:"name"`
+	Authenticated bool   `json:"authenticated"`
+	SysAdmin      bool   `json:"sys_admin"`
+	// Type is the type of the security context, e.g. "local", "robot", "v2token"
+	Type string `json:"type"`
+}
+
+// Project is the project which the artifact belongs to
+type Project struct {
+	ID       int64             `json:"id"`
+	Name     string            `json:"name"`
+	Public   bool              `json:"public"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// Artifact is the artifact to push or pull, the labels, scan summary and accessories are only available
+// when pulling as the artifact doesn't exist yet when pushing
+type Artifact struct {
+	Repository   string            `json:"repository"`
+	Reference    string            `json:"reference"`
+	Tag          string            `json:"tag,omitempty"`
+	Digest       string            `json:"digest"`
+	MediaType    string            `json:"media_type"`
+	ArtifactType string            `json:"artifact_type,omitempty"`
+	Type         string            `json:"type,omitempty"`
+	Annotations  map[string]string `json:"annotations"`
+	Labels       []string          `json:"labels"`
+	ScanSummary  *ScanSummary      `json:"scan_summary,omitempty"`
+	Accessories  []*Accessory      `json:"accessories"`
+}
+
+// ScanSummary is the summary of the vulnerability scan report of the artifact
+type ScanSummary struct {
+	ScanStatus string `json:"scan_status"`
+	// Severity is the highest severity of the vulnerabilities which are not bypassed by the CVE allowlist
+	Severity string   `json:"severity"`
+	Total    int      `json:"total"`
+	Bypassed []string `json:"bypassed"`
+}
+
+// Accessory is the accessory attached to the artifact, e.g. signature, SBOM
+type Accessory struct {
+	Type   string `json:"type"`
+	Digest string `json:"digest"`
+}
@@ -0,0 +1,159 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"context"
+	"slices"
+
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/admission/dao"
+	"github.com/goharbor/harbor/src/pkg/admission/model"
+)
+
+// Mgr is the global admission policy manager instance
+var Mgr = NewManager()
+
+// Manager manages the admission policies and evaluates them
+type Manager interface {
+	// Create validates the admission policy and creates it
+	Create(ctx context.Context, policy *model.Policy) (id int64, err error)
+	// Get the admission policy specified by ID
+	Get(ctx context.Context, id int64) (policy *model.Policy, err error)
+	// Count returns the total count of admission policies according to the query
+	Count(ctx context.Context, query *q.Query) (total int64, err error)
+	// List the admission policies according to the query
+	List(ctx context.Context, query *q.Query) (policies []*model.Policy, err error)
+	// Update validates the admission policy and updates it
+	Update(ctx context.Context, policy *model.Policy) (err error)
+	// Delete the admission policy specified by ID
+	Delete(ctx context.Context, id int64) (err error)
+	// ListApplicable returns the enabled policies of the project and the system which apply to the action
+	ListApplicable(ctx context.Context, projectID int64, action string) (policies []*model.Policy, err error)
+	// Evaluate the policies against the input, an error is returned when the policy engine isn't configured
+	Evaluate(ctx context.Context, policies []*model.Policy, input *Input) (decision *Decision, err error)
+}
+
+// NewManager returns an instance of the default manager
+func NewManager() Manager {
+	return &manager{
+		dao: dao.New(),
+		engine: func() Engine {
+			endpoint := config.AdmissionPolicyEngineURL()
+			if endpoint == "" {
+				return nil
+			}
+			return NewOPAEngine(endpoint)
+		},
+	}
+}
+
+type manager struct {
+	dao    dao.DAO
+	engine func() Engine
+}
+
+func (m *manager) Create(ctx context.Context, policy *model.Policy) (int64, error) {
+	if err := validate(policy); err != nil {
+		return 0, err
+	}
+	return m.dao.Create(ctx, policy)
+}
+
+func (m *manager) Get(ctx context.Context, id int64) (*model.Policy, error) {
+	return m.dao.Get(ctx, id)
+}
+
+func (m *manager) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return m.dao.Count(ctx, query)
+}
+
+func (m *manager) List(ctx context.Context, query *q.Query) ([]*model.Policy, error) {
+	return m.dao.List(ctx, query)
+}
+
+func (m *manager) Update(ctx context.Context, policy *model.Policy) error {
+	if err := validate(policy); err != nil {
+		return err
+	}
+	return m.dao.Update(ctx, policy, "Name", "Description", "Module", "Actions", "Enabled")
+}
+
+func (m *manager) Delete(ctx context.Context, id int64) error {
+	return m.dao.Delete(ctx, id)
+}
+
+func (m *manager) ListApplicable(ctx context.Context, projectID int64, action string) ([]*model.Policy, error) {
+	query := q.New(q.KeyWords{
+		"ProjectID": q.NewOrList([]any{int64(0), projectID}),
+		"Enabled":   true,
+	}).First(q.NewSort("id", false))
+	policies, err := m.dao.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*model.Policy
+	for _, policy := range policies {
+		if policy.AppliesTo(action) {
+			results = append(results, policy)
+		}
+	}
+	return results, nil
+}
+
+func (m *manager) Evaluate(ctx context.Context, policies []*model.Policy, input *Input) (*Decision, error) {
+	if len(policies) == 0 {
+		return &Decision{Allowed: true}, nil
+	}
+
+	// fail closed, the enabled policies must not be skipped silently
+	engine := m.engine()
+	if engine == nil {
+		log.G(ctx).Errorf("the admission policy engine isn't configured, unable to evaluate %d admission policies", len(policies))
+		return nil, errors.New(nil).WithCode(errors.PreconditionCode).
+			WithMessage("the admission policy engine isn't configured, disable the admission policies or configure the engine")
+	}
+
+	return engine.Evaluate(ctx, policies, input)
+}
+
+func validate(policy *model.Policy) error {
+	if policy.Name == "" {
+		return errors.BadRequestError(nil).WithMessage("the name of the admission policy is required")
+	}
+
+	if _, err := PackageOf(policy.Module); err != nil {
+		return err
+	}
+	if !denyRuleRegexp.MatchString(policy.Module) {
+		return errors.BadRequestError(nil).WithMessagef("the %s rule is required in the admission policy", denyRule)
+	}
+
+	actions := policy.GetActions()
+	if len(actions) == 0 {
+		return errors.BadRequestError(nil).WithMessage("at least one action is required in the admission policy")
+	}
+	for _, action := range actions {
+		if !slices.Contains([]string{model.ActionPush, model.ActionPull}, action) {
+			return errors.BadRequestError(nil).WithMessagef("unsupported action %s of the admission policy", action)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/admission/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/admission/dao"
+)
+
+const denyModule = `package deny_all
+
+deny contains "denied"
+`
+
+type fakeEngine struct {
+	policies []*model.Policy
+}
+
+func (f *fakeEngine) Evaluate(_ context.Context, policies []*model.Policy, _ *Input) (*Decision, error) {
+	f.policies = policies
+	return &Decision{Allowed: false, Reasons: []string{"denied"}}, nil
+}
+
+type managerTestSuite struct {
+	suite.Suite
+	mgr    *manager
+	dao    *dao.DAO
+	engine *fakeEngine
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.dao = &dao.DAO{}
+	m.engine = &fakeEngine{}
+	m.mgr = &manager{
+		dao:    m.dao,
+		engine: func() Engine { return m.engine },
+	}
+}
+
+func (m *managerTestSuite) TestCreate() {
+	m.dao.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	id, err := m.mgr.Create(context.Background(), &model.Policy{Name: "deny-all", Module: denyModule, Actions: "push, pull"})
+	m.Require().Nil(err)
+	m.Equal(int64(1), id)
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestCreateInvalid() {
+	policies := []*model.Policy{
+		{Module: denyModule, Actions: "push"},
+		{Name: "no-package", Module: `deny contains "denied"`, Actions: "push"},
+		{Name: "no-deny-rule", Module: "package allow_all\n\nallow := true\n", Actions: "push"},
+		{Name: "no-action", Module: denyModule},
+		{Name: "invalid-action", Module: denyModule, Actions: "delete"},
+	}
+	for _, policy := range policies {
+		_, err := m.mgr.Create(context.Background(), policy)
+		m.Require().NotNil(err)
+		m.Equal(errors.BadRequestCode, errors.ErrCode(err))
+	}
+	m.dao.AssertNotCalled(m.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (m *managerTestSuite) TestUpdate() {
+	m.dao.On("Update", mock.Anything, mock.Anything, "Name", "Description", "Module", "Actions", "Enabled").Return(nil)
+	err := m.mgr.Update(context.Background(), &model.Policy{ID: 1, Name: "deny-all", Module: denyModule, Actions: "pull"})
+	m.Require().Nil(err)
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestListApplicable() {
+	m.dao.On("List", mock.Anything, mock.Anything).Return([]*model.Policy{
+		{ID: 1, Name: "system", Actions: "push,pull"},
+		{ID: 2, ProjectID: 1, Name: "push-only", Actions: "push"},
+	}, nil)
+	policies, err := m.mgr.ListApplicable(context.Background(), 1, model.ActionPull)
+	m.Require().Nil(err)
+	m.Require().Len(policies, 1)
+	m.Equal("system", policies[0].Name)
+}
+
+func (m *managerTestSuite) TestEvaluate() {
+	// no policies
+	decision, err := m.mgr.Evaluate(context.Background(), nil, &Input{})
+	m.Require().Nil(err)
+	m.True(decision.Allowed)
+	m.Nil(m.engine.policies)
+
+	// engine not configured, fail closed
+	m.mgr.engine = func() Engine { return nil }
+	policies := []*model.Policy{{ID: 1, Name: "deny-all", Module: denyModule}}
+	_, err = m.mgr.Evaluate(context.Background(), policies, &Input{})
+	m.Require().NotNil(err)
+	m.Equal(errors.PreconditionCode, errors.ErrCode(err))
+
+	m.mgr.engine = func() Engine { return m.engine }
+	decision, err = m.mgr.Evaluate(context.Background(), policies, &Input{})
+	m.Require().Nil(err)
+	m.False(decision.Allowed)
+	m.Equal(policies, m.engine.policies)
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
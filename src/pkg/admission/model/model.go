@@ -0,0 +1,77 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+)
+
+// the actions which the admission policies apply to
+const (
+	ActionPush = "push"
+	ActionPull = "pull"
+)
+
+func init() {
+	orm.RegisterModel(&Policy{})
+}
+
+// Policy is the admission policy written in Rego, the policy with project ID 0 is the system level one
+// which applies to all the projects
+type Policy struct {
+	ID           int64     `orm:"pk;auto;column(id)" json:"id"`
+	ProjectID    int64     `orm:"column(project_id)" json:"project_id"`
+	Name         string    `orm:"column(name)" json:"name"`
+	Description  string    `orm:"column(description)" json:"description"`
+	Module       string    `orm:"column(module);type(text)" json:"module"`
+	Actions      string    `orm:"column(actions)" json:"actions"`
+	Enabled      bool      `orm:"column(enabled)" json:"enabled"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+	UpdateTime   time.Time `orm:"column(update_time);auto_now" json:"update_time"`
+}
+
+// TableName ...
+func (p *Policy) TableName() string {
+	return "admission_policy"
+}
+
+// GetActions returns the actions which the policy applies to
+func (p *Policy) GetActions() []string {
+	var actions []string
+	for a := range strings.SplitSeq(p.Actions, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			actions = append(actions, a)
+		}
+	}
+	return actions
+}
+
+// AppliesTo returns true when the policy applies to the action
+func (p *Policy) AppliesTo(action string) bool {
+	for _, a := range p.GetActions() {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSystemLevel returns true when the policy is the system level one
+func (p *Policy) IsSystemLevel() bool {
+	return p.ProjectID == 0
+}
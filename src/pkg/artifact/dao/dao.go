@@ -56,6 +56,10 @@ type DAO interface {
 	DeleteReferences(ctx context.Context, parentID int64) (err error)
 	// ListWithLatest ...
 	ListWithLatest(ctx context.Context, query *q.Query) (artifacts []*Artifact, err error)
+	// ListByPullTime lists the artifacts ordered by the pull time desc and ID, the never pulled ones come last.
+	// The artifacts are paged by the keyset (pull time, ID), only the ones after the "after" artifact are returned
+	// when it is set. Only the "project_id"(*q.OrList) and "pull_time"(*q.Range with Min) keywords are supported
+	ListByPullTime(ctx context.Context, query *q.Query, after *Artifact) (artifacts []*Artifact, err error)
 }
 
 const (
@@ -284,6 +288,53 @@ func (d *dao) DeleteReferences(ctx context.Context, parentID int64) error {
 	return err
 }
 
+func (d *dao) ListByPullTime(ctx context.Context, query *q.Query, after *Artifact) ([]*Artifact, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		conditions []string
+		params     []any
+	)
+	if query != nil {
+		if ol, ok := query.Keywords["project_id"].(*q.OrList); ok && len(ol.Values) > 0 {
+			conditions = append(conditions, fmt.Sprintf("project_id IN (%s)", orm.ParamPlaceholderForIn(len(ol.Values))))
+			params = append(params, ol.Values...)
+		}
+		if r, ok := query.Keywords["pull_time"].(*q.Range); ok && r.Min != nil {
+			conditions = append(conditions, "pull_time >= ?")
+			params = append(params, r.Min)
+		}
+	}
+	if after != nil {
+		if after.PullTime.IsZero() {
+			conditions = append(conditions, "(pull_time IS NULL AND id > ?)")
+			params = append(params, after.ID)
+		} else {
+			conditions = append(conditions, "(pull_time < ? OR (pull_time = ? AND id > ?) OR pull_time IS NULL)")
+			params = append(params, after.PullTime, after.PullTime, after.ID)
+		}
+	}
+
+	sql := `SELECT * FROM artifact`
+	if len(conditions) > 0 {
+		sql += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	sql += ` ORDER BY pull_time DESC NULLS LAST, id`
+	if query != nil && query.PageSize > 0 {
+		sql += ` LIMIT ?`
+		params = append(params, query.PageSize)
+	}
+
+	arts := []*Artifact{}
+	if _, err = ormer.Raw(sql, params...).QueryRows(&arts); err != nil {
+		return nil, err
+	}
+	return arts, nil
+}
+
 func (d *dao) ListWithLatest(ctx context.Context, query *q.Query) (artifacts []*Artifact, err error) {
 	ormer, err := orm.FromContext(ctx)
 	if err != nil {
@@ -424,6 +424,48 @@ func (d *daoTestSuite) TestUpdatePullTime() {
 	d.Equal(after.Unix(), artifact.PullTime.Unix())
 }
 
+func (d *daoTestSuite) TestListByPullTime() {
+	query := &q.Query{
+		Keywords: map[string]any{
+			"project_id": &q.OrList{Values: []any{int64(1)}},
+			"pull_time":  &q.Range{Min: time.Now().Add(-time.Minute)},
+		},
+		PageSize: 2,
+	}
+	// the artifacts pulled at the same time are ordered by ID
+	artifacts, err := d.dao.ListByPullTime(d.ctx, query, nil)
+	d.Require().Nil(err)
+	d.Require().Len(artifacts, 2)
+	d.Equal(d.parentArtID, artifacts[0].ID)
+	d.Equal(d.childArt01ID, artifacts[1].ID)
+
+	artifacts, err = d.dao.ListByPullTime(d.ctx, query, artifacts[1])
+	d.Require().Nil(err)
+	d.Require().Len(artifacts, 1)
+	d.Equal(d.childArt02ID, artifacts[0].ID)
+
+	// the never pulled artifacts come last
+	id, err := d.dao.Create(d.ctx, &Artifact{
+		Type:              "IMAGE",
+		MediaType:         v1.MediaTypeImageConfig,
+		ManifestMediaType: v1.MediaTypeImageManifest,
+		ProjectID:         1,
+		RepositoryID:      1,
+		RepositoryName:    "library/hello-world",
+		Digest:            "never_pulled_digest",
+		PushTime:          time.Now(),
+	})
+	d.Require().Nil(err)
+	defer d.dao.Delete(d.ctx, id)
+
+	delete(query.Keywords, "pull_time")
+	query.PageSize = 0
+	artifacts, err = d.dao.ListByPullTime(d.ctx, query, nil)
+	d.Require().Nil(err)
+	d.Require().NotEmpty(artifacts)
+	d.Equal(id, artifacts[len(artifacts)-1].ID)
+}
+
 func (d *daoTestSuite) TestCreateReference() {
 	// happy pass is covered in SetupTest
 
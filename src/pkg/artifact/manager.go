@@ -50,6 +50,9 @@ type Manager interface {
 	DeleteReference(ctx context.Context, id int64) (err error)
 	// ListWithLatest list the artifacts when the latest_in_repository in the query was set
 	ListWithLatest(ctx context.Context, query *q.Query) (artifacts []*Artifact, err error)
+	// ListByPullTime lists the artifacts ordered by the pull time desc and ID, the never pulled ones come last.
+	// Only the artifacts after the "after" artifact are returned when it is set, the references aren't populated
+	ListByPullTime(ctx context.Context, query *q.Query, after *Artifact) (artifacts []*Artifact, err error)
 }
 
 // NewManager returns an instance of the default manager
@@ -165,6 +168,24 @@ func (m *manager) ListWithLatest(ctx context.Context, query *q.Query) ([]*Artifa
 	return artifacts, nil
 }
 
+func (m *manager) ListByPullTime(ctx context.Context, query *q.Query, after *Artifact) ([]*Artifact, error) {
+	var key *dao.Artifact
+	if after != nil {
+		key = &dao.Artifact{ID: after.ID, PullTime: after.PullTime}
+	}
+	arts, err := m.dao.ListByPullTime(ctx, query, key)
+	if err != nil {
+		return nil, err
+	}
+	var artifacts []*Artifact
+	for _, art := range arts {
+		artifact := &Artifact{}
+		artifact.From(art)
+		artifacts = append(artifacts, artifact)
+	}
+	return artifacts, nil
+}
+
 // assemble the artifact with references populated
 func (m *manager) assemble(ctx context.Context, art *dao.Artifact) (*Artifact, error) {
 	artifact := &Artifact{}
@@ -85,6 +85,11 @@ func (f *fakeDao) ListWithLatest(ctx context.Context, query *q.Query) ([]*dao.Ar
 	return args.Get(0).([]*dao.Artifact), args.Error(1)
 }
 
+func (f *fakeDao) ListByPullTime(ctx context.Context, query *q.Query, after *dao.Artifact) ([]*dao.Artifact, error) {
+	args := f.Called(ctx, query, after)
+	return args.Get(0).([]*dao.Artifact), args.Error(1)
+}
+
 type managerTestSuite struct {
 	suite.Suite
 	mgr *manager
@@ -162,6 +167,17 @@ func (m *managerTestSuite) TestListWithLatest() {
 	m.Equal(art.ID, artifacts[0].ID)
 }
 
+func (m *managerTestSuite) TestListByPullTime() {
+	pullTime := time.Now()
+	m.dao.On("ListByPullTime", mock.Anything, mock.Anything, &dao.Artifact{ID: 1, PullTime: pullTime}).
+		Return([]*dao.Artifact{{ID: 2, PullTime: pullTime}}, nil)
+	artifacts, err := m.mgr.ListByPullTime(nil, nil, &Artifact{ID: 1, PullTime: pullTime, Digest: "sha256:1"})
+	m.Require().Nil(err)
+	m.Require().Len(artifacts, 1)
+	m.Equal(int64(2), artifacts[0].ID)
+	m.dao.AssertExpectations(m.T())
+}
+
 func (m *managerTestSuite) TestList() {
 	art := &dao.Artifact{
 		ID:                1,
@@ -61,6 +61,7 @@ var EventTypes = []string{
 	"create_robot",
 	"delete_robot",
 	"update_configuration",
+	"admit_artifact",
 }
 
 // OtherEventTypes defines the types of other audit log event types excludes previous EventTypes: create_artifact, delete_artifact, pull_artifact
@@ -54,6 +54,11 @@ type DAO interface {
 	// UpdateBlob update blob status
 	UpdateBlobStatus(ctx context.Context, blob *models.Blob) (int64, error)
 
+	// MarkBlobDeleting update the GC candidate blob to StatusDeleting only when it's still not referenced by any project
+	// and isn't touched(created, pushed or checked by HEAD) after the specified time, the re-check and the status update
+	// are done in one statement.
+	MarkBlobDeleting(ctx context.Context, blob *models.Blob, updatedBefore time.Time) (int64, error)
+
 	// ListBlobs list blobs by query
 	ListBlobs(ctx context.Context, query *q.Query) ([]*models.Blob, error)
 
@@ -212,6 +217,35 @@ func (d *dao) UpdateBlobStatus(ctx context.Context, blob *models.Blob) (int64, e
 	return 1, nil
 }
 
+func (d *dao) MarkBlobDeleting(ctx context.Context, blob *models.Blob, updatedBefore time.Time) (int64, error) {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	sql := `UPDATE blob SET version = version + 1, update_time = ?, status = ? WHERE id = ? AND version = ? AND status IN (%s)
+AND update_time <= ? AND NOT EXISTS (SELECT 1 FROM project_blob AS pb WHERE pb.blob_id = blob.id) RETURNING version as new_version`
+
+	var newVersion int64
+	params := []any{time.Now(), models.StatusDeleting, blob.ID, blob.Version}
+	stats := models.StatusMap[models.StatusDeleting]
+	for _, stat := range stats {
+		params = append(params, stat)
+	}
+	params = append(params, updatedBefore)
+	if err := o.Raw(fmt.Sprintf(sql, orm.ParamPlaceholderForIn(len(stats))), params...).QueryRow(&newVersion); err != nil {
+		if e := orm.AsNotFoundError(err, "no blob is updated"); e != nil {
+			log.Warningf("no blob is marked as deleting, it may be referenced again, id: %d, err: %v", blob.ID, e)
+			return 0, nil
+		}
+		return -1, err
+	}
+
+	blob.Status = models.StatusDeleting
+	blob.Version = newVersion
+	return 1, nil
+}
+
 // UpdateBlob cannot handle the status change and version increase, for handling blob status change, please call
 // for the UpdateBlobStatus.
 func (d *dao) UpdateBlob(ctx context.Context, blob *models.Blob) error {
@@ -397,7 +431,7 @@ func (d *dao) GetBlobsNotRefedByProjectBlob(ctx context.Context, timeWindowHours
 		return noneRefed, err
 	}
 
-	sql := fmt.Sprintf(`SELECT b.id, b.digest, b.content_type, b.status, b.version, b.size FROM blob AS b LEFT JOIN project_blob pb ON b.id = pb.blob_id WHERE pb.id IS NULL AND b.update_time <= now() - interval '%d hours';`, timeWindowHours)
+	sql := fmt.Sprintf(`SELECT b.id, b.digest, b.content_type, b.status, b.version, b.size, b.creation_time, b.update_time FROM blob AS b LEFT JOIN project_blob pb ON b.id = pb.blob_id WHERE pb.id IS NULL AND b.update_time <= now() - interval '%d hours';`, timeWindowHours)
 	_, err = ormer.Raw(sql).QueryRows(&noneRefed)
 	if err != nil {
 		return noneRefed, err
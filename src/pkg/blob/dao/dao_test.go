@@ -196,6 +196,47 @@ func (suite *DaoTestSuite) TestUpdateBlobStatus() {
 	}
 }
 
+func (suite *DaoTestSuite) TestMarkBlobDeleting() {
+	ctx := suite.Context()
+
+	digest := suite.DigestString()
+	suite.dao.CreateBlob(ctx, &models.Blob{Digest: digest})
+	blob, err := suite.dao.GetBlobByDigest(ctx, digest)
+	suite.Require().Nil(err)
+
+	// StatusNone cannot be marked as deleting
+	count, err := suite.dao.MarkBlobDeleting(ctx, blob, time.Now().Add(time.Minute))
+	suite.Nil(err)
+	suite.Equal(int64(0), count)
+
+	blob.Status = models.StatusDelete
+	count, err = suite.dao.UpdateBlobStatus(ctx, blob)
+	suite.Nil(err)
+	suite.Equal(int64(1), count)
+
+	// the blob touched in the grace period cannot be marked as deleting
+	count, err = suite.dao.MarkBlobDeleting(ctx, blob, time.Now().Add(-time.Hour))
+	suite.Nil(err)
+	suite.Equal(int64(0), count)
+
+	// the blob referenced by project again cannot be marked as deleting
+	_, err = suite.dao.CreateProjectBlob(ctx, 1, blob.ID)
+	suite.Nil(err)
+	count, err = suite.dao.MarkBlobDeleting(ctx, blob, time.Now().Add(time.Minute))
+	suite.Nil(err)
+	suite.Equal(int64(0), count)
+
+	suite.Nil(suite.dao.DeleteProjectBlob(ctx, 1, blob.ID))
+	count, err = suite.dao.MarkBlobDeleting(ctx, blob, time.Now().Add(time.Minute))
+	suite.Nil(err)
+	suite.Equal(int64(1), count)
+
+	blob, err = suite.dao.GetBlobByDigest(ctx, digest)
+	if suite.Nil(err) {
+		suite.Equal(models.StatusDeleting, blob.Status)
+	}
+}
+
 func (suite *DaoTestSuite) TestListBlobs() {
 	ctx := suite.Context()
 
@@ -16,6 +16,7 @@ package blob
 
 import (
 	"context"
+	"time"
 
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/q"
@@ -69,6 +70,9 @@ type Manager interface {
 	// Update the blob status
 	UpdateBlobStatus(ctx context.Context, blob *models.Blob) (int64, error)
 
+	// MarkDeleting marks the GC candidate as deleting only if it's not referenced by any project and not touched after the time
+	MarkDeleting(ctx context.Context, blob *models.Blob, updatedBefore time.Time) (int64, error)
+
 	// List returns blobs by params
 	List(ctx context.Context, query *q.Query) ([]*Blob, error)
 
@@ -145,6 +149,10 @@ func (m *manager) UpdateBlobStatus(ctx context.Context, blob *models.Blob) (int6
 	return m.dao.UpdateBlobStatus(ctx, blob)
 }
 
+func (m *manager) MarkDeleting(ctx context.Context, blob *models.Blob, updatedBefore time.Time) (int64, error) {
+	return m.dao.MarkBlobDeleting(ctx, blob, updatedBefore)
+}
+
 func (m *manager) List(ctx context.Context, query *q.Query) ([]*Blob, error) {
 	return m.dao.ListBlobs(ctx, query)
 }
@@ -49,6 +49,9 @@ StatusDelete -> StatusDeleteFailed : The storage driver returns fail when to del
 StatusDelete -> StatusDelete : Encounter failure in the GC sweep phase. When to rerun the GC job, all of blob candidates are marked as StatusDelete again.
 StatusDeleteFailed -> StatusNone : The delete failed blobs can be pushed again, and back to normal.
 StatusDeleteFailed -> StatusDelete : The delete failed blobs should be in the candidate.
+
+For the online GC, StatusDelete -> StatusDeleting only happens when the blob is still not referenced by any project and is out of
+the grace period, the check is done in the same statement, so a blob referenced by a push after the mark is never deleted.
 */
 const (
 	StatusNone         = "none"
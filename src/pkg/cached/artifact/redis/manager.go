@@ -69,6 +69,10 @@ func (m *Manager) ListWithLatest(ctx context.Context, query *q.Query) ([]*artifa
 	return m.delegator.ListWithLatest(ctx, query)
 }
 
+func (m *Manager) ListByPullTime(ctx context.Context, query *q.Query, after *artifact.Artifact) ([]*artifact.Artifact, error) {
+	return m.delegator.ListByPullTime(ctx, query, after)
+}
+
 func (m *Manager) Create(ctx context.Context, artifact *artifact.Artifact) (int64, error) {
 	return m.delegator.Create(ctx, artifact)
 }
@@ -27,6 +27,8 @@ import (
 type DAO interface {
 	// Create the job log
 	Create(ctx context.Context, jobLog *models.JobLog) (id int64, err error)
+	// Append the content to the job log specified by UUID, the job log is created if it doesn't exist
+	Append(ctx context.Context, uuid string, content string) (err error)
 	// Get the job log specified by UUID
 	Get(ctx context.Context, uuid string) (jobLog *models.JobLog, err error)
 	// DeleteBefore the job log specified by time
@@ -54,6 +56,18 @@ func (d *dao) Create(ctx context.Context, jobLog *models.JobLog) (int64, error)
 	return count, nil
 }
 
+// Append ...
+func (d *dao) Append(ctx context.Context, uuid string, content string) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	sql := `insert into job_log (job_uuid, content, creation_time) values (?, ?, ?)
+		on conflict (job_uuid) do update set content = job_log.content || excluded.content`
+	_, err = ormer.Raw(sql, uuid, content, time.Now()).Exec()
+	return err
+}
+
 // Get ...
 func (d *dao) Get(ctx context.Context, uuid string) (jobLog *models.JobLog, err error) {
 	ormer, err := orm.FromContext(ctx)
@@ -47,6 +47,13 @@ func (suite *DaoTestSuite) TestMethodsOfJobLog() {
 	suite.Equal(updateContent, log.Content)
 	suite.Equal(jobLog.LogID, log.LogID)
 
+	// append
+	err = suite.dao.Append(ctx, uuid, " appended")
+	suite.Nil(err)
+	log, err = suite.dao.Get(ctx, uuid)
+	suite.Nil(err)
+	suite.Equal(updateContent+" appended", log.Content)
+
 	// delete
 	count, err := suite.dao.DeleteBefore(ctx, time.Now().Add(time.Duration(time.Minute)))
 	suite.Nil(err)
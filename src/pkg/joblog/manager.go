@@ -31,6 +31,8 @@ type Manager interface {
 	Get(ctx context.Context, uuid string) (jobLog *models.JobLog, err error)
 	// Create the job log
 	Create(ctx context.Context, jobLog *models.JobLog) (id int64, err error)
+	// Append the content to the job log specified by UUID, the job log is created if it doesn't exist
+	Append(ctx context.Context, uuid string, content string) (err error)
 	// DeleteBefore the job log specified by time
 	DeleteBefore(ctx context.Context, t time.Time) (id int64, err error)
 }
@@ -56,6 +58,11 @@ func (m *manager) Create(ctx context.Context, jobLog *models.JobLog) (id int64,
 	return m.dao.Create(ctx, jobLog)
 }
 
+// Append ...
+func (m *manager) Append(ctx context.Context, uuid string, content string) error {
+	return m.dao.Append(ctx, uuid, content)
+}
+
 // DeleteBefore ...
 func (m *manager) DeleteBefore(ctx context.Context, t time.Time) (id int64, err error) {
 	return m.dao.DeleteBefore(ctx, t)
@@ -25,6 +25,7 @@ import (
 	"github.com/goharbor/harbor/src/common/job"
 	"github.com/goharbor/harbor/src/jobservice/common/rds"
 	"github.com/goharbor/harbor/src/jobservice/config"
+	jobSvc "github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/log"
 	libRedis "github.com/goharbor/harbor/src/lib/redis"
 )
@@ -45,6 +46,12 @@ type RedisClient interface {
 	UnpauseJob(ctx context.Context, jobName string) error
 	// StopPendingJobs stop the pending jobs of the specified type, and remove the jobs from the waiting queue
 	StopPendingJobs(ctx context.Context, jobType string) (jobIDs []string, err error)
+	// GetJobPriorities returns the job priorities adjusted at runtime, nil if not adjusted
+	GetJobPriorities(ctx context.Context) (*jobSvc.Priorities, error)
+	// SetJobPriorities adjusts the job priorities at runtime
+	SetJobPriorities(ctx context.Context, priorities *jobSvc.Priorities) error
+	// DeleteJobPriorities removes the job priorities adjusted at runtime to restore the configured ones
+	DeleteJobPriorities(ctx context.Context) error
 }
 
 type redisClientImpl struct {
@@ -167,6 +174,43 @@ func (r *redisClientImpl) UnpauseJob(_ context.Context, jobName string) error {
 	return err
 }
 
+func (r *redisClientImpl) GetJobPriorities(_ context.Context) (*jobSvc.Priorities, error) {
+	conn := r.redisPool.Get()
+	defer conn.Close()
+	data, err := redis.Bytes(conn.Do("GET", rds.KeyJobPriorities(fmt.Sprintf("{%s}", r.namespace))))
+	if err != nil {
+		if err == redis.ErrNil {
+			return nil, nil
+		}
+		return nil, err
+	}
+	priorities := &jobSvc.Priorities{}
+	if err := json.Unmarshal(data, priorities); err != nil {
+		return nil, err
+	}
+	return priorities, nil
+}
+
+func (r *redisClientImpl) SetJobPriorities(_ context.Context, priorities *jobSvc.Priorities) error {
+	log.Infof("set job priorities: %+v", priorities)
+	data, err := json.Marshal(priorities)
+	if err != nil {
+		return err
+	}
+	conn := r.redisPool.Get()
+	defer conn.Close()
+	_, err = conn.Do("SET", rds.KeyJobPriorities(fmt.Sprintf("{%s}", r.namespace)), data)
+	return err
+}
+
+func (r *redisClientImpl) DeleteJobPriorities(_ context.Context) error {
+	log.Info("delete job priorities")
+	conn := r.redisPool.Get()
+	defer conn.Close()
+	_, err := conn.Do("DEL", rds.KeyJobPriorities(fmt.Sprintf("{%s}", r.namespace)))
+	return err
+}
+
 // JobServiceRedisClient function to create redis client for job service
 func JobServiceRedisClient() (RedisClient, error) {
 	cfg, err := job.GlobalClient.GetJobServiceConfig()
@@ -27,6 +27,7 @@ import (
 	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/jobservice/common/rds"
 	"github.com/goharbor/harbor/src/jobservice/config"
+	jobSvc "github.com/goharbor/harbor/src/jobservice/job"
 )
 
 type RedisClientTestSuite struct {
@@ -128,6 +129,30 @@ func (s *RedisClientTestSuite) TestStopPendingJobs() {
 	s.Assert().Equal(100, len(jobIDs))
 }
 
+func (s *RedisClientTestSuite) TestJobPriorities() {
+	ctx := context.Background()
+	s.Require().NoError(s.redisClient.DeleteJobPriorities(ctx))
+
+	p, err := s.redisClient.GetJobPriorities(ctx)
+	s.Require().NoError(err)
+	s.Nil(p)
+
+	err = s.redisClient.SetJobPriorities(ctx, &jobSvc.Priorities{
+		Default:             1000,
+		VendorTypes:         map[string]uint{"IMAGE_SCAN": 2000},
+		MaxRunningPerTenant: 10,
+	})
+	s.Require().NoError(err)
+
+	p, err = s.redisClient.GetJobPriorities(ctx)
+	s.Require().NoError(err)
+	s.Require().NotNil(p)
+	s.Equal(uint(2000), p.VendorTypes["IMAGE_SCAN"])
+	s.Equal(uint(10), p.MaxRunningPerTenant)
+
+	s.Require().NoError(s.redisClient.DeleteJobPriorities(ctx))
+}
+
 func TestRedisClientTestSuite(t *testing.T) {
 	suite.Run(t, &RedisClientTestSuite{})
 }
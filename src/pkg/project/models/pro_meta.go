@@ -19,11 +19,27 @@ const (
 	ProMetaPublic                   = "public"
 	ProMetaEnableContentTrust       = "enable_content_trust"
 	ProMetaEnableContentTrustCosign = "enable_content_trust_cosign"
-	ProMetaPreventVul               = "prevent_vul" // prevent vulnerable images from being pulled
+	ProMetaCosignKeylessIssuers     = "cosign_keyless_issuers"    // the OIDC issuers allowed to sign the images in keyless mode, separated by comma
+	ProMetaCosignKeylessIdentities  = "cosign_keyless_identities" // the subject identities allowed to sign the images in keyless mode, separated by comma
+	ProMetaPreventVul               = "prevent_vul"               // prevent vulnerable images from being pulled
 	ProMetaSeverity                 = "severity"
+	ProMetaPreventVulCVSSScore      = "prevent_vul_cvss_score"      // prevent the images with vulnerabilities of CVSS score higher than it
+	ProMetaPreventVulFixableOnly    = "prevent_vul_fixable_only"    // prevent the images only by the vulnerabilities with fix available
+	ProMetaPreventVulMinAgeDays     = "prevent_vul_min_age_days"    // prevent the images only by the vulnerabilities published more than the days ago
+	ProMetaPreventVulKnownExploited = "prevent_vul_known_exploited" // prevent the images only by the vulnerabilities known exploited
+	ProMetaPreventVulEPSSScore      = "prevent_vul_epss_score"      // prevent the images only by the vulnerabilities of EPSS score higher than it
 	ProMetaAutoScan                 = "auto_scan"
 	ProMetaReuseSysCVEAllowlist     = "reuse_sys_cve_allowlist"
 	ProMetaAutoSBOMGen              = "auto_sbom_generation"
+	ProMetaAutoSign                 = "auto_sign"         // sign the artifacts automatically with the project signing key
+	ProMetaAutoSignFormat           = "auto_sign_format"  // the format of the signatures: cosign or notation
+	ProMetaAutoSignTrigger          = "auto_sign_trigger" // when to sign the artifacts: push or promotion
 	ProMetaProxySpeed               = "proxy_speed_kb"
 	ProMetaMaxUpstreamConn          = "max_upstream_conn"
 )
+
+// values of the auto sign trigger
+const (
+	AutoSignTriggerPush      = "push"
+	AutoSignTriggerPromotion = "promotion"
+)
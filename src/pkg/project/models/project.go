@@ -112,6 +112,16 @@ func (p *Project) ContentTrustCosignEnabled() bool {
 	return isTrue(enabled)
 }
 
+// CosignKeylessIssuers returns the OIDC issuers allowed to sign the images in keyless mode
+func (p *Project) CosignKeylessIssuers() []string {
+	return listMetadata(p, ProMetaCosignKeylessIssuers)
+}
+
+// CosignKeylessIdentities returns the subject identities allowed to sign the images in keyless mode
+func (p *Project) CosignKeylessIdentities() []string {
+	return listMetadata(p, ProMetaCosignKeylessIdentities)
+}
+
 // VulPrevented ...
 func (p *Project) VulPrevented() bool {
 	prevent, exist := p.GetMetadata(ProMetaPreventVul)
@@ -139,6 +149,61 @@ func (p *Project) Severity() string {
 	return severity
 }
 
+// PreventVulCVSSScore returns the minimum CVSS score of the vulnerabilities to prevent the images, 0 means not configured
+func (p *Project) PreventVulCVSSScore() float64 {
+	return p.floatMetadata(ProMetaPreventVulCVSSScore)
+}
+
+// PreventVulFixableOnly ...
+func (p *Project) PreventVulFixableOnly() bool {
+	fixable, exist := p.GetMetadata(ProMetaPreventVulFixableOnly)
+	if !exist {
+		return false
+	}
+	return isTrue(fixable)
+}
+
+// PreventVulMinAgeDays returns the minimum age in days of the vulnerabilities to prevent the images, 0 means not configured
+func (p *Project) PreventVulMinAgeDays() int {
+	days, exist := p.GetMetadata(ProMetaPreventVulMinAgeDays)
+	if !exist {
+		return 0
+	}
+	d, err := strconv.Atoi(days)
+	if err != nil || d < 0 {
+		log.Warningf("failed to parse the %s, val: %s error %v", ProMetaPreventVulMinAgeDays, days, err)
+		return 0
+	}
+	return d
+}
+
+// PreventVulKnownExploited ...
+func (p *Project) PreventVulKnownExploited() bool {
+	kev, exist := p.GetMetadata(ProMetaPreventVulKnownExploited)
+	if !exist {
+		return false
+	}
+	return isTrue(kev)
+}
+
+// PreventVulEPSSScore returns the minimum EPSS score of the vulnerabilities to prevent the images, 0 means not configured
+func (p *Project) PreventVulEPSSScore() float64 {
+	return p.floatMetadata(ProMetaPreventVulEPSSScore)
+}
+
+func (p *Project) floatMetadata(key string) float64 {
+	val, exist := p.GetMetadata(key)
+	if !exist {
+		return 0
+	}
+	f, err := strconv.ParseFloat(val, 64)
+	if err != nil || f < 0 {
+		log.Warningf("failed to parse the %s, val: %s error %v", key, val, err)
+		return 0
+	}
+	return f
+}
+
 // AutoScan ...
 func (p *Project) AutoScan() bool {
 	auto, exist := p.GetMetadata(ProMetaAutoScan)
@@ -157,6 +222,33 @@ func (p *Project) AutoSBOMGen() bool {
 	return isTrue(auto)
 }
 
+// AutoSign returns whether the artifacts of the project should be signed automatically
+func (p *Project) AutoSign() bool {
+	auto, exist := p.GetMetadata(ProMetaAutoSign)
+	if !exist {
+		return false
+	}
+	return isTrue(auto)
+}
+
+// AutoSignFormat returns the signature format used by the automatic signing, cosign by default
+func (p *Project) AutoSignFormat() string {
+	format, exist := p.GetMetadata(ProMetaAutoSignFormat)
+	if !exist || format == "" {
+		return "cosign"
+	}
+	return format
+}
+
+// AutoSignTrigger returns when the artifacts should be signed automatically, on push by default
+func (p *Project) AutoSignTrigger() string {
+	trigger, exist := p.GetMetadata(ProMetaAutoSignTrigger)
+	if !exist || trigger == "" {
+		return AutoSignTriggerPush
+	}
+	return trigger
+}
+
 // ProxyCacheSpeed ...
 func (p *Project) ProxyCacheSpeed() int32 {
 	speed, exist := p.GetMetadata(ProMetaProxySpeed)
@@ -257,6 +349,21 @@ func (p *Project) FilterByNames(_ context.Context, qs orm.QuerySeter, _ string,
 	return qs.FilterRaw("project_id", fmt.Sprintf("IN (%s)", subQuery))
 }
 
+// listMetadata splits the comma separated metadata value into the trimmed non-empty items
+func listMetadata(p *Project, key string) []string {
+	value, exist := p.GetMetadata(key)
+	if !exist {
+		return nil
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func isTrue(i any) bool {
 	switch value := i.(type) {
 	case bool:
@@ -14,7 +14,10 @@
 
 package model
 
-import "github.com/goharbor/harbor/src/lib/errors"
+import (
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
 
 // const definition
 const (
@@ -95,5 +98,21 @@ type Trigger struct {
 
 // TriggerSettings is the setting about the trigger
 type TriggerSettings struct {
-	Cron string `json:"cron"`
+	Cron            string                `json:"cron"`
+	Timezone        string                `json:"timezone,omitempty"`
+	Jitter          int64                 `json:"jitter,omitempty"`
+	BlackoutWindows []*job.BlackoutWindow `json:"blackout_windows,omitempty"`
+}
+
+// ScheduleOptions returns the timezone, jitter and blackout windows of the settings,
+// nil is returned if none of them is set
+func (t *TriggerSettings) ScheduleOptions() *job.ScheduleOptions {
+	if t == nil || (t.Timezone == "" && t.Jitter == 0 && len(t.BlackoutWindows) == 0) {
+		return nil
+	}
+	return &job.ScheduleOptions{
+		Timezone:  t.Timezone,
+		Jitter:    t.Jitter,
+		Blackouts: t.BlackoutWindows,
+	}
 }
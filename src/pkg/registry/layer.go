@@ -0,0 +1,64 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+// ReadLayer reads the layers of the artifact stored in the repository one by one and returns the content of the
+// first layer accepted by the check, e.g. the document carried by an accessory artifact. The kind describes
+// the expected content in the messages, a not found error is returned if none of the layers is accepted.
+func ReadLayer(ctx context.Context, cli Client, repository, digest, kind string, check func(content []byte) error) ([]byte, error) {
+	man, _, err := cli.PullManifest(repository, digest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to pull the manifest of %s", kind)
+	}
+	_, payload, err := man.Payload()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the payload of %s", kind)
+	}
+	manifest := &v1.Manifest{}
+	if err := json.Unmarshal(payload, manifest); err != nil {
+		return nil, err
+	}
+
+	for _, layer := range manifest.Layers {
+		_, blob, err := cli.PullBlob(repository, layer.Digest.String())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to pull the blob of %s", kind)
+		}
+		content, err := io.ReadAll(blob)
+		blob.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := check(content); err != nil {
+			log.G(ctx).Debugf("the layer %s of %s@%s is not a %s, error: %v", layer.Digest, repository, digest, kind, err)
+			continue
+		}
+		return content, nil
+	}
+
+	return nil, errors.NotFoundError(nil).WithMessagef("no %s found in %s@%s", kind, repository, digest)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/go-openapi/strfmt"
 
 	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/retention/dao"
 	"github.com/goharbor/harbor/src/pkg/retention/dao/models"
@@ -100,7 +101,11 @@ func (d *DefaultManager) GetPolicy(ctx context.Context, id int64) (*policy.Metad
 	if p.Trigger.Kind == policy.TriggerKindSchedule {
 		cron, ok := p.Trigger.Settings[policy.TriggerSettingsCron]
 		if ok && len(cron.(string)) > 0 {
-			p.Trigger.Settings[policy.TriggerSettingNextScheduledTime] = strfmt.DateTime(utils.NextSchedule(cron.(string), time.Now()))
+			next := utils.NextSchedule(cron.(string), time.Now())
+			if options, err := p.Trigger.ScheduleOptions(); err == nil && options != nil {
+				next = job.NextSchedule(cron.(string), options, time.Now())
+			}
+			p.Trigger.Settings[policy.TriggerSettingNextScheduledTime] = strfmt.DateTime(next)
 		}
 	}
 	return p, nil
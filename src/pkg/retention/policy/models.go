@@ -15,9 +15,12 @@
 package policy
 
 import (
+	"encoding/json"
+
 	"github.com/beego/beego/v2/core/validation"
 
 	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/selector/selectors/doublestar"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
@@ -34,6 +37,15 @@ const (
 	// TriggerSettingsCron cron
 	TriggerSettingsCron = "cron"
 
+	// TriggerSettingsTimezone timezone
+	TriggerSettingsTimezone = "timezone"
+
+	// TriggerSettingsJitter jitter
+	TriggerSettingsJitter = "jitter"
+
+	// TriggerSettingsBlackoutWindows blackout_windows
+	TriggerSettingsBlackoutWindows = "blackout_windows"
+
 	// TriggerSettingNextScheduledTime next_scheduled_time
 	TriggerSettingNextScheduledTime = "next_scheduled_time"
 
@@ -72,6 +84,15 @@ func (m *Metadata) ValidateRetentionPolicy() error {
 						WithMessagef("invalid cron string for scheduled tag retention: %s, error: %v", cronItem.(string), err)
 				}
 			}
+			options, err := m.Trigger.ScheduleOptions()
+			if err != nil {
+				return errors.New(nil).WithCode(errors.BadRequestCode).
+					WithMessagef("invalid schedule options for scheduled tag retention: %v", err)
+			}
+			if err = options.Validate(); err != nil {
+				return errors.New(nil).WithCode(errors.BadRequestCode).
+					WithMessagef("invalid schedule options for scheduled tag retention: %v", err)
+			}
 		}
 	}
 	return nil
@@ -120,6 +141,32 @@ type Trigger struct {
 	Settings map[string]any `json:"settings" valid:"Required"`
 }
 
+// ScheduleOptions extracts the timezone, jitter and blackout windows from the
+// trigger settings, nil is returned if none of them is set
+func (t *Trigger) ScheduleOptions() (*job.ScheduleOptions, error) {
+	if t == nil || t.Settings == nil {
+		return nil, nil
+	}
+	settings := map[string]any{}
+	for _, key := range []string{TriggerSettingsTimezone, TriggerSettingsJitter, TriggerSettingsBlackoutWindows} {
+		if v, ok := t.Settings[key]; ok && v != nil {
+			settings[key] = v
+		}
+	}
+	if len(settings) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+	options := &job.ScheduleOptions{}
+	if err = json.Unmarshal(data, options); err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
 // Scope definition
 type Scope struct {
 	// Scope level declaration
@@ -66,6 +66,10 @@ type Registration struct {
 
 	Metadata *v1.ScannerAdapterMetadata `orm:"-" json:"-"`
 
+	// The time when the vulnerability database of the scanner was updated, it's recorded when
+	// checking the database updates to trigger the rescan of the artifacts
+	DBUpdatedAt time.Time `orm:"column(db_updated_at);null;type(datetime)" json:"-"`
+
 	// Timestamps
 	CreateTime   time.Time      `orm:"column(create_time);auto_now_add;type(datetime)" json:"create_time"`
 	UpdateTime   time.Time      `orm:"column(update_time);auto_now;type(datetime)" json:"update_time"`
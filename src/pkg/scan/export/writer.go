@@ -0,0 +1,381 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gocarina/gocsv"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+const (
+	// FormatCSV exports the scan data as the CSV file
+	FormatCSV = "csv"
+	// FormatJSON exports the scan data as the JSON lines, one record per line
+	FormatJSON = "json"
+	// FormatSARIF exports the scan data as the SARIF 2.1.0 log
+	FormatSARIF = "sarif"
+	// FormatCycloneDX exports the scan data as the CycloneDX 1.5 vulnerability document
+	FormatCycloneDX = "cyclonedx"
+)
+
+// FormatInfo describes the file generated for the export format
+type FormatInfo struct {
+	Extension   string
+	ContentType string
+}
+
+// Formats declares the supported export formats
+var Formats = map[string]*FormatInfo{
+	FormatCSV:       {Extension: "csv", ContentType: "text/csv"},
+	FormatJSON:      {Extension: "jsonl", ContentType: "application/x-ndjson"},
+	FormatSARIF:     {Extension: "sarif", ContentType: "application/sarif+json"},
+	FormatCycloneDX: {Extension: "cdx.json", ContentType: "application/vnd.cyclonedx+json"},
+}
+
+// GetFormat returns the info of the format, the CSV is returned for the empty format
+func GetFormat(format string) (*FormatInfo, error) {
+	if format == "" {
+		format = FormatCSV
+	}
+
+	info, ok := Formats[strings.ToLower(format)]
+	if !ok {
+		return nil, errors.BadRequestError(nil).WithMessagef("unsupported export format %s", format)
+	}
+
+	return info, nil
+}
+
+// Writer writes the exported scan data in the specific format
+type Writer interface {
+	// Write writes the records
+	Write(data []Data) error
+	// Close completes the document, nothing is written if no record written
+	Close() error
+}
+
+// NewWriter returns the writer of the format, the CSV writer is returned for the empty format
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch strings.ToLower(format) {
+	case "", FormatCSV:
+		return &csvWriter{w: w}, nil
+	case FormatJSON:
+		return &jsonLinesWriter{enc: json.NewEncoder(w)}, nil
+	case FormatSARIF:
+		return &sarifWriter{documentWriter: documentWriter{w: w}, rules: map[string]*sarifRule{}}, nil
+	case FormatCycloneDX:
+		return &cycloneDXWriter{documentWriter: documentWriter{w: w}, components: map[string]*cdxComponent{}}, nil
+	default:
+		return nil, errors.BadRequestError(nil).WithMessagef("unsupported export format %s", format)
+	}
+}
+
+type csvWriter struct {
+	w       io.Writer
+	written bool
+}
+
+func (c *csvWriter) Write(data []Data) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	// write the CSV with the headers for the first records
+	if !c.written {
+		c.written = true
+		return gocsv.Marshal(data, c.w)
+	}
+
+	return gocsv.MarshalWithoutHeaders(data, c.w)
+}
+
+func (c *csvWriter) Close() error {
+	return nil
+}
+
+type jsonLinesWriter struct {
+	enc *json.Encoder
+}
+
+func (j *jsonLinesWriter) Write(data []Data) error {
+	for i := range data {
+		if err := j.enc.Encode(&data[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (j *jsonLinesWriter) Close() error {
+	return nil
+}
+
+// documentWriter streams the items of the array in the JSON document,
+// the document is opened with the first item so that nothing is written for the empty export
+type documentWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (d *documentWriter) writeItem(prefix string, item any) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	sep := ","
+	if d.count == 0 {
+		sep = prefix
+	}
+	d.count++
+
+	if _, err := io.WriteString(d.w, sep); err != nil {
+		return err
+	}
+
+	_, err = d.w.Write(data)
+	return err
+}
+
+func (d *documentWriter) close(suffix any) error {
+	if d.count == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(suffix)
+	if err != nil {
+		return err
+	}
+
+	// the suffix is an object, merge its fields into the opened document
+	_, err = fmt.Fprintf(d.w, "],%s", strings.TrimPrefix(string(data), "{"))
+	return err
+}
+
+type sarifRule struct {
+	ID               string         `json:"id"`
+	ShortDescription sarifMessage   `json:"shortDescription"`
+	HelpURI          string         `json:"helpUri,omitempty"`
+	Properties       map[string]any `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifWriter struct {
+	documentWriter
+	rules map[string]*sarifRule
+}
+
+func (s *sarifWriter) Write(data []Data) error {
+	for _, d := range data {
+		if _, ok := s.rules[d.CVEId]; !ok {
+			rule := &sarifRule{ID: d.CVEId, ShortDescription: sarifMessage{Text: d.CVEId}}
+			if strings.HasPrefix(d.CVEId, "CVE-") {
+				rule.HelpURI = fmt.Sprintf("https://nvd.nist.gov/vuln/detail/%s", d.CVEId)
+			}
+			if cwes := splitCWEIds(d.CWEIds); len(cwes) > 0 {
+				rule.Properties = map[string]any{"tags": cwes}
+			}
+			s.rules[d.CVEId] = rule
+		}
+
+		text := fmt.Sprintf("%s %s is affected by %s", d.Package, d.Version, d.CVEId)
+		if d.FixVersion != "" {
+			text = fmt.Sprintf("%s, fixed in %s", text, d.FixVersion)
+		}
+
+		result := map[string]any{
+			"ruleId":  d.CVEId,
+			"level":   sarifLevel(d.Severity),
+			"message": sarifMessage{Text: text},
+			"locations": []any{
+				map[string]any{
+					"physicalLocation": map[string]any{
+						"artifactLocation": map[string]any{"uri": fmt.Sprintf("%s@%s", d.Repository, d.ArtifactDigest)},
+					},
+				},
+			},
+			"properties": map[string]any{
+				"package":    d.Package,
+				"version":    d.Version,
+				"fixVersion": d.FixVersion,
+				"severity":   d.Severity,
+				"scanner":    d.ScannerName,
+			},
+		}
+
+		if err := s.writeItem(`{"version":"2.1.0","$schema":"https://json.schemastore.org/sarif-2.1.0.json","runs":[{"results":[`, result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *sarifWriter) Close() error {
+	rules := make([]*sarifRule, 0, len(s.rules))
+	for _, rule := range s.rules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+
+	if err := s.close(map[string]any{"tool": map[string]any{"driver": map[string]any{"name": "Harbor", "rules": rules}}}); err != nil {
+		return err
+	}
+
+	if s.count == 0 {
+		return nil
+	}
+
+	// close the run and the log
+	_, err := io.WriteString(s.w, "]}")
+	return err
+}
+
+// sarifLevel maps the severity of the vulnerability to the level of the SARIF result
+func sarifLevel(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical", "high":
+		return "error"
+	case "medium":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+type cdxComponent struct {
+	Type       string        `json:"type"`
+	BomRef     string        `json:"bom-ref"`
+	Name       string        `json:"name"`
+	Version    string        `json:"version,omitempty"`
+	Properties []cdxProperty `json:"properties,omitempty"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cycloneDXWriter struct {
+	documentWriter
+	components map[string]*cdxComponent
+}
+
+func (c *cycloneDXWriter) Write(data []Data) error {
+	for _, d := range data {
+		ref := fmt.Sprintf("%s@%s:%s@%s", d.Repository, d.ArtifactDigest, d.Package, d.Version)
+		if _, ok := c.components[ref]; !ok {
+			c.components[ref] = &cdxComponent{
+				Type:    "library",
+				BomRef:  ref,
+				Name:    d.Package,
+				Version: d.Version,
+				Properties: []cdxProperty{
+					{Name: "harbor:repository", Value: d.Repository},
+					{Name: "harbor:digest", Value: d.ArtifactDigest},
+				},
+			}
+		}
+
+		vul := map[string]any{
+			"id":      d.CVEId,
+			"ratings": []any{map[string]any{"severity": cdxSeverity(d.Severity)}},
+			"affects": []any{map[string]any{"ref": ref}},
+		}
+		if d.ScannerName != "" {
+			vul["source"] = map[string]any{"name": d.ScannerName}
+		}
+		if cwes := cweNumbers(d.CWEIds); len(cwes) > 0 {
+			vul["cwes"] = cwes
+		}
+		if d.FixVersion != "" {
+			vul["recommendation"] = fmt.Sprintf("Upgrade %s to %s", d.Package, d.FixVersion)
+		}
+
+		if err := c.writeItem(`{"bomFormat":"CycloneDX","specVersion":"1.5","version":1,"vulnerabilities":[`, vul); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *cycloneDXWriter) Close() error {
+	components := make([]*cdxComponent, 0, len(c.components))
+	for _, component := range c.components {
+		components = append(components, component)
+	}
+	sort.Slice(components, func(i, j int) bool { return components[i].BomRef < components[j].BomRef })
+
+	return c.close(map[string]any{
+		"metadata": map[string]any{
+			"timestamp": time.Now().UTC().Format(time.RFC3339),
+			"tools":     []any{map[string]any{"vendor": "Harbor", "name": "scan data export"}},
+		},
+		"components": components,
+	})
+}
+
+// cdxSeverity maps the severity of the vulnerability to the severity of the CycloneDX rating
+func cdxSeverity(severity string) string {
+	switch s := strings.ToLower(severity); s {
+	case "critical", "high", "medium", "low", "none":
+		return s
+	case "negligible":
+		return "info"
+	default:
+		return "unknown"
+	}
+}
+
+var cweRegexp = regexp.MustCompile(`(?i)CWE-(\d+)`)
+
+// splitCWEIds splits the CWE ids joined by the comma
+func splitCWEIds(cweIDs string) []string {
+	var ids []string
+	for _, id := range strings.Split(cweIDs, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// cweNumbers returns the numbers of the CWE ids, e.g. 79 for CWE-79
+func cweNumbers(cweIDs string) []int {
+	var numbers []int
+	for _, match := range cweRegexp.FindAllStringSubmatch(cweIDs, -1) {
+		if n, err := strconv.Atoi(match[1]); err == nil {
+			numbers = append(numbers, n)
+		}
+	}
+
+	return numbers
+}
@@ -0,0 +1,171 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+func mockData() []Data {
+	return []Data{
+		{Repository: "library/nginx", ArtifactDigest: "sha256:1", CVEId: "CVE-2023-0001", Package: "openssl", Version: "1.1", FixVersion: "1.2", Severity: "Critical", CWEIds: "CWE-79,CWE-20", ScannerName: "Trivy"},
+		{Repository: "library/nginx", ArtifactDigest: "sha256:1", CVEId: "CVE-2023-0002", Package: "curl", Version: "7.0", Severity: "Negligible", ScannerName: "Trivy"},
+	}
+}
+
+func TestGetFormat(t *testing.T) {
+	info, err := GetFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, "csv", info.Extension)
+
+	info, err = GetFormat("SARIF")
+	require.NoError(t, err)
+	assert.Equal(t, "application/sarif+json", info.ContentType)
+
+	_, err = GetFormat("xml")
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+
+	_, err = NewWriter("xml", &bytes.Buffer{})
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+}
+
+func TestWriterWithoutData(t *testing.T) {
+	for format := range Formats {
+		buf := &bytes.Buffer{}
+		w, err := NewWriter(format, buf)
+		require.NoError(t, err)
+		require.NoError(t, w.Write(nil))
+		require.NoError(t, w.Close())
+		assert.Zero(t, buf.Len(), format)
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(FormatCSV, buf)
+	require.NoError(t, err)
+	data := mockData()
+	require.NoError(t, w.Write(data[:1]))
+	require.NoError(t, w.Write(data[1:]))
+	require.NoError(t, w.Close())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "Repository,Artifact Digest,CVE"))
+}
+
+func TestJSONLinesWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(FormatJSON, buf)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(mockData()))
+	require.NoError(t, w.Close())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	record := map[string]any{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	assert.Equal(t, "CVE-2023-0001", record["cve_id"])
+	assert.Equal(t, "1.2", record["fix_version"])
+}
+
+func TestSARIFWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(FormatSARIF, buf)
+	require.NoError(t, err)
+	data := mockData()
+	require.NoError(t, w.Write(data[:1]))
+	require.NoError(t, w.Write(data[1:]))
+	require.NoError(t, w.Close())
+
+	log := struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID  string `json:"ruleId"`
+				Level   string `json:"level"`
+				Message struct {
+					Text string `json:"text"`
+				} `json:"message"`
+			} `json:"results"`
+		} `json:"runs"`
+	}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	assert.Equal(t, "Harbor", log.Runs[0].Tool.Driver.Name)
+	require.Len(t, log.Runs[0].Tool.Driver.Rules, 2)
+	assert.Equal(t, "CVE-2023-0001", log.Runs[0].Tool.Driver.Rules[0].ID)
+	require.Len(t, log.Runs[0].Results, 2)
+	assert.Equal(t, "error", log.Runs[0].Results[0].Level)
+	assert.Equal(t, "openssl 1.1 is affected by CVE-2023-0001, fixed in 1.2", log.Runs[0].Results[0].Message.Text)
+	assert.Equal(t, "note", log.Runs[0].Results[1].Level)
+}
+
+func TestCycloneDXWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(FormatCycloneDX, buf)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(mockData()))
+	require.NoError(t, w.Close())
+
+	bom := struct {
+		BomFormat       string `json:"bomFormat"`
+		SpecVersion     string `json:"specVersion"`
+		Vulnerabilities []struct {
+			ID      string `json:"id"`
+			Ratings []struct {
+				Severity string `json:"severity"`
+			} `json:"ratings"`
+			CWEs    []int `json:"cwes"`
+			Affects []struct {
+				Ref string `json:"ref"`
+			} `json:"affects"`
+			Recommendation string `json:"recommendation"`
+		} `json:"vulnerabilities"`
+		Components []struct {
+			BomRef string `json:"bom-ref"`
+			Name   string `json:"name"`
+		} `json:"components"`
+	}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &bom))
+	assert.Equal(t, "CycloneDX", bom.BomFormat)
+	assert.Equal(t, "1.5", bom.SpecVersion)
+	require.Len(t, bom.Vulnerabilities, 2)
+	assert.Equal(t, "critical", bom.Vulnerabilities[0].Ratings[0].Severity)
+	assert.Equal(t, []int{79, 20}, bom.Vulnerabilities[0].CWEs)
+	assert.Equal(t, "Upgrade openssl to 1.2", bom.Vulnerabilities[0].Recommendation)
+	assert.Equal(t, "info", bom.Vulnerabilities[1].Ratings[0].Severity)
+	require.Len(t, bom.Components, 2)
+	assert.Equal(t, bom.Vulnerabilities[0].Affects[0].Ref, bom.Components[1].BomRef)
+	assert.Equal(t, "openssl", bom.Components[1].Name)
+}
@@ -15,6 +15,8 @@
 package report
 
 import (
+	"sort"
+
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
 	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
@@ -87,3 +89,35 @@ func (l Reports) ResolveData(mimeType string) (any, error) {
 
 	return result, nil
 }
+
+// VulnerabilityKeys returns the sorted keys of the vulnerabilities found in the vulnerability reports
+func (l Reports) VulnerabilityKeys() ([]string, error) {
+	keys := map[string]struct{}{}
+	for _, rp := range l {
+		if _, ok := SupportedMergers[rp.MimeType]; !ok || len(rp.Report) == 0 {
+			continue
+		}
+
+		vrp, err := ResolveData(rp.MimeType, []byte(rp.Report), WithArtifactDigest(rp.Digest))
+		if err != nil {
+			return nil, err
+		}
+
+		nr, ok := vrp.(*vuln.Report)
+		if !ok {
+			continue
+		}
+
+		for _, item := range nr.Vulnerabilities {
+			keys[item.Key()] = struct{}{}
+		}
+	}
+
+	result := make([]string, 0, len(keys))
+	for key := range keys {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+
+	return result, nil
+}
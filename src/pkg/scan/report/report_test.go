@@ -0,0 +1,56 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+)
+
+func TestVulnerabilityKeys(t *testing.T) {
+	makeReport := func(mimeType string, items ...*vuln.VulnerabilityItem) *scan.Report {
+		data, err := json.Marshal(&vuln.Report{Vulnerabilities: items})
+		require.NoError(t, err)
+
+		return &scan.Report{Digest: "digest", MimeType: mimeType, Report: string(data)}
+	}
+
+	reports := Reports{
+		makeReport(v1.MimeTypeNativeReport,
+			&vuln.VulnerabilityItem{ID: "CVE-2", Package: "openssl", Version: "1.1"},
+			&vuln.VulnerabilityItem{ID: "CVE-1", Package: "curl", Version: "7.0"},
+		),
+		makeReport(v1.MimeTypeGenericVulnerabilityReport,
+			&vuln.VulnerabilityItem{ID: "CVE-1", Package: "curl", Version: "7.0"},
+		),
+		makeReport(v1.MimeTypeSBOMReport),
+		{MimeType: v1.MimeTypeNativeReport},
+	}
+
+	keys, err := reports.VulnerabilityKeys()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CVE-1-curl-7.0", "CVE-2-openssl-1.1"}, keys)
+
+	keys, err = Reports(nil).VulnerabilityKeys()
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"slices"
+	"time"
 
 	"github.com/goharbor/harbor/src/lib/errors"
 )
@@ -25,6 +26,10 @@ import (
 const (
 	supportVulnerability = "support_vulnerability"
 	supportSBOM          = "support_sbom"
+
+	// PropertyDBUpdatedAt is the property of the scanner adapter metadata which reports
+	// the time when the vulnerability database of the scanner was updated
+	PropertyDBUpdatedAt = "harbor.scanner-adapter/vulnerability-database-updated-at"
 )
 
 var supportedMimeTypes = []string{
@@ -124,6 +129,22 @@ func (md *ScannerAdapterMetadata) HasCapability(mimeType string) bool {
 	})
 }
 
+// GetDBUpdatedAt returns the time when the vulnerability database of the scanner was updated,
+// nil is returned when the scanner doesn't report it or reports an invalid time
+func (md *ScannerAdapterMetadata) GetDBUpdatedAt() *time.Time {
+	v, ok := md.Properties[PropertyDBUpdatedAt]
+	if !ok || len(v) == 0 {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
+
 // GetCapability returns capability for the mime type
 func (md *ScannerAdapterMetadata) GetCapability(mimeType string) *ScannerCapability {
 	for _, capability := range md.Capabilities {
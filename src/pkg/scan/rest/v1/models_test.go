@@ -2,6 +2,7 @@ package v1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -39,3 +40,16 @@ func TestConvertCapabilityOldScaner(t *testing.T) {
 	assert.Equal(t, result[supportSBOM], false)
 	assert.Equal(t, result[supportVulnerability], true)
 }
+
+func TestGetDBUpdatedAt(t *testing.T) {
+	md := &ScannerAdapterMetadata{}
+	assert.Nil(t, md.GetDBUpdatedAt())
+
+	md.Properties = ScannerProperties{PropertyDBUpdatedAt: "invalid"}
+	assert.Nil(t, md.GetDBUpdatedAt())
+
+	md.Properties = ScannerProperties{PropertyDBUpdatedAt: "2019-08-13T08:16:33.345Z"}
+	if assert.NotNil(t, md.GetDBUpdatedAt()) {
+		assert.Equal(t, time.Date(2019, 8, 13, 8, 16, 33, 345000000, time.UTC), *md.GetDBUpdatedAt())
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom/component/model"
+)
+
+// sql to query the components and the artifacts containing them
+const searchSQL = `SELECT c.id, c.artifact_id, c.source, c.name, c.version, c.purl, c.type, c.licenses, c.creation_time,
+       a.project_id, a.repository_name, a.digest
+FROM sbom_component c
+         JOIN artifact a ON c.artifact_id = a.id`
+
+// sql to count the components and the artifacts containing them
+const countSQL = `SELECT count(*)
+FROM sbom_component c
+         JOIN artifact a ON c.artifact_id = a.id`
+
+// sql to query the distinct versions of the components
+const versionsSQL = `SELECT DISTINCT c.version
+FROM sbom_component c
+         JOIN artifact a ON c.artifact_id = a.id`
+
+// DAO is the data access object interface for the SBOM components
+type DAO interface {
+	// CreateMany creates the components in batch
+	CreateMany(ctx context.Context, components []*model.Component) (err error)
+	// DeleteByArtifactID deletes the components of the artifact
+	DeleteByArtifactID(ctx context.Context, artifactID int64) (n int64, err error)
+	// DeleteBySource deletes the components indexed from the SBOM specified by the source digest,
+	// only the ones of the artifact are deleted if the artifact ID is greater than 0
+	DeleteBySource(ctx context.Context, artifactID int64, source string) (n int64, err error)
+	// Versions returns the distinct versions of the components which match the criteria
+	Versions(ctx context.Context, criteria *model.Criteria) (versions []string, err error)
+	// Count returns the total count of the components which match the criteria
+	Count(ctx context.Context, criteria *model.Criteria) (total int64, err error)
+	// Search returns the components and the artifacts containing them which match the criteria,
+	// only the pagination of the query is used
+	Search(ctx context.Context, criteria *model.Criteria, query *q.Query) (items []*model.Item, err error)
+}
+
+// New creates an instance of the default DAO
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+func (d *dao) CreateMany(ctx context.Context, components []*model.Component) error {
+	if len(components) == 0 {
+		return nil
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = ormer.InsertMulti(100, components)
+	return err
+}
+
+func (d *dao) DeleteByArtifactID(ctx context.Context, artifactID int64) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return ormer.QueryTable(&model.Component{}).Filter("ArtifactID", artifactID).Delete()
+}
+
+func (d *dao) DeleteBySource(ctx context.Context, artifactID int64, source string) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	qs := ormer.QueryTable(&model.Component{}).Filter("Source", source)
+	if artifactID > 0 {
+		qs = qs.Filter("ArtifactID", artifactID)
+	}
+	return qs.Delete()
+}
+
+func (d *dao) Versions(ctx context.Context, criteria *model.Criteria) ([]string, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	where, params := buildWhere(criteria)
+	var versions []string
+	if _, err := ormer.Raw(versionsSQL+where, params...).QueryRows(&versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+func (d *dao) Count(ctx context.Context, criteria *model.Criteria) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, params := buildWhere(criteria)
+	var total int64
+	if err := ormer.Raw(countSQL+where, params...).QueryRow(&total); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (d *dao) Search(ctx context.Context, criteria *model.Criteria, query *q.Query) ([]*model.Item, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	where, params := buildWhere(criteria)
+	sql, params := orm.PaginationOnRawSQL(query, searchSQL+where+" ORDER BY a.project_id, a.repository_name, a.digest, c.name, c.version, c.id", params)
+
+	var items []*model.Item
+	if _, err := ormer.Raw(sql, params...).QueryRows(&items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// buildWhere builds the where clause of the criteria, the version range is ignored as it's resolved into the versions
+func buildWhere(criteria *model.Criteria) (string, []any) {
+	var (
+		sql    strings.Builder
+		params []any
+	)
+	sql.WriteString(" WHERE 1 = 1")
+	if criteria == nil {
+		return sql.String(), params
+	}
+	if len(criteria.Name) > 0 {
+		sql.WriteString(" AND lower(c.name) = lower(?)")
+		params = append(params, criteria.Name)
+	}
+	if len(criteria.PURL) > 0 {
+		sql.WriteString(" AND c.purl LIKE ?")
+		params = append(params, orm.Escape(criteria.PURL)+"%")
+	}
+	if criteria.ProjectID > 0 {
+		sql.WriteString(" AND a.project_id = ?")
+		params = append(params, criteria.ProjectID)
+	}
+	if len(criteria.RepositoryName) > 0 {
+		sql.WriteString(" AND a.repository_name = ?")
+		params = append(params, criteria.RepositoryName)
+	}
+	if len(criteria.Versions) > 0 {
+		sql.WriteString(" AND c.version IN (" + orm.ParamPlaceholderForIn(len(criteria.Versions)) + ")")
+		for _, v := range criteria.Versions {
+			params = append(params, v)
+		}
+	}
+	return sql.String(), params
+}
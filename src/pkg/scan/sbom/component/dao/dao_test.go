@@ -0,0 +1,119 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/q"
+	artdao "github.com/goharbor/harbor/src/pkg/artifact/dao"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom/component/model"
+	htesting "github.com/goharbor/harbor/src/testing"
+)
+
+type daoTestSuite struct {
+	htesting.Suite
+	dao    DAO
+	artDAO artdao.DAO
+	artID  int64
+}
+
+func (d *daoTestSuite) SetupSuite() {
+	d.Suite.SetupSuite()
+	d.dao = New()
+	d.artDAO = artdao.New()
+}
+
+func (d *daoTestSuite) SetupTest() {
+	id, err := d.artDAO.Create(d.Context(), &artdao.Artifact{
+		Type:              "IMAGE",
+		MediaType:         v1.MediaTypeImageConfig,
+		ManifestMediaType: v1.MediaTypeImageManifest,
+		ProjectID:         1,
+		RepositoryID:      1,
+		RepositoryName:    "library/component",
+		Digest:            d.DigestString(),
+	})
+	d.Require().Nil(err)
+	d.artID = id
+}
+
+func (d *daoTestSuite) TearDownTest() {
+	d.Require().Nil(d.artDAO.Delete(d.Context(), d.artID))
+}
+
+func (d *daoTestSuite) TestSearch() {
+	ctx := d.Context()
+
+	err := d.dao.CreateMany(ctx, []*model.Component{
+		{ArtifactID: d.artID, Source: "sha256:cyclonedx", Name: "log4j-core", Version: "2.14.1", PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", Type: "maven"},
+		{ArtifactID: d.artID, Source: "sha256:spdx", Name: "openssl", Version: "3.0.2", PURL: "pkg:deb/ubuntu/openssl@3.0.2", Type: "deb"},
+	})
+	d.Require().Nil(err)
+
+	items, err := d.dao.Search(ctx, &model.Criteria{Name: "Log4j-Core"}, nil)
+	d.Require().Nil(err)
+	d.Require().Len(items, 1)
+	d.Equal("2.14.1", items[0].Version)
+	d.Equal("library/component", items[0].RepositoryName)
+	d.Equal(int64(1), items[0].ProjectID)
+
+	items, err = d.dao.Search(ctx, &model.Criteria{PURL: "pkg:deb/"}, nil)
+	d.Require().Nil(err)
+	d.Require().Len(items, 1)
+	d.Equal("openssl", items[0].Name)
+
+	items, err = d.dao.Search(ctx, &model.Criteria{Name: "openssl", ProjectID: 2}, nil)
+	d.Require().Nil(err)
+	d.Empty(items)
+
+	versions, err := d.dao.Versions(ctx, &model.Criteria{PURL: "pkg:"})
+	d.Require().Nil(err)
+	d.ElementsMatch([]string{"2.14.1", "3.0.2"}, versions)
+
+	total, err := d.dao.Count(ctx, &model.Criteria{PURL: "pkg:", Versions: []string{"3.0.2"}})
+	d.Require().Nil(err)
+	d.Equal(int64(1), total)
+
+	items, err = d.dao.Search(ctx, &model.Criteria{PURL: "pkg:"}, &q.Query{PageNumber: 2, PageSize: 1})
+	d.Require().Nil(err)
+	d.Require().Len(items, 1)
+	d.Equal("openssl", items[0].Name)
+
+	// only the components of the same source are deleted
+	n, err := d.dao.DeleteBySource(ctx, d.artID, "sha256:spdx")
+	d.Require().Nil(err)
+	d.Equal(int64(1), n)
+
+	items, err = d.dao.Search(ctx, &model.Criteria{PURL: "pkg:"}, nil)
+	d.Require().Nil(err)
+	d.Require().Len(items, 1)
+	d.Equal("sha256:cyclonedx", items[0].Source)
+
+	n, err = d.dao.DeleteByArtifactID(ctx, d.artID)
+	d.Require().Nil(err)
+	d.Equal(int64(1), n)
+
+	items, err = d.dao.Search(ctx, &model.Criteria{Name: "log4j-core"}, nil)
+	d.Require().Nil(err)
+	d.Empty(items)
+}
+
+func TestDAO(t *testing.T) {
+	suite.Run(t, &daoTestSuite{})
+}
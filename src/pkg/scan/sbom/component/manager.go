@@ -0,0 +1,151 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/registry"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom/component/dao"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom/component/model"
+)
+
+// Mgr is the global SBOM component manager instance
+var Mgr = NewManager()
+
+// Manager manages the components indexed from the SBOMs of the artifacts
+type Manager interface {
+	// Index parses the SBOM of the artifact and replaces the components of the artifact indexed from the same
+	// source with the ones in it, the source is the digest of the SBOM, so the multiple SBOMs of the artifact
+	// are indexed side by side
+	Index(ctx context.Context, artifactID int64, source string, sbom []byte) (count int, err error)
+	// IndexAccessory reads the SBOM from the accessory artifact stored in the repository and indexes it as the
+	// components of the subject artifact with the digest of the accessory as the source
+	IndexAccessory(ctx context.Context, artifactID int64, repository, digest string) (count int, err error)
+	// DeleteByArtifactID deletes the indexed components of the artifact
+	DeleteByArtifactID(ctx context.Context, artifactID int64) (err error)
+	// DeleteBySource deletes the components indexed from the SBOM specified by the digest
+	DeleteBySource(ctx context.Context, source string) (err error)
+	// Search the components matching the criteria and returns the specified page of them,
+	// at least one of the name and purl of the criteria is required
+	Search(ctx context.Context, criteria *model.Criteria, pageNumber, pageSize int64) (total int64, items []*model.Item, err error)
+}
+
+// NewManager returns an instance of the default manager
+func NewManager() Manager {
+	return &manager{
+		dao:    dao.New(),
+		regCli: registry.Cli,
+	}
+}
+
+type manager struct {
+	dao    dao.DAO
+	regCli registry.Client
+}
+
+func (m *manager) Index(ctx context.Context, artifactID int64, source string, sbom []byte) (int, error) {
+	components, err := Parse(sbom)
+	if err != nil {
+		return 0, err
+	}
+	for _, c := range components {
+		c.ArtifactID = artifactID
+		c.Source = source
+	}
+
+	h := func(ctx context.Context) error {
+		if _, err := m.dao.DeleteBySource(ctx, artifactID, source); err != nil {
+			return err
+		}
+		return m.dao.CreateMany(ctx, components)
+	}
+	if err := orm.WithTransaction(h)(orm.SetTransactionOpNameToContext(ctx, "tx-index-sbom-component")); err != nil {
+		return 0, err
+	}
+	return len(components), nil
+}
+
+func (m *manager) IndexAccessory(ctx context.Context, artifactID int64, repository, digest string) (int, error) {
+	content, err := registry.ReadLayer(ctx, m.regCli, repository, digest, "SBOM", func(content []byte) error {
+		_, err := Parse(content)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return m.Index(ctx, artifactID, digest, content)
+}
+
+func (m *manager) DeleteByArtifactID(ctx context.Context, artifactID int64) error {
+	_, err := m.dao.DeleteByArtifactID(ctx, artifactID)
+	return err
+}
+
+func (m *manager) DeleteBySource(ctx context.Context, source string) error {
+	_, err := m.dao.DeleteBySource(ctx, 0, source)
+	return err
+}
+
+func (m *manager) Search(ctx context.Context, criteria *model.Criteria, pageNumber, pageSize int64) (int64, []*model.Item, error) {
+	if criteria == nil || (len(criteria.Name) == 0 && len(criteria.PURL) == 0) {
+		return 0, nil, errors.BadRequestError(nil).WithMessage("either the name or the purl of the component is required")
+	}
+	var versionRange VersionRange
+	if len(criteria.Version) > 0 {
+		vr, err := ParseVersionRange(criteria.Version)
+		if err != nil {
+			return 0, nil, err
+		}
+		versionRange = vr
+	}
+
+	// the versions can't be compared in the database, resolve the range into the matched versions,
+	// the distinct versions of the component are only a few
+	if versionRange != nil {
+		versions, err := m.dao.Versions(ctx, criteria)
+		if err != nil {
+			return 0, nil, err
+		}
+		var matched []string
+		for _, v := range versions {
+			if versionRange.Contains(v) {
+				matched = append(matched, v)
+			}
+		}
+		if len(matched) == 0 {
+			return 0, []*model.Item{}, nil
+		}
+		c := *criteria
+		c.Versions = matched
+		criteria = &c
+	}
+
+	total, err := m.dao.Count(ctx, criteria)
+	if err != nil {
+		return 0, nil, err
+	}
+	if total == 0 || (pageSize > 0 && (max(pageNumber, 1)-1)*pageSize >= total) {
+		return total, []*model.Item{}, nil
+	}
+	items, err := m.dao.Search(ctx, criteria, &q.Query{PageNumber: pageNumber, PageSize: pageSize})
+	if err != nil {
+		return 0, nil, err
+	}
+	return total, items, nil
+}
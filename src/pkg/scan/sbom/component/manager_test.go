@@ -0,0 +1,175 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/docker/distribution"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom/component/model"
+	ormtesting "github.com/goharbor/harbor/src/testing/lib/orm"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/registry"
+	"github.com/goharbor/harbor/src/testing/pkg/scan/sbom/component/dao"
+)
+
+type managerTestSuite struct {
+	suite.Suite
+	mgr    *manager
+	dao    *dao.DAO
+	regCli *registry.Client
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.dao = &dao.DAO{}
+	m.regCli = &registry.Client{}
+	m.mgr = &manager{
+		dao:    m.dao,
+		regCli: m.regCli,
+	}
+}
+
+func (m *managerTestSuite) TestIndex() {
+	m.dao.On("DeleteBySource", mock.Anything, int64(1), "sha256:sbom").Return(int64(3), nil)
+	var created []*model.Component
+	m.dao.On("CreateMany", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(1).([]*model.Component)
+	}).Return(nil)
+
+	count, err := m.mgr.Index(orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{}), 1, "sha256:sbom", []byte(spdxSBOM))
+	m.Require().Nil(err)
+	m.Equal(2, count)
+	m.Require().Len(created, 2)
+	for _, c := range created {
+		m.Equal(int64(1), c.ArtifactID)
+		m.Equal("sha256:sbom", c.Source)
+	}
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestIndexInvalid() {
+	_, err := m.mgr.Index(context.TODO(), 1, "sha256:sbom", []byte("{}"))
+	m.Require().NotNil(err)
+	m.Equal(errors.BadRequestCode, errors.ErrCode(err))
+	m.dao.AssertNotCalled(m.T(), "CreateMany", mock.Anything, mock.Anything)
+}
+
+func (m *managerTestSuite) TestIndexAccessory() {
+	manifest := `{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.manifest.v1+json",
+  "artifactType": "application/vnd.cyclonedx+json",
+  "config": {
+    "mediaType": "application/vnd.oci.empty.v1+json",
+    "digest": "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a",
+    "size": 2
+  },
+  "layers": [
+    {
+      "mediaType": "application/vnd.cyclonedx+json",
+      "digest": "sha256:abc",
+      "size": 512
+    }
+  ]
+}`
+	mani, _, err := distribution.UnmarshalManifest(v1.MediaTypeImageManifest, []byte(manifest))
+	m.Require().Nil(err)
+	m.regCli.On("PullManifest", "library/hello", "sha256:sbom").Return(mani, "sha256:sbom", nil)
+	m.regCli.On("PullBlob", "library/hello", "sha256:abc").Return(int64(512), io.NopCloser(strings.NewReader(cycloneDXSBOM)), nil)
+	// only the components indexed from the same SBOM are replaced
+	m.dao.On("DeleteBySource", mock.Anything, int64(1), "sha256:sbom").Return(int64(0), nil)
+	m.dao.On("CreateMany", mock.Anything, mock.Anything).Return(nil)
+
+	count, err := m.mgr.IndexAccessory(orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{}), 1, "library/hello", "sha256:sbom")
+	m.Require().Nil(err)
+	m.Equal(2, count)
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestDeleteBySource() {
+	m.dao.On("DeleteBySource", mock.Anything, int64(0), "sha256:sbom").Return(int64(2), nil)
+	m.Require().Nil(m.mgr.DeleteBySource(context.TODO(), "sha256:sbom"))
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestSearch() {
+	m.dao.On("Versions", mock.Anything, mock.Anything).Return([]string{"2.14.1", "2.17.1", "2.0.2", "1.2.17"}, nil)
+	m.dao.On("Count", mock.Anything, &model.Criteria{
+		Name:     "log4j-core",
+		Version:  ">=2.0.0,<2.15.0",
+		Versions: []string{"2.14.1", "2.0.2"},
+	}).Return(int64(2), nil)
+	m.dao.On("Search", mock.Anything, mock.Anything, &q.Query{PageNumber: 1, PageSize: 1}).Return([]*model.Item{
+		{Component: model.Component{Name: "log4j-core", Version: "2.14.1"}, Digest: "sha256:1"},
+	}, nil)
+
+	criteria := &model.Criteria{Name: "log4j-core", Version: ">=2.0.0,<2.15.0"}
+	total, result, err := m.mgr.Search(context.TODO(), criteria, 1, 1)
+	m.Require().Nil(err)
+	m.Equal(int64(2), total)
+	m.Require().Len(result, 1)
+	m.Equal("sha256:1", result[0].Digest)
+	// the criteria of the caller is not changed
+	m.Empty(criteria.Versions)
+
+	// the page is out of the range
+	_, result, err = m.mgr.Search(context.TODO(), criteria, 3, 1)
+	m.Require().Nil(err)
+	m.Empty(result)
+	m.dao.AssertNumberOfCalls(m.T(), "Search", 1)
+
+	// no version is in the range
+	total, result, err = m.mgr.Search(context.TODO(), &model.Criteria{Name: "log4j-core", Version: ">=3.0.0"}, 1, 10)
+	m.Require().Nil(err)
+	m.Equal(int64(0), total)
+	m.Empty(result)
+	m.dao.AssertNumberOfCalls(m.T(), "Count", 2)
+}
+
+func (m *managerTestSuite) TestSearchWithoutVersion() {
+	m.dao.On("Count", mock.Anything, mock.Anything).Return(int64(4), nil)
+	m.dao.On("Search", mock.Anything, mock.Anything, &q.Query{}).Return(make([]*model.Item, 4), nil)
+
+	total, result, err := m.mgr.Search(context.TODO(), &model.Criteria{Name: "log4j-core"}, 0, 0)
+	m.Require().Nil(err)
+	m.Equal(int64(4), total)
+	m.Len(result, 4)
+	m.dao.AssertNotCalled(m.T(), "Versions", mock.Anything, mock.Anything)
+}
+
+func (m *managerTestSuite) TestSearchInvalid() {
+	_, _, err := m.mgr.Search(context.TODO(), &model.Criteria{Version: "2.14.1"}, 1, 10)
+	m.Require().NotNil(err)
+	m.Equal(errors.BadRequestCode, errors.ErrCode(err))
+
+	_, _, err = m.mgr.Search(context.TODO(), &model.Criteria{Name: "log4j-core", Version: "<"}, 1, 10)
+	m.Require().NotNil(err)
+	m.Equal(errors.BadRequestCode, errors.ErrCode(err))
+	m.dao.AssertNotCalled(m.T(), "Versions", mock.Anything, mock.Anything)
+	m.dao.AssertNotCalled(m.T(), "Search", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
@@ -0,0 +1,66 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+)
+
+func init() {
+	orm.RegisterModel(&Component{})
+}
+
+// Component is the software component listed in the SBOM of the artifact
+type Component struct {
+	ID         int64 `orm:"pk;auto;column(id)" json:"id"`
+	ArtifactID int64 `orm:"column(artifact_id)" json:"artifact_id"`
+	// Source is the digest of the SBOM which the component is indexed from
+	Source       string    `orm:"column(source)" json:"source"`
+	Name         string    `orm:"column(name)" json:"name"`
+	Version      string    `orm:"column(version)" json:"version"`
+	PURL         string    `orm:"column(purl)" json:"purl"`
+	Type         string    `orm:"column(type)" json:"type"`
+	Licenses     string    `orm:"column(licenses)" json:"licenses"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+}
+
+// TableName ...
+func (c *Component) TableName() string {
+	return "sbom_component"
+}
+
+// Item is the component found in the artifact
+type Item struct {
+	Component
+	ProjectID      int64    `orm:"column(project_id)" json:"project_id"`
+	RepositoryName string   `orm:"column(repository_name)" json:"repository_name"`
+	Digest         string   `orm:"column(digest)" json:"digest"`
+	Tags           []string `orm:"-" json:"tags"`
+}
+
+// Criteria defines the conditions to search the components, the name is matched case-insensitively,
+// the purl is matched by prefix and the version is a range like ">=2.0.0,<2.15.0", the empty field is ignored
+type Criteria struct {
+	Name           string
+	Version        string
+	PURL           string
+	ProjectID      int64
+	RepositoryName string
+	// Versions are the exact versions matched, the version range is resolved into them before searching
+	// as the versions can't be compared in the database, the empty list is ignored
+	Versions []string
+}
@@ -0,0 +1,191 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom/component/model"
+)
+
+// the license values of SPDX which mean no license info
+var spdxNoLicense = map[string]bool{
+	"":            true,
+	"NONE":        true,
+	"NOASSERTION": true,
+}
+
+type spdxDocument struct {
+	SPDXVersion string         `json:"spdxVersion"`
+	Packages    []*spdxPackage `json:"packages"`
+}
+
+type spdxPackage struct {
+	Name             string `json:"name"`
+	VersionInfo      string `json:"versionInfo"`
+	LicenseConcluded string `json:"licenseConcluded"`
+	LicenseDeclared  string `json:"licenseDeclared"`
+	ExternalRefs     []struct {
+		ReferenceType    string `json:"referenceType"`
+		ReferenceLocator string `json:"referenceLocator"`
+	} `json:"externalRefs"`
+}
+
+type cycloneDXDocument struct {
+	BOMFormat  string                `json:"bomFormat"`
+	Components []*cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXComponent struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	PURL     string `json:"purl"`
+	Licenses []struct {
+		License *struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"license"`
+		Expression string `json:"expression"`
+	} `json:"licenses"`
+	Components []*cycloneDXComponent `json:"components"`
+}
+
+// Parse the SPDX or CycloneDX JSON SBOM and returns the components listed in it,
+// the duplicated components are merged
+func Parse(content []byte) ([]*model.Component, error) {
+	probe := struct {
+		SPDXVersion string `json:"spdxVersion"`
+		BOMFormat   string `json:"bomFormat"`
+	}{}
+	if err := json.Unmarshal(content, &probe); err != nil {
+		return nil, errors.BadRequestError(err).WithMessage("the SBOM is not a valid JSON document")
+	}
+
+	var components []*model.Component
+	switch {
+	case len(probe.SPDXVersion) > 0:
+		doc := &spdxDocument{}
+		if err := json.Unmarshal(content, doc); err != nil {
+			return nil, errors.BadRequestError(err).WithMessage("invalid SPDX document")
+		}
+		components = fromSPDX(doc)
+	case strings.EqualFold(probe.BOMFormat, "CycloneDX"):
+		doc := &cycloneDXDocument{}
+		if err := json.Unmarshal(content, doc); err != nil {
+			return nil, errors.BadRequestError(err).WithMessage("invalid CycloneDX document")
+		}
+		components = fromCycloneDX(doc.Components)
+	default:
+		return nil, errors.BadRequestError(nil).WithMessage("unsupported SBOM format, only SPDX and CycloneDX JSON are supported")
+	}
+
+	return dedup(components), nil
+}
+
+func fromSPDX(doc *spdxDocument) []*model.Component {
+	var components []*model.Component
+	for _, pkg := range doc.Packages {
+		if pkg == nil || len(pkg.Name) == 0 {
+			continue
+		}
+		c := &model.Component{
+			Name:    pkg.Name,
+			Version: pkg.VersionInfo,
+		}
+		for _, ref := range pkg.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				c.PURL = ref.ReferenceLocator
+				break
+			}
+		}
+		license := pkg.LicenseConcluded
+		if spdxNoLicense[license] {
+			license = pkg.LicenseDeclared
+		}
+		if !spdxNoLicense[license] {
+			c.Licenses = license
+		}
+		c.Type = purlType(c.PURL)
+		components = append(components, c)
+	}
+	return components
+}
+
+func fromCycloneDX(cs []*cycloneDXComponent) []*model.Component {
+	var components []*model.Component
+	for _, comp := range cs {
+		if comp == nil {
+			continue
+		}
+		if len(comp.Name) > 0 {
+			var licenses []string
+			for _, l := range comp.Licenses {
+				switch {
+				case l.License != nil && len(l.License.ID) > 0:
+					licenses = append(licenses, l.License.ID)
+				case l.License != nil && len(l.License.Name) > 0:
+					licenses = append(licenses, l.License.Name)
+				case len(l.Expression) > 0:
+					licenses = append(licenses, l.Expression)
+				}
+			}
+			components = append(components, &model.Component{
+				Name:     comp.Name,
+				Version:  comp.Version,
+				PURL:     comp.PURL,
+				Type:     purlType(comp.PURL),
+				Licenses: strings.Join(licenses, ","),
+			})
+		}
+		// the components can be nested in CycloneDX
+		components = append(components, fromCycloneDX(comp.Components)...)
+	}
+	return components
+}
+
+// purlType returns the type of the package URL, e.g. "maven" for "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"
+func purlType(purl string) string {
+	if !strings.HasPrefix(purl, "pkg:") {
+		return ""
+	}
+	t, _, found := strings.Cut(strings.TrimPrefix(purl, "pkg:"), "/")
+	if !found {
+		return ""
+	}
+	return strings.ToLower(t)
+}
+
+func dedup(components []*model.Component) []*model.Component {
+	seen := map[string]bool{}
+	var result []*model.Component
+	for _, c := range components {
+		key := c.Name + "\x00" + c.Version + "\x00" + c.PURL
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, c)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Version < result[j].Version
+	})
+	return result
+}
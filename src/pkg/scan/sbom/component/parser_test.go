@@ -0,0 +1,128 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+const spdxSBOM = `{
+  "spdxVersion": "SPDX-2.3",
+  "packages": [
+    {
+      "name": "log4j-core",
+      "versionInfo": "2.14.1",
+      "licenseConcluded": "NOASSERTION",
+      "licenseDeclared": "Apache-2.0",
+      "externalRefs": [
+        {
+          "referenceCategory": "PACKAGE-MANAGER",
+          "referenceType": "purl",
+          "referenceLocator": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"
+        }
+      ]
+    },
+    {
+      "name": "openssl",
+      "versionInfo": "3.0.2-0ubuntu1.10",
+      "externalRefs": [
+        {
+          "referenceCategory": "PACKAGE-MANAGER",
+          "referenceType": "purl",
+          "referenceLocator": "pkg:deb/ubuntu/openssl@3.0.2-0ubuntu1.10?arch=amd64"
+        }
+      ]
+    },
+    {
+      "name": "log4j-core",
+      "versionInfo": "2.14.1",
+      "externalRefs": [
+        {
+          "referenceType": "purl",
+          "referenceLocator": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"
+        }
+      ]
+    },
+    {
+      "versionInfo": "1.0"
+    }
+  ]
+}`
+
+const cycloneDXSBOM = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "components": [
+    {
+      "type": "library",
+      "name": "lodash",
+      "version": "4.17.20",
+      "purl": "pkg:npm/lodash@4.17.20",
+      "licenses": [{"license": {"id": "MIT"}}],
+      "components": [
+        {
+          "type": "library",
+          "name": "minimist",
+          "version": "1.2.5",
+          "purl": "pkg:npm/minimist@1.2.5",
+          "licenses": [{"expression": "MIT OR Apache-2.0"}]
+        }
+      ]
+    }
+  ]
+}`
+
+func TestParseSPDX(t *testing.T) {
+	components, err := Parse([]byte(spdxSBOM))
+	require.Nil(t, err)
+	require.Len(t, components, 2)
+
+	assert.Equal(t, "log4j-core", components[0].Name)
+	assert.Equal(t, "2.14.1", components[0].Version)
+	assert.Equal(t, "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", components[0].PURL)
+	assert.Equal(t, "maven", components[0].Type)
+	assert.Equal(t, "Apache-2.0", components[0].Licenses)
+
+	assert.Equal(t, "openssl", components[1].Name)
+	assert.Equal(t, "deb", components[1].Type)
+	assert.Empty(t, components[1].Licenses)
+}
+
+func TestParseCycloneDX(t *testing.T) {
+	components, err := Parse([]byte(cycloneDXSBOM))
+	require.Nil(t, err)
+	require.Len(t, components, 2)
+
+	assert.Equal(t, "lodash", components[0].Name)
+	assert.Equal(t, "npm", components[0].Type)
+	assert.Equal(t, "MIT", components[0].Licenses)
+	assert.Equal(t, "minimist", components[1].Name)
+	assert.Equal(t, "MIT OR Apache-2.0", components[1].Licenses)
+}
+
+func TestParseUnsupported(t *testing.T) {
+	_, err := Parse([]byte(`{"foo": "bar"}`))
+	require.NotNil(t, err)
+	assert.Equal(t, errors.BadRequestCode, errors.ErrCode(err))
+
+	_, err = Parse([]byte(`not json`))
+	require.NotNil(t, err)
+	assert.Equal(t, errors.BadRequestCode, errors.ErrCode(err))
+}
@@ -0,0 +1,177 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+// the operators supported in the version range, the longer ones must be put before their prefixes
+var operators = []string{">=", "<=", "!=", "==", ">", "<", "="}
+
+type constraint struct {
+	operator string
+	version  string
+}
+
+// VersionRange is a set of version constraints which are ANDed, e.g. ">=2.0.0,<2.15.0"
+type VersionRange []*constraint
+
+// ParseVersionRange parses the version range which consists of the comma separated constraints,
+// the constraint without operator means the exact version
+func ParseVersionRange(s string) (VersionRange, error) {
+	var vr VersionRange
+	for part := range strings.SplitSeq(s, ",") {
+		part = strings.TrimSpace(part)
+		if len(part) == 0 {
+			continue
+		}
+		c := &constraint{operator: "=", version: part}
+		for _, op := range operators {
+			if strings.HasPrefix(part, op) {
+				c.operator = op
+				c.version = strings.TrimSpace(strings.TrimPrefix(part, op))
+				break
+			}
+		}
+		if len(c.version) == 0 {
+			return nil, errors.BadRequestError(nil).WithMessagef("invalid version constraint %q", part)
+		}
+		vr = append(vr, c)
+	}
+	if len(vr) == 0 {
+		return nil, errors.BadRequestError(nil).WithMessagef("invalid version range %q", s)
+	}
+	return vr, nil
+}
+
+// Contains returns true when the version satisfies all the constraints of the range
+func (vr VersionRange) Contains(version string) bool {
+	if len(version) == 0 {
+		return false
+	}
+	for _, c := range vr {
+		r := CompareVersions(version, c.version)
+		var ok bool
+		switch c.operator {
+		case ">=":
+			ok = r >= 0
+		case "<=":
+			ok = r <= 0
+		case ">":
+			ok = r > 0
+		case "<":
+			ok = r < 0
+		case "!=":
+			ok = r != 0
+		default:
+			ok = r == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// CompareVersions compares the versions segment by segment, the numeric segments are compared numerically
+// and the others lexically, returns -1, 0 or 1 when a is less than, equal to or greater than b.
+// It doesn't follow any specific versioning scheme but works for the most common ones of the packages
+func CompareVersions(a, b string) int {
+	as, bs := segments(a), segments(b)
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		// the missing numeric segment is treated as 0, so that "2.14" equals "2.14.0"
+		var x, y string
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+		if r := compareSegment(x, y); r != 0 {
+			return r
+		}
+	}
+	return 0
+}
+
+func compareSegment(x, y string) int {
+	xn, xerr := strconv.ParseUint(orZero(x, y), 10, 64)
+	yn, yerr := strconv.ParseUint(orZero(y, x), 10, 64)
+	switch {
+	case xerr == nil && yerr == nil:
+		switch {
+		case xn < yn:
+			return -1
+		case xn > yn:
+			return 1
+		}
+		return 0
+	case xerr == nil:
+		// the numeric segment is greater than the textual one, e.g. "1.0.1" > "1.0.rc1"
+		return 1
+	case yerr == nil:
+		return -1
+	case len(x) == 0:
+		// the release is greater than the pre-release, e.g. "1.0.0" > "1.0.0-rc1"
+		return 1
+	case len(y) == 0:
+		return -1
+	}
+	return strings.Compare(x, y)
+}
+
+// orZero returns "0" for the missing segment when the other one is numeric
+func orZero(s, other string) string {
+	if len(s) == 0 && len(other) > 0 && unicode.IsDigit(rune(other[0])) {
+		return "0"
+	}
+	return s
+}
+
+// segments splits the version into the numeric and textual segments, e.g. "v2.14.1-rc1" -> ["2", "14", "1", "rc", "1"]
+func segments(version string) []string {
+	version = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(version)), "v")
+	var (
+		result  []string
+		current []rune
+		digit   bool
+	)
+	flush := func() {
+		if len(current) > 0 {
+			result = append(result, string(current))
+			current = current[:0]
+		}
+	}
+	for _, r := range version {
+		switch {
+		case r == '.' || r == '-' || r == '_' || r == '+' || r == ':' || r == '~':
+			flush()
+		case unicode.IsDigit(r) != digit && len(current) > 0:
+			flush()
+			digit = unicode.IsDigit(r)
+			current = append(current, r)
+		default:
+			digit = unicode.IsDigit(r)
+			current = append(current, r)
+		}
+	}
+	flush()
+	return result
+}
@@ -0,0 +1,68 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"2.14.1", "2.14.1", 0},
+		{"2.14", "2.14.0", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"2.14.1", "2.15.0", -1},
+		{"2.9.0", "2.10.0", -1},
+		{"2.17.0", "2.15.0", 1},
+		{"1.0.0", "1.0.0-rc1", 1},
+		{"1.0.0-rc1", "1.0.0-rc2", -1},
+		{"1.0.1", "1.0.rc1", 1},
+		{"3.0.2-0ubuntu1.10", "3.0.2-0ubuntu1.9", 1},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, CompareVersions(c.a, c.b), "%s vs %s", c.a, c.b)
+	}
+}
+
+func TestVersionRange(t *testing.T) {
+	vr, err := ParseVersionRange(">=2.0.0, <2.15.0")
+	require.Nil(t, err)
+	assert.True(t, vr.Contains("2.14.1"))
+	assert.True(t, vr.Contains("2.0.0"))
+	assert.False(t, vr.Contains("2.15.0"))
+	assert.False(t, vr.Contains("1.2.17"))
+	assert.False(t, vr.Contains(""))
+
+	vr, err = ParseVersionRange("2.14.1")
+	require.Nil(t, err)
+	assert.True(t, vr.Contains("2.14.1"))
+	assert.False(t, vr.Contains("2.14.2"))
+
+	vr, err = ParseVersionRange("!=2.14.1")
+	require.Nil(t, err)
+	assert.False(t, vr.Contains("2.14.1"))
+	assert.True(t, vr.Contains("2.14.2"))
+
+	_, err = ParseVersionRange(">=")
+	assert.NotNil(t, err)
+	_, err = ParseVersionRange(" , ")
+	assert.NotNil(t, err)
+}
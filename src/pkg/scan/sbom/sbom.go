@@ -36,6 +36,7 @@ import (
 	"github.com/goharbor/harbor/src/pkg/scan"
 	scanModel "github.com/goharbor/harbor/src/pkg/scan/dao/scan"
 	"github.com/goharbor/harbor/src/pkg/scan/dao/scanner"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom/component"
 	sbom "github.com/goharbor/harbor/src/pkg/scan/sbom/model"
 	"github.com/goharbor/harbor/src/pkg/task"
 
@@ -53,6 +54,7 @@ func init() {
 	scan.RegisterScanHanlder(v1.ScanTypeSbom, &scanHandler{
 		GenAccessoryFunc:       scan.GenAccessoryArt,
 		SBOMMgrFunc:            func() Manager { return Mgr },
+		ComponentMgrFunc:       func() component.Manager { return component.Mgr },
 		TaskMgrFunc:            func() task.Manager { return task.Mgr },
 		ArtifactControllerFunc: func() artifact.Controller { return artifact.Ctl },
 		ScanControllerFunc:     func() scanCtl.Controller { return scanCtl.DefaultController },
@@ -65,6 +67,7 @@ func init() {
 type scanHandler struct {
 	GenAccessoryFunc       func(scanRep v1.ScanRequest, sbomContent []byte, labels map[string]string, mediaType string, robot *model.Robot) (string, error)
 	SBOMMgrFunc            func() Manager
+	ComponentMgrFunc       func() component.Manager
 	TaskMgrFunc            func() task.Manager
 	ArtifactControllerFunc func() artifact.Controller
 	ScanControllerFunc     func() scanCtl.Controller
@@ -106,9 +109,24 @@ func (h *scanHandler) PostScan(ctx job.Context, sr *v1.ScanRequest, _ *scanModel
 		myLogger.Errorf("error when create accessory from image %v", err)
 		return "", err
 	}
+	// the failure of indexing the components doesn't fail the SBOM generation
+	if err := h.indexComponents(ctx.SystemContext(), sr.Artifact, dgst, sbomContent); err != nil {
+		myLogger.Warningf("failed to index the components of the SBOM of %s@%s, error: %v", sr.Artifact.Repository, sr.Artifact.Digest, err)
+	}
 	return h.generateReport(startTime, sr.Artifact.Repository, dgst, "Success", s)
 }
 
+// indexComponents indexes the components of the SBOM pushed as the accessory with the digest into database
+// for searching them across the artifacts
+func (h *scanHandler) indexComponents(ctx context.Context, art *v1.Artifact, digest string, sbomContent []byte) error {
+	a, err := h.ArtifactControllerFunc().GetByReference(ctx, art.Repository, art.Digest, nil)
+	if err != nil {
+		return err
+	}
+	_, err = h.ComponentMgrFunc().Index(ctx, a.ID, digest, sbomContent)
+	return err
+}
+
 // URLParameter defines the parameters for scan report url
 func (h *scanHandler) URLParameter(_ *v1.ScanRequest) (string, error) {
 	return fmt.Sprintf("sbom_media_type=%s", url.QueryEscape(sbomMediaTypeSpdx)), nil
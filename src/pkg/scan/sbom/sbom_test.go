@@ -11,10 +11,12 @@ import (
 
 	sc "github.com/goharbor/harbor/src/controller/scan"
 	"github.com/goharbor/harbor/src/controller/scanner"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/orm"
 	accessoryModel "github.com/goharbor/harbor/src/pkg/accessory/model"
 	basemodel "github.com/goharbor/harbor/src/pkg/accessory/model/base"
 	art "github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom/component"
 	sbomModel "github.com/goharbor/harbor/src/pkg/scan/sbom/model"
 	htesting "github.com/goharbor/harbor/src/testing"
 	artifactTest "github.com/goharbor/harbor/src/testing/controller/artifact"
@@ -31,6 +33,7 @@ import (
 	scannerTest "github.com/goharbor/harbor/src/testing/controller/scanner"
 	"github.com/goharbor/harbor/src/testing/jobservice"
 	sbomTest "github.com/goharbor/harbor/src/testing/pkg/scan/sbom"
+	componentTest "github.com/goharbor/harbor/src/testing/pkg/scan/sbom/component"
 	taskTest "github.com/goharbor/harbor/src/testing/pkg/task"
 )
 
@@ -130,6 +133,7 @@ type SBOMTestSuite struct {
 	htesting.Suite
 	handler           *scanHandler
 	sbomManager       *sbomTest.Manager
+	componentMgr      *componentTest.Manager
 	taskMgr           *taskTest.Manager
 	artifactCtl       *artifactTest.Controller
 	artifact          *artifact.Artifact
@@ -140,6 +144,7 @@ type SBOMTestSuite struct {
 
 func (suite *SBOMTestSuite) SetupSuite() {
 	suite.sbomManager = &sbomTest.Manager{}
+	suite.componentMgr = &componentTest.Manager{}
 	suite.taskMgr = &taskTest.Manager{}
 	suite.artifactCtl = &artifactTest.Controller{}
 	suite.scannerController = &scannerTest.Controller{}
@@ -148,6 +153,7 @@ func (suite *SBOMTestSuite) SetupSuite() {
 	suite.handler = &scanHandler{
 		GenAccessoryFunc:       mockGenAccessory,
 		SBOMMgrFunc:            func() Manager { return suite.sbomManager },
+		ComponentMgrFunc:       func() component.Manager { return suite.componentMgr },
 		TaskMgrFunc:            func() task.Manager { return suite.taskMgr },
 		ArtifactControllerFunc: func() artifact.Controller { return suite.artifactCtl },
 		ScanControllerFunc:     func() sc.Controller { return suite.scanController },
@@ -188,9 +194,18 @@ func (suite *SBOMTestSuite) TestPostScan() {
 	rawReport := `{"sbom": { "key": "value" }}`
 	ctx := &jobservice.MockJobContext{}
 	ctx.On("GetLogger").Return(&jobservice.MockJobLogger{})
+	mock.OnAnything(suite.artifactCtl, "GetByReference").Return(suite.artifact, nil).Once()
+	suite.componentMgr.On("Index", mock.Anything, int64(1), "sha256:1234567890", []byte(`{"key":"value"}`)).Return(1, nil).Once()
 	accessory, err := suite.handler.PostScan(ctx, req, nil, rawReport, startTime, robot)
 	suite.Require().NoError(err)
 	suite.Require().NotEmpty(accessory)
+	suite.componentMgr.AssertExpectations(suite.T())
+
+	// failing to index the components doesn't fail the scan
+	mock.OnAnything(suite.artifactCtl, "GetByReference").Return(nil, errors.NotFoundError(nil)).Once()
+	accessory, err = suite.handler.PostScan(ctx, req, nil, rawReport, startTime, robot)
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(accessory)
 }
 
 func (suite *SBOMTestSuite) TestMakeReportPlaceHolder() {
@@ -44,7 +44,8 @@ type Manager interface {
 	Get(ctx context.Context, registrationUUID string) (*scanner.Registration, error)
 
 	// Update updates the specified scanner registration.
-	Update(ctx context.Context, registration *scanner.Registration) error
+	// Only the specified columns are updated when the cols are provided.
+	Update(ctx context.Context, registration *scanner.Registration, cols ...string) error
 
 	// Delete deletes the specified scanner registration.
 	Delete(ctx context.Context, registrationUUID string) error
@@ -106,7 +107,7 @@ func (bm *basicManager) Get(ctx context.Context, registrationUUID string) (*scan
 }
 
 // Update ...
-func (bm *basicManager) Update(ctx context.Context, registration *scanner.Registration) error {
+func (bm *basicManager) Update(ctx context.Context, registration *scanner.Registration, cols ...string) error {
 	if registration == nil {
 		return errors.New("nil registration to update")
 	}
@@ -115,7 +116,7 @@ func (bm *basicManager) Update(ctx context.Context, registration *scanner.Regist
 		return errors.Wrap(err, "update registration")
 	}
 
-	return scanner.UpdateRegistration(ctx, registration)
+	return scanner.UpdateRegistration(ctx, registration, cols...)
 }
 
 // Delete ...
@@ -0,0 +1,231 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vuln
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// keys of the vendor attributes which carry the data used by the prevention policy,
+// both the lower snake case and the upper camel case keys are accepted as the scanners use different conventions
+var (
+	publishedDateKeys  = []string{"published_date", "PublishedDate"}
+	epssKeys           = []string{"epss", "EPSS"}
+	knownExploitedKeys = []string{"kev", "KEV", "known_exploited", "KnownExploited"}
+)
+
+// PreventionPolicy defines the criteria for a vulnerability item to prevent the artifact from being pulled,
+// a vulnerability item is matched only when it meets all the configured criteria
+type PreventionPolicy struct {
+	// Severity the vulnerability items with severity lower than it are not matched
+	Severity Severity
+	// CVSSScore the vulnerability items with CVSS v3 score lower than it are not matched, 0 means not configured
+	CVSSScore float64
+	// FixableOnly the vulnerability items without fix version are not matched when it's true
+	FixableOnly bool
+	// MinAgeDays the vulnerability items published within the days are not matched, 0 means not configured
+	MinAgeDays int
+	// KnownExploited the vulnerability items which are not flagged as known exploited are not matched when it's true
+	KnownExploited bool
+	// EPSSScore the vulnerability items with EPSS score lower than it are not matched, 0 means not configured
+	EPSSScore float64
+}
+
+// IsSeverityOnly returns true when only the severity is configured in the policy
+func (p *PreventionPolicy) IsSeverityOnly() bool {
+	return p.CVSSScore <= 0 && !p.FixableOnly && p.MinAgeDays <= 0 && !p.KnownExploited && p.EPSSScore <= 0
+}
+
+// Matches returns true when the vulnerability item meets all the criteria of the policy
+func (p *PreventionPolicy) Matches(item *VulnerabilityItem, now time.Time) bool {
+	if item == nil {
+		return false
+	}
+
+	if p.Severity != "" && item.Severity.Code() < p.Severity.Code() {
+		return false
+	}
+
+	if p.CVSSScore > 0 {
+		score, ok := item.CVSSScore()
+		if !ok || score < p.CVSSScore {
+			return false
+		}
+	}
+
+	if p.FixableOnly && !item.IsFixable() {
+		return false
+	}
+
+	if p.MinAgeDays > 0 {
+		published, ok := item.PublishedDate()
+		if !ok || now.Sub(published) < time.Duration(p.MinAgeDays)*24*time.Hour {
+			return false
+		}
+	}
+
+	// the known exploited flag and the EPSS score are both the signals of the exploitation,
+	// the item is matched when any of the configured signals is present
+	if p.KnownExploited || p.EPSSScore > 0 {
+		exploited := p.KnownExploited && item.IsKnownExploited()
+		if !exploited && p.EPSSScore > 0 {
+			score, ok := item.EPSSScore()
+			exploited = ok && score >= p.EPSSScore
+		}
+
+		if !exploited {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Filter returns the vulnerability items which match the policy
+func (p *PreventionPolicy) Filter(items []*VulnerabilityItem, now time.Time) []*VulnerabilityItem {
+	var matched []*VulnerabilityItem
+	for _, item := range items {
+		if p.Matches(item, now) {
+			matched = append(matched, item)
+		}
+	}
+
+	return matched
+}
+
+// String returns the readable description of the policy
+func (p *PreventionPolicy) String() string {
+	criteria := []string{fmt.Sprintf("severity of %q or higher", p.Severity)}
+	if p.CVSSScore > 0 {
+		criteria = append(criteria, fmt.Sprintf("CVSS score of %s or higher", strconv.FormatFloat(p.CVSSScore, 'f', -1, 64)))
+	}
+	if p.FixableOnly {
+		criteria = append(criteria, "fix available")
+	}
+	if p.MinAgeDays > 0 {
+		criteria = append(criteria, fmt.Sprintf("published more than %d days ago", p.MinAgeDays))
+	}
+
+	var signals []string
+	if p.KnownExploited {
+		signals = append(signals, "known exploited")
+	}
+	if p.EPSSScore > 0 {
+		signals = append(signals, fmt.Sprintf("EPSS score of %s or higher", strconv.FormatFloat(p.EPSSScore, 'f', -1, 64)))
+	}
+	if len(signals) > 0 {
+		criteria = append(criteria, strings.Join(signals, " or "))
+	}
+
+	return strings.Join(criteria, ", ")
+}
+
+// CVSSScore returns the CVSS v3 score of the vulnerability item
+func (v *VulnerabilityItem) CVSSScore() (float64, bool) {
+	if v.CVSSDetails.ScoreV3 != nil {
+		return *v.CVSSDetails.ScoreV3, true
+	}
+
+	return 0, false
+}
+
+// IsFixable returns true when the fix version of the vulnerability item is available
+func (v *VulnerabilityItem) IsFixable() bool {
+	return v.FixVersion != ""
+}
+
+// PublishedDate returns the published date of the vulnerability from the vendor attributes
+func (v *VulnerabilityItem) PublishedDate() (time.Time, bool) {
+	val, ok := v.vendorAttribute(publishedDateKeys...)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	s, ok := val.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// EPSSScore returns the EPSS score of the vulnerability from the vendor attributes,
+// both the plain score and the object with the "score" field are accepted
+func (v *VulnerabilityItem) EPSSScore() (float64, bool) {
+	val, ok := v.vendorAttribute(epssKeys...)
+	if !ok {
+		return 0, false
+	}
+
+	if m, ok := val.(map[string]any); ok {
+		val, ok = m["score"]
+		if !ok {
+			return 0, false
+		}
+	}
+
+	return toFloat(val)
+}
+
+// IsKnownExploited returns true when the vulnerability is flagged as known exploited in the vendor attributes
+func (v *VulnerabilityItem) IsKnownExploited() bool {
+	val, ok := v.vendorAttribute(knownExploitedKeys...)
+	if !ok {
+		return false
+	}
+
+	switch t := val.(type) {
+	case bool:
+		return t
+	case string:
+		b, _ := strconv.ParseBool(t)
+		return b
+	default:
+		return false
+	}
+}
+
+func (v *VulnerabilityItem) vendorAttribute(keys ...string) (any, bool) {
+	for _, key := range keys {
+		if val, ok := v.VendorAttributes[key]; ok && val != nil {
+			return val, true
+		}
+	}
+
+	return nil, false
+}
+
+func toFloat(val any) (float64, bool) {
+	switch t := val.(type) {
+	case float64:
+		return t, true
+	case int:
+		return float64(t), true
+	case string:
+		f, err := strconv.ParseFloat(t, 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
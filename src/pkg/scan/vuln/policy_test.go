@@ -0,0 +1,129 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vuln
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreventionPolicyIsSeverityOnly(t *testing.T) {
+	assert.True(t, (&PreventionPolicy{Severity: High}).IsSeverityOnly())
+	assert.False(t, (&PreventionPolicy{Severity: High, CVSSScore: 7}).IsSeverityOnly())
+	assert.False(t, (&PreventionPolicy{Severity: High, FixableOnly: true}).IsSeverityOnly())
+	assert.False(t, (&PreventionPolicy{Severity: High, MinAgeDays: 30}).IsSeverityOnly())
+	assert.False(t, (&PreventionPolicy{Severity: High, KnownExploited: true}).IsSeverityOnly())
+	assert.False(t, (&PreventionPolicy{Severity: High, EPSSScore: 0.5}).IsSeverityOnly())
+}
+
+func TestPreventionPolicyMatches(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	score := func(f float64) *float64 { return &f }
+
+	item := &VulnerabilityItem{
+		ID:          "CVE-2024-0001",
+		Severity:    High,
+		FixVersion:  "1.2.3",
+		CVSSDetails: CVSS{ScoreV3: score(8.1)},
+		VendorAttributes: map[string]any{
+			"published_date": "2024-01-01T00:00:00Z",
+			"epss":           map[string]any{"score": 0.42},
+			"kev":            true,
+		},
+	}
+
+	cases := []struct {
+		name   string
+		policy PreventionPolicy
+		item   *VulnerabilityItem
+		want   bool
+	}{
+		{"nil item", PreventionPolicy{Severity: Low}, nil, false},
+		{"severity matched", PreventionPolicy{Severity: High}, item, true},
+		{"severity not matched", PreventionPolicy{Severity: Critical}, item, false},
+		{"cvss matched", PreventionPolicy{Severity: Low, CVSSScore: 8}, item, true},
+		{"cvss not matched", PreventionPolicy{Severity: Low, CVSSScore: 9}, item, false},
+		{"cvss missing", PreventionPolicy{Severity: Low, CVSSScore: 1}, &VulnerabilityItem{Severity: High}, false},
+		{"fixable matched", PreventionPolicy{Severity: Low, FixableOnly: true}, item, true},
+		{"fixable not matched", PreventionPolicy{Severity: Low, FixableOnly: true}, &VulnerabilityItem{Severity: High}, false},
+		{"age matched", PreventionPolicy{Severity: Low, MinAgeDays: 30}, item, true},
+		{"age not matched", PreventionPolicy{Severity: Low, MinAgeDays: 365}, item, false},
+		{"age missing", PreventionPolicy{Severity: Low, MinAgeDays: 1}, &VulnerabilityItem{Severity: High}, false},
+		{"kev matched", PreventionPolicy{Severity: Low, KnownExploited: true}, item, true},
+		{"kev not matched", PreventionPolicy{Severity: Low, KnownExploited: true}, &VulnerabilityItem{Severity: High}, false},
+		{"epss matched", PreventionPolicy{Severity: Low, EPSSScore: 0.4}, item, true},
+		{"epss not matched", PreventionPolicy{Severity: Low, EPSSScore: 0.5}, item, false},
+		{
+			"kev or epss",
+			PreventionPolicy{Severity: Low, KnownExploited: true, EPSSScore: 0.1},
+			&VulnerabilityItem{Severity: High, VendorAttributes: map[string]any{"EPSS": 0.2}},
+			true,
+		},
+		{
+			"all criteria",
+			PreventionPolicy{Severity: High, CVSSScore: 7, FixableOnly: true, MinAgeDays: 30, KnownExploited: true},
+			item,
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, c.policy.Matches(c.item, now))
+		})
+	}
+}
+
+func TestPreventionPolicyFilter(t *testing.T) {
+	p := &PreventionPolicy{Severity: Medium, FixableOnly: true}
+	items := []*VulnerabilityItem{
+		{ID: "CVE-1", Severity: High, FixVersion: "1.0"},
+		{ID: "CVE-2", Severity: High},
+		{ID: "CVE-3", Severity: Low, FixVersion: "1.0"},
+	}
+
+	matched := p.Filter(items, time.Now())
+	if assert.Len(t, matched, 1) {
+		assert.Equal(t, "CVE-1", matched[0].ID)
+	}
+}
+
+func TestPreventionPolicyString(t *testing.T) {
+	assert.Equal(t, `severity of "High" or higher`, (&PreventionPolicy{Severity: High}).String())
+	assert.Equal(t,
+		`severity of "Low" or higher, CVSS score of 7.5 or higher, fix available, published more than 30 days ago, known exploited or EPSS score of 0.1 or higher`,
+		(&PreventionPolicy{Severity: Low, CVSSScore: 7.5, FixableOnly: true, MinAgeDays: 30, KnownExploited: true, EPSSScore: 0.1}).String(),
+	)
+}
+
+func TestVulnerabilityItemPublishedDate(t *testing.T) {
+	_, ok := (&VulnerabilityItem{}).PublishedDate()
+	assert.False(t, ok)
+
+	d, ok := (&VulnerabilityItem{VendorAttributes: map[string]any{"PublishedDate": "2024-01-02"}}).PublishedDate()
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), d)
+
+	_, ok = (&VulnerabilityItem{VendorAttributes: map[string]any{"published_date": "yesterday"}}).PublishedDate()
+	assert.False(t, ok)
+}
+
+func TestVulnerabilityItemIsKnownExploited(t *testing.T) {
+	assert.False(t, (&VulnerabilityItem{}).IsKnownExploited())
+	assert.True(t, (&VulnerabilityItem{VendorAttributes: map[string]any{"KEV": "true"}}).IsKnownExploited())
+	assert.False(t, (&VulnerabilityItem{VendorAttributes: map[string]any{"kev": false}}).IsKnownExploited())
+}
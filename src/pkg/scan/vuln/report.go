@@ -17,10 +17,14 @@ package vuln
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
 )
 
+// vexStatusNotAffected is the VEX status for the vulnerabilities which are not exploitable
+const vexStatusNotAffected = "not_affected"
+
 // Report model for vulnerability scan
 type Report struct {
 	// Time of generating this report
@@ -143,7 +147,7 @@ func (l *VulnerabilityItemList) Add(items ...*VulnerabilityItem) {
 	for _, item := range items {
 		key := item.Key()
 		if v, ok := l.indexed[key]; ok {
-			v.ArtifactDigests = append(v.ArtifactDigests, item.ArtifactDigests...)
+			v.merge(item)
 		} else {
 			l.items = append(l.items, item)
 			l.indexed[key] = item
@@ -228,6 +232,50 @@ type VulnerabilityItem struct {
 	// A collection of vendor specific attributes for the vulnerability item
 	// with each attribute represented as a key-value pair.
 	VendorAttributes map[string]any `json:"vendor_attributes"`
+	// The VEX statement applied to the vulnerability item, it's not provided by the scanner
+	// but resolved from the VEX documents of the artifact when reading the report
+	VEX *VEXAnnotation `json:"vex,omitempty"`
+}
+
+// VEXAnnotation is the VEX statement applied to a vulnerability item
+type VEXAnnotation struct {
+	// The status of the vulnerability declared by the VEX statement
+	// e.g. not_affected
+	Status string `json:"status"`
+	// The justification for the not_affected status
+	// e.g. vulnerable_code_not_in_execute_path
+	Justification string `json:"justification,omitempty"`
+	// The free form explanation of the status
+	ImpactStatement string `json:"impact_statement,omitempty"`
+}
+
+// IsNotAffected returns true when the VEX statement declares the vulnerability is not exploitable
+func (a *VEXAnnotation) IsNotAffected() bool {
+	return a != nil && a.Status == vexStatusNotAffected
+}
+
+// merge merges the same vulnerability reported for another artifact or by another scanner into the item
+func (item *VulnerabilityItem) merge(another *VulnerabilityItem) {
+	for _, digest := range another.ArtifactDigests {
+		if !slices.Contains(item.ArtifactDigests, digest) {
+			item.ArtifactDigests = append(item.ArtifactDigests, digest)
+		}
+	}
+
+	// the scanners may rate the vulnerability differently, keep the highest severity
+	if another.Severity.Code() > item.Severity.Code() {
+		item.Severity = another.Severity
+	}
+
+	if len(item.FixVersion) == 0 {
+		item.FixVersion = another.FixVersion
+	}
+
+	for _, link := range another.Links {
+		if !slices.Contains(item.Links, link) {
+			item.Links = append(item.Links, link)
+		}
+	}
 }
 
 // Key returns the uniq key for the item
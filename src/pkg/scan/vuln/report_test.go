@@ -113,3 +113,54 @@ func TestGetSummarySeverity(t *testing.T) {
 	assert.Equal(1, sum.Fixable)
 	assert.Equal(s, sum.Summary)
 }
+
+func TestVulnerabilityItemListAddDuplicated(t *testing.T) {
+	assert := assert.New(t)
+
+	l := VulnerabilityItemList{}
+	l.Add(&VulnerabilityItem{
+		ID:              "cve1",
+		Package:         "openssl",
+		Version:         "1.1.1",
+		Severity:        Medium,
+		Links:           []string{"https://a"},
+		ArtifactDigests: []string{"sha256:1"},
+	})
+	// the same vulnerability reported by another scanner
+	l.Add(&VulnerabilityItem{
+		ID:              "cve1",
+		Package:         "openssl",
+		Version:         "1.1.1",
+		Severity:        High,
+		FixVersion:      "1.1.2",
+		Links:           []string{"https://a", "https://b"},
+		ArtifactDigests: []string{"sha256:1", "sha256:2"},
+	})
+
+	items := l.Items()
+	if assert.Len(items, 1) {
+		assert.Equal(High, items[0].Severity)
+		assert.Equal("1.1.2", items[0].FixVersion)
+		assert.Equal([]string{"https://a", "https://b"}, items[0].Links)
+		assert.Equal([]string{"sha256:1", "sha256:2"}, items[0].ArtifactDigests)
+	}
+}
+
+func TestVEXAnnotation(t *testing.T) {
+	assert := assert.New(t)
+
+	var a *VEXAnnotation
+	assert.False(a.IsNotAffected())
+
+	a = &VEXAnnotation{Status: "affected"}
+	assert.False(a.IsNotAffected())
+
+	a = &VEXAnnotation{Status: "not_affected", Justification: "component_not_present"}
+	assert.True(a.IsNotAffected())
+
+	b, _ := json.Marshal(&VulnerabilityItem{ID: "cve1"})
+	assert.NotContains(string(b), `"vex"`)
+
+	b, _ = json.Marshal(&VulnerabilityItem{ID: "cve1", VEX: a})
+	assert.Contains(string(b), `"vex":{"status":"not_affected","justification":"component_not_present"}`)
+}
@@ -35,7 +35,8 @@ type schedule struct {
 	VendorID          int64     `orm:"column(vendor_id)"`
 	CRONType          string    `orm:"column(cron_type)"`
 	CRON              string    `orm:"column(cron)"`
-	Revision          int64     `orm:"column(revision)"` // to identity the duplicated checkin hook from jobservice
+	CRONOptions       string    `orm:"column(cron_options)"` // json of the timezone, jitter and blackout windows
+	Revision          int64     `orm:"column(revision)"`     // to identity the duplicated checkin hook from jobservice
 	ExtraAttrs        string    `orm:"column(extra_attrs)"`
 	CallbackFuncName  string    `orm:"column(callback_func_name)"`
 	CallbackFuncParam string    `orm:"column(callback_func_param)"`
@@ -36,15 +36,17 @@ var (
 
 // Schedule describes the detail information about the created schedule
 type Schedule struct {
-	ID           int64          `json:"id"`
-	VendorType   string         `json:"vendor_type"`
-	VendorID     int64          `json:"vendor_id"`
-	CRONType     string         `json:"cron_type"`
-	CRON         string         `json:"cron"`
-	ExtraAttrs   map[string]any `json:"extra_attrs"`
-	Status       string         `json:"status"` // status of the underlying task(jobservice job)
-	CreationTime time.Time      `json:"creation_time"`
-	UpdateTime   time.Time      `json:"update_time"`
+	ID         int64  `json:"id"`
+	VendorType string `json:"vendor_type"`
+	VendorID   int64  `json:"vendor_id"`
+	CRONType   string `json:"cron_type"`
+	CRON       string `json:"cron"`
+	// The timezone, jitter and blackout windows applied to the cron
+	Options      *job.ScheduleOptions `json:"options,omitempty"`
+	ExtraAttrs   map[string]any       `json:"extra_attrs"`
+	Status       string               `json:"status"` // status of the underlying task(jobservice job)
+	CreationTime time.Time            `json:"creation_time"`
+	UpdateTime   time.Time            `json:"update_time"`
 	// we can extend this model to include more information(e.g. how many times the schedule already
 	// runs; when will the schedule runs next time)
 }
@@ -61,6 +63,10 @@ type Scheduler interface {
 	// The customized attributes can be put into the "extraAttrs"
 	Schedule(ctx context.Context, vendorType string, vendorID int64, cronType string,
 		cron string, callbackFuncName string, callbackFuncParams any, extraAttrs map[string]any) (int64, error)
+	// ScheduleWithOptions is same with Schedule, but the cron is evaluated with the "options":
+	// in the specified timezone, delayed by the random jitter and skipped in the blackout windows
+	ScheduleWithOptions(ctx context.Context, vendorType string, vendorID int64, cronType string,
+		cron string, options *job.ScheduleOptions, callbackFuncName string, callbackFuncParams any, extraAttrs map[string]any) (int64, error)
 	// UnScheduleByID the schedule specified by ID
 	UnScheduleByID(ctx context.Context, id int64) error
 	// UnScheduleByVendor the schedule specified by vendor
@@ -94,6 +100,11 @@ func (s *scheduler) CountSchedules(ctx context.Context, query *q.Query) (int64,
 
 func (s *scheduler) Schedule(ctx context.Context, vendorType string, vendorID int64, cronType string,
 	cron string, callbackFuncName string, callbackFuncParams any, extraAttrs map[string]any) (int64, error) {
+	return s.ScheduleWithOptions(ctx, vendorType, vendorID, cronType, cron, nil, callbackFuncName, callbackFuncParams, extraAttrs)
+}
+
+func (s *scheduler) ScheduleWithOptions(ctx context.Context, vendorType string, vendorID int64, cronType string,
+	cron string, options *job.ScheduleOptions, callbackFuncName string, callbackFuncParams any, extraAttrs map[string]any) (int64, error) {
 	if len(vendorType) == 0 {
 		return 0, fmt.Errorf("empty vendor type")
 	}
@@ -101,6 +112,10 @@ func (s *scheduler) Schedule(ctx context.Context, vendorType string, vendorID in
 		return 0, errors.New(nil).WithCode(errors.BadRequestCode).
 			WithMessagef("invalid cron %s: %v", cron, err)
 	}
+	if err := options.Validate(); err != nil {
+		return 0, errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessagef("invalid schedule options: %v", err)
+	}
 	if !callbackFuncExist(callbackFuncName) {
 		return 0, fmt.Errorf("callback function %s not found", callbackFuncName)
 	}
@@ -133,6 +148,13 @@ func (s *scheduler) Schedule(ctx context.Context, vendorType string, vendorID in
 		return 0, err
 	}
 	sched.ExtraAttrs = string(extrasData)
+	if options != nil {
+		optionsData, err := json.Marshal(options)
+		if err != nil {
+			return 0, err
+		}
+		sched.CRONOptions = string(optionsData)
+	}
 
 	var scheduleID, taskID int64
 	// ensureTask makes sure the task has been created at the end
@@ -154,8 +176,9 @@ func (s *scheduler) Schedule(ctx context.Context, vendorType string, vendorID in
 		taskID, err = s.taskMgr.Create(ctx, execID, &task.Job{
 			Name: JobNameScheduler,
 			Metadata: &job.Metadata{
-				JobKind: job.KindPeriodic,
-				Cron:    cron,
+				JobKind:         job.KindPeriodic,
+				Cron:            cron,
+				ScheduleOptions: options,
 			},
 		})
 		if err != nil {
@@ -289,6 +312,14 @@ func (s *scheduler) convertSchedule(ctx context.Context, schedule *schedule) (*S
 		}
 		schd.ExtraAttrs = extras
 	}
+	if len(schedule.CRONOptions) > 0 {
+		options := &job.ScheduleOptions{}
+		if err := json.Unmarshal([]byte(schedule.CRONOptions), options); err != nil {
+			log.Errorf("failed to unmarshal the cron options of schedule %d: %v", schedule.ID, err)
+			return nil, err
+		}
+		schd.Options = options
+	}
 
 	executions, err := s.execMgr.List(ctx, &q.Query{
 		Keywords: map[string]any{
@@ -0,0 +1,211 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/securityhub/model"
+)
+
+// sql to query the CVEs found in the packages of the artifacts of a project by the completed reports of the scanner,
+// the report is completed if its scan task succeeds or the task is already swept
+const findingSQL = `SELECT DISTINCT vr.cve_id, vr.package, vr.severity
+FROM artifact a
+         JOIN scan_report s ON a.digest = s.digest
+         JOIN report_vulnerability_record rvr ON s.uuid = rvr.report_uuid
+         JOIN vulnerability_record vr ON rvr.vuln_record_id = vr.id
+WHERE vr.registration_uuid = ?
+  AND s.registration_uuid = vr.registration_uuid
+  AND a.project_id = ?
+  AND NOT EXISTS (SELECT 1
+                  FROM task t
+                  WHERE t.vendor_type = 'IMAGE_SCAN'
+                    AND t.extra_attrs::jsonb -> 'report_uuids' @> to_jsonb(s.uuid::text)
+                    AND t.status <> 'Success')`
+
+// sql to count the completed reports and the reports in progress of the scanner in a project
+const reportProgressSQL = `SELECT count(*) FILTER (WHERE t.status IS NULL OR t.status = 'Success')        AS completed,
+       count(*) FILTER (WHERE t.status IN ('Pending', 'Scheduled', 'Running')) AS running
+FROM artifact a
+         JOIN scan_report s ON a.digest = s.digest
+         LEFT JOIN task t ON t.vendor_type = 'IMAGE_SCAN'
+    AND t.extra_attrs::jsonb -> 'report_uuids' @> to_jsonb(s.uuid::text)
+WHERE s.registration_uuid = ?
+  AND a.project_id = ?`
+
+// RemediationDao defines the interface to access the vulnerability remediations.
+type RemediationDao interface {
+	// Create the remediation
+	Create(ctx context.Context, remediation *model.Remediation) (id int64, err error)
+	// Get the remediation specified by ID
+	Get(ctx context.Context, id int64) (remediation *model.Remediation, err error)
+	// Count returns the total count of remediations according to the query
+	Count(ctx context.Context, query *q.Query) (total int64, err error)
+	// List the remediations according to the query
+	List(ctx context.Context, query *q.Query) (remediations []*model.Remediation, err error)
+	// Update the remediation, only the properties specified by "props" will be updated if it is set
+	Update(ctx context.Context, remediation *model.Remediation, props ...string) (err error)
+	// Delete the remediation specified by ID
+	Delete(ctx context.Context, id int64) (err error)
+	// DeleteByProject deletes the remediations of the project
+	DeleteByProject(ctx context.Context, projectID int64) (err error)
+	// ListFindings returns the findings of the CVEs in the project, all the findings are returned if no CVE specified
+	ListFindings(ctx context.Context, scannerUUID string, projectID int64, cveIDs ...string) (findings []*model.Finding, err error)
+	// ScanCompleted returns true if the project has the completed reports of the scanner and no report in progress
+	ScanCompleted(ctx context.Context, scannerUUID string, projectID int64) (completed bool, err error)
+}
+
+// NewRemediationDao creates a new RemediationDao instance.
+func NewRemediationDao() RemediationDao {
+	return &remediationDao{}
+}
+
+type remediationDao struct{}
+
+func (d *remediationDao) Create(ctx context.Context, remediation *model.Remediation) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	id, err := ormer.Insert(remediation)
+	if err != nil {
+		if e := orm.AsConflictError(err, "remediation of %s in package %s already exists in project %d",
+			remediation.CVEID, remediation.Package, remediation.ProjectID); e != nil {
+			err = e
+		}
+	}
+	return id, err
+}
+
+func (d *remediationDao) Get(ctx context.Context, id int64) (*model.Remediation, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	remediation := &model.Remediation{
+		ID: id,
+	}
+	if err = ormer.Read(remediation); err != nil {
+		if e := orm.AsNotFoundError(err, "remediation %d not found", id); e != nil {
+			err = e
+		}
+		return nil, err
+	}
+	return remediation, nil
+}
+
+func (d *remediationDao) Count(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &model.Remediation{}, query)
+	if err != nil {
+		return 0, err
+	}
+	return qs.Count()
+}
+
+func (d *remediationDao) List(ctx context.Context, query *q.Query) ([]*model.Remediation, error) {
+	remediations := []*model.Remediation{}
+	qs, err := orm.QuerySetter(ctx, &model.Remediation{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.All(&remediations); err != nil {
+		return nil, err
+	}
+	return remediations, nil
+}
+
+func (d *remediationDao) Update(ctx context.Context, remediation *model.Remediation, props ...string) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.Update(remediation, props...)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessagef("remediation %d not found", remediation.ID)
+	}
+	return nil
+}
+
+func (d *remediationDao) Delete(ctx context.Context, id int64) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.Delete(&model.Remediation{
+		ID: id,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessagef("remediation %d not found", id)
+	}
+	return nil
+}
+
+func (d *remediationDao) DeleteByProject(ctx context.Context, projectID int64) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = ormer.QueryTable(&model.Remediation{}).Filter("ProjectID", projectID).Delete()
+	return err
+}
+
+func (d *remediationDao) ListFindings(ctx context.Context, scannerUUID string, projectID int64, cveIDs ...string) ([]*model.Finding, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sql    strings.Builder
+		params = []any{scannerUUID, projectID}
+	)
+	sql.WriteString(findingSQL)
+	if len(cveIDs) > 0 {
+		sql.WriteString(" AND vr.cve_id IN (" + orm.ParamPlaceholderForIn(len(cveIDs)) + ")")
+		for _, cveID := range cveIDs {
+			params = append(params, cveID)
+		}
+	}
+
+	findings := []*model.Finding{}
+	if _, err := ormer.Raw(sql.String(), params...).QueryRows(&findings); err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+func (d *remediationDao) ScanCompleted(ctx context.Context, scannerUUID string, projectID int64) (bool, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var completed, running int64
+	if err := ormer.Raw(reportProgressSQL, scannerUUID, projectID).QueryRow(&completed, &running); err != nil {
+		return false, err
+	}
+	return completed > 0 && running == 0, nil
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
@@ -122,6 +123,44 @@ func (suite *SecurityDaoTestSuite) TestGetDangerousCVEs() {
 	suite.Equal(5, len(records))
 }
 
+func (suite *SecurityDaoTestSuite) TestSnapshots() {
+	ctx := suite.Context()
+	snapshotDao := NewSnapshotDao()
+
+	// the generic report of the same artifact isn't counted again
+	testDao.ExecuteBatchSQL([]string{
+		`insert into scan_report(uuid, digest, registration_uuid, mime_type, critical_cnt, high_cnt, medium_cnt, low_cnt, unknown_cnt, fixable_cnt) values('uuid-generic', 'digest1001', 'ruuid', 'application/vnd.security.vulnerability.report; version=1.1', 50, 50, 50, 0, 0, 20)`,
+	})
+	defer testDao.ExecuteBatchSQL([]string{`delete from scan_report where uuid = 'uuid-generic'`})
+
+	snapshots, err := snapshotDao.ProjectSummaries(ctx, "ruuid")
+	suite.Require().NoError(err)
+	suite.Require().Len(snapshots, 1)
+	suite.Equal(int64(1), snapshots[0].ProjectID)
+	// the accessory isn't counted
+	suite.Equal(int64(2), snapshots[0].TotalArtifactCnt)
+	suite.Equal(int64(1), snapshots[0].ScannedCnt)
+	suite.Equal(int64(50), snapshots[0].CriticalCnt)
+	suite.Equal(int64(20), snapshots[0].FixableCnt)
+
+	snapshotTime := time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)
+	snapshots[0].SnapshotTime = snapshotTime
+	suite.Require().NoError(snapshotDao.CreateMany(ctx, snapshots))
+	defer snapshotDao.DeleteBySnapshotTime(ctx, snapshotTime)
+
+	list, err := snapshotDao.List(ctx, q.New(q.KeyWords{
+		"project_id":    int64(1),
+		"snapshot_time": &q.Range{Min: snapshotTime.AddDate(0, 0, -1), Max: snapshotTime.AddDate(0, 0, 1)},
+	}))
+	suite.Require().NoError(err)
+	suite.Require().Len(list, 1)
+	suite.Equal(int64(50), list[0].CriticalCnt)
+
+	n, err := snapshotDao.DeleteBySnapshotTime(ctx, snapshotTime)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), n)
+}
+
 func Test_checkQFilter(t *testing.T) {
 	type args struct {
 		query     *q.Query
@@ -0,0 +1,118 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+	"github.com/goharbor/harbor/src/pkg/securityhub/model"
+)
+
+// sql to query the security summary of each project, only one vulnerability report of an artifact is joined and the
+// native one is preferred like reading the vulnerabilities of the artifact, so that an artifact is counted only once,
+// the accessories are not counted as artifacts
+const projectSummarySQL = `SELECT a.project_id,
+       count(1)                         total_artifact_cnt,
+       count(s.digest)                  scanned_cnt,
+       coalesce(sum(s.critical_cnt), 0) critical_cnt,
+       coalesce(sum(s.high_cnt), 0)     high_cnt,
+       coalesce(sum(s.medium_cnt), 0)   medium_cnt,
+       coalesce(sum(s.low_cnt), 0)      low_cnt,
+       coalesce(sum(s.none_cnt), 0)     none_cnt,
+       coalesce(sum(s.unknown_cnt), 0)  unknown_cnt,
+       coalesce(sum(s.fixable_cnt), 0)  fixable_cnt
+FROM artifact a
+         LEFT JOIN (SELECT DISTINCT ON (digest) digest,
+                                                critical_cnt,
+                                                high_cnt,
+                                                medium_cnt,
+                                                low_cnt,
+                                                none_cnt,
+                                                unknown_cnt,
+                                                fixable_cnt
+                    FROM scan_report
+                    WHERE registration_uuid = ?
+                      AND mime_type IN (?, ?)
+                    ORDER BY digest, mime_type = ? DESC) s ON a.digest = s.digest
+WHERE NOT EXISTS (SELECT 1 FROM artifact_accessory aa WHERE aa.artifact_id = a.id)
+GROUP BY a.project_id
+ORDER BY a.project_id`
+
+// SnapshotDao defines the interface to access the security summary snapshots.
+type SnapshotDao interface {
+	// ProjectSummaries returns the current security summary of each project which has artifacts
+	ProjectSummaries(ctx context.Context, scannerUUID string) ([]*model.Snapshot, error)
+	// CreateMany creates the snapshots in batch
+	CreateMany(ctx context.Context, snapshots []*model.Snapshot) error
+	// DeleteBySnapshotTime deletes the snapshots taken at the specified time
+	DeleteBySnapshotTime(ctx context.Context, snapshotTime time.Time) (int64, error)
+	// List the snapshots by query
+	List(ctx context.Context, query *q.Query) ([]*model.Snapshot, error)
+}
+
+// NewSnapshotDao creates a new SnapshotDao instance.
+func NewSnapshotDao() SnapshotDao {
+	return &snapshotDao{}
+}
+
+type snapshotDao struct{}
+
+func (d *snapshotDao) ProjectSummaries(ctx context.Context, scannerUUID string) ([]*model.Snapshot, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var snapshots []*model.Snapshot
+	if _, err := ormer.Raw(projectSummarySQL, scannerUUID, v1.MimeTypeNativeReport, v1.MimeTypeGenericVulnerabilityReport, v1.MimeTypeNativeReport).QueryRows(&snapshots); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+func (d *snapshotDao) CreateMany(ctx context.Context, snapshots []*model.Snapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = ormer.InsertMulti(100, snapshots)
+	return err
+}
+
+func (d *snapshotDao) DeleteBySnapshotTime(ctx context.Context, snapshotTime time.Time) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return ormer.QueryTable(&model.Snapshot{}).Filter("SnapshotTime", snapshotTime).Delete()
+}
+
+func (d *snapshotDao) List(ctx context.Context, query *q.Query) ([]*model.Snapshot, error) {
+	qs, err := orm.QuerySetter(ctx, &model.Snapshot{}, query)
+	if err != nil {
+		return nil, err
+	}
+	var snapshots []*model.Snapshot
+	if _, err = qs.All(&snapshots); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
@@ -16,6 +16,7 @@ package securityhub
 
 import (
 	"context"
+	"time"
 
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
@@ -44,18 +45,26 @@ type Manager interface {
 	TotalVuls(ctx context.Context, scannerUUID string, projectID int64, tuneCount bool, query *q.Query) (int64, error)
 	// ListVuls returns vulnerabilities list
 	ListVuls(ctx context.Context, scannerUUID string, projectID int64, query *q.Query) ([]*model.VulnerabilityItem, error)
+	// ProjectSummaries returns the current security summary of each project for the given scanner.
+	ProjectSummaries(ctx context.Context, scannerUUID string) ([]*model.Snapshot, error)
+	// SaveSnapshots replaces the snapshots taken at the specified time with the given ones.
+	SaveSnapshots(ctx context.Context, snapshotTime time.Time, snapshots []*model.Snapshot) error
+	// ListSnapshots returns the snapshots list
+	ListSnapshots(ctx context.Context, query *q.Query) ([]*model.Snapshot, error)
 }
 
 // NewManager news security manager.
 func NewManager() Manager {
 	return &securityManager{
-		dao: dao.New(),
+		dao:         dao.New(),
+		snapshotDao: dao.NewSnapshotDao(),
 	}
 }
 
 // securityManager is a default implementation of security manager.
 type securityManager struct {
-	dao dao.SecurityHubDao
+	dao         dao.SecurityHubDao
+	snapshotDao dao.SnapshotDao
 }
 
 func (s *securityManager) TotalArtifactsCount(ctx context.Context, projectID int64) (int64, error) {
@@ -85,3 +94,21 @@ func (s *securityManager) TotalVuls(ctx context.Context, scannerUUID string, pro
 func (s *securityManager) ListVuls(ctx context.Context, scannerUUID string, projectID int64, query *q.Query) ([]*model.VulnerabilityItem, error) {
 	return s.dao.ListVulnerabilities(ctx, scannerUUID, projectID, query)
 }
+
+func (s *securityManager) ProjectSummaries(ctx context.Context, scannerUUID string) ([]*model.Snapshot, error) {
+	return s.snapshotDao.ProjectSummaries(ctx, scannerUUID)
+}
+
+func (s *securityManager) SaveSnapshots(ctx context.Context, snapshotTime time.Time, snapshots []*model.Snapshot) error {
+	if _, err := s.snapshotDao.DeleteBySnapshotTime(ctx, snapshotTime); err != nil {
+		return err
+	}
+	for _, snapshot := range snapshots {
+		snapshot.SnapshotTime = snapshotTime
+	}
+	return s.snapshotDao.CreateMany(ctx, snapshots)
+}
+
+func (s *securityManager) ListSnapshots(ctx context.Context, query *q.Query) ([]*model.Snapshot, error) {
+	return s.snapshotDao.List(ctx, query)
+}
@@ -14,7 +14,16 @@
 
 package model
 
-import "github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+import (
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+)
+
+func init() {
+	orm.RegisterModel(new(Snapshot))
+}
 
 // Summary is the summary of scan result
 type Summary struct {
@@ -52,3 +61,38 @@ type VulnerabilityItem struct {
 	Tags           []string `orm:"-"`
 	ProjectID      int64    `orm:"column(project_id)"`
 }
+
+// Snapshot is the daily persisted security summary of a project, the project ID 0 stands for the whole system
+type Snapshot struct {
+	ID               int64     `json:"id" orm:"pk;auto;column(id)"`
+	ProjectID        int64     `json:"project_id" orm:"column(project_id)"`
+	SnapshotTime     time.Time `json:"snapshot_time" orm:"column(snapshot_time)" sort:"default"`
+	CriticalCnt      int64     `json:"critical_cnt" orm:"column(critical_cnt)"`
+	HighCnt          int64     `json:"high_cnt" orm:"column(high_cnt)"`
+	MediumCnt        int64     `json:"medium_cnt" orm:"column(medium_cnt)"`
+	LowCnt           int64     `json:"low_cnt" orm:"column(low_cnt)"`
+	NoneCnt          int64     `json:"none_cnt" orm:"column(none_cnt)"`
+	UnknownCnt       int64     `json:"unknown_cnt" orm:"column(unknown_cnt)"`
+	FixableCnt       int64     `json:"fixable_cnt" orm:"column(fixable_cnt)"`
+	ScannedCnt       int64     `json:"scanned_cnt" orm:"column(scanned_cnt)"`
+	TotalArtifactCnt int64     `json:"total_artifact_cnt" orm:"column(total_artifact_cnt)"`
+	CreationTime     time.Time `json:"creation_time" orm:"column(creation_time);auto_now_add"`
+}
+
+// TableName returns the table name of the snapshot
+func (s *Snapshot) TableName() string {
+	return "security_hub_snapshot"
+}
+
+// Add accumulates the counts of the other snapshot
+func (s *Snapshot) Add(o *Snapshot) {
+	s.CriticalCnt += o.CriticalCnt
+	s.HighCnt += o.HighCnt
+	s.MediumCnt += o.MediumCnt
+	s.LowCnt += o.LowCnt
+	s.NoneCnt += o.NoneCnt
+	s.UnknownCnt += o.UnknownCnt
+	s.FixableCnt += o.FixableCnt
+	s.ScannedCnt += o.ScannedCnt
+	s.TotalArtifactCnt += o.TotalArtifactCnt
+}
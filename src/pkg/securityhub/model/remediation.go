@@ -0,0 +1,107 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/orm"
+)
+
+func init() {
+	orm.RegisterModel(new(Remediation))
+}
+
+const (
+	// RemediationStateOpen the finding is waiting to be fixed
+	RemediationStateOpen = "open"
+	// RemediationStateInProgress the owner is working on the fix
+	RemediationStateInProgress = "in_progress"
+	// RemediationStateAcceptedRisk the finding is accepted as a risk until the expiry time
+	RemediationStateAcceptedRisk = "accepted_risk"
+	// RemediationStateResolved the finding disappears from the scan reports of the project
+	RemediationStateResolved = "resolved"
+)
+
+// DueTime returns the due time of the finding with the severity found at the specified time according to
+// the days to fix the finding of each severity, zero time is returned if no SLA defined for the severity
+func DueTime(slaDays map[string]int, severity string, found time.Time) time.Time {
+	days, ok := slaDays[severity]
+	if !ok {
+		return time.Time{}
+	}
+	return found.AddDate(0, 0, days)
+}
+
+// IsValidRemediationState checks whether the state can be set by the user,
+// the resolved state is only set when the finding disappears after rescan
+func IsValidRemediationState(state string) bool {
+	switch state {
+	case RemediationStateOpen, RemediationStateInProgress, RemediationStateAcceptedRisk:
+		return true
+	default:
+		return false
+	}
+}
+
+// Remediation tracks the remediation of a CVE finding in a project
+type Remediation struct {
+	ID             int64     `json:"id" orm:"pk;auto;column(id)"`
+	ProjectID      int64     `json:"project_id" orm:"column(project_id)"`
+	CVEID          string    `json:"cve_id" orm:"column(cve_id)"`
+	Package        string    `json:"package" orm:"column(package)"`
+	Severity       string    `json:"severity" orm:"column(severity)"`
+	ScannerUUID    string    `json:"registration_uuid" orm:"column(registration_uuid)"`
+	State          string    `json:"state" orm:"column(state)"`
+	Owner          string    `json:"owner" orm:"column(owner)"`
+	DueTime        time.Time `json:"due_time" orm:"column(due_time);null;type(datetime)"`
+	Justification  string    `json:"justification" orm:"column(justification)"`
+	RiskExpiryTime time.Time `json:"risk_expiry_time" orm:"column(risk_expiry_time);null;type(datetime)"`
+	ResolvedTime   time.Time `json:"resolved_time" orm:"column(resolved_time);null;type(datetime)"`
+	CreationTime   time.Time `json:"creation_time" orm:"column(creation_time);auto_now_add" sort:"default:desc"`
+	UpdateTime     time.Time `json:"update_time" orm:"column(update_time);auto_now"`
+}
+
+// TableName returns the table name of the remediation
+func (r *Remediation) TableName() string {
+	return "vulnerability_remediation"
+}
+
+// RiskExpired returns true if the accepted risk expires at the specified time
+func (r *Remediation) RiskExpired(now time.Time) bool {
+	return r.State == RemediationStateAcceptedRisk && !r.RiskExpiryTime.IsZero() && r.RiskExpiryTime.Before(now)
+}
+
+// Overdue returns true if the remediation is neither resolved nor accepted as a risk after the due time
+func (r *Remediation) Overdue(now time.Time) bool {
+	switch r.State {
+	case RemediationStateResolved, RemediationStateAcceptedRisk:
+		return false
+	default:
+		return !r.DueTime.IsZero() && r.DueTime.Before(now)
+	}
+}
+
+// Finding is the CVE found in the package of the artifacts of a project
+type Finding struct {
+	CVEID    string `orm:"column(cve_id)"`
+	Package  string `orm:"column(package)"`
+	Severity string `orm:"column(severity)"`
+}
+
+// Key returns the key to identify the finding in the project
+func (f *Finding) Key() string {
+	return f.CVEID + "|" + f.Package
+}
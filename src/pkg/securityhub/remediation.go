@@ -0,0 +1,95 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securityhub
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/securityhub/dao"
+	"github.com/goharbor/harbor/src/pkg/securityhub/model"
+)
+
+// RemediationMgr is the global remediation manager
+var RemediationMgr = NewRemediationManager()
+
+// RemediationManager is used to manage the remediations of the vulnerability findings.
+type RemediationManager interface {
+	// Create the remediation
+	Create(ctx context.Context, remediation *model.Remediation) (id int64, err error)
+	// Get the remediation specified by ID
+	Get(ctx context.Context, id int64) (remediation *model.Remediation, err error)
+	// Count returns the total count of remediations according to the query
+	Count(ctx context.Context, query *q.Query) (total int64, err error)
+	// List the remediations according to the query
+	List(ctx context.Context, query *q.Query) (remediations []*model.Remediation, err error)
+	// Update the remediation, only the properties specified by "props" will be updated if it is set
+	Update(ctx context.Context, remediation *model.Remediation, props ...string) (err error)
+	// Delete the remediation specified by ID
+	Delete(ctx context.Context, id int64) (err error)
+	// DeleteByProject deletes the remediations of the project
+	DeleteByProject(ctx context.Context, projectID int64) (err error)
+	// ListFindings returns the findings of the CVEs in the project, all the findings are returned if no CVE specified
+	ListFindings(ctx context.Context, scannerUUID string, projectID int64, cveIDs ...string) (findings []*model.Finding, err error)
+	// ScanCompleted returns true if the project has the completed reports of the scanner and no report in progress
+	ScanCompleted(ctx context.Context, scannerUUID string, projectID int64) (completed bool, err error)
+}
+
+// NewRemediationManager news remediation manager.
+func NewRemediationManager() RemediationManager {
+	return &remediationManager{
+		dao: dao.NewRemediationDao(),
+	}
+}
+
+type remediationManager struct {
+	dao dao.RemediationDao
+}
+
+func (r *remediationManager) Create(ctx context.Context, remediation *model.Remediation) (int64, error) {
+	return r.dao.Create(ctx, remediation)
+}
+
+func (r *remediationManager) Get(ctx context.Context, id int64) (*model.Remediation, error) {
+	return r.dao.Get(ctx, id)
+}
+
+func (r *remediationManager) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return r.dao.Count(ctx, query)
+}
+
+func (r *remediationManager) List(ctx context.Context, query *q.Query) ([]*model.Remediation, error) {
+	return r.dao.List(ctx, query)
+}
+
+func (r *remediationManager) Update(ctx context.Context, remediation *model.Remediation, props ...string) error {
+	return r.dao.Update(ctx, remediation, props...)
+}
+
+func (r *remediationManager) Delete(ctx context.Context, id int64) error {
+	return r.dao.Delete(ctx, id)
+}
+
+func (r *remediationManager) DeleteByProject(ctx context.Context, projectID int64) error {
+	return r.dao.DeleteByProject(ctx, projectID)
+}
+
+func (r *remediationManager) ListFindings(ctx context.Context, scannerUUID string, projectID int64, cveIDs ...string) ([]*model.Finding, error) {
+	return r.dao.ListFindings(ctx, scannerUUID, projectID, cveIDs...)
+}
+
+func (r *remediationManager) ScanCompleted(ctx context.Context, scannerUUID string, projectID int64) (bool, error) {
+	return r.dao.ScanCompleted(ctx, scannerUUID, projectID)
+}
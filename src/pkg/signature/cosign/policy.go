@@ -0,0 +1,67 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"strings"
+)
+
+// Policy defines the keyless signers trusted by a project, both the issuers and the identities are required
+// as the same identity can be issued by any OIDC provider
+type Policy struct {
+	// Issuers are the OIDC issuers allowed to sign
+	Issuers []string
+	// Identities are the subject identities(email or URI) allowed to sign.
+	// An identity ending with "*" matches all the identities having the prefix
+	Identities []string
+}
+
+// IsEmpty returns true when no issuer and identity is specified by the policy
+func (p *Policy) IsEmpty() bool {
+	return p == nil || (len(p.Issuers) == 0 && len(p.Identities) == 0)
+}
+
+// IsComplete returns true when both the issuers and the identities are specified by the policy
+func (p *Policy) IsComplete() bool {
+	return p != nil && len(p.Issuers) > 0 && len(p.Identities) > 0
+}
+
+// AllowIssuer checks whether the issuer is allowed by the policy
+func (p *Policy) AllowIssuer(issuer string) bool {
+	for _, allowed := range p.Issuers {
+		if strings.TrimSuffix(allowed, "/") == strings.TrimSuffix(issuer, "/") {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowIdentity checks whether any of the identities is allowed by the policy
+func (p *Policy) AllowIdentity(identities ...string) bool {
+	for _, allowed := range p.Identities {
+		for _, identity := range identities {
+			if prefix, ok := strings.CutSuffix(allowed, "*"); ok {
+				if strings.HasPrefix(identity, prefix) {
+					return true
+				}
+				continue
+			}
+			if allowed == identity {
+				return true
+			}
+		}
+	}
+	return false
+}
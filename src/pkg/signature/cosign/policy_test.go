@@ -0,0 +1,70 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type policyTestSuite struct {
+	suite.Suite
+}
+
+func (p *policyTestSuite) TestIsEmpty() {
+	var policy *Policy
+	p.True(policy.IsEmpty())
+	p.True((&Policy{}).IsEmpty())
+	p.False((&Policy{Issuers: []string{"https://token.actions.githubusercontent.com"}}).IsEmpty())
+	p.False((&Policy{Identities: []string{"dev@example.com"}}).IsEmpty())
+}
+
+func (p *policyTestSuite) TestIsComplete() {
+	var policy *Policy
+	p.False(policy.IsComplete())
+	p.False((&Policy{Issuers: []string{"https://token.actions.githubusercontent.com"}}).IsComplete())
+	p.False((&Policy{Identities: []string{"dev@example.com"}}).IsComplete())
+	p.True((&Policy{Issuers: []string{"https://token.actions.githubusercontent.com"}, Identities: []string{"dev@example.com"}}).IsComplete())
+}
+
+func (p *policyTestSuite) TestAllowIssuer() {
+	policy := &Policy{}
+	p.False(policy.AllowIssuer("https://accounts.google.com"))
+
+	policy = &Policy{Issuers: []string{"https://token.actions.githubusercontent.com/"}}
+	p.True(policy.AllowIssuer("https://token.actions.githubusercontent.com"))
+	p.False(policy.AllowIssuer("https://accounts.google.com"))
+}
+
+func (p *policyTestSuite) TestAllowIdentity() {
+	policy := &Policy{}
+	p.False(policy.AllowIdentity("dev@example.com"))
+
+	policy = &Policy{Identities: []string{
+		"dev@example.com",
+		"https://github.com/goharbor/harbor/.github/workflows/*",
+	}}
+	p.True(policy.AllowIdentity("dev@example.com"))
+	p.True(policy.AllowIdentity("https://github.com/goharbor/harbor/.github/workflows/release.yml@refs/heads/main"))
+	p.True(policy.AllowIdentity("other@example.com", "dev@example.com"))
+	p.False(policy.AllowIdentity("other@example.com"))
+	p.False(policy.AllowIdentity("https://github.com/evil/harbor/.github/workflows/release.yml@refs/heads/main"))
+	p.False(policy.AllowIdentity())
+}
+
+func TestPolicyTestSuite(t *testing.T) {
+	suite.Run(t, &policyTestSuite{})
+}
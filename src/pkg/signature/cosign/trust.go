@@ -0,0 +1,141 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/goharbor/harbor/src/lib/config"
+)
+
+// TrustRoot holds the certificates and keys the keyless signatures are verified against
+type TrustRoot struct {
+	// FulcioRoots are the trusted root certificates of Fulcio
+	FulcioRoots *x509.CertPool
+	// FulcioIntermediates are the trusted intermediate certificates of Fulcio
+	FulcioIntermediates *x509.CertPool
+	// RekorKeys are the trusted public keys of Rekor indexed by the log ID
+	RekorKeys map[string]crypto.PublicKey
+}
+
+// TrustRootLoader loads the trust root
+type TrustRootLoader func() (*TrustRoot, error)
+
+// NewTrustRoot builds the trust root from the PEM encoded Fulcio certificates and Rekor public keys
+func NewTrustRoot(fulcioPEM, rekorPEM []byte) (*TrustRoot, error) {
+	root := &TrustRoot{
+		FulcioRoots:         x509.NewCertPool(),
+		FulcioIntermediates: x509.NewCertPool(),
+		RekorKeys:           map[string]crypto.PublicKey{},
+	}
+	certs, err := parseCertificates(fulcioPEM)
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no Fulcio certificate found")
+	}
+	for _, cert := range certs {
+		// self-signed certificates are the roots, others are the intermediates
+		if cert.CheckSignatureFrom(cert) == nil {
+			root.FulcioRoots.AddCert(cert)
+		} else {
+			root.FulcioIntermediates.AddCert(cert)
+		}
+	}
+
+	for block, rest := pem.Decode(rekorPEM); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "PUBLIC KEY" {
+			continue
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the Rekor public key: %v", err)
+		}
+		root.RekorKeys[logID(block.Bytes)] = key
+	}
+	if len(root.RekorKeys) == 0 {
+		return nil, fmt.Errorf("no Rekor public key found")
+	}
+	return root, nil
+}
+
+// logID returns the Rekor log ID of the DER encoded public key
+func logID(der []byte) string {
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])
+}
+
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the certificate: %v", err)
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+// defaultTrustRoot loads the trust root from the files configured
+var defaultTrustRoot = fileTrustRootLoader()
+
+// CheckTrustRoot checks the configured trust root can be loaded, the keyless verification
+// rejects all the signatures without it
+func CheckTrustRoot() error {
+	_, err := defaultTrustRoot()
+	return err
+}
+
+// fileTrustRootLoader returns a loader reading the trust root from the files configured.
+// The trust root is cached once loaded successfully
+func fileTrustRootLoader() TrustRootLoader {
+	var (
+		lock sync.Mutex
+		root *TrustRoot
+	)
+	return func() (*TrustRoot, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		if root != nil {
+			return root, nil
+		}
+		fulcio, err := os.ReadFile(config.GetCosignFulcioRootsPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the Fulcio certificates: %v", err)
+		}
+		rekor, err := os.ReadFile(config.GetCosignRekorPublicKeysPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the Rekor public keys: %v", err)
+		}
+		r, err := NewTrustRoot(fulcio, rekor)
+		if err != nil {
+			return nil, err
+		}
+		root = r
+		return root, nil
+	}
+}
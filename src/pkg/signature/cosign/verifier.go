@@ -0,0 +1,429 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/goharbor/harbor/src/lib/cache"
+	_ "github.com/goharbor/harbor/src/lib/cache/memory" // memory cache
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/registry"
+)
+
+const (
+	// the annotations of the cosign signature layer
+	annotationSignature   = "dev.cosignproject.cosign/signature"
+	annotationCertificate = "dev.sigstore.cosign/certificate"
+	annotationChain       = "dev.sigstore.cosign/chain"
+	annotationBundle      = "dev.sigstore.cosign/bundle"
+
+	hashedRekordKind = "hashedrekord"
+
+	// verdictExpiration is how long the verdict of the artifact is cached
+	verdictExpiration = 5 * time.Minute
+)
+
+var (
+	// the Fulcio certificate extensions carrying the OIDC issuer
+	oidIssuerV1 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+
+	// DefaultVerifier is the default keyless signature verifier
+	DefaultVerifier = NewVerifier()
+)
+
+// Verifier verifies the cosign keyless signatures
+type Verifier interface {
+	// Verify checks that at least one of the signatures of the artifact is signed by the signer allowed by the policy
+	// and is recorded in the transparency log. The error with code PROJECTPOLICYVIOLATION is returned when
+	// no signature passes the verification
+	Verify(ctx context.Context, repository, digest string, signatures []string, policy *Policy) error
+}
+
+// NewVerifier creates a verifier pulling the signatures from the local registry and
+// verifying them against the configured trust root
+func NewVerifier() Verifier {
+	verdicts, _ := cache.New(cache.Memory, cache.Expiration(verdictExpiration))
+	return &verifier{
+		regCli:    registry.Cli,
+		trustRoot: defaultTrustRoot,
+		verdicts:  verdicts,
+	}
+}
+
+type verifier struct {
+	regCli    registry.Client
+	trustRoot TrustRootLoader
+	// verdicts caches the verdicts of the artifacts by the signatures and the policy
+	verdicts cache.Cache
+}
+
+// verdict is the result of the verification, the empty violation means the artifact is trusted
+type verdict struct {
+	Violation string `json:"violation,omitempty"`
+}
+
+func (v *verifier) Verify(ctx context.Context, repository, digest string, signatures []string, policy *Policy) error {
+	if len(signatures) == 0 {
+		return errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).WithMessage("no cosign signature found")
+	}
+	if !policy.IsComplete() {
+		return errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).WithMessage("both the issuers and the identities are required by the keyless policy")
+	}
+
+	result := &verdict{}
+	if err := cache.FetchOrSave(ctx, v.verdicts, verdictKey(digest, signatures, policy), result, func() (any, error) {
+		return v.verify(ctx, repository, digest, signatures, policy)
+	}); err != nil {
+		return err
+	}
+	if len(result.Violation) > 0 {
+		return errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).WithMessage(result.Violation)
+	}
+	return nil
+}
+
+// verify pulls and verifies the signatures. The unreadable signatures are skipped, but the verdict isn't
+// returned to be cached if none of the readable signatures is trusted
+func (v *verifier) verify(ctx context.Context, repository, digest string, signatures []string, policy *Policy) (*verdict, error) {
+	root, err := v.trustRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	var violation, unreadable error
+	for _, signature := range signatures {
+		layers, err := v.pullLayers(repository, signature)
+		if err != nil {
+			log.G(ctx).Warningf("failed to read the signature %s of %s@%s: %v", signature, repository, digest, err)
+			unreadable = err
+			continue
+		}
+		for _, layer := range layers {
+			payload, err := v.pullPayload(repository, layer)
+			if err != nil {
+				log.G(ctx).Warningf("failed to read the layer %s of the signature %s of %s@%s: %v", layer.Digest, signature, repository, digest, err)
+				unreadable = err
+				continue
+			}
+			if err = verifyLayer(root, layer.Annotations, payload, digest, policy); err != nil {
+				log.G(ctx).Debugf("the signature %s of %s@%s isn't trusted: %v", signature, repository, digest, err)
+				violation = err
+				continue
+			}
+			return &verdict{}, nil
+		}
+	}
+	switch {
+	case violation != nil && unreadable != nil:
+		return nil, errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).WithMessage(violation.Error())
+	case unreadable != nil:
+		return nil, unreadable
+	case violation == nil:
+		violation = fmt.Errorf("no cosign signature layer found")
+	}
+	return &verdict{Violation: violation.Error()}, nil
+}
+
+// verdictKey returns the cache key of the verdict of the artifact
+func verdictKey(digest string, signatures []string, policy *Policy) string {
+	h := sha256.New()
+	for _, items := range [][]string{{digest}, slices.Sorted(slices.Values(signatures)), policy.Issuers, policy.Identities} {
+		_, _ = fmt.Fprintf(h, "%q\n", items)
+	}
+	return fmt.Sprintf("cosign:verdict:%s", hex.EncodeToString(h.Sum(nil)))
+}
+
+func (v *verifier) pullLayers(repository, signature string) ([]v1.Descriptor, error) {
+	man, _, err := v.regCli.PullManifest(repository, signature)
+	if err != nil {
+		return nil, err
+	}
+	_, payload, err := man.Payload()
+	if err != nil {
+		return nil, err
+	}
+	manifest := &v1.Manifest{}
+	if err := json.Unmarshal(payload, manifest); err != nil {
+		return nil, err
+	}
+	return manifest.Layers, nil
+}
+
+func (v *verifier) pullPayload(repository string, layer v1.Descriptor) ([]byte, error) {
+	_, blob, err := v.regCli.PullBlob(repository, layer.Digest.String())
+	if err != nil {
+		return nil, err
+	}
+	defer blob.Close()
+	return io.ReadAll(blob)
+}
+
+// verifyLayer verifies one signature layer, the payload is the content of the layer
+func verifyLayer(root *TrustRoot, annotations map[string]string, payload []byte, digest string, policy *Policy) error {
+	certPEM := annotations[annotationCertificate]
+	if len(certPEM) == 0 {
+		return fmt.Errorf("not a keyless signature")
+	}
+	certs, err := parseCertificates([]byte(certPEM))
+	if err != nil {
+		return err
+	}
+	if len(certs) == 0 {
+		return fmt.Errorf("no signing certificate found")
+	}
+	cert := certs[0]
+
+	sig, err := base64.StdEncoding.DecodeString(annotations[annotationSignature])
+	if err != nil || len(sig) == 0 {
+		return fmt.Errorf("invalid signature")
+	}
+
+	// the transparency log entry proves the signature was created while the short-lived certificate was valid
+	entry, err := verifyBundle(root, annotations[annotationBundle])
+	if err != nil {
+		return err
+	}
+	if err = entry.matches(payload, sig, cert); err != nil {
+		return err
+	}
+
+	if err = verifyCertificate(root, cert, annotations[annotationChain], time.Unix(entry.IntegratedTime, 0)); err != nil {
+		return err
+	}
+
+	issuer, err := certificateIssuer(cert)
+	if err != nil {
+		return err
+	}
+	if !policy.AllowIssuer(issuer) {
+		return fmt.Errorf("the issuer %s isn't allowed", issuer)
+	}
+	identities := certificateIdentities(cert)
+	if !policy.AllowIdentity(identities...) {
+		return fmt.Errorf("the identities %v aren't allowed", identities)
+	}
+
+	if err = VerifySignature(cert.PublicKey, payload, sig); err != nil {
+		return err
+	}
+	return verifyPayload(payload, digest)
+}
+
+func verifyCertificate(root *TrustRoot, cert *x509.Certificate, chainPEM string, signedAt time.Time) error {
+	intermediates := root.FulcioIntermediates.Clone()
+	chain, err := parseCertificates([]byte(chainPEM))
+	if err != nil {
+		return err
+	}
+	for _, c := range chain {
+		// the root in the chain must not be trusted, only the intermediates are taken
+		if c.CheckSignatureFrom(c) != nil {
+			intermediates.AddCert(c)
+		}
+	}
+	if _, err = cert.Verify(x509.VerifyOptions{
+		Roots:         root.FulcioRoots,
+		Intermediates: intermediates,
+		CurrentTime:   signedAt,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return fmt.Errorf("failed to verify the signing certificate: %v", err)
+	}
+	return nil
+}
+
+// certificateIssuer returns the OIDC issuer recorded in the Fulcio certificate
+func certificateIssuer(cert *x509.Certificate) (string, error) {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuerV2) {
+			var issuer string
+			if _, err := asn1.UnmarshalWithParams(ext.Value, &issuer, "utf8"); err != nil {
+				return "", fmt.Errorf("invalid issuer extension: %v", err)
+			}
+			return issuer, nil
+		}
+	}
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuerV1) {
+			return string(ext.Value), nil
+		}
+	}
+	return "", fmt.Errorf("no issuer found in the signing certificate")
+}
+
+// certificateIdentities returns the subject identities recorded in the Fulcio certificate
+func certificateIdentities(cert *x509.Certificate) []string {
+	identities := append([]string{}, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+	return identities
+}
+
+// VerifySignature verifies the signature over the payload with the ECDSA, RSA or Ed25519 public key
+func VerifySignature(pub crypto.PublicKey, payload, sig []byte) error {
+	sum := sha256.Sum256(payload)
+	switch key := pub.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, sum[:], sig) {
+			return fmt.Errorf("invalid signature")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, sum[:], sig); err != nil {
+			return fmt.Errorf("invalid signature: %v", err)
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, payload, sig) {
+			return fmt.Errorf("invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", pub)
+	}
+	return nil
+}
+
+// verifyPayload checks the signed payload refers to the artifact
+func verifyPayload(payload []byte, digest string) error {
+	simpleSigning := &struct {
+		Critical struct {
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+		} `json:"critical"`
+	}{}
+	if err := json.Unmarshal(payload, simpleSigning); err != nil {
+		return fmt.Errorf("invalid signature payload: %v", err)
+	}
+	if simpleSigning.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf("the signature is for %s rather than %s", simpleSigning.Critical.Image.DockerManifestDigest, digest)
+	}
+	return nil
+}
+
+// bundle is the offline Rekor bundle embedded in the signature layer
+type bundle struct {
+	SignedEntryTimestamp []byte        `json:"SignedEntryTimestamp"`
+	Payload              bundlePayload `json:"Payload"`
+}
+
+// bundlePayload is the transparency log entry, the fields are in the canonical order
+type bundlePayload struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+}
+
+// verifyBundle verifies the signed entry timestamp issued by Rekor and returns the log entry
+func verifyBundle(root *TrustRoot, data string) (*bundlePayload, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no transparency log bundle found")
+	}
+	b := &bundle{}
+	if err := json.Unmarshal([]byte(data), b); err != nil {
+		return nil, fmt.Errorf("invalid transparency log bundle: %v", err)
+	}
+	key, exist := root.RekorKeys[b.Payload.LogID]
+	if !exist {
+		return nil, fmt.Errorf("the transparency log %s isn't trusted", b.Payload.LogID)
+	}
+	canonical, err := json.Marshal(b.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if err = VerifySignature(key, canonical, b.SignedEntryTimestamp); err != nil {
+		return nil, fmt.Errorf("failed to verify the transparency log bundle: %v", err)
+	}
+	return &b.Payload, nil
+}
+
+// matches checks the log entry records the signature over the payload by the signing certificate
+func (b *bundlePayload) matches(payload, sig []byte, cert *x509.Certificate) error {
+	body, err := base64.StdEncoding.DecodeString(b.Body)
+	if err != nil {
+		return fmt.Errorf("invalid transparency log entry: %v", err)
+	}
+	entry := &struct {
+		Kind string `json:"kind"`
+		Spec struct {
+			Data struct {
+				Hash struct {
+					Algorithm string `json:"algorithm"`
+					Value     string `json:"value"`
+				} `json:"hash"`
+			} `json:"data"`
+			Signature struct {
+				Content   []byte `json:"content"`
+				PublicKey struct {
+					Content []byte `json:"content"`
+				} `json:"publicKey"`
+			} `json:"signature"`
+		} `json:"spec"`
+	}{}
+	if err = json.Unmarshal(body, entry); err != nil {
+		return fmt.Errorf("invalid transparency log entry: %v", err)
+	}
+	if entry.Kind != hashedRekordKind {
+		return fmt.Errorf("unsupported transparency log entry kind %s", entry.Kind)
+	}
+	sum := sha256.Sum256(payload)
+	if entry.Spec.Data.Hash.Algorithm != "sha256" || entry.Spec.Data.Hash.Value != hex.EncodeToString(sum[:]) {
+		return fmt.Errorf("the transparency log entry doesn't match the payload")
+	}
+	if !bytes.Equal(entry.Spec.Signature.Content, sig) {
+		return fmt.Errorf("the transparency log entry doesn't match the signature")
+	}
+	return matchPublicKey(entry.Spec.Signature.PublicKey.Content, cert)
+}
+
+// matchPublicKey checks the PEM encoded certificate or public key recorded in the log entry is the signing certificate
+func matchPublicKey(data []byte, cert *x509.Certificate) error {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return fmt.Errorf("no public key found in the transparency log entry")
+	}
+	switch block.Type {
+	case "CERTIFICATE":
+		if bytes.Equal(block.Bytes, cert.Raw) {
+			return nil
+		}
+	case "PUBLIC KEY":
+		if bytes.Equal(block.Bytes, cert.RawSubjectPublicKeyInfo) {
+			return nil
+		}
+	}
+	return fmt.Errorf("the transparency log entry doesn't match the signing certificate")
+}
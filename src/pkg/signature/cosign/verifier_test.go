@@ -0,0 +1,272 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/cache"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/distribution"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/registry"
+)
+
+const (
+	testIssuer   = "https://token.actions.githubusercontent.com"
+	testIdentity = "https://github.com/goharbor/harbor/.github/workflows/release.yml@refs/heads/main"
+	testDigest   = "sha256:4b4ab0e6bc1b4cc44f2e0d5e6da8a7ae8e5d2cbb0e0c4e7b9c0f0b1b9c1b4e8a"
+)
+
+type verifierTestSuite struct {
+	suite.Suite
+	root      *TrustRoot
+	caKey     *ecdsa.PrivateKey
+	ca        *x509.Certificate
+	rekorKey  *ecdsa.PrivateKey
+	rekorID   string
+	signedAt  time.Time
+	regCli    *registry.Client
+	verifier  *verifier
+	policy    *Policy
+	signature map[string]string
+	payload   []byte
+}
+
+func (v *verifierTestSuite) SetupTest() {
+	var err error
+	v.signedAt = time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	v.caKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	v.Require().Nil(err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "fulcio"},
+		NotBefore:             v.signedAt.Add(-24 * time.Hour),
+		NotAfter:              v.signedAt.Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &v.caKey.PublicKey, v.caKey)
+	v.Require().Nil(err)
+	v.ca, err = x509.ParseCertificate(der)
+	v.Require().Nil(err)
+
+	v.rekorKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	v.Require().Nil(err)
+	rekorDER, err := x509.MarshalPKIXPublicKey(&v.rekorKey.PublicKey)
+	v.Require().Nil(err)
+	v.rekorID = logID(rekorDER)
+
+	v.root, err = NewTrustRoot(
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: v.ca.Raw}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: rekorDER}),
+	)
+	v.Require().Nil(err)
+
+	v.regCli = &registry.Client{}
+	verdicts, err := cache.New(cache.Memory)
+	v.Require().Nil(err)
+	v.verifier = &verifier{
+		regCli:    v.regCli,
+		trustRoot: func() (*TrustRoot, error) { return v.root, nil },
+		verdicts:  verdicts,
+	}
+	v.policy = &Policy{
+		Issuers:    []string{testIssuer},
+		Identities: []string{"https://github.com/goharbor/harbor/.github/workflows/*"},
+	}
+	v.payload = []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"harbor.example.com/library/hello"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, testDigest))
+	v.signature = v.sign(v.payload, testIssuer, testIdentity, nil)
+}
+
+// sign creates the annotations of a keyless signature layer over the payload, the log entry records
+// the signing certificate unless the public key is specified
+func (v *verifierTestSuite) sign(payload []byte, issuer, identity string, publicKey []byte) map[string]string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	v.Require().Nil(err)
+	issuerExt, err := asn1.MarshalWithParams(issuer, "utf8")
+	v.Require().Nil(err)
+	uri, err := url.Parse(identity)
+	v.Require().Nil(err)
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		NotBefore:       v.signedAt.Add(-5 * time.Minute),
+		NotAfter:        v.signedAt.Add(5 * time.Minute),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		URIs:            []*url.URL{uri},
+		ExtraExtensions: []pkix.Extension{{Id: oidIssuerV2, Value: issuerExt}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, v.ca, &key.PublicKey, v.caKey)
+	v.Require().Nil(err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if publicKey == nil {
+		publicKey = certPEM
+	}
+
+	sum := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, sum[:])
+	v.Require().Nil(err)
+
+	body, err := json.Marshal(map[string]any{
+		"apiVersion": "0.0.1",
+		"kind":       hashedRekordKind,
+		"spec": map[string]any{
+			"data":      map[string]any{"hash": map[string]string{"algorithm": "sha256", "value": hex.EncodeToString(sum[:])}},
+			"signature": map[string]any{"content": sig, "publicKey": map[string]any{"content": publicKey}},
+		},
+	})
+	v.Require().Nil(err)
+	entry := bundlePayload{
+		Body:           base64.StdEncoding.EncodeToString(body),
+		IntegratedTime: v.signedAt.Unix(),
+		LogID:          v.rekorID,
+		LogIndex:       1,
+	}
+	canonical, err := json.Marshal(entry)
+	v.Require().Nil(err)
+	canonicalSum := sha256.Sum256(canonical)
+	set, err := ecdsa.SignASN1(rand.Reader, v.rekorKey, canonicalSum[:])
+	v.Require().Nil(err)
+	b, err := json.Marshal(&bundle{SignedEntryTimestamp: set, Payload: entry})
+	v.Require().Nil(err)
+
+	return map[string]string{
+		annotationSignature:   base64.StdEncoding.EncodeToString(sig),
+		annotationCertificate: string(certPEM),
+		annotationBundle:      string(b),
+	}
+}
+
+func (v *verifierTestSuite) TestVerifyLayer() {
+	// valid
+	v.Nil(verifyLayer(v.root, v.signature, v.payload, testDigest, v.policy))
+
+	// no signer is allowed by the empty policy
+	v.NotNil(verifyLayer(v.root, v.signature, v.payload, testDigest, &Policy{}))
+
+	// the log entry records another public key
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	v.Require().Nil(err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	v.Require().Nil(err)
+	annotations := v.sign(v.payload, testIssuer, testIdentity, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	v.NotNil(verifyLayer(v.root, annotations, v.payload, testDigest, v.policy))
+
+	// not keyless
+	v.NotNil(verifyLayer(v.root, map[string]string{annotationSignature: v.signature[annotationSignature]}, v.payload, testDigest, v.policy))
+
+	// issuer not allowed
+	v.NotNil(verifyLayer(v.root, v.signature, v.payload, testDigest, &Policy{Issuers: []string{"https://accounts.google.com"}, Identities: v.policy.Identities}))
+
+	// identity not allowed
+	v.NotNil(verifyLayer(v.root, v.signature, v.payload, testDigest, &Policy{Issuers: v.policy.Issuers, Identities: []string{"https://github.com/evil/*"}}))
+
+	// the payload refers to another artifact
+	v.NotNil(verifyLayer(v.root, v.signature, v.payload, "sha256:0000", v.policy))
+
+	// tampered payload
+	tampered := bytes.Replace(v.payload, []byte("hello"), []byte("world"), 1)
+	v.NotNil(verifyLayer(v.root, v.signature, tampered, testDigest, v.policy))
+
+	// no bundle
+	annotations = map[string]string{}
+	for key, value := range v.signature {
+		annotations[key] = value
+	}
+	delete(annotations, annotationBundle)
+	v.NotNil(verifyLayer(v.root, annotations, v.payload, testDigest, v.policy))
+
+	// untrusted transparency log
+	root := &TrustRoot{FulcioRoots: v.root.FulcioRoots, FulcioIntermediates: v.root.FulcioIntermediates}
+	v.NotNil(verifyLayer(root, v.signature, v.payload, testDigest, v.policy))
+
+	// untrusted certificate authority
+	root = &TrustRoot{FulcioRoots: x509.NewCertPool(), FulcioIntermediates: x509.NewCertPool(), RekorKeys: v.root.RekorKeys}
+	v.NotNil(verifyLayer(root, v.signature, v.payload, testDigest, v.policy))
+}
+
+func (v *verifierTestSuite) TestVerify() {
+	layer := v1.Descriptor{
+		MediaType:   "application/vnd.dev.cosign.simplesigning.v1+json",
+		Digest:      digest.FromBytes(v.payload),
+		Size:        int64(len(v.payload)),
+		Annotations: v.signature,
+	}
+	content, err := json.Marshal(&v1.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: v1.MediaTypeImageManifest,
+		Config:    v1.Descriptor{MediaType: "application/vnd.oci.image.config.v1+json", Digest: digest.FromString("{}"), Size: 2},
+		Layers:    []v1.Descriptor{layer},
+	})
+	v.Require().Nil(err)
+	manifest, _, err := distribution.UnmarshalManifest(v1.MediaTypeImageManifest, content)
+	v.Require().Nil(err)
+
+	// no signature
+	err = v.verifier.Verify(context.TODO(), "library/hello", testDigest, nil, v.policy)
+	v.True(errors.IsErr(err, errors.PROJECTPOLICYVIOLATION))
+
+	// incomplete policy
+	err = v.verifier.Verify(context.TODO(), "library/hello", testDigest, []string{"sha256:signature"}, &Policy{Issuers: v.policy.Issuers})
+	v.True(errors.IsErr(err, errors.PROJECTPOLICYVIOLATION))
+
+	// the unreadable signature is skipped
+	v.regCli.On("PullManifest", "library/hello", "sha256:unreadable").Return(nil, "", fmt.Errorf("not found")).Once()
+	v.regCli.On("PullManifest", "library/hello", "sha256:signature").Return(manifest, "sha256:signature", nil)
+	mock.OnAnything(v.regCli, "PullBlob").Return(int64(len(v.payload)), io.NopCloser(bytes.NewReader(v.payload)), nil).Once()
+	v.Nil(v.verifier.Verify(context.TODO(), "library/hello", testDigest, []string{"sha256:unreadable", "sha256:signature"}, v.policy))
+
+	// the verdict is cached
+	v.Nil(v.verifier.Verify(context.TODO(), "library/hello", testDigest, []string{"sha256:signature", "sha256:unreadable"}, v.policy))
+
+	policy := &Policy{Issuers: []string{"https://accounts.google.com"}, Identities: v.policy.Identities}
+	mock.OnAnything(v.regCli, "PullBlob").Return(int64(len(v.payload)), io.NopCloser(bytes.NewReader(v.payload)), nil).Once()
+	err = v.verifier.Verify(context.TODO(), "library/hello", testDigest, []string{"sha256:signature"}, policy)
+	v.True(errors.IsErr(err, errors.PROJECTPOLICYVIOLATION))
+	err = v.verifier.Verify(context.TODO(), "library/hello", testDigest, []string{"sha256:signature"}, policy)
+	v.True(errors.IsErr(err, errors.PROJECTPOLICYVIOLATION))
+	v.regCli.AssertNumberOfCalls(v.T(), "PullBlob", 2)
+}
+
+func TestVerifierTestSuite(t *testing.T) {
+	suite.Run(t, &verifierTestSuite{})
+}
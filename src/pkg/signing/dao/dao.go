@@ -0,0 +1,85 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/signing/model"
+)
+
+// DAO is the data access object interface for signing key
+type DAO interface {
+	// Create the signing key
+	Create(ctx context.Context, key *model.Key) (id int64, err error)
+	// GetByProjectID gets the signing key of the project
+	GetByProjectID(ctx context.Context, projectID int64) (key *model.Key, err error)
+	// DeleteByProjectID deletes the signing key of the project
+	DeleteByProjectID(ctx context.Context, projectID int64) (err error)
+}
+
+// New creates an instance of the default DAO
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+func (d *dao) Create(ctx context.Context, key *model.Key) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	id, err := ormer.Insert(key)
+	if err != nil {
+		if e := orm.AsConflictError(err, "signing key of project %d already exists", key.ProjectID); e != nil {
+			err = e
+		}
+	}
+	return id, err
+}
+
+func (d *dao) GetByProjectID(ctx context.Context, projectID int64) (*model.Key, error) {
+	qs, err := orm.QuerySetter(ctx, &model.Key{}, q.New(q.KeyWords{"ProjectID": projectID}))
+	if err != nil {
+		return nil, err
+	}
+	keys := []*model.Key{}
+	if _, err = qs.All(&keys); err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, errors.NotFoundError(nil).WithMessagef("signing key of project %d not found", projectID)
+	}
+	return keys[0], nil
+}
+
+func (d *dao) DeleteByProjectID(ctx context.Context, projectID int64) error {
+	qs, err := orm.QuerySetter(ctx, &model.Key{}, q.New(q.KeyWords{"ProjectID": projectID}))
+	if err != nil {
+		return err
+	}
+	n, err := qs.Delete()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessagef("signing key of project %d not found", projectID)
+	}
+	return nil
+}
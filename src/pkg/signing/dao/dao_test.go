@@ -0,0 +1,79 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/signing/model"
+	htesting "github.com/goharbor/harbor/src/testing"
+)
+
+type daoTestSuite struct {
+	htesting.Suite
+	dao DAO
+}
+
+func (d *daoTestSuite) SetupSuite() {
+	d.Suite.SetupSuite()
+	d.dao = New()
+}
+
+func (d *daoTestSuite) TestCRUD() {
+	ctx := d.Context()
+
+	_, err := d.dao.Create(ctx, &model.Key{
+		ProjectID:   1,
+		Provider:    model.ProviderDatabase,
+		PrivateKey:  "encrypted",
+		PublicKey:   "public key",
+		Certificate: "certificate",
+	})
+	d.Require().Nil(err)
+	defer d.dao.DeleteByProjectID(ctx, 1)
+
+	// conflict
+	_, err = d.dao.Create(ctx, &model.Key{
+		ProjectID:   1,
+		Provider:    model.ProviderDatabase,
+		PublicKey:   "public key",
+		Certificate: "certificate",
+	})
+	d.Require().NotNil(err)
+	d.True(errors.IsConflictErr(err))
+
+	key, err := d.dao.GetByProjectID(ctx, 1)
+	d.Require().Nil(err)
+	d.Equal(model.ProviderDatabase, key.Provider)
+	d.Equal("encrypted", key.PrivateKey)
+	d.Equal("public key", key.PublicKey)
+
+	d.Require().Nil(d.dao.DeleteByProjectID(ctx, 1))
+
+	_, err = d.dao.GetByProjectID(ctx, 1)
+	d.Require().NotNil(err)
+	d.True(errors.IsNotFoundErr(err))
+
+	err = d.dao.DeleteByProjectID(ctx, 1)
+	d.Require().NotNil(err)
+	d.True(errors.IsNotFoundErr(err))
+}
+
+func TestDaoTestSuite(t *testing.T) {
+	suite.Run(t, &daoTestSuite{})
+}
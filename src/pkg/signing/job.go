@@ -0,0 +1,269 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/registry"
+	"github.com/goharbor/harbor/src/pkg/signature/cosign"
+)
+
+const (
+	// ParamProjectID is the job parameter of the ID of the project owning the signing key
+	ParamProjectID = "project_id"
+	// ParamRepository is the job parameter of the repository of the artifact
+	ParamRepository = "repository"
+	// ParamDigest is the job parameter of the digest of the artifact
+	ParamDigest = "digest"
+	// ParamMediaType is the job parameter of the manifest media type of the artifact
+	ParamMediaType = "media_type"
+	// ParamSize is the job parameter of the manifest size of the artifact
+	ParamSize = "size"
+	// ParamFormat is the job parameter of the signing format, "cosign" or "notation"
+	ParamFormat = "format"
+	// ParamDockerReference is the job parameter of the reference recorded in the cosign signature payload
+	ParamDockerReference = "docker_reference"
+)
+
+// CheckIn is the data checked in by the signing job after the signature is pushed
+type CheckIn struct {
+	// Digest of the signature manifest
+	Digest string `json:"digest"`
+	// Type is the accessory type of the signature
+	Type string `json:"type"`
+	// Reference is the tag of the signature manifest if it is tagged
+	Reference string `json:"reference,omitempty"`
+}
+
+// Job signs the artifact with the signing key of the project and pushes the signature into the repository of the artifact
+type Job struct {
+	logger logger.Interface
+	mgr    Manager
+	regCli registry.Client
+}
+
+type jobParams struct {
+	projectID       int64
+	repository      string
+	digest          string
+	mediaType       string
+	size            int64
+	format          string
+	dockerReference string
+}
+
+// MaxFails of signing job
+func (j *Job) MaxFails() uint {
+	return 3
+}
+
+// MaxCurrency of signing job, no limitation
+func (j *Job) MaxCurrency() uint {
+	return 0
+}
+
+// ShouldRetry indicates the failed signing job can be retried
+func (j *Job) ShouldRetry() bool {
+	return true
+}
+
+// Validate the parameters of signing job
+func (j *Job) Validate(params job.Parameters) error {
+	_, err := parseParams(params)
+	return err
+}
+
+// Run the signing job
+func (j *Job) Run(ctx job.Context, params job.Parameters) error {
+	j.init(ctx)
+	p, err := parseParams(params)
+	if err != nil {
+		return err
+	}
+	j.logger.Infof("start to sign the artifact %s@%s in %s format", p.repository, p.digest, p.format)
+
+	key, err := j.mgr.Get(ctx.SystemContext(), p.projectID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the signing key")
+	}
+	signer, cert, err := j.mgr.Signer(ctx.SystemContext(), key)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the signer")
+	}
+
+	var signature *Signature
+	if p.format == FormatNotation {
+		subject := v1.Descriptor{MediaType: p.mediaType, Digest: digest.Digest(p.digest), Size: p.size}
+		signature, err = NewNotationSignature(signer, cert, subject, time.Now())
+	} else {
+		var signed string
+		signature, signed, err = j.cosignSignature(p, signer)
+		if err == nil && signature == nil {
+			j.logger.Infof("the artifact %s@%s is already signed by the project key in %s", p.repository, p.digest, signed)
+			return j.checkIn(ctx, &CheckIn{Digest: signed, Type: TypeOf(FormatCosign), Reference: CosignTag(p.digest)})
+		}
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to sign the artifact")
+	}
+
+	for dgst, blob := range signature.Blobs {
+		exist, err := j.regCli.BlobExist(p.repository, dgst)
+		if err != nil {
+			return err
+		}
+		if exist {
+			continue
+		}
+		if err = j.regCli.PushBlob(p.repository, dgst, int64(len(blob)), bytes.NewReader(blob)); err != nil {
+			return errors.Wrapf(err, "failed to push the blob %s", dgst)
+		}
+	}
+	reference := signature.Reference
+	if len(reference) == 0 {
+		reference = signature.Digest()
+	}
+	dgst, err := j.regCli.PushManifest(p.repository, reference, signature.MediaType, signature.Manifest)
+	if err != nil {
+		return errors.Wrap(err, "failed to push the signature manifest")
+	}
+	j.logger.Infof("the signature %s of the artifact %s@%s is pushed", dgst, p.repository, p.digest)
+
+	return j.checkIn(ctx, &CheckIn{Digest: dgst, Type: signature.Type, Reference: signature.Reference})
+}
+
+// cosignSignature appends the signature layer to the existing cosign signature of the artifact. The digest of the
+// existing signature is returned without the new signature if the artifact is already signed by the key
+func (j *Job) cosignSignature(p *jobParams, signer crypto.Signer) (*Signature, string, error) {
+	var layers []v1.Descriptor
+	tag := CosignTag(p.digest)
+	exist, _, err := j.regCli.ManifestExist(p.repository, tag)
+	if err != nil {
+		return nil, "", err
+	}
+	if exist {
+		man, dgst, err := j.regCli.PullManifest(p.repository, tag, v1.MediaTypeImageManifest)
+		if err != nil {
+			return nil, "", err
+		}
+		_, content, err := man.Payload()
+		if err != nil {
+			return nil, "", err
+		}
+		manifest := &v1.Manifest{}
+		if err = json.Unmarshal(content, manifest); err != nil {
+			return nil, "", err
+		}
+		for _, layer := range manifest.Layers {
+			if j.signedBy(p.repository, layer, signer.Public()) {
+				return nil, dgst, nil
+			}
+		}
+		layers = manifest.Layers
+	}
+
+	payload, layer, err := NewCosignLayer(signer, p.dockerReference, p.digest)
+	if err != nil {
+		return nil, "", err
+	}
+	signature, err := NewCosignSignature(p.digest, append(layers, layer), map[string][]byte{layer.Digest.String(): payload})
+	if err != nil {
+		return nil, "", err
+	}
+	return signature, "", nil
+}
+
+// signedBy checks whether the cosign signature layer is signed by the key
+func (j *Job) signedBy(repository string, layer v1.Descriptor, pub crypto.PublicKey) bool {
+	sig, err := CosignLayerSignature(layer)
+	if err != nil || len(sig) == 0 {
+		return false
+	}
+	_, blob, err := j.regCli.PullBlob(repository, layer.Digest.String())
+	if err != nil {
+		j.logger.Warningf("failed to pull the signature payload %s: %v", layer.Digest, err)
+		return false
+	}
+	defer blob.Close()
+	payload, err := io.ReadAll(blob)
+	if err != nil {
+		return false
+	}
+	return cosign.VerifySignature(pub, payload, sig) == nil
+}
+
+func (j *Job) checkIn(ctx job.Context, data *CheckIn) error {
+	content, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return ctx.Checkin(string(content))
+}
+
+func (j *Job) init(ctx job.Context) {
+	j.logger = ctx.GetLogger()
+	if j.mgr == nil {
+		j.mgr = Mgr
+	}
+	if j.regCli == nil {
+		j.regCli = registry.Cli
+	}
+}
+
+func parseParams(params job.Parameters) (*jobParams, error) {
+	p := &jobParams{}
+	projectID, ok := params[ParamProjectID].(float64)
+	if !ok || projectID <= 0 {
+		return nil, fmt.Errorf("invalid parameter %s: %v", ParamProjectID, params[ParamProjectID])
+	}
+	p.projectID = int64(projectID)
+	size, _ := params[ParamSize].(float64)
+	p.size = int64(size)
+	for key, value := range map[string]*string{
+		ParamRepository: &p.repository,
+		ParamDigest:     &p.digest,
+		ParamMediaType:  &p.mediaType,
+		ParamFormat:     &p.format,
+	} {
+		v, ok := params[key].(string)
+		if !ok || len(v) == 0 {
+			return nil, fmt.Errorf("missing parameter %s", key)
+		}
+		*value = v
+	}
+	if !IsValidFormat(p.format) {
+		return nil, fmt.Errorf("invalid signing format %s", p.format)
+	}
+	if _, err := digest.Parse(p.digest); err != nil {
+		return nil, fmt.Errorf("invalid digest %s: %v", p.digest, err)
+	}
+	p.dockerReference, _ = params[ParamDockerReference].(string)
+	if len(p.dockerReference) == 0 {
+		p.dockerReference = p.repository
+	}
+	return p, nil
+}
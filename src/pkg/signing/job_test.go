@@ -0,0 +1,152 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/pkg/distribution"
+	signingmodel "github.com/goharbor/harbor/src/pkg/signing/model"
+	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/registry"
+	"github.com/goharbor/harbor/src/testing/pkg/signing"
+)
+
+type jobTestSuite struct {
+	suite.Suite
+	key    *ecdsa.PrivateKey
+	mgr    *signing.Manager
+	regCli *registry.Client
+	jobCtx *mockjobservice.MockJobContext
+	job    *Job
+	params job.Parameters
+}
+
+func (j *jobTestSuite) SetupTest() {
+	var err error
+	j.key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	j.Require().Nil(err)
+	cert, err := NewCertificate(j.key, "Harbor project 1", time.Now().Add(-time.Hour), time.Hour)
+	j.Require().Nil(err)
+
+	j.mgr = &signing.Manager{}
+	key := &signingmodel.Key{ProjectID: 1}
+	j.mgr.On("Get", mock.Anything, int64(1)).Return(key, nil)
+	j.mgr.On("Signer", mock.Anything, key).Return(j.key, cert, nil)
+	j.regCli = &registry.Client{}
+	j.jobCtx = &mockjobservice.MockJobContext{}
+	j.job = &Job{mgr: j.mgr, regCli: j.regCli}
+	j.params = job.Parameters{
+		ParamProjectID:       float64(1),
+		ParamRepository:      "library/hello",
+		ParamDigest:          testDigest,
+		ParamMediaType:       v1.MediaTypeImageManifest,
+		ParamSize:            float64(1024),
+		ParamFormat:          FormatCosign,
+		ParamDockerReference: "harbor.example.com/library/hello",
+	}
+}
+
+func (j *jobTestSuite) checkedIn(expected *CheckIn) any {
+	return testifymock.MatchedBy(func(content string) bool {
+		data := &CheckIn{}
+		return json.Unmarshal([]byte(content), data) == nil && *data == *expected
+	})
+}
+
+func (j *jobTestSuite) TestValidate() {
+	j.Nil(j.job.Validate(j.params))
+
+	for _, key := range []string{ParamProjectID, ParamRepository, ParamDigest, ParamMediaType, ParamFormat} {
+		params := job.Parameters{}
+		for k, v := range j.params {
+			params[k] = v
+		}
+		delete(params, key)
+		j.NotNil(j.job.Validate(params), key)
+	}
+
+	j.params[ParamFormat] = "gpg"
+	j.NotNil(j.job.Validate(j.params))
+	j.params[ParamFormat] = FormatCosign
+	j.params[ParamDigest] = "invalid"
+	j.NotNil(j.job.Validate(j.params))
+}
+
+func (j *jobTestSuite) TestRunCosign() {
+	tag := CosignTag(testDigest)
+	j.regCli.On("ManifestExist", "library/hello", tag).Return(false, nil, nil)
+	j.regCli.On("BlobExist", "library/hello", mock.Anything).Return(false, nil)
+	j.regCli.On("PushBlob", "library/hello", mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+	j.regCli.On("PushManifest", "library/hello", tag, v1.MediaTypeImageManifest, mock.Anything).Return("sha256:signature", nil)
+	j.jobCtx.On("Checkin", j.checkedIn(&CheckIn{Digest: "sha256:signature", Type: model.TypeCosignSignature, Reference: tag})).Return(nil)
+
+	j.Require().Nil(j.job.Run(j.jobCtx, j.params))
+	j.regCli.AssertExpectations(j.T())
+	j.jobCtx.AssertExpectations(j.T())
+}
+
+func (j *jobTestSuite) TestRunCosignSigned() {
+	payload, layer, err := NewCosignLayer(j.key, "harbor.example.com/library/hello", testDigest)
+	j.Require().Nil(err)
+	signature, err := NewCosignSignature(testDigest, []v1.Descriptor{layer}, nil)
+	j.Require().Nil(err)
+	manifest, _, err := distribution.UnmarshalManifest(v1.MediaTypeImageManifest, signature.Manifest)
+	j.Require().Nil(err)
+
+	tag := CosignTag(testDigest)
+	j.regCli.On("ManifestExist", "library/hello", tag).Return(true, nil, nil)
+	j.regCli.On("PullManifest", "library/hello", tag, v1.MediaTypeImageManifest).Return(manifest, "sha256:signed", nil)
+	j.regCli.On("PullBlob", "library/hello", layer.Digest.String()).Return(int64(len(payload)), io.NopCloser(bytes.NewReader(payload)), nil)
+	j.jobCtx.On("Checkin", j.checkedIn(&CheckIn{Digest: "sha256:signed", Type: model.TypeCosignSignature, Reference: tag})).Return(nil)
+
+	j.Require().Nil(j.job.Run(j.jobCtx, j.params))
+	j.regCli.AssertNotCalled(j.T(), "PushManifest", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	j.jobCtx.AssertExpectations(j.T())
+}
+
+func (j *jobTestSuite) TestRunNotation() {
+	j.params[ParamFormat] = FormatNotation
+	var pushed string
+	j.regCli.On("BlobExist", "library/hello", v1.DescriptorEmptyJSON.Digest.String()).Return(true, nil)
+	j.regCli.On("BlobExist", "library/hello", mock.Anything).Return(false, nil)
+	j.regCli.On("PushBlob", "library/hello", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	j.regCli.On("PushManifest", "library/hello", mock.Anything, v1.MediaTypeImageManifest, mock.Anything).
+		Run(func(args testifymock.Arguments) { pushed = args.String(1) }).Return("sha256:signature", nil)
+	j.jobCtx.On("Checkin", j.checkedIn(&CheckIn{Digest: "sha256:signature", Type: model.TypeNotationSignature})).Return(nil)
+
+	j.Require().Nil(j.job.Run(j.jobCtx, j.params))
+	j.Contains(pushed, "sha256:")
+	j.regCli.AssertExpectations(j.T())
+	j.jobCtx.AssertExpectations(j.T())
+}
+
+func TestJobTestSuite(t *testing.T) {
+	suite.Run(t, &jobTestSuite{})
+}
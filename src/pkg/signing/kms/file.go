@@ -0,0 +1,70 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kms
+
+import (
+	"context"
+	"crypto"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+const (
+	// FileProvider is the name of the KMS plugin reading the keys from the local files
+	FileProvider = "file"
+
+	defaultFileDir = "/etc/harbor/signing"
+)
+
+func init() {
+	dir := os.Getenv("SIGNING_KMS_FILE_DIR")
+	if len(dir) == 0 {
+		dir = defaultFileDir
+	}
+	if err := Register(FileProvider, NewFileKMS(dir)); err != nil {
+		log.Errorf("failed to register the KMS plugin %s: %v", FileProvider, err)
+	}
+}
+
+// NewFileKMS returns a KMS reading the PEM encoded private keys from the files under the directory,
+// the keys of each project are under the sub directory named by the project ID, and the key reference
+// is the path of the key file relative to the sub directory, e.g. the key reference "cosign.pem" of
+// project 1 refers to the file "<dir>/1/cosign.pem"
+func NewFileKMS(dir string) KMS {
+	return &fileKMS{dir: dir}
+}
+
+type fileKMS struct {
+	dir string
+}
+
+func (f *fileKMS) Signer(_ context.Context, projectID int64, keyRef string) (crypto.Signer, error) {
+	// the key must be under the directory of the project
+	if !filepath.IsLocal(keyRef) {
+		return nil, errors.BadRequestError(nil).WithMessagef("invalid key reference %s", keyRef)
+	}
+	data, err := os.ReadFile(filepath.Join(f.dir, strconv.FormatInt(projectID, 10), keyRef))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.NotFoundError(nil).WithMessagef("the key %s not found", keyRef)
+		}
+		return nil, err
+	}
+	return ParsePrivateKey(data)
+}
@@ -0,0 +1,87 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+type fileKMSTestSuite struct {
+	suite.Suite
+	dir string
+	key *ecdsa.PrivateKey
+	kms KMS
+}
+
+func (f *fileKMSTestSuite) SetupTest() {
+	f.dir = f.T().TempDir()
+	var err error
+	f.key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	f.Require().Nil(err)
+	der, err := x509.MarshalPKCS8PrivateKey(f.key)
+	f.Require().Nil(err)
+	f.Require().Nil(os.MkdirAll(filepath.Join(f.dir, "1"), 0700))
+	f.Require().Nil(os.MkdirAll(filepath.Join(f.dir, "2"), 0700))
+	f.Require().Nil(os.WriteFile(filepath.Join(f.dir, "1", "library.pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+	f.Require().Nil(os.WriteFile(filepath.Join(f.dir, "1", "invalid.pem"), []byte("invalid"), 0600))
+	f.kms = NewFileKMS(f.dir)
+}
+
+func (f *fileKMSTestSuite) TestSigner() {
+	signer, err := f.kms.Signer(context.TODO(), 1, "library.pem")
+	f.Require().Nil(err)
+	f.True(f.key.PublicKey.Equal(signer.Public()))
+
+	_, err = f.kms.Signer(context.TODO(), 1, "notexist.pem")
+	f.True(errors.IsNotFoundErr(err))
+
+	// the keys of other projects are invisible
+	_, err = f.kms.Signer(context.TODO(), 2, "library.pem")
+	f.True(errors.IsNotFoundErr(err))
+
+	_, err = f.kms.Signer(context.TODO(), 2, "../1/library.pem")
+	f.True(errors.IsErr(err, errors.BadRequestCode))
+
+	_, err = f.kms.Signer(context.TODO(), 1, "invalid.pem")
+	f.NotNil(err)
+}
+
+func (f *fileKMSTestSuite) TestRegistry() {
+	kms, err := Get(FileProvider)
+	f.Require().Nil(err)
+	f.NotNil(kms)
+	f.Contains(List(), FileProvider)
+
+	f.NotNil(Register(FileProvider, f.kms))
+
+	_, err = Get("notexist")
+	f.True(errors.IsNotFoundErr(err))
+}
+
+func TestFileKMSTestSuite(t *testing.T) {
+	suite.Run(t, &fileKMSTestSuite{})
+}
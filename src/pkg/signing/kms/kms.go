@@ -0,0 +1,111 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kms
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+var (
+	registry = map[string]KMS{}
+	lock     sync.RWMutex
+)
+
+// KMS is the plugin interface of the key management services which hold the signing keys outside Harbor
+type KMS interface {
+	// Signer returns the signer of the private key referenced by the keyRef, the keys are scoped by
+	// the project, the plugin must not return the keys belonging to other projects
+	Signer(ctx context.Context, projectID int64, keyRef string) (signer crypto.Signer, err error)
+}
+
+// Register registers the KMS plugin with the name
+func Register(name string, kms KMS) error {
+	lock.Lock()
+	defer lock.Unlock()
+	if _, exist := registry[name]; exist {
+		return fmt.Errorf("the KMS plugin %s already exists", name)
+	}
+	registry[name] = kms
+	return nil
+}
+
+// Get returns the KMS plugin specified by the name
+func Get(name string) (KMS, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+	kms, exist := registry[name]
+	if !exist {
+		return nil, errors.NotFoundError(nil).WithMessagef("the KMS plugin %s not found", name)
+	}
+	return kms, nil
+}
+
+// List returns the names of the registered KMS plugins
+func List() []string {
+	lock.RLock()
+	defer lock.RUnlock()
+	var names []string
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParsePrivateKey parses the PEM encoded ECDSA, RSA or Ed25519 private key
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded private key found")
+	}
+	var (
+		key any
+		err error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %s", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the private key: %v", err)
+	}
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		return k, nil
+	case *rsa.PrivateKey:
+		return k, nil
+	case ed25519.PrivateKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/encrypt"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/signing/dao"
+	"github.com/goharbor/harbor/src/pkg/signing/kms"
+	"github.com/goharbor/harbor/src/pkg/signing/model"
+)
+
+const (
+	// the validity of the self-signed certificate of the signing key
+	certificateValidity = 10 * 365 * 24 * time.Hour
+)
+
+// Mgr is the global signing key manager instance
+var Mgr = NewManager()
+
+// Manager manages the keys used to sign the artifacts of the projects
+type Manager interface {
+	// Create the signing key of the project. A new key is generated and stored encrypted in the database when
+	// the provider is "database", otherwise the key referenced by the keyRef in the KMS plugin is used
+	Create(ctx context.Context, projectID int64, provider, keyRef string) (key *model.Key, err error)
+	// Get the signing key of the project
+	Get(ctx context.Context, projectID int64) (key *model.Key, err error)
+	// Delete the signing key of the project
+	Delete(ctx context.Context, projectID int64) (err error)
+	// Signer returns the signer of the private key and the certificate of the signing key
+	Signer(ctx context.Context, key *model.Key) (signer crypto.Signer, cert *x509.Certificate, err error)
+}
+
+// NewManager returns an instance of the default manager
+func NewManager() Manager {
+	return &manager{
+		dao:       dao.New(),
+		encryptor: encrypt.Instance,
+	}
+}
+
+type manager struct {
+	dao       dao.DAO
+	encryptor func() encrypt.Encryptor
+}
+
+func (m *manager) Create(ctx context.Context, projectID int64, provider, keyRef string) (*model.Key, error) {
+	key := &model.Key{
+		ProjectID: projectID,
+		Provider:  provider,
+		KeyRef:    keyRef,
+	}
+
+	var signer crypto.Signer
+	if provider == model.ProviderDatabase {
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		if err != nil {
+			return nil, err
+		}
+		key.KeyRef = ""
+		key.PrivateKey, err = m.encryptor().Encrypt(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encrypt the signing key")
+		}
+		signer = privateKey
+	} else {
+		if len(keyRef) == 0 {
+			return nil, errors.BadRequestError(nil).WithMessage("the key reference is required by the KMS plugin")
+		}
+		plugin, err := kms.Get(provider)
+		if err != nil {
+			return nil, errors.BadRequestError(err).WithMessagef("invalid provider %s", provider)
+		}
+		signer, err = plugin.Signer(ctx, projectID, keyRef)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	key.PublicKey = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	cert, err := NewCertificate(signer, fmt.Sprintf("Harbor project %d", projectID), time.Now().Add(-time.Hour), certificateValidity)
+	if err != nil {
+		return nil, err
+	}
+	key.Certificate = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+
+	if key.ID, err = m.dao.Create(ctx, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (m *manager) Get(ctx context.Context, projectID int64) (*model.Key, error) {
+	return m.dao.GetByProjectID(ctx, projectID)
+}
+
+func (m *manager) Delete(ctx context.Context, projectID int64) error {
+	return m.dao.DeleteByProjectID(ctx, projectID)
+}
+
+func (m *manager) Signer(ctx context.Context, key *model.Key) (crypto.Signer, *x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(key.Certificate))
+	if block == nil {
+		return nil, nil, fmt.Errorf("no certificate found in the signing key of project %d", key.ProjectID)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var signer crypto.Signer
+	if key.Provider == model.ProviderDatabase {
+		privateKey, err := m.encryptor().Decrypt(key.PrivateKey)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to decrypt the signing key")
+		}
+		if signer, err = kms.ParsePrivateKey([]byte(privateKey)); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		plugin, err := kms.Get(key.Provider)
+		if err != nil {
+			return nil, nil, err
+		}
+		if signer, err = plugin.Signer(ctx, key.ProjectID, key.KeyRef); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// the key in the KMS may be rotated after the certificate is issued
+	if pub, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool }); !ok || !pub.Equal(cert.PublicKey) {
+		return nil, nil, fmt.Errorf("the private key doesn't match the certificate of the signing key of project %d", key.ProjectID)
+	}
+	return signer, cert, nil
+}
@@ -0,0 +1,128 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/encrypt"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/signing/kms"
+	"github.com/goharbor/harbor/src/pkg/signing/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/signing/dao"
+)
+
+type fakeKMS struct {
+	signer crypto.Signer
+}
+
+func (f *fakeKMS) Signer(_ context.Context, projectID int64, keyRef string) (crypto.Signer, error) {
+	if projectID != 1 || keyRef != "project-key" {
+		return nil, errors.NotFoundError(nil).WithMessagef("the key %s not found", keyRef)
+	}
+	return f.signer, nil
+}
+
+type managerTestSuite struct {
+	suite.Suite
+	mgr *manager
+	dao *dao.DAO
+	kms *fakeKMS
+}
+
+func (m *managerTestSuite) SetupSuite() {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	m.Require().Nil(err)
+	m.kms = &fakeKMS{signer: key}
+	m.Require().Nil(kms.Register("fake", m.kms))
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.dao = &dao.DAO{}
+	encryptor := encrypt.NewAESEncryptor(&encrypt.PresetKeyProvider{Key: "naa4JtarA1Zsc3uY"})
+	m.mgr = &manager{
+		dao:       m.dao,
+		encryptor: func() encrypt.Encryptor { return encryptor },
+	}
+}
+
+func (m *managerTestSuite) TestCreateInDatabase() {
+	m.dao.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	key, err := m.mgr.Create(context.Background(), 1, model.ProviderDatabase, "ignored")
+	m.Require().Nil(err)
+	m.dao.AssertExpectations(m.T())
+	m.Equal(int64(1), key.ID)
+	m.Empty(key.KeyRef)
+	m.NotEmpty(key.PrivateKey)
+	m.False(strings.Contains(key.PrivateKey, "PRIVATE KEY"))
+	m.Contains(key.PublicKey, "PUBLIC KEY")
+
+	signer, cert, err := m.mgr.Signer(context.Background(), key)
+	m.Require().Nil(err)
+	m.True(signer.Public().(*ecdsa.PublicKey).Equal(cert.PublicKey))
+	m.Equal("Harbor project 1", cert.Subject.CommonName)
+}
+
+func (m *managerTestSuite) TestCreateInKMS() {
+	m.dao.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	key, err := m.mgr.Create(context.Background(), 1, "fake", "project-key")
+	m.Require().Nil(err)
+	m.Empty(key.PrivateKey)
+	m.Equal("project-key", key.KeyRef)
+
+	signer, cert, err := m.mgr.Signer(context.Background(), key)
+	m.Require().Nil(err)
+	m.Equal(m.kms.signer, signer)
+	m.True(m.kms.signer.Public().(*ecdsa.PublicKey).Equal(cert.PublicKey))
+
+	// the key in KMS is rotated
+	original := m.kms.signer
+	defer func() { m.kms.signer = original }()
+	m.kms.signer, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	m.Require().Nil(err)
+	_, _, err = m.mgr.Signer(context.Background(), key)
+	m.NotNil(err)
+}
+
+func (m *managerTestSuite) TestCreateInvalid() {
+	_, err := m.mgr.Create(context.Background(), 1, "fake", "")
+	m.True(errors.IsErr(err, errors.BadRequestCode))
+
+	_, err = m.mgr.Create(context.Background(), 1, "notexist", "project-key")
+	m.True(errors.IsErr(err, errors.BadRequestCode))
+
+	_, err = m.mgr.Create(context.Background(), 1, "fake", "notexist")
+	m.True(errors.IsNotFoundErr(err))
+	m.dao.AssertNotCalled(m.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (m *managerTestSuite) TestDelete() {
+	m.dao.On("DeleteByProjectID", mock.Anything, int64(1)).Return(nil)
+	m.Nil(m.mgr.Delete(context.Background(), 1))
+	m.dao.AssertExpectations(m.T())
+}
+
+func TestManagerTestSuite(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
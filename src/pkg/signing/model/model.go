@@ -0,0 +1,50 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+)
+
+const (
+	// ProviderDatabase means the private key is encrypted and stored in the database
+	ProviderDatabase = "database"
+)
+
+func init() {
+	orm.RegisterModel(&Key{})
+}
+
+// Key is the key used to sign the artifacts of a project
+type Key struct {
+	ID        int64 `orm:"pk;auto;column(id)" json:"id"`
+	ProjectID int64 `orm:"column(project_id)" json:"project_id"`
+	// Provider is "database" or the name of the KMS plugin holding the private key
+	Provider string `orm:"column(provider)" json:"provider"`
+	// KeyRef references the private key in the KMS
+	KeyRef string `orm:"column(key_ref)" json:"key_ref"`
+	// PrivateKey is the encrypted PEM encoded private key, only for the "database" provider
+	PrivateKey   string    `orm:"column(private_key);type(text)" json:"-"`
+	PublicKey    string    `orm:"column(public_key);type(text)" json:"public_key"`
+	Certificate  string    `orm:"column(certificate);type(text)" json:"certificate"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+}
+
+// TableName ...
+func (k *Key) TableName() string {
+	return "signing_key"
+}
@@ -0,0 +1,346 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
+)
+
+const (
+	// FormatCosign signs the artifacts in cosign format
+	FormatCosign = "cosign"
+	// FormatNotation signs the artifacts in notation format
+	FormatNotation = "notation"
+
+	cosignPayloadMediaType    = "application/vnd.dev.cosign.simplesigning.v1+json"
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	cosignSignatureType       = "cosign container image signature"
+
+	notationArtifactType         = "application/vnd.cncf.notary.signature"
+	notationEnvelopeMediaType    = "application/jose+json"
+	notationPayloadMediaType     = "application/vnd.cncf.notary.payload.v1+json"
+	notationThumbprintAnnotation = "io.cncf.notary.x509chain.thumbprint#S256"
+	notationSigningScheme        = "io.cncf.notary.signingScheme"
+	notationSigningTime          = "io.cncf.notary.signingTime"
+	notationSigningAgent         = "io.cncf.notary.signingAgent"
+	notationSchemeX509           = "notary.x509"
+)
+
+// Signature is the signature artifact to be pushed into the repository of the signed artifact
+type Signature struct {
+	// Type is the accessory type of the signature
+	Type string
+	// Reference is the tag of the signature manifest, the manifest is pushed by digest if it is empty
+	Reference string
+	// MediaType of the manifest
+	MediaType string
+	// Manifest is the content of the signature manifest
+	Manifest []byte
+	// Blobs are the contents of the blobs referenced by the manifest and not existing yet, indexed by the digest
+	Blobs map[string][]byte
+}
+
+// Digest returns the digest of the signature manifest
+func (s *Signature) Digest() string {
+	return digest.FromBytes(s.Manifest).String()
+}
+
+// IsValidFormat checks whether the signing format is supported
+func IsValidFormat(format string) bool {
+	return format == FormatCosign || format == FormatNotation
+}
+
+// TypeOf returns the accessory type of the signature in the format
+func TypeOf(format string) string {
+	if format == FormatNotation {
+		return model.TypeNotationSignature
+	}
+	return model.TypeCosignSignature
+}
+
+// CosignTag returns the tag of the cosign signature of the artifact
+func CosignTag(dgst string) string {
+	return strings.Replace(dgst, ":", "-", 1) + ".sig"
+}
+
+// NewCosignLayer signs the artifact and returns the simple signing payload and the signature layer describing it
+func NewCosignLayer(signer crypto.Signer, dockerReference, dgst string) ([]byte, v1.Descriptor, error) {
+	payload, err := json.Marshal(map[string]any{
+		"critical": map[string]any{
+			"identity": map[string]string{"docker-reference": dockerReference},
+			"image":    map[string]string{"docker-manifest-digest": dgst},
+			"type":     cosignSignatureType,
+		},
+		"optional": nil,
+	})
+	if err != nil {
+		return nil, v1.Descriptor{}, err
+	}
+	sig, err := sign(signer, payload)
+	if err != nil {
+		return nil, v1.Descriptor{}, err
+	}
+	return payload, v1.Descriptor{
+		MediaType: cosignPayloadMediaType,
+		Digest:    digest.FromBytes(payload),
+		Size:      int64(len(payload)),
+		Annotations: map[string]string{
+			cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sig),
+		},
+	}, nil
+}
+
+// CosignLayerSignature returns the signature carried by the cosign signature layer
+func CosignLayerSignature(layer v1.Descriptor) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(layer.Annotations[cosignSignatureAnnotation])
+}
+
+// NewCosignSignature builds the cosign signature manifest of the artifact with the signature layers,
+// the blobs carry the payloads of the layers not pushed yet
+func NewCosignSignature(dgst string, layers []v1.Descriptor, blobs map[string][]byte) (*Signature, error) {
+	var diffIDs []digest.Digest
+	for _, layer := range layers {
+		diffIDs = append(diffIDs, layer.Digest)
+	}
+	config, err := json.Marshal(map[string]any{
+		"architecture": "",
+		"os":           "",
+		"created":      time.Time{},
+		"config":       map[string]any{},
+		"rootfs":       map[string]any{"type": "layers", "diff_ids": diffIDs},
+	})
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := json.Marshal(&v1.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: v1.MediaTypeImageManifest,
+		Config: v1.Descriptor{
+			MediaType: v1.MediaTypeImageConfig,
+			Digest:    digest.FromBytes(config),
+			Size:      int64(len(config)),
+		},
+		Layers: layers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	all := map[string][]byte{digest.FromBytes(config).String(): config}
+	for dgst, blob := range blobs {
+		all[dgst] = blob
+	}
+	return &Signature{
+		Type:      model.TypeCosignSignature,
+		Reference: CosignTag(dgst),
+		MediaType: v1.MediaTypeImageManifest,
+		Manifest:  manifest,
+		Blobs:     all,
+	}, nil
+}
+
+// NewNotationSignature signs the subject artifact and builds the notation signature manifest with the JWS envelope
+func NewNotationSignature(signer crypto.Signer, cert *x509.Certificate, subject v1.Descriptor, signingTime time.Time) (*Signature, error) {
+	alg, err := jwsAlgorithm(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(map[string]any{
+		"targetArtifact": v1.Descriptor{
+			MediaType: subject.MediaType,
+			Digest:    subject.Digest,
+			Size:      subject.Size,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	protected, err := json.Marshal(map[string]any{
+		"alg":                 alg,
+		"crit":                []string{notationSigningScheme},
+		"cty":                 notationPayloadMediaType,
+		notationSigningScheme: notationSchemeX509,
+		notationSigningTime:   signingTime.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(protected) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	sig, err := signJWS(signer, alg, []byte(signingInput))
+	if err != nil {
+		return nil, err
+	}
+	envelope, err := json.Marshal(map[string]any{
+		"payload":   base64.RawURLEncoding.EncodeToString(payload),
+		"protected": base64.RawURLEncoding.EncodeToString(protected),
+		"header": map[string]any{
+			"x5c":                [][]byte{cert.Raw},
+			notationSigningAgent: "Harbor",
+		},
+		"signature": base64.RawURLEncoding.EncodeToString(sig),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	thumbprint := sha256.Sum256(cert.Raw)
+	thumbprints, err := json.Marshal([]string{hex.EncodeToString(thumbprint[:])})
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := json.Marshal(&v1.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    v1.MediaTypeImageManifest,
+		ArtifactType: notationArtifactType,
+		Config:       v1.DescriptorEmptyJSON,
+		Layers: []v1.Descriptor{{
+			MediaType: notationEnvelopeMediaType,
+			Digest:    digest.FromBytes(envelope),
+			Size:      int64(len(envelope)),
+		}},
+		Subject: &subject,
+		Annotations: map[string]string{
+			notationThumbprintAnnotation: string(thumbprints),
+			v1.AnnotationCreated:         signingTime.UTC().Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Signature{
+		Type:      model.TypeNotationSignature,
+		MediaType: v1.MediaTypeImageManifest,
+		Manifest:  manifest,
+		Blobs: map[string][]byte{
+			v1.DescriptorEmptyJSON.Digest.String(): v1.DescriptorEmptyJSON.Data,
+			digest.FromBytes(envelope).String():    envelope,
+		},
+	}, nil
+}
+
+// NewCertificate creates the self-signed code signing certificate of the key, which is required by notation
+func NewCertificate(signer crypto.Signer, commonName string, notBefore time.Time, validity time.Duration) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"Harbor"}},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(validity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the certificate: %v", err)
+	}
+	return x509.ParseCertificate(der)
+}
+
+// sign signs the payload in the way cosign does
+func sign(signer crypto.Signer, payload []byte) ([]byte, error) {
+	if _, ok := signer.Public().(ed25519.PublicKey); ok {
+		return signer.Sign(rand.Reader, payload, crypto.Hash(0))
+	}
+	sum := sha256.Sum256(payload)
+	return signer.Sign(rand.Reader, sum[:], crypto.SHA256)
+}
+
+// jwsAlgorithm returns the JWS algorithm supported by notation for the public key
+func jwsAlgorithm(pub crypto.PublicKey) (string, error) {
+	switch key := pub.(type) {
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return "ES256", nil
+		case elliptic.P384():
+			return "ES384", nil
+		case elliptic.P521():
+			return "ES512", nil
+		}
+	case *rsa.PublicKey:
+		switch key.Size() {
+		case 256, 384, 512:
+			return "PS256", nil
+		}
+	}
+	return "", fmt.Errorf("the key %T isn't supported by notation", pub)
+}
+
+// signJWS signs the JWS signing input, the ECDSA signature is encoded as R || S required by JWS
+func signJWS(signer crypto.Signer, alg string, input []byte) ([]byte, error) {
+	var hash crypto.Hash
+	switch alg {
+	case "ES384":
+		hash = crypto.SHA384
+	case "ES512":
+		hash = crypto.SHA512
+	default:
+		hash = crypto.SHA256
+	}
+	h := hash.New()
+	h.Write(input)
+	sum := h.Sum(nil)
+
+	switch key := signer.Public().(type) {
+	case *ecdsa.PublicKey:
+		der, err := signer.Sign(rand.Reader, sum, hash)
+		if err != nil {
+			return nil, err
+		}
+		return ecdsaRawSignature(der, (key.Curve.Params().BitSize+7)/8)
+	default:
+		return signer.Sign(rand.Reader, sum, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: hash})
+	}
+}
+
+// ecdsaRawSignature converts the ASN.1 DER encoded ECDSA signature into R || S with the fixed size
+func ecdsaRawSignature(der []byte, size int) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	if _, err := asn1.Unmarshal(der, &sig); err != nil {
+		return nil, fmt.Errorf("invalid ECDSA signature: %v", err)
+	}
+	raw := make([]byte, 2*size)
+	sig.R.FillBytes(raw[:size])
+	sig.S.FillBytes(raw[size:])
+	return raw, nil
+}
@@ -0,0 +1,151 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/pkg/signature/cosign"
+)
+
+const testDigest = "sha256:4b4ab0e6bc1b4cc44f2e0d5e6da8a7ae8e5d2cbb0e0c4e7b9c0f0b1b9c1b4e8a"
+
+type signatureTestSuite struct {
+	suite.Suite
+	key *ecdsa.PrivateKey
+}
+
+func (s *signatureTestSuite) SetupSuite() {
+	var err error
+	s.key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().Nil(err)
+}
+
+func (s *signatureTestSuite) TestCosignTag() {
+	s.Equal("sha256-4b4ab0e6bc1b4cc44f2e0d5e6da8a7ae8e5d2cbb0e0c4e7b9c0f0b1b9c1b4e8a.sig", CosignTag(testDigest))
+}
+
+func (s *signatureTestSuite) TestCosign() {
+	payload, layer, err := NewCosignLayer(s.key, "harbor.example.com/library/hello", testDigest)
+	s.Require().Nil(err)
+	s.Equal(digest.FromBytes(payload), layer.Digest)
+	s.Contains(string(payload), testDigest)
+
+	sig, err := CosignLayerSignature(layer)
+	s.Require().Nil(err)
+	s.Nil(cosign.VerifySignature(s.key.Public(), payload, sig))
+
+	signature, err := NewCosignSignature(testDigest, []v1.Descriptor{layer}, map[string][]byte{layer.Digest.String(): payload})
+	s.Require().Nil(err)
+	s.Equal(model.TypeCosignSignature, signature.Type)
+	s.Equal(CosignTag(testDigest), signature.Reference)
+	s.Equal(digest.FromBytes(signature.Manifest).String(), signature.Digest())
+
+	manifest := &v1.Manifest{}
+	s.Require().Nil(json.Unmarshal(signature.Manifest, manifest))
+	s.Require().Len(manifest.Layers, 1)
+	s.Equal(layer.Digest, manifest.Layers[0].Digest)
+	s.Len(signature.Blobs, 2)
+	s.Contains(signature.Blobs, manifest.Config.Digest.String())
+	s.Contains(string(signature.Blobs[manifest.Config.Digest.String()]), layer.Digest.String())
+}
+
+func (s *signatureTestSuite) TestNotation() {
+	cert, err := NewCertificate(s.key, "Harbor project 1", time.Now().Add(-time.Hour), time.Hour*24)
+	s.Require().Nil(err)
+	subject := v1.Descriptor{MediaType: v1.MediaTypeImageManifest, Digest: digest.Digest(testDigest), Size: 1024}
+
+	signature, err := NewNotationSignature(s.key, cert, subject, time.Now())
+	s.Require().Nil(err)
+	s.Equal(model.TypeNotationSignature, signature.Type)
+	s.Empty(signature.Reference)
+
+	manifest := &v1.Manifest{}
+	s.Require().Nil(json.Unmarshal(signature.Manifest, manifest))
+	s.Equal(notationArtifactType, manifest.ArtifactType)
+	s.Require().NotNil(manifest.Subject)
+	s.Equal(subject.Digest, manifest.Subject.Digest)
+	s.Require().Len(manifest.Layers, 1)
+	s.Equal(notationEnvelopeMediaType, manifest.Layers[0].MediaType)
+	s.Contains(signature.Blobs, manifest.Config.Digest.String())
+
+	envelope := &struct {
+		Payload   string `json:"payload"`
+		Protected string `json:"protected"`
+		Signature string `json:"signature"`
+		Header    struct {
+			X5C [][]byte `json:"x5c"`
+		} `json:"header"`
+	}{}
+	s.Require().Nil(json.Unmarshal(signature.Blobs[manifest.Layers[0].Digest.String()], envelope))
+	s.Require().Len(envelope.Header.X5C, 1)
+	s.Equal(cert.Raw, envelope.Header.X5C[0])
+
+	protected, err := base64.RawURLEncoding.DecodeString(envelope.Protected)
+	s.Require().Nil(err)
+	s.Contains(string(protected), `"alg":"ES256"`)
+	payload, err := base64.RawURLEncoding.DecodeString(envelope.Payload)
+	s.Require().Nil(err)
+	s.Contains(string(payload), testDigest)
+
+	raw, err := base64.RawURLEncoding.DecodeString(envelope.Signature)
+	s.Require().Nil(err)
+	s.Require().Len(raw, 64)
+	sum := sha256.Sum256([]byte(envelope.Protected + "." + envelope.Payload))
+	s.True(ecdsa.Verify(&s.key.PublicKey, sum[:], new(big.Int).SetBytes(raw[:32]), new(big.Int).SetBytes(raw[32:])))
+}
+
+func (s *signatureTestSuite) TestNotationRSA() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().Nil(err)
+	cert, err := NewCertificate(key, "Harbor project 1", time.Now().Add(-time.Hour), time.Hour*24)
+	s.Require().Nil(err)
+	subject := v1.Descriptor{MediaType: v1.MediaTypeImageManifest, Digest: digest.Digest(testDigest), Size: 1024}
+
+	signature, err := NewNotationSignature(key, cert, subject, time.Now())
+	s.Require().Nil(err)
+	manifest := &v1.Manifest{}
+	s.Require().Nil(json.Unmarshal(signature.Manifest, manifest))
+	envelope := &struct {
+		Payload   string `json:"payload"`
+		Protected string `json:"protected"`
+		Signature string `json:"signature"`
+	}{}
+	s.Require().Nil(json.Unmarshal(signature.Blobs[manifest.Layers[0].Digest.String()], envelope))
+	sig, err := base64.RawURLEncoding.DecodeString(envelope.Signature)
+	s.Require().Nil(err)
+	sum := sha256.Sum256([]byte(strings.Join([]string{envelope.Protected, envelope.Payload}, ".")))
+	s.Nil(rsa.VerifyPSS(&key.PublicKey, crypto.SHA256, sum[:], sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}))
+}
+
+func TestSignatureTestSuite(t *testing.T) {
+	suite.Run(t, &signatureTestSuite{})
+}
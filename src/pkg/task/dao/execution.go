@@ -16,8 +16,10 @@ package dao
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -48,6 +50,10 @@ func RegisterExecutionStatusChangePostFunc(vendor string, fc ExecutionStatusChan
 	executionStatusChangePostFuncRegistry[vendor] = fc
 }
 
+// BytesTransferredAttr is the key of the task extra attribute recording the bytes transferred by the task,
+// it's summed up in the execution statistics
+const BytesTransferredAttr = "bytes_transferred"
+
 var (
 	// ExecDAO is the global execution dao
 	ExecDAO                               = NewExecutionDAO()
@@ -87,6 +93,14 @@ type ExecutionDAO interface {
 	// AsyncRefreshStatus refreshes the status of the specified execution in the async mode, which will register
 	// a update flag in the redis and then wait for global periodic job to scan and update the status to db finally.
 	AsyncRefreshStatus(ctx context.Context, id int64, vendor string) (err error)
+	// Stats returns the statistics of the executions matching the query, the first one is across all the
+	// vendor types and followed by the ones of each vendor type.
+	// Only the "vendor_type", "status", "trigger" and the range of "start_time" are supported in the query
+	Stats(ctx context.Context, query *q.Query) (stats []*ExecutionStats, err error)
+	// IncreaseExtraAttrs increases the numeric extra attributes of the specified execution by the deltas
+	// in one statement, so the concurrent increases aren't lost. The absent or non-numeric attributes are
+	// treated as 0. Returns the values of the attributes after increasing
+	IncreaseExtraAttrs(ctx context.Context, id int64, deltas map[string]int64) (values map[string]int64, err error)
 }
 
 // NewExecutionDAO returns an instance of ExecutionDAO
@@ -166,6 +180,62 @@ func (e *executionDAO) Update(ctx context.Context, execution *Execution, props .
 	return nil
 }
 
+func (e *executionDAO) IncreaseExtraAttrs(ctx context.Context, id int64, deltas map[string]int64) (map[string]int64, error) {
+	if len(deltas) == 0 {
+		return map[string]int64{}, nil
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(deltas))
+	for key := range deltas {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var (
+		fields []string
+		params []any
+	)
+	for _, key := range keys {
+		fields = append(fields, fmt.Sprintf("?::text, coalesce(%s, 0)::bigint + ?", numericExtraAttr("extra_attrs")))
+		params = append(params, key, key, key, deltas[key])
+	}
+	params = append(params, id)
+
+	sql := fmt.Sprintf(`update execution
+		set extra_attrs = (coalesce(extra_attrs::jsonb, '{}'::jsonb) || jsonb_build_object(%s))::json, update_time = now()
+		where id = ? returning extra_attrs::text`, strings.Join(fields, ", "))
+	var attrs string
+	if err = ormer.Raw(sql, params...).QueryRow(&attrs); err != nil {
+		if e := orm.AsNotFoundError(err, "execution %d not found", id); e != nil {
+			err = e
+		}
+		return nil, err
+	}
+
+	values := map[string]any{}
+	if err = json.Unmarshal([]byte(attrs), &values); err != nil {
+		return nil, err
+	}
+	result := map[string]int64{}
+	for _, key := range keys {
+		if v, ok := values[key].(float64); ok {
+			result[key] = int64(v)
+		}
+	}
+	return result, nil
+}
+
+// numericExtraAttr returns the SQL expression reading the extra attribute specified by the parameter as numeric,
+// the non-numeric value is read as NULL rather than failing the whole statement. It takes 2 parameters, both are
+// the key of the attribute
+func numericExtraAttr(column string) string {
+	return fmt.Sprintf(`(case when %[1]s->>? ~ '^\s*-?[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?\s*$' then (%[1]s->>?)::numeric end)`, column)
+}
+
 func (e *executionDAO) Delete(ctx context.Context, id int64) error {
 	ormer, err := orm.FromContext(ctx)
 	if err != nil {
@@ -343,6 +413,113 @@ func (e *executionDAO) refreshStatus(ctx context.Context, id int64) (bool, strin
 	return status != execution.Status, status, false, err
 }
 
+func (e *executionDAO) Stats(ctx context.Context, query *q.Query) ([]*ExecutionStats, error) {
+	where, args, err := statsFilter(query)
+	if err != nil {
+		return nil, err
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	success, failed, stopped := job.SuccessStatus.String(), job.ErrorStatus.String(), job.StoppedStatus.String()
+	sql := fmt.Sprintf(`with e as (
+				select id, vendor_type, status, start_time, end_time
+				from execution %s
+			),
+			b as (
+				select t.execution_id, sum(%s) as bytes
+				from task t join e on t.execution_id = e.id
+				group by t.execution_id
+			)
+			select
+				case when grouping(e.vendor_type) = 1 then '' else e.vendor_type end as vendor_type,
+				count(e.id) as total,
+				count(e.id) filter (where e.status = ?) as success_count,
+				count(e.id) filter (where e.status = ?) as error_count,
+				count(e.id) filter (where e.status = ?) as stopped_count,
+				count(e.id) filter (where e.status not in (?, ?, ?)) as running_count,
+				coalesce(percentile_cont(0.5) within group (order by extract(epoch from e.end_time - e.start_time))
+					filter (where e.status in (?, ?, ?) and e.end_time >= e.start_time), 0) as p50_duration,
+				coalesce(percentile_cont(0.95) within group (order by extract(epoch from e.end_time - e.start_time))
+					filter (where e.status in (?, ?, ?) and e.end_time >= e.start_time), 0) as p95_duration,
+				coalesce(sum(b.bytes), 0)::bigint as bytes_transferred
+			from e left join b on b.execution_id = e.id
+			group by grouping sets ((), (e.vendor_type))
+			order by grouping(e.vendor_type) desc, e.vendor_type`, where, numericExtraAttr("t.extra_attrs"))
+	args = append(args, BytesTransferredAttr, BytesTransferredAttr,
+		success, failed, stopped,
+		success, failed, stopped,
+		success, failed, stopped,
+		success, failed, stopped)
+
+	stats := []*ExecutionStats{}
+	if _, err = ormer.Raw(sql, args...).QueryRows(&stats); err != nil {
+		return nil, err
+	}
+	for _, s := range stats {
+		if finished := s.SuccessCount + s.ErrorCount + s.StoppedCount; finished > 0 {
+			s.SuccessRate = float64(s.SuccessCount) / float64(finished)
+		}
+	}
+	return stats, nil
+}
+
+// statsFilter builds the where clause of the execution statistics from the query
+func statsFilter(query *q.Query) (string, []any, error) {
+	if query == nil || len(query.Keywords) == 0 {
+		return "", nil, nil
+	}
+
+	// sort the keys to make the clause stable
+	keys := make([]string, 0, len(query.Keywords))
+	for key := range query.Keywords {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var (
+		conds []string
+		args  []any
+	)
+	for _, key := range keys {
+		value := query.Keywords[key]
+		switch key {
+		case "vendor_type", "status", "trigger":
+			if ol, ok := value.(*q.OrList); ok {
+				if len(ol.Values) == 0 {
+					continue
+				}
+				conds = append(conds, fmt.Sprintf("%s in (%s)", key, orm.ParamPlaceholderForIn(len(ol.Values))))
+				args = append(args, ol.Values...)
+				continue
+			}
+			conds = append(conds, fmt.Sprintf("%s = ?", key))
+			args = append(args, value)
+		case "start_time":
+			r, ok := value.(*q.Range)
+			if !ok {
+				return "", nil, errors.BadRequestError(nil).WithMessagef("the %s must be a range", key)
+			}
+			if r.Min != nil {
+				conds = append(conds, "start_time >= ?")
+				args = append(args, r.Min)
+			}
+			if r.Max != nil {
+				conds = append(conds, "start_time <= ?")
+				args = append(args, r.Max)
+			}
+		default:
+			return "", nil, errors.BadRequestError(nil).WithMessagef("unsupported query key %s for the execution statistics", key)
+		}
+	}
+	if len(conds) == 0 {
+		return "", nil, nil
+	}
+	return "where " + strings.Join(conds, " and "), args, nil
+}
+
 type jsonbStru struct {
 	keyPrefix string
 	key       string
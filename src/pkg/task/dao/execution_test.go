@@ -138,6 +138,27 @@ func (e *executionDAOTestSuite) TestUpdate() {
 	e.Equal("failed", execution.Status)
 }
 
+func (e *executionDAOTestSuite) TestIncreaseExtraAttrs() {
+	// not exist
+	_, err := e.executionDAO.IncreaseExtraAttrs(e.ctx, 10000, map[string]int64{"count": 1})
+	e.Require().NotNil(err)
+	e.True(errors.IsNotFoundErr(err))
+
+	err = e.executionDAO.Update(e.ctx, &Execution{
+		ID:         e.executionID,
+		ExtraAttrs: `{"key":"value","size":"invalid","count":2}`,
+	}, "ExtraAttrs")
+	e.Require().Nil(err)
+
+	values, err := e.executionDAO.IncreaseExtraAttrs(e.ctx, e.executionID, map[string]int64{"count": 3, "size": 10, "new": 1})
+	e.Require().Nil(err)
+	e.Equal(map[string]int64{"count": 5, "size": 10, "new": 1}, values)
+
+	execution, err := e.executionDAO.Get(e.ctx, e.executionID)
+	e.Require().Nil(err)
+	e.Contains(execution.ExtraAttrs, `"key": "value"`)
+}
+
 func (e *executionDAOTestSuite) TestDelete() {
 	// not exist
 	err := e.executionDAO.Delete(e.ctx, 10000)
@@ -390,6 +411,81 @@ func (e *executionDAOTestSuite) TestScanAndRefreshOutdateStatus() {
 	e.Equal(job.ErrorStatus.String(), exec2.Status)
 }
 
+func (e *executionDAOTestSuite) TestStats() {
+	now := time.Now()
+	id, err := e.executionDAO.Create(e.ctx, &Execution{
+		VendorType: "test_stats",
+		Trigger:    "MANUAL",
+		Status:     job.SuccessStatus.String(),
+		ExtraAttrs: "{}",
+		StartTime:  now.Add(-10 * time.Second),
+		EndTime:    now,
+	})
+	e.Require().Nil(err)
+	defer e.executionDAO.Delete(e.ctx, id)
+
+	taskID, err := e.taskDao.Create(e.ctx, &Task{
+		ExecutionID: id,
+		Status:      job.SuccessStatus.String(),
+		StatusCode:  job.SuccessStatus.Code(),
+		ExtraAttrs:  `{"bytes_transferred":1024}`,
+	})
+	e.Require().Nil(err)
+	defer e.taskDao.Delete(e.ctx, taskID)
+
+	// the non-numeric value is ignored
+	taskID2, err := e.taskDao.Create(e.ctx, &Task{
+		ExecutionID: id,
+		Status:      job.SuccessStatus.String(),
+		StatusCode:  job.SuccessStatus.Code(),
+		ExtraAttrs:  `{"bytes_transferred":"unknown"}`,
+	})
+	e.Require().Nil(err)
+	defer e.taskDao.Delete(e.ctx, taskID2)
+
+	id2, err := e.executionDAO.Create(e.ctx, &Execution{
+		VendorType: "test_stats",
+		Trigger:    "SCHEDULE",
+		Status:     job.ErrorStatus.String(),
+		ExtraAttrs: "{}",
+		StartTime:  now.Add(-30 * time.Second),
+		EndTime:    now,
+	})
+	e.Require().Nil(err)
+	defer e.executionDAO.Delete(e.ctx, id2)
+
+	stats, err := e.executionDAO.Stats(e.ctx, q.New(q.KeyWords{"vendor_type": "test_stats"}))
+	e.Require().Nil(err)
+	e.Require().Len(stats, 2)
+	// the overall statistics comes first
+	e.Equal("", stats[0].VendorType)
+	e.Equal("test_stats", stats[1].VendorType)
+	for _, s := range stats {
+		e.Equal(int64(2), s.Total)
+		e.Equal(int64(1), s.SuccessCount)
+		e.Equal(int64(1), s.ErrorCount)
+		e.Equal(0.5, s.SuccessRate)
+		e.InDelta(20, s.P50Duration, 0.01)
+		e.InDelta(29, s.P95Duration, 0.01)
+		e.Equal(int64(1024), s.BytesTransferred)
+	}
+
+	stats, err = e.executionDAO.Stats(e.ctx, q.New(q.KeyWords{"vendor_type": "test_stats", "trigger": "MANUAL"}))
+	e.Require().Nil(err)
+	e.Require().Len(stats, 2)
+	e.Equal(int64(1), stats[0].Total)
+	e.Equal(1.0, stats[0].SuccessRate)
+
+	// no matched executions
+	stats, err = e.executionDAO.Stats(e.ctx, q.New(q.KeyWords{"vendor_type": "non_existing"}))
+	e.Require().Nil(err)
+	e.Require().Len(stats, 1)
+	e.Equal(int64(0), stats[0].Total)
+
+	_, err = e.executionDAO.Stats(e.ctx, q.New(q.KeyWords{"vendor_id": 1}))
+	e.True(errors.IsErr(err, errors.BadRequestCode))
+}
+
 func TestExecutionDAOSuite(t *testing.T) {
 	suite.Run(t, &executionDAOTestSuite{})
 }
@@ -464,3 +560,43 @@ func Test_extractExecIDVendorFromKey(t *testing.T) {
 		})
 	}
 }
+
+func Test_statsFilter(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		query    *q.Query
+		wantSQL  string
+		wantArgs []any
+		wantErr  bool
+	}{
+		{"nil query", nil, "", nil, false},
+		{"exact match", q.New(q.KeyWords{"vendor_type": "REPLICATION", "status": "Error"}),
+			"where status = ? and vendor_type = ?", []any{"Error", "REPLICATION"}, false},
+		{"or list", q.New(q.KeyWords{"trigger": &q.OrList{Values: []any{"MANUAL", "SCHEDULE"}}}),
+			"where trigger in (?,?)", []any{"MANUAL", "SCHEDULE"}, false},
+		{"time range", q.New(q.KeyWords{"start_time": &q.Range{Min: start}}),
+			"where start_time >= ?", []any{start}, false},
+		{"invalid range", q.New(q.KeyWords{"start_time": start}), "", nil, true},
+		{"unsupported key", q.New(q.KeyWords{"extra_attrs.key": "value"}), "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := statsFilter(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("statsFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if sql != tt.wantSQL {
+				t.Errorf("statsFilter() sql = %v, want %v", sql, tt.wantSQL)
+			}
+			if len(args) != len(tt.wantArgs) {
+				t.Fatalf("statsFilter() args = %v, want %v", args, tt.wantArgs)
+			}
+			for i := range args {
+				if args[i] != tt.wantArgs[i] {
+					t.Errorf("statsFilter() args = %v, want %v", args, tt.wantArgs)
+				}
+			}
+		})
+	}
+}
@@ -56,6 +56,24 @@ type Metrics struct {
 	StoppedTaskCount   int64 `json:"stopped_task_count"`
 }
 
+// ExecutionStats is the aggregated statistics of the executions
+type ExecutionStats struct {
+	// VendorType is empty for the statistics across all the vendor types
+	VendorType   string `orm:"column(vendor_type)" json:"vendor_type,omitempty"`
+	Total        int64  `orm:"column(total)" json:"total"`
+	SuccessCount int64  `orm:"column(success_count)" json:"success_count"`
+	ErrorCount   int64  `orm:"column(error_count)" json:"error_count"`
+	StoppedCount int64  `orm:"column(stopped_count)" json:"stopped_count"`
+	RunningCount int64  `orm:"column(running_count)" json:"running_count"`
+	// SuccessRate is the ratio of the succeeded executions to the finished ones
+	SuccessRate float64 `orm:"-" json:"success_rate"`
+	// P50Duration and P95Duration are the percentiles of the durations(in seconds) of the finished executions
+	P50Duration float64 `orm:"column(p50_duration)" json:"p50_duration"`
+	P95Duration float64 `orm:"column(p95_duration)" json:"p95_duration"`
+	// BytesTransferred sums up the "bytes_transferred" extra attribute reported by the tasks
+	BytesTransferred int64 `orm:"column(bytes_transferred)" json:"bytes_transferred"`
+}
+
 // Task database model
 type Task struct {
 	ID             int64     `orm:"pk;auto;column(id)"`
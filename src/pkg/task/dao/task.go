@@ -45,6 +45,10 @@ type TaskDAO interface {
 	Update(ctx context.Context, task *Task, props ...string) (err error)
 	// UpdateStatus updates the status of task
 	UpdateStatus(ctx context.Context, id int64, status string, statusRevision int64) (err error)
+	// ResetStatus forces the status of the task regardless of the status code, the status revision is replaced
+	// to reject the late hooks of the previous job. It is an optimistic update which only takes effect when the
+	// update time of the task is still the specified one, "updated" reports whether the task is updated
+	ResetStatus(ctx context.Context, id int64, status, message string, statusRevision int64, updateTime time.Time) (updated bool, err error)
 	// Delete the specified task
 	Delete(ctx context.Context, id int64) (err error)
 	// ListStatusCount lists the status count for the tasks reference the specified execution
@@ -205,6 +209,32 @@ func (t *taskDAO) UpdateStatus(ctx context.Context, id int64, status string, sta
 	return err
 }
 
+func (t *taskDAO) ResetStatus(ctx context.Context, id int64, status, message string, statusRevision int64, updateTime time.Time) (bool, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	jobStatus := job.Status(status)
+	var endTime time.Time
+	now := time.Now()
+	if jobStatus.Final() {
+		endTime = now
+	}
+	sql := `update task set status = ?, status_code = ?, status_revision = ?, status_message = ?, update_time = ?, end_time = ? 
+		where id = ? and update_time = ?`
+	result, err := ormer.Raw(sql, status, jobStatus.Code(), statusRevision, message, now, endTime,
+		id, updateTime).Exec()
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
 func (t *taskDAO) Delete(ctx context.Context, id int64) error {
 	ormer, err := orm.FromContext(ctx)
 	if err != nil {
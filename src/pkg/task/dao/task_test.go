@@ -224,6 +224,46 @@ func (t *taskDAOTestSuite) TestUpdateStatus() {
 	t.Equal(time.Time{}, task.EndTime)
 }
 
+func (t *taskDAOTestSuite) TestResetStatus() {
+	statusRevision := time.Now().Unix()
+	err := t.taskDAO.UpdateStatus(t.ctx, t.taskID, job.RunningStatus.String(), statusRevision)
+	t.Require().Nil(err)
+	task, err := t.taskDAO.Get(t.ctx, t.taskID)
+	t.Require().Nil(err)
+
+	// the update time doesn't match
+	updated, err := t.taskDAO.ResetStatus(t.ctx, t.taskID, job.ErrorStatus.String(), "stuck",
+		statusRevision, task.UpdateTime.Add(-1*time.Second))
+	t.Require().Nil(err)
+	t.False(updated)
+
+	// reset to error
+	updated, err = t.taskDAO.ResetStatus(t.ctx, t.taskID, job.ErrorStatus.String(), "stuck",
+		statusRevision, task.UpdateTime)
+	t.Require().Nil(err)
+	t.True(updated)
+	task, err = t.taskDAO.Get(t.ctx, t.taskID)
+	t.Require().Nil(err)
+	t.Equal(job.ErrorStatus.String(), task.Status)
+	t.Equal(job.ErrorStatus.Code(), task.StatusCode)
+	t.Equal("stuck", task.StatusMessage)
+	t.NotEqual(time.Time{}, task.EndTime)
+
+	// reset to pending with a newer revision, the hooks of the previous job are rejected
+	newRevision := statusRevision + 1
+	updated, err = t.taskDAO.ResetStatus(t.ctx, t.taskID, job.PendingStatus.String(), "",
+		newRevision, task.UpdateTime)
+	t.Require().Nil(err)
+	t.True(updated)
+	err = t.taskDAO.UpdateStatus(t.ctx, t.taskID, job.StoppedStatus.String(), statusRevision)
+	t.Require().Nil(err)
+	task, err = t.taskDAO.Get(t.ctx, t.taskID)
+	t.Require().Nil(err)
+	t.Equal(job.PendingStatus.String(), task.Status)
+	t.Equal(newRevision, task.StatusRevision)
+	t.Equal(time.Time{}, task.EndTime)
+}
+
 func (t *taskDAOTestSuite) TestDelete() {
 	// not exist
 	err := t.taskDAO.Delete(t.ctx, 10000)
@@ -44,6 +44,9 @@ type ExecutionManager interface {
 		extraAttrs ...map[string]any) (id int64, err error)
 	// Update the extra attributes of the specified execution
 	UpdateExtraAttrs(ctx context.Context, id int64, extraAttrs map[string]any) (err error)
+	// IncreaseExtraAttrs increases the numeric extra attributes of the specified execution by the deltas atomically,
+	// and returns the values of the attributes after increasing
+	IncreaseExtraAttrs(ctx context.Context, id int64, deltas map[string]int64) (values map[string]int64, err error)
 	// MarkDone marks the status of the specified execution as success.
 	// It must be called to update the execution status if the created execution contains no tasks.
 	// In other cases, the execution status can be calculated from the referenced tasks automatically
@@ -74,6 +77,10 @@ type ExecutionManager interface {
 	// Count counts total of executions according to the query.
 	// Query the "ExtraAttrs" by setting 'query.Keywords["ExtraAttrs.key"]="value"'
 	Count(ctx context.Context, query *q.Query) (int64, error)
+	// Stats returns the statistics of the executions matching the query, the first one is across all the
+	// vendor types and followed by the ones of each vendor type.
+	// Only the "vendor_type", "status", "trigger" and the range of "start_time" are supported in the query
+	Stats(ctx context.Context, query *q.Query) (stats []*dao.ExecutionStats, err error)
 }
 
 // NewExecutionManager return an instance of the default execution manager
@@ -139,6 +146,10 @@ func (e *executionManager) UpdateExtraAttrs(ctx context.Context, id int64, extra
 	return e.executionDAO.Update(ctx, execution, "ExtraAttrs", "UpdateTime")
 }
 
+func (e *executionManager) IncreaseExtraAttrs(ctx context.Context, id int64, deltas map[string]int64) (map[string]int64, error) {
+	return e.executionDAO.IncreaseExtraAttrs(ctx, id, deltas)
+}
+
 func (e *executionManager) MarkDone(ctx context.Context, id int64, message string) error {
 	now := time.Now()
 	return e.executionDAO.Update(ctx, &dao.Execution{
@@ -340,6 +351,10 @@ func (e *executionManager) List(ctx context.Context, query *q.Query) ([]*Executi
 	return execs, nil
 }
 
+func (e *executionManager) Stats(ctx context.Context, query *q.Query) ([]*dao.ExecutionStats, error) {
+	return e.executionDAO.Stats(ctx, query)
+}
+
 func (e *executionManager) populateExecution(ctx context.Context, execution *dao.Execution) *Execution {
 	exec := &Execution{
 		ID:            execution.ID,
@@ -3,9 +3,14 @@
 package task
 
 import (
-	job "github.com/goharbor/harbor/src/jobservice/job"
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 
+	io "io"
+
+	job "github.com/goharbor/harbor/src/jobservice/job"
+
 	models "github.com/goharbor/harbor/src/common/job/models"
 )
 
@@ -14,6 +19,36 @@ type mockJobserviceClient struct {
 	mock.Mock
 }
 
+// FollowJobLog provides a mock function with given fields: ctx, uuid, offset
+func (_m *mockJobserviceClient) FollowJobLog(ctx context.Context, uuid string, offset int64) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, uuid, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FollowJobLog")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (io.ReadCloser, error)); ok {
+		return rf(ctx, uuid, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) io.ReadCloser); ok {
+		r0 = rf(ctx, uuid, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, uuid, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetExecutions provides a mock function with given fields: uuid
 func (_m *mockJobserviceClient) GetExecutions(uuid string) ([]job.Stats, error) {
 	ret := _m.Called(uuid)
@@ -104,6 +139,36 @@ func (_m *mockJobserviceClient) GetJobServiceConfig() (*job.Config, error) {
 	return r0, r1
 }
 
+// GetJobStats provides a mock function with given fields: uuid
+func (_m *mockJobserviceClient) GetJobStats(uuid string) (*job.Stats, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJobStats")
+	}
+
+	var r0 *job.Stats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*job.Stats, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) *job.Stats); ok {
+		r0 = rf(uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*job.Stats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PostAction provides a mock function with given fields: uuid, action
 func (_m *mockJobserviceClient) PostAction(uuid string, action string) error {
 	ret := _m.Called(uuid, action)
@@ -270,6 +270,34 @@ func (_m *mockTaskDAO) ListStatusCount(ctx context.Context, executionID int64) (
 	return r0, r1
 }
 
+// ResetStatus provides a mock function with given fields: ctx, id, status, message, statusRevision, updateTime
+func (_m *mockTaskDAO) ResetStatus(ctx context.Context, id int64, status string, message string, statusRevision int64, updateTime time.Time) (bool, error) {
+	ret := _m.Called(ctx, id, status, message, statusRevision, updateTime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetStatus")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string, int64, time.Time) (bool, error)); ok {
+		return rf(ctx, id, status, message, statusRevision, updateTime)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string, int64, time.Time) bool); ok {
+		r0 = rf(ctx, id, status, message, statusRevision, updateTime)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, string, int64, time.Time) error); ok {
+		r1 = rf(ctx, id, status, message, statusRevision, updateTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, _a1, props
 func (_m *mockTaskDAO) Update(ctx context.Context, _a1 *dao.Task, props ...string) error {
 	_va := make([]interface{}, len(props))
@@ -5,6 +5,8 @@ package task
 import (
 	context "context"
 
+	io "io"
+
 	q "github.com/goharbor/harbor/src/lib/q"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -77,6 +79,34 @@ func (_m *mockTaskManager) Create(ctx context.Context, executionID int64, job *J
 	return r0, r1
 }
 
+// CreateRecord provides a mock function with given fields: ctx, executionID, extraAttrs
+func (_m *mockTaskManager) CreateRecord(ctx context.Context, executionID int64, extraAttrs map[string]interface{}) (int64, error) {
+	ret := _m.Called(ctx, executionID, extraAttrs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRecord")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, map[string]interface{}) (int64, error)); ok {
+		return rf(ctx, executionID, extraAttrs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, map[string]interface{}) int64); ok {
+		r0 = rf(ctx, executionID, extraAttrs)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, map[string]interface{}) error); ok {
+		r1 = rf(ctx, executionID, extraAttrs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExecutionIDsByVendorAndStatus provides a mock function with given fields: ctx, vendorType, status
 func (_m *mockTaskManager) ExecutionIDsByVendorAndStatus(ctx context.Context, vendorType string, status string) ([]int64, error) {
 	ret := _m.Called(ctx, vendorType, status)
@@ -107,6 +137,36 @@ func (_m *mockTaskManager) ExecutionIDsByVendorAndStatus(ctx context.Context, ve
 	return r0, r1
 }
 
+// FollowLog provides a mock function with given fields: ctx, id, offset
+func (_m *mockTaskManager) FollowLog(ctx context.Context, id int64, offset int64) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, id, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FollowLog")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (io.ReadCloser, error)); ok {
+		return rf(ctx, id, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) io.ReadCloser); ok {
+		r0 = rf(ctx, id, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, id, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: ctx, id
 func (_m *mockTaskManager) Get(ctx context.Context, id int64) (*Task, error) {
 	ret := _m.Called(ctx, id)
@@ -311,6 +371,24 @@ func (_m *mockTaskManager) Stop(ctx context.Context, id int64) error {
 	return r0
 }
 
+// Submit provides a mock function with given fields: ctx, id, job
+func (_m *mockTaskManager) Submit(ctx context.Context, id int64, job *Job) error {
+	ret := _m.Called(ctx, id, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Submit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *Job) error); ok {
+		r0 = rf(ctx, id, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, _a1, props
 func (_m *mockTaskManager) Update(ctx context.Context, _a1 *Task, props ...string) error {
 	_va := make([]interface{}, len(props))
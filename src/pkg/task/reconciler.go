@@ -0,0 +1,296 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	cjob "github.com/goharbor/harbor/src/common/job"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/gtask"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/task/dao"
+)
+
+const (
+	// RelaunchCountAttr is the key of the task extra attribute recording how many times the task is re-launched
+	RelaunchCountAttr = "relaunch_count"
+	// reconcileBatchSize is the count of tasks reconciled in one batch
+	reconcileBatchSize = 100
+	// schedulerVendorType is the vendor type of the periodic jobs owned by the scheduler, keep it
+	// same with scheduler.JobNameScheduler which cannot be imported here to avoid the import cycle
+	schedulerVendorType = "SCHEDULER"
+)
+
+func init() {
+	// register the stuck task reconciler if it is enabled
+	if interval := config.GetTaskReconcileIntervalSeconds(); interval > 0 {
+		gtask.DefaultPool().AddTask(reconcileStuckTasks, time.Duration(interval)*time.Second)
+	}
+}
+
+// reconcileStuckTasks compares the status of the unfinished tasks with the jobs in jobservice,
+// do not want to expose to external use so keep it as private.
+func reconcileStuckTasks(ctx context.Context) {
+	timeout := time.Duration(config.GetTaskHeartbeatTimeoutSeconds()) * time.Second
+	if timeout <= 0 {
+		log.Debug("skip to reconcile the stuck tasks, the heartbeat timeout isn't set")
+		return
+	}
+	r := &reconciler{
+		mgr: &manager{
+			dao:      dao.NewTaskDAO(),
+			execDAO:  dao.NewExecutionDAO(),
+			jsClient: cjob.GlobalClient,
+			coreURL:  config.GetCoreURL(),
+		},
+	}
+	r.reconcile(ctx, timeout, config.GetTaskMaxRelaunches())
+}
+
+// reconciler detects the tasks which stay unfinished forever because the jobs are lost in jobservice or
+// stop reporting heartbeat, e.g. jobservice restarts or Redis loses state. The stuck tasks are marked as
+// error, or re-launched if the vendor type registers a RelaunchFunc.
+type reconciler struct {
+	mgr *manager
+}
+
+func (r *reconciler) reconcile(ctx context.Context, timeout time.Duration, maxRelaunches int64) {
+	deadline := time.Now().Add(-timeout)
+	var lastID, stuck int64
+	for {
+		tasks, err := r.mgr.dao.List(ctx, &q.Query{
+			Keywords: map[string]any{
+				"Status": &q.OrList{
+					Values: []any{job.PendingStatus.String(), job.RunningStatus.String()},
+				},
+				"UpdateTime": &q.Range{Max: deadline},
+				"ID":         &q.Range{Min: lastID + 1},
+			},
+			Sorts: []*q.Sort{
+				{
+					Key: "ID",
+				},
+			},
+			PageSize: reconcileBatchSize,
+		})
+		if err != nil {
+			log.Errorf("failed to list the unfinished tasks: %v", err)
+			return
+		}
+		for _, task := range tasks {
+			lastID = task.ID
+			// the scheduled tasks are the periodic jobs owned by the scheduler, skip them
+			if task.VendorType == schedulerVendorType {
+				continue
+			}
+			if r.reconcileTask(ctx, task, deadline, maxRelaunches) {
+				stuck++
+			}
+		}
+		if len(tasks) < reconcileBatchSize {
+			break
+		}
+	}
+	if stuck > 0 {
+		log.Infof("%d stuck tasks are reconciled", stuck)
+	}
+}
+
+// reconcileTask reconciles the single task, returns true if the task is stuck
+func (r *reconciler) reconcileTask(ctx context.Context, task *dao.Task, deadline time.Time, maxRelaunches int64) bool {
+	var stats *job.Stats
+	if len(task.JobID) > 0 {
+		var err error
+		stats, err = r.mgr.jsClient.GetJobStats(task.JobID)
+		if err != nil && err != cjob.ErrJobNotFound {
+			log.Errorf("failed to get the stats of job %s for task %d: %v", task.JobID, task.ID, err)
+			return false
+		}
+	}
+
+	reason, final := diagnose(stats, deadline)
+	if len(final) > 0 {
+		// the status hook is missed, sync the status from jobservice
+		log.Infof("the job %s of task %d is %s in jobservice, sync the status", task.JobID, task.ID, final)
+		if err := r.mgr.dao.UpdateStatus(ctx, task.ID, final, stats.Info.Revision); err != nil {
+			log.Errorf("failed to sync the status of task %d: %v", task.ID, err)
+			return false
+		}
+		r.postStatusChange(ctx, task.ID, task.ExecutionID, task.VendorType, final)
+		return true
+	}
+	if len(reason) == 0 {
+		return false
+	}
+
+	log.Warningf("the task %d is stuck: %s", task.ID, reason)
+	if fc, exist := relaunchFuncRegistry[task.VendorType]; exist && fc != nil {
+		exec, err := r.mgr.execDAO.Get(ctx, task.ExecutionID)
+		if err != nil {
+			log.Errorf("failed to get the execution %d of task %d: %v", task.ExecutionID, task.ID, err)
+			return false
+		}
+		t := &Task{}
+		t.From(task)
+		if job.Status(exec.Status).Final() {
+			// the execution is stopped or finished, don't bring its tasks back
+			reason = fmt.Sprintf("%s, the execution %d is %s", reason, exec.ID, exec.Status)
+		} else if count := relaunchCount(t); count >= maxRelaunches {
+			reason = fmt.Sprintf("%s, the task has been re-launched %d times", reason, count)
+		} else if jb, err := fc(ctx, t); err != nil {
+			reason = fmt.Sprintf("%s, failed to build the job to re-launch: %v", reason, err)
+		} else {
+			if err = r.relaunch(ctx, t, jb, reason, count); err != nil {
+				log.Errorf("failed to re-launch the stuck task %d: %v", task.ID, err)
+			}
+			return true
+		}
+	}
+
+	updated, err := r.mgr.dao.ResetStatus(ctx, task.ID, job.ErrorStatus.String(), reason, task.StatusRevision, task.UpdateTime)
+	if err != nil {
+		log.Errorf("failed to mark the stuck task %d as error: %v", task.ID, err)
+		return false
+	}
+	if !updated {
+		log.Debugf("the task %d is updated during the reconciling, skip it", task.ID)
+		return false
+	}
+	r.stopJob(task.JobID)
+	r.postStatusChange(ctx, task.ID, task.ExecutionID, task.VendorType, job.ErrorStatus.String())
+	return true
+}
+
+// diagnose checks the job stats got from jobservice, the nil stats means the job is lost. Returns the
+// reason if the task is stuck or the final status if the job is done but the task isn't notified
+func diagnose(stats *job.Stats, deadline time.Time) (reason string, final string) {
+	if stats == nil || stats.Info == nil {
+		return "the job is lost in jobservice", ""
+	}
+	info := stats.Info
+	status := job.Status(info.Status)
+	if status.Final() {
+		return "", status.String()
+	}
+	if status != job.RunningStatus {
+		// pending, scheduled or retrying jobs are still tracked by jobservice
+		return "", ""
+	}
+	heartbeat := max(info.HeartbeatAt, info.CheckInAt, info.UpdateTime)
+	if heartbeat < deadline.Unix() {
+		return fmt.Sprintf("no heartbeat from the job since %s", time.Unix(heartbeat, 0).UTC().Format(time.RFC3339)), ""
+	}
+	return "", ""
+}
+
+func (r *reconciler) relaunch(ctx context.Context, task *Task, jb *Job, reason string, count int64) error {
+	// bump the status revision to reject the late hooks of the stuck job
+	revision := time.Now().Unix()
+	if revision <= task.StatusRevision {
+		revision = task.StatusRevision + 1
+	}
+	message := fmt.Sprintf("re-launched as %s", reason)
+	updated, err := r.mgr.dao.ResetStatus(ctx, task.ID, job.PendingStatus.String(), message, revision, task.UpdateTime)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		log.Debugf("the task %d is updated during the reconciling, skip it", task.ID)
+		return nil
+	}
+	r.stopJob(task.JobID)
+
+	jobID, err := r.mgr.submitJob(ctx, task.ExecutionID, task.ID, jb)
+	if err != nil {
+		now := time.Now()
+		if e := r.mgr.dao.Update(ctx, &dao.Task{
+			ID:            task.ID,
+			Status:        job.ErrorStatus.String(),
+			StatusCode:    job.ErrorStatus.Code(),
+			StatusMessage: fmt.Sprintf("failed to re-launch: %v", err),
+			UpdateTime:    now,
+			EndTime:       now,
+		}, "Status", "StatusCode", "StatusMessage", "UpdateTime", "EndTime"); e != nil {
+			log.Errorf("failed to mark the task %d as error: %v", task.ID, e)
+		}
+		r.postStatusChange(ctx, task.ID, task.ExecutionID, task.VendorType, job.ErrorStatus.String())
+		return err
+	}
+
+	extras := task.ExtraAttrs
+	if extras == nil {
+		extras = map[string]any{}
+	}
+	extras[RelaunchCountAttr] = count + 1
+	data, err := json.Marshal(extras)
+	if err != nil {
+		return err
+	}
+	if err = r.mgr.dao.Update(ctx, &dao.Task{
+		ID:         task.ID,
+		JobID:      jobID,
+		ExtraAttrs: string(data),
+	}, "JobID", "ExtraAttrs"); err != nil {
+		return err
+	}
+	log.Infof("the stuck task %d is re-launched as job %s", task.ID, jobID)
+	return nil
+}
+
+// stopJob stops the stuck job in best effort in case it is still alive
+func (r *reconciler) stopJob(jobID string) {
+	if len(jobID) == 0 {
+		return
+	}
+	if err := r.mgr.jsClient.PostAction(jobID, string(job.StopCommand)); err != nil && err != cjob.ErrJobNotFound {
+		log.Debugf("failed to stop the stuck job %s: %v", jobID, err)
+	}
+}
+
+// postStatusChange runs the status change post functions and refreshes the execution status
+func (r *reconciler) postStatusChange(ctx context.Context, taskID, executionID int64, vendorType, status string) {
+	if fc, exist := statusChangePostFuncRegistry[vendorType]; exist && fc != nil {
+		if err := fc(ctx, taskID, status); err != nil {
+			log.Errorf("failed to run the task status change post function for task %d: %v", taskID, err)
+		}
+	}
+	statusChanged, currentStatus, err := r.mgr.execDAO.RefreshStatus(ctx, executionID)
+	if err != nil {
+		log.Errorf("failed to refresh the status of execution %d: %v", executionID, err)
+		return
+	}
+	if fc, exist := executionStatusChangePostFuncRegistry[vendorType]; exist && fc != nil && statusChanged {
+		if err = fc(ctx, executionID, currentStatus); err != nil {
+			log.Errorf("failed to run the execution status change post function for execution %d: %v", executionID, err)
+		}
+	}
+}
+
+func relaunchCount(task *Task) int64 {
+	if len(task.ExtraAttrs) == 0 {
+		return 0
+	}
+	// the numbers are unmarshalled as float64 from the json string
+	if count, ok := task.ExtraAttrs[RelaunchCountAttr].(float64); ok {
+		return int64(count)
+	}
+	return 0
+}
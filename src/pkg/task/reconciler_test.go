@@ -0,0 +1,227 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	cjob "github.com/goharbor/harbor/src/common/job"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/pkg/task/dao"
+)
+
+func TestDiagnose(t *testing.T) {
+	now := time.Now()
+	deadline := now.Add(-time.Hour)
+
+	// lost
+	reason, final := diagnose(nil, deadline)
+	assert.NotEmpty(t, reason)
+	assert.Empty(t, final)
+
+	// done but not notified
+	reason, final = diagnose(&job.Stats{Info: &job.StatsInfo{Status: job.SuccessStatus.String()}}, deadline)
+	assert.Empty(t, reason)
+	assert.Equal(t, job.SuccessStatus.String(), final)
+
+	// pending in jobservice
+	reason, final = diagnose(&job.Stats{Info: &job.StatsInfo{Status: job.PendingStatus.String()}}, deadline)
+	assert.Empty(t, reason)
+	assert.Empty(t, final)
+
+	// running with heartbeat
+	reason, final = diagnose(&job.Stats{Info: &job.StatsInfo{
+		Status:      job.RunningStatus.String(),
+		UpdateTime:  now.Add(-2 * time.Hour).Unix(),
+		HeartbeatAt: now.Unix(),
+	}}, deadline)
+	assert.Empty(t, reason)
+	assert.Empty(t, final)
+
+	// running without heartbeat
+	reason, final = diagnose(&job.Stats{Info: &job.StatsInfo{
+		Status:     job.RunningStatus.String(),
+		UpdateTime: now.Add(-2 * time.Hour).Unix(),
+	}}, deadline)
+	assert.Contains(t, reason, "no heartbeat")
+	assert.Empty(t, final)
+}
+
+type reconcilerTestSuite struct {
+	suite.Suite
+	r        *reconciler
+	dao      *mockTaskDAO
+	execDAO  *mockExecutionDAO
+	jsClient *mockJobserviceClient
+}
+
+func (r *reconcilerTestSuite) SetupTest() {
+	r.dao = &mockTaskDAO{}
+	r.execDAO = &mockExecutionDAO{}
+	r.jsClient = &mockJobserviceClient{}
+	r.r = &reconciler{
+		mgr: &manager{
+			dao:      r.dao,
+			execDAO:  r.execDAO,
+			jsClient: r.jsClient,
+		},
+	}
+}
+
+func (r *reconcilerTestSuite) task(extraAttrs string) *dao.Task {
+	return &dao.Task{
+		ID:             1,
+		VendorType:     "reconcile-test",
+		ExecutionID:    1,
+		JobID:          "job-1",
+		Status:         job.RunningStatus.String(),
+		StatusRevision: 100,
+		ExtraAttrs:     extraAttrs,
+		UpdateTime:     time.Now().Add(-2 * time.Hour),
+	}
+}
+
+func (r *reconcilerTestSuite) TestLostJob() {
+	r.dao.On("List", mock.Anything, mock.Anything).Return([]*dao.Task{r.task("")}, nil)
+	r.jsClient.On("GetJobStats", "job-1").Return(nil, cjob.ErrJobNotFound)
+	r.dao.On("ResetStatus", mock.Anything, int64(1), job.ErrorStatus.String(), mock.Anything,
+		int64(100), mock.Anything).Return(true, nil)
+	r.jsClient.On("PostAction", "job-1", string(job.StopCommand)).Return(cjob.ErrJobNotFound)
+	r.execDAO.On("RefreshStatus", mock.Anything, int64(1)).Return(true, job.ErrorStatus.String(), nil)
+
+	r.r.reconcile(context.TODO(), time.Hour, 3)
+	r.dao.AssertExpectations(r.T())
+	r.jsClient.AssertExpectations(r.T())
+	r.execDAO.AssertExpectations(r.T())
+}
+
+func (r *reconcilerTestSuite) TestJobServiceError() {
+	r.dao.On("List", mock.Anything, mock.Anything).Return([]*dao.Task{r.task("")}, nil)
+	r.jsClient.On("GetJobStats", "job-1").Return(nil, errors.New("error"))
+
+	r.r.reconcile(context.TODO(), time.Hour, 3)
+	r.dao.AssertNotCalled(r.T(), "ResetStatus", mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (r *reconcilerTestSuite) TestMissedHook() {
+	r.dao.On("List", mock.Anything, mock.Anything).Return([]*dao.Task{r.task("")}, nil)
+	r.jsClient.On("GetJobStats", "job-1").Return(&job.Stats{Info: &job.StatsInfo{
+		Status:   job.SuccessStatus.String(),
+		Revision: 100,
+	}}, nil)
+	r.dao.On("UpdateStatus", mock.Anything, int64(1), job.SuccessStatus.String(), int64(100)).Return(nil)
+	r.execDAO.On("RefreshStatus", mock.Anything, int64(1)).Return(true, job.SuccessStatus.String(), nil)
+
+	r.r.reconcile(context.TODO(), time.Hour, 3)
+	r.dao.AssertExpectations(r.T())
+	r.execDAO.AssertExpectations(r.T())
+}
+
+func (r *reconcilerTestSuite) TestHealthy() {
+	r.dao.On("List", mock.Anything, mock.Anything).Return([]*dao.Task{r.task("")}, nil)
+	r.jsClient.On("GetJobStats", "job-1").Return(&job.Stats{Info: &job.StatsInfo{
+		Status:      job.RunningStatus.String(),
+		HeartbeatAt: time.Now().Unix(),
+	}}, nil)
+
+	r.r.reconcile(context.TODO(), time.Hour, 3)
+	r.dao.AssertNotCalled(r.T(), "ResetStatus", mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything)
+	r.dao.AssertNotCalled(r.T(), "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (r *reconcilerTestSuite) TestRelaunch() {
+	relaunchFuncRegistry["reconcile-test"] = func(_ context.Context, _ *Task) (*Job, error) {
+		return &Job{Name: "test"}, nil
+	}
+	defer delete(relaunchFuncRegistry, "reconcile-test")
+
+	r.dao.On("List", mock.Anything, mock.Anything).Return([]*dao.Task{r.task(`{"relaunch_count":1}`)}, nil)
+	r.jsClient.On("GetJobStats", "job-1").Return(nil, cjob.ErrJobNotFound)
+	r.execDAO.On("Get", mock.Anything, int64(1)).Return(&dao.Execution{ID: 1, Status: job.RunningStatus.String()}, nil)
+	r.dao.On("ResetStatus", mock.Anything, int64(1), job.PendingStatus.String(), mock.Anything,
+		mock.Anything, mock.Anything).Return(true, nil)
+	r.jsClient.On("PostAction", "job-1", string(job.StopCommand)).Return(cjob.ErrJobNotFound)
+	r.jsClient.On("SubmitJob", mock.Anything).Return("job-2", nil)
+	r.dao.On("Update", mock.Anything, &dao.Task{
+		ID:         1,
+		JobID:      "job-2",
+		ExtraAttrs: `{"relaunch_count":2}`,
+	}, "JobID", "ExtraAttrs").Return(nil)
+
+	r.r.reconcile(context.TODO(), time.Hour, 3)
+	r.dao.AssertExpectations(r.T())
+	r.jsClient.AssertExpectations(r.T())
+}
+
+func (r *reconcilerTestSuite) TestRelaunchExceeded() {
+	relaunchFuncRegistry["reconcile-test"] = func(_ context.Context, _ *Task) (*Job, error) {
+		return &Job{Name: "test"}, nil
+	}
+	defer delete(relaunchFuncRegistry, "reconcile-test")
+
+	r.dao.On("List", mock.Anything, mock.Anything).Return([]*dao.Task{r.task(`{"relaunch_count":3}`)}, nil)
+	r.jsClient.On("GetJobStats", "job-1").Return(nil, cjob.ErrJobNotFound)
+	r.execDAO.On("Get", mock.Anything, int64(1)).Return(&dao.Execution{ID: 1, Status: job.RunningStatus.String()}, nil)
+	r.dao.On("ResetStatus", mock.Anything, int64(1), job.ErrorStatus.String(), mock.Anything,
+		int64(100), mock.Anything).Return(true, nil)
+	r.jsClient.On("PostAction", "job-1", string(job.StopCommand)).Return(cjob.ErrJobNotFound)
+	r.execDAO.On("RefreshStatus", mock.Anything, int64(1)).Return(true, job.ErrorStatus.String(), nil)
+
+	r.r.reconcile(context.TODO(), time.Hour, 3)
+	r.dao.AssertExpectations(r.T())
+	r.jsClient.AssertNotCalled(r.T(), "SubmitJob", mock.Anything)
+}
+
+func (r *reconcilerTestSuite) TestRelaunchStoppedExecution() {
+	relaunchFuncRegistry["reconcile-test"] = func(_ context.Context, _ *Task) (*Job, error) {
+		return &Job{Name: "test"}, nil
+	}
+	defer delete(relaunchFuncRegistry, "reconcile-test")
+
+	r.dao.On("List", mock.Anything, mock.Anything).Return([]*dao.Task{r.task("")}, nil)
+	r.jsClient.On("GetJobStats", "job-1").Return(nil, cjob.ErrJobNotFound)
+	r.execDAO.On("Get", mock.Anything, int64(1)).Return(&dao.Execution{ID: 1, Status: job.StoppedStatus.String()}, nil)
+	r.dao.On("ResetStatus", mock.Anything, int64(1), job.ErrorStatus.String(), mock.Anything,
+		int64(100), mock.Anything).Return(true, nil)
+	r.jsClient.On("PostAction", "job-1", string(job.StopCommand)).Return(cjob.ErrJobNotFound)
+	r.execDAO.On("RefreshStatus", mock.Anything, int64(1)).Return(false, job.StoppedStatus.String(), nil)
+
+	r.r.reconcile(context.TODO(), time.Hour, 3)
+	r.dao.AssertExpectations(r.T())
+	r.execDAO.AssertExpectations(r.T())
+	r.jsClient.AssertNotCalled(r.T(), "SubmitJob", mock.Anything)
+}
+
+func (r *reconcilerTestSuite) TestSchedulerTask() {
+	task := r.task("")
+	task.VendorType = schedulerVendorType
+	r.dao.On("List", mock.Anything, mock.Anything).Return([]*dao.Task{task}, nil)
+
+	r.r.reconcile(context.TODO(), time.Hour, 3)
+	r.jsClient.AssertNotCalled(r.T(), "GetJobStats", mock.Anything)
+}
+
+func TestReconcilerTestSuite(t *testing.T) {
+	suite.Run(t, &reconcilerTestSuite{})
+}
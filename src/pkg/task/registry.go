@@ -25,6 +25,7 @@ var (
 	checkInProcessorRegistry              = map[string]CheckInProcessor{}
 	statusChangePostFuncRegistry          = map[string]StatusChangePostFunc{}
 	executionStatusChangePostFuncRegistry = map[string]ExecutionStatusChangePostFunc{}
+	relaunchFuncRegistry                  = map[string]RelaunchFunc{}
 )
 
 // CheckInProcessor is the processor to process the check in data which is sent by jobservice via webhook
@@ -36,6 +37,10 @@ type StatusChangePostFunc func(ctx context.Context, taskID int64, status string)
 // ExecutionStatusChangePostFunc is the function called after the execution status changed
 type ExecutionStatusChangePostFunc func(ctx context.Context, executionID int64, status string) (err error)
 
+// RelaunchFunc builds the job to re-launch the stuck task, registering it declares the jobs of the vendor type
+// are idempotent and safe to be run again
+type RelaunchFunc func(ctx context.Context, task *Task) (job *Job, err error)
+
 // RegisterCheckInProcessor registers check in processor for the specific vendor type
 func RegisterCheckInProcessor(vendorType string, processor CheckInProcessor) error {
 	if _, exist := checkInProcessorRegistry[vendorType]; exist {
@@ -62,3 +67,12 @@ func RegisterExecutionStatusChangePostFunc(vendorType string, fc ExecutionStatus
 	executionStatusChangePostFuncRegistry[vendorType] = fc
 	return nil
 }
+
+// RegisterRelaunchFunc registers a relaunch function for the specific vendor type
+func RegisterRelaunchFunc(vendorType string, fc RelaunchFunc) error {
+	if _, exist := relaunchFuncRegistry[vendorType]; exist {
+		return fmt.Errorf("the relaunch function for %s already exists", vendorType)
+	}
+	relaunchFuncRegistry[vendorType] = fc
+	return nil
+}
@@ -46,3 +46,12 @@ func TestRegisterExecutionStatusChangePostFunc(t *testing.T) {
 	err = RegisterExecutionStatusChangePostFunc("test", nil)
 	assert.NotNil(t, err)
 }
+
+func TestRegisterRelaunchFunc(t *testing.T) {
+	err := RegisterRelaunchFunc("test", nil)
+	assert.Nil(t, err)
+
+	// already exist
+	err = RegisterRelaunchFunc("test", nil)
+	assert.NotNil(t, err)
+}
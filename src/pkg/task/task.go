@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"time"
 
 	cjob "github.com/goharbor/harbor/src/common/job"
@@ -42,6 +43,12 @@ type Manager interface {
 	// An execution must be created first and the task will be linked to it.
 	// The "extraAttrs" can be used to set the customized attributes
 	Create(ctx context.Context, executionID int64, job *Job, extraAttrs ...map[string]any) (id int64, err error)
+	// CreateRecord only creates the task record without submitting the job, the job is submitted by Submit
+	// later. It's used when the task records must be committed before the jobs start running
+	CreateRecord(ctx context.Context, executionID int64, extraAttrs map[string]any) (id int64, err error)
+	// Submit submits the job of the task created by CreateRecord to jobservice, the task is marked as error
+	// if the job cannot be submitted
+	Submit(ctx context.Context, id int64, job *Job) (err error)
 	// Stop the specified task
 	Stop(ctx context.Context, id int64) (err error)
 	// Get the specified task
@@ -53,6 +60,9 @@ type Manager interface {
 	UpdateExtraAttrs(ctx context.Context, id int64, extraAttrs map[string]any) (err error)
 	// Get the log of the specified task
 	GetLog(ctx context.Context, id int64) (log []byte, err error)
+	// FollowLog follows the log of the specified task from the offset as a stream of Server-Sent Events
+	// which ends when the task is done, the caller is responsible for closing the stream
+	FollowLog(ctx context.Context, id int64, offset int64) (stream io.ReadCloser, err error)
 	// GetLogByJobID get the log of specified job id
 	GetLogByJobID(ctx context.Context, jobID string) (log []byte, err error)
 	// Count counts total of tasks according to the query.
@@ -114,7 +124,7 @@ func (m *manager) Create(ctx context.Context, executionID int64, jb *Job, extraA
 	// when the job is submitted to the jobservice and running, the task record may not
 	// insert yet, this will cause the status hook handler returning 404, and the jobservice
 	// will re-send the status hook again
-	jobID, err := m.submitJob(ctx, id, jb)
+	jobID, err := m.submitJob(ctx, executionID, id, jb)
 	if err != nil {
 		// failed to submit job to jobservice, delete the task record
 		log.Errorf("delete task %d from db due to failed to submit job %v, error: %v", id, jb.Name, err)
@@ -137,6 +147,45 @@ func (m *manager) Create(ctx context.Context, executionID int64, jb *Job, extraA
 	return id, nil
 }
 
+func (m *manager) CreateRecord(ctx context.Context, executionID int64, extraAttrs map[string]any) (int64, error) {
+	return m.createTaskRecord(ctx, executionID, extraAttrs)
+}
+
+func (m *manager) Submit(ctx context.Context, id int64, jb *Job) error {
+	task, err := m.dao.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	jobID, err := m.submitJob(ctx, task.ExecutionID, id, jb)
+	if err != nil {
+		// the task record is kept for the audit, mark it as error as no status hook will be sent
+		log.Errorf("failed to submit the job %v of task %d: %v", jb.Name, id, err)
+		now := time.Now()
+		if e := m.dao.Update(ctx, &dao.Task{
+			ID:            id,
+			Status:        job.ErrorStatus.String(),
+			StatusCode:    job.ErrorStatus.Code(),
+			StatusMessage: fmt.Sprintf("failed to submit the job: %v", err),
+			UpdateTime:    now,
+			EndTime:       now,
+		}, "Status", "StatusCode", "StatusMessage", "UpdateTime", "EndTime"); e != nil {
+			log.Errorf("failed to mark the task %d as error: %v", id, e)
+		}
+		if _, _, e := m.execDAO.RefreshStatus(ctx, task.ExecutionID); e != nil {
+			log.Errorf("failed to refresh the status of execution %d: %v", task.ExecutionID, e)
+		}
+		return err
+	}
+
+	log.Debugf("the task %d is submitted to jobservice, the job ID is %s", id, jobID)
+
+	return m.dao.Update(ctx, &dao.Task{
+		ID:    id,
+		JobID: jobID,
+	}, "JobID")
+}
+
 func (m *manager) createTaskRecord(ctx context.Context, executionID int64, extraAttrs ...map[string]any) (int64, error) {
 	exec, err := m.execDAO.Get(ctx, executionID)
 	if err != nil {
@@ -163,7 +212,7 @@ func (m *manager) createTaskRecord(ctx context.Context, executionID int64, extra
 	})
 }
 
-func (m *manager) submitJob(_ context.Context, id int64, jb *Job) (string, error) {
+func (m *manager) submitJob(_ context.Context, executionID, id int64, jb *Job) (string, error) {
 	jobData := &models.JobData{
 		Name:       jb.Name,
 		StatusHook: fmt.Sprintf("%s/service/notifications/tasks/%d", m.coreURL, id),
@@ -173,10 +222,13 @@ func (m *manager) submitJob(_ context.Context, id int64, jb *Job) (string, error
 	}
 	if jb.Metadata != nil {
 		jobData.Metadata = &models.JobMetadata{
-			JobKind:       jb.Metadata.JobKind,
-			ScheduleDelay: jb.Metadata.ScheduleDelay,
-			Cron:          jb.Metadata.Cron,
-			IsUnique:      jb.Metadata.IsUnique,
+			JobKind:         jb.Metadata.JobKind,
+			ScheduleDelay:   jb.Metadata.ScheduleDelay,
+			Cron:            jb.Metadata.Cron,
+			IsUnique:        jb.Metadata.IsUnique,
+			Tenant:          jb.Metadata.Tenant,
+			ExecutionID:     executionID,
+			ScheduleOptions: jb.Metadata.ScheduleOptions,
 		}
 	}
 
@@ -275,6 +327,14 @@ func (m *manager) GetLog(ctx context.Context, id int64) ([]byte, error) {
 	return m.jsClient.GetJobLog(task.JobID)
 }
 
+func (m *manager) FollowLog(ctx context.Context, id int64, offset int64) (io.ReadCloser, error) {
+	task, err := m.dao.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return m.jsClient.FollowJobLog(ctx, task.JobID, offset)
+}
+
 func (m *manager) UpdateStatusInBatch(ctx context.Context, jobIDs []string, status string, batchSize int) error {
 	return m.dao.UpdateStatusInBatch(ctx, jobIDs, status, batchSize)
 }
@@ -84,6 +84,33 @@ func (t *taskManagerTestSuite) TestCreate() {
 	t.jsClient.AssertExpectations(t.T())
 }
 
+func (t *taskManagerTestSuite) TestSubmit() {
+	// success to submit job to jobservice
+	t.dao.On("Get", mock.Anything, int64(1)).Return(&dao.Task{ID: 1, ExecutionID: 1}, nil)
+	t.jsClient.On("SubmitJob", mock.Anything).Return("1", nil)
+	t.dao.On("Update", mock.Anything, &dao.Task{ID: 1, JobID: "1"}, "JobID").Return(nil)
+
+	err := t.mgr.Submit(nil, 1, &Job{})
+	t.Require().Nil(err)
+	t.dao.AssertExpectations(t.T())
+	t.jsClient.AssertExpectations(t.T())
+
+	// reset mock
+	t.SetupTest()
+
+	// failed to submit job to jobservice, the task is marked as error
+	t.dao.On("Get", mock.Anything, int64(1)).Return(&dao.Task{ID: 1, ExecutionID: 1}, nil)
+	t.jsClient.On("SubmitJob", mock.Anything).Return("", errors.New("error"))
+	t.dao.On("Update", mock.Anything, mock.Anything, "Status", "StatusCode", "StatusMessage", "UpdateTime", "EndTime").Return(nil)
+	t.execDAO.On("RefreshStatus", mock.Anything, int64(1)).Return(true, job.ErrorStatus.String(), nil)
+
+	err = t.mgr.Submit(nil, 1, &Job{})
+	t.Require().NotNil(err)
+	t.dao.AssertExpectations(t.T())
+	t.execDAO.AssertExpectations(t.T())
+	t.dao.AssertNotCalled(t.T(), "Delete", mock.Anything, mock.Anything)
+}
+
 func (t *taskManagerTestSuite) TestStop() {
 	// job not found
 	t.dao.On("Get", mock.Anything, mock.Anything).Return(&dao.Task{
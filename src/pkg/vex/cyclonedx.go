@@ -0,0 +1,158 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vex
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+// cycloneDXDocument is the subset of the CycloneDX document needed by the VEX,
+// see https://cyclonedx.org/capabilities/vex/
+type cycloneDXDocument struct {
+	BOMFormat string `json:"bomFormat"`
+	Metadata  *struct {
+		Timestamp *time.Time          `json:"timestamp"`
+		Component *cycloneDXComponent `json:"component"`
+	} `json:"metadata"`
+	Components      []*cycloneDXComponent     `json:"components"`
+	Vulnerabilities []*cycloneDXVulnerability `json:"vulnerabilities"`
+}
+
+type cycloneDXComponent struct {
+	BOMRef     string                `json:"bom-ref"`
+	Name       string                `json:"name"`
+	Version    string                `json:"version"`
+	PURL       string                `json:"purl"`
+	Components []*cycloneDXComponent `json:"components"`
+}
+
+// identifier returns the purl of the component if present, otherwise name@version
+func (c *cycloneDXComponent) identifier() string {
+	if c.PURL != "" {
+		return c.PURL
+	}
+	if c.Version != "" {
+		return c.Name + "@" + c.Version
+	}
+	return c.Name
+}
+
+type cycloneDXVulnerability struct {
+	ID         string `json:"id"`
+	References []*struct {
+		ID string `json:"id"`
+	} `json:"references"`
+	Analysis *struct {
+		State         string `json:"state"`
+		Justification string `json:"justification"`
+		Detail        string `json:"detail"`
+	} `json:"analysis"`
+	Affects []*struct {
+		Ref string `json:"ref"`
+	} `json:"affects"`
+	Updated *time.Time `json:"updated"`
+}
+
+// cycloneDXStates maps the impact analysis states of CycloneDX to the VEX status
+var cycloneDXStates = map[string]string{
+	"not_affected":           StatusNotAffected,
+	"false_positive":         StatusNotAffected,
+	"resolved":               StatusFixed,
+	"resolved_with_pedigree": StatusFixed,
+	"exploitable":            StatusAffected,
+	"in_triage":              StatusUnderInvestigation,
+}
+
+func parseCycloneDX(data []byte) ([]*Statement, error) {
+	doc := &cycloneDXDocument{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, errors.BadRequestError(err).WithMessage("invalid CycloneDX document")
+	}
+
+	refs := map[string]string{}
+	var walk func(components []*cycloneDXComponent)
+	walk = func(components []*cycloneDXComponent) {
+		for _, c := range components {
+			if c.BOMRef != "" {
+				refs[c.BOMRef] = c.identifier()
+			}
+			walk(c.Components)
+		}
+	}
+	walk(doc.Components)
+
+	var (
+		products  []string
+		timestamp *time.Time
+	)
+	if doc.Metadata != nil {
+		timestamp = doc.Metadata.Timestamp
+		if doc.Metadata.Component != nil {
+			products = append(products, doc.Metadata.Component.identifier())
+			if doc.Metadata.Component.BOMRef != "" {
+				refs[doc.Metadata.Component.BOMRef] = doc.Metadata.Component.identifier()
+			}
+		}
+	}
+
+	var statements []*Statement
+	for _, v := range doc.Vulnerabilities {
+		// the vulnerabilities without analysis are the findings of an SBOM but not VEX statements
+		if v.Analysis == nil || v.Analysis.State == "" {
+			continue
+		}
+
+		s := &Statement{
+			VulnerabilityID: v.ID,
+			Products:        products,
+			Status:          cycloneDXStates[v.Analysis.State],
+			Justification:   v.Analysis.Justification,
+			ImpactStatement: v.Analysis.Detail,
+			Timestamp:       timestamp,
+		}
+		if s.Status == "" {
+			s.Status = v.Analysis.State
+		}
+		if v.Updated != nil {
+			s.Timestamp = v.Updated
+		}
+		for _, r := range v.References {
+			if r.ID != "" && r.ID != v.ID {
+				s.Aliases = append(s.Aliases, r.ID)
+			}
+		}
+		for _, a := range v.Affects {
+			if a.Ref == "" {
+				continue
+			}
+			// the affects referring to the product itself don't narrow the statement
+			if id, ok := refs[a.Ref]; ok {
+				if len(products) > 0 && id == products[0] {
+					continue
+				}
+				s.Subcomponents = append(s.Subcomponents, id)
+			} else {
+				s.Subcomponents = append(s.Subcomponents, a.Ref)
+			}
+		}
+
+		statements = append(statements, s)
+	}
+
+	return statements, nil
+}
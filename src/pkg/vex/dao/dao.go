@@ -0,0 +1,113 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/vex/model"
+)
+
+// DAO is the data access object interface for VEX document
+type DAO interface {
+	// Create the VEX document
+	Create(ctx context.Context, doc *model.Document) (id int64, err error)
+	// Get the VEX document specified by ID
+	Get(ctx context.Context, id int64) (doc *model.Document, err error)
+	// Count returns the total count of VEX documents according to the query
+	Count(ctx context.Context, query *q.Query) (total int64, err error)
+	// List the VEX documents according to the query
+	List(ctx context.Context, query *q.Query) (docs []*model.Document, err error)
+	// Delete the VEX document specified by ID
+	Delete(ctx context.Context, id int64) (err error)
+}
+
+// New creates an instance of the default DAO
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+func (d *dao) Create(ctx context.Context, doc *model.Document) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	id, err := ormer.Insert(doc)
+	if err != nil {
+		if e := orm.AsConflictError(err, "VEX document %s already exists in project %d", doc.Name, doc.ProjectID); e != nil {
+			err = e
+		}
+	}
+	return id, err
+}
+
+func (d *dao) Get(ctx context.Context, id int64) (*model.Document, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	doc := &model.Document{
+		ID: id,
+	}
+	if err = ormer.Read(doc); err != nil {
+		if e := orm.AsNotFoundError(err, "VEX document %d not found", id); e != nil {
+			err = e
+		}
+		return nil, err
+	}
+	return doc, nil
+}
+
+func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &model.Document{}, query)
+	if err != nil {
+		return 0, err
+	}
+	return qs.Count()
+}
+
+func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.Document, error) {
+	docs := []*model.Document{}
+	qs, err := orm.QuerySetter(ctx, &model.Document{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.All(&docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (d *dao) Delete(ctx context.Context, id int64) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.Delete(&model.Document{
+		ID: id,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessagef("VEX document %d not found", id)
+	}
+	return nil
+}
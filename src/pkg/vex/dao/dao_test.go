@@ -0,0 +1,86 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/vex/model"
+	htesting "github.com/goharbor/harbor/src/testing"
+)
+
+type daoTestSuite struct {
+	htesting.Suite
+	dao DAO
+}
+
+func (d *daoTestSuite) SetupSuite() {
+	d.Suite.SetupSuite()
+	d.dao = New()
+}
+
+func (d *daoTestSuite) TestCRUD() {
+	ctx := d.Context()
+
+	id, err := d.dao.Create(ctx, &model.Document{
+		ProjectID: 1,
+		Name:      "openvex.json",
+		Format:    "openvex",
+		Content:   `{"@context":"https://openvex.dev/ns/v0.2.0"}`,
+	})
+	d.Require().Nil(err)
+	defer d.dao.Delete(ctx, id)
+
+	// conflict
+	_, err = d.dao.Create(ctx, &model.Document{
+		ProjectID: 1,
+		Name:      "openvex.json",
+		Format:    "openvex",
+	})
+	d.Require().NotNil(err)
+	d.True(errors.IsConflictErr(err))
+
+	doc, err := d.dao.Get(ctx, id)
+	d.Require().Nil(err)
+	d.Equal("openvex.json", doc.Name)
+	d.Equal("openvex", doc.Format)
+
+	total, err := d.dao.Count(ctx, q.New(q.KeyWords{"ProjectID": 1}))
+	d.Require().Nil(err)
+	d.Equal(int64(1), total)
+
+	docs, err := d.dao.List(ctx, q.New(q.KeyWords{"ProjectID": 1}))
+	d.Require().Nil(err)
+	d.Require().Len(docs, 1)
+	d.Equal(id, docs[0].ID)
+
+	d.Require().Nil(d.dao.Delete(ctx, id))
+
+	_, err = d.dao.Get(ctx, id)
+	d.Require().NotNil(err)
+	d.True(errors.IsNotFoundErr(err))
+
+	err = d.dao.Delete(ctx, id)
+	d.Require().NotNil(err)
+	d.True(errors.IsNotFoundErr(err))
+}
+
+func TestDaoTestSuite(t *testing.T) {
+	suite.Run(t, &daoTestSuite{})
+}
@@ -0,0 +1,216 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vex
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Product is the artifact which the VEX statements are evaluated against
+type Product struct {
+	// The repository name of the artifact, e.g. library/hello-world
+	Repository string
+	// The digest of the artifact
+	Digest string
+	// The tags of the artifact
+	Tags []string
+}
+
+// Matches checks whether the product is identified by the id, the id can be an OCI purl,
+// a reference in "repository@digest" or "repository:tag" form, or a digest
+func (p *Product) Matches(id string) bool {
+	if strings.HasPrefix(id, "pkg:") {
+		pkg := parsePURL(id)
+		if pkg.typ != "oci" && pkg.typ != "docker" {
+			return false
+		}
+		if pkg.version != "" {
+			return pkg.version == p.Digest
+		}
+		return pkg.name == p.Repository || strings.HasSuffix(p.Repository, "/"+pkg.name)
+	}
+
+	if strings.HasPrefix(id, "sha256:") {
+		return id == p.Digest
+	}
+
+	repository, reference := id, ""
+	if i := strings.Index(id, "@"); i >= 0 {
+		repository, reference = id[:i], id[i+1:]
+	} else if i := strings.LastIndex(id, ":"); i > strings.LastIndex(id, "/") {
+		repository, reference = id[:i], id[i+1:]
+	}
+
+	if repository != p.Repository && !strings.HasSuffix(repository, "/"+p.Repository) {
+		return false
+	}
+
+	switch {
+	case reference == "":
+		return true
+	case strings.HasPrefix(reference, "sha256:"):
+		return reference == p.Digest
+	default:
+		for _, tag := range p.Tags {
+			if tag == reference {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// AppliesTo checks whether the statement applies to the product
+func (s *Statement) AppliesTo(p *Product) bool {
+	if len(s.Products) == 0 {
+		return true
+	}
+
+	for _, id := range s.Products {
+		if p.Matches(id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Covers checks whether the statement covers the package in the version
+func (s *Statement) Covers(pkg, version string) bool {
+	if len(s.Subcomponents) == 0 {
+		return true
+	}
+
+	for _, id := range s.Subcomponents {
+		if componentMatches(id, pkg, version) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func componentMatches(id, pkg, version string) bool {
+	var names []string
+	v := ""
+	if strings.HasPrefix(id, "pkg:") {
+		p := parsePURL(id)
+		names = append(names, p.name)
+		if p.namespace != "" {
+			names = append(names, p.namespace+"/"+p.name, p.namespace+":"+p.name)
+		}
+		v = p.version
+	} else {
+		name := id
+		if i := strings.LastIndex(id, "@"); i > 0 {
+			name, v = id[:i], id[i+1:]
+		}
+		names = append(names, name)
+	}
+
+	if v != "" && strings.TrimPrefix(v, "v") != strings.TrimPrefix(version, "v") {
+		return false
+	}
+
+	for _, name := range names {
+		if name == pkg {
+			return true
+		}
+	}
+
+	return false
+}
+
+type purl struct {
+	typ       string
+	namespace string
+	name      string
+	version   string
+}
+
+// parsePURL parses the package URL, see https://github.com/package-url/purl-spec
+func parsePURL(s string) *purl {
+	s = strings.TrimPrefix(s, "pkg:")
+	if i := strings.IndexAny(s, "?#"); i >= 0 {
+		s = s[:i]
+	}
+
+	p := &purl{}
+	if i := strings.LastIndex(s, "@"); i >= 0 {
+		p.version, _ = url.PathUnescape(s[i+1:])
+		s = s[:i]
+	}
+
+	parts := strings.Split(strings.Trim(s, "/"), "/")
+	p.typ = strings.ToLower(parts[0])
+	if len(parts) > 1 {
+		p.name, _ = url.PathUnescape(parts[len(parts)-1])
+	}
+	if len(parts) > 2 {
+		p.namespace, _ = url.PathUnescape(strings.Join(parts[1:len(parts)-1], "/"))
+	}
+
+	return p
+}
+
+// Index indexes the VEX statements by the vulnerability IDs to look up the statement for a vulnerability
+type Index struct {
+	statements map[string][]*Statement
+}
+
+// NewIndex returns an empty index
+func NewIndex() *Index {
+	return &Index{statements: map[string][]*Statement{}}
+}
+
+// Add adds the statements applying to the product into the index, all the statements are added when
+// the product is nil. The statements added later take precedence over the ones added before.
+func (i *Index) Add(product *Product, statements ...*Statement) {
+	for _, s := range statements {
+		if product != nil && !s.AppliesTo(product) {
+			continue
+		}
+
+		for _, id := range s.IDs() {
+			key := strings.ToUpper(id)
+			i.statements[key] = append(i.statements[key], s)
+		}
+	}
+}
+
+// Len returns the count of the vulnerability IDs in the index
+func (i *Index) Len() int {
+	if i == nil {
+		return 0
+	}
+	return len(i.statements)
+}
+
+// Lookup returns the statement for the vulnerability found in the package with the version, nil when not found
+func (i *Index) Lookup(vulnerabilityID, pkg, version string) *Statement {
+	if i == nil {
+		return nil
+	}
+
+	statements := i.statements[strings.ToUpper(vulnerabilityID)]
+	for j := len(statements) - 1; j >= 0; j-- {
+		if statements[j].Covers(pkg, version) {
+			return statements[j]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type indexTestSuite struct {
+	suite.Suite
+	product *Product
+}
+
+func (i *indexTestSuite) SetupTest() {
+	i.product = &Product{
+		Repository: "library/nginx",
+		Digest:     "sha256:05bef0d6a1a6f8d4d4bd8cd3b2d6b3ee49f6f5f9c2aa4bd5b2d1c1e2f3a4b5c6",
+		Tags:       []string{"1.25", "latest"},
+	}
+}
+
+func (i *indexTestSuite) TestProductMatches() {
+	cases := map[string]bool{
+		"pkg:oci/nginx@sha256%3A05bef0d6a1a6f8d4d4bd8cd3b2d6b3ee49f6f5f9c2aa4bd5b2d1c1e2f3a4b5c6": true,
+		"pkg:oci/nginx@sha256%3A0000":                                   false,
+		"pkg:oci/nginx?repository_url=harbor.example.com/library/nginx": true,
+		"pkg:oci/redis": false,
+		"pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1":                                                     false,
+		"sha256:05bef0d6a1a6f8d4d4bd8cd3b2d6b3ee49f6f5f9c2aa4bd5b2d1c1e2f3a4b5c6":                                  true,
+		"harbor.example.com/library/nginx@sha256:05bef0d6a1a6f8d4d4bd8cd3b2d6b3ee49f6f5f9c2aa4bd5b2d1c1e2f3a4b5c6": true,
+		"harbor.example.com:443/library/nginx:1.25":                                                                true,
+		"library/nginx:1.24": false,
+		"library/nginx":      true,
+		"library/redis":      false,
+	}
+	for id, expected := range cases {
+		i.Equal(expected, i.product.Matches(id), id)
+	}
+}
+
+func (i *indexTestSuite) TestCovers() {
+	s := &Statement{Subcomponents: []string{
+		"pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+		"pkg:golang/github.com/docker/distribution@v2.8.1",
+		"openssl@3.0.7",
+	}}
+	i.True(s.Covers("org.apache.logging.log4j:log4j-core", "2.14.1"))
+	i.True(s.Covers("log4j-core", "2.14.1"))
+	i.False(s.Covers("log4j-core", "2.17.0"))
+	i.True(s.Covers("github.com/docker/distribution", "2.8.1"))
+	i.True(s.Covers("openssl", "3.0.7"))
+	i.False(s.Covers("libssl3", "3.0.7"))
+
+	s = &Statement{}
+	i.True(s.Covers("anything", "1.0"))
+}
+
+func (i *indexTestSuite) TestLookup() {
+	var nilIndex *Index
+	i.Nil(nilIndex.Lookup("CVE-2021-44228", "log4j-core", "2.14.1"))
+	i.Equal(0, nilIndex.Len())
+
+	index := NewIndex()
+	index.Add(i.product,
+		&Statement{VulnerabilityID: "CVE-2021-44228", Aliases: []string{"GHSA-jfh8-c2jp-5v3q"}, Status: StatusNotAffected, Subcomponents: []string{"pkg:maven/org.apache.logging.log4j/log4j-core"}},
+		&Statement{VulnerabilityID: "CVE-2023-1234", Status: StatusNotAffected, Products: []string{"library/redis"}},
+	)
+	i.Equal(2, index.Len())
+
+	s := index.Lookup("cve-2021-44228", "org.apache.logging.log4j:log4j-core", "2.14.1")
+	i.Require().NotNil(s)
+	i.True(s.IsNotAffected())
+	i.NotNil(index.Lookup("GHSA-jfh8-c2jp-5v3q", "log4j-core", "2.14.1"))
+	i.Nil(index.Lookup("CVE-2021-44228", "log4j-api", "2.14.1"))
+	// not applies to the product
+	i.Nil(index.Lookup("CVE-2023-1234", "openssl", "3.0.7"))
+
+	// the statements added later take precedence
+	index.Add(nil, &Statement{VulnerabilityID: "CVE-2021-44228", Status: StatusAffected, Products: []string{"library/redis"}})
+	s = index.Lookup("CVE-2021-44228", "log4j-core", "2.14.1")
+	i.Require().NotNil(s)
+	i.Equal(StatusAffected, s.Status)
+}
+
+func TestIndexTestSuite(t *testing.T) {
+	suite.Run(t, &indexTestSuite{})
+}
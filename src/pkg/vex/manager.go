@@ -0,0 +1,119 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vex
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/registry"
+	"github.com/goharbor/harbor/src/pkg/vex/dao"
+	"github.com/goharbor/harbor/src/pkg/vex/model"
+)
+
+// Mgr is the global VEX manager instance
+var Mgr = NewManager()
+
+// Manager manages the VEX documents uploaded to the projects and reads the VEX accessories
+type Manager interface {
+	// Create validates the content of the VEX document and creates it
+	Create(ctx context.Context, doc *model.Document) (id int64, err error)
+	// Get the VEX document specified by ID
+	Get(ctx context.Context, id int64) (doc *model.Document, err error)
+	// Count returns the total count of VEX documents according to the query
+	Count(ctx context.Context, query *q.Query) (total int64, err error)
+	// List the VEX documents according to the query
+	List(ctx context.Context, query *q.Query) (docs []*model.Document, err error)
+	// Delete the VEX document specified by ID
+	Delete(ctx context.Context, id int64) (err error)
+	// ListStatements returns the statements of all the VEX documents uploaded to the project
+	ListStatements(ctx context.Context, projectID int64) (statements []*Statement, err error)
+	// ReadAccessory returns the statements of the VEX accessory artifact stored in the repository
+	ReadAccessory(ctx context.Context, repository, digest string) (statements []*Statement, err error)
+}
+
+// NewManager returns an instance of the default manager
+func NewManager() Manager {
+	return &manager{
+		dao:    dao.New(),
+		regCli: registry.Cli,
+	}
+}
+
+type manager struct {
+	dao    dao.DAO
+	regCli registry.Client
+}
+
+func (m *manager) Create(ctx context.Context, doc *model.Document) (int64, error) {
+	if doc.Name == "" {
+		return 0, errors.BadRequestError(nil).WithMessage("the name of the VEX document is required")
+	}
+	format, _, err := Parse([]byte(doc.Content))
+	if err != nil {
+		return 0, err
+	}
+	doc.Format = format
+	return m.dao.Create(ctx, doc)
+}
+
+func (m *manager) Get(ctx context.Context, id int64) (*model.Document, error) {
+	return m.dao.Get(ctx, id)
+}
+
+func (m *manager) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return m.dao.Count(ctx, query)
+}
+
+func (m *manager) List(ctx context.Context, query *q.Query) ([]*model.Document, error) {
+	return m.dao.List(ctx, query)
+}
+
+func (m *manager) Delete(ctx context.Context, id int64) error {
+	return m.dao.Delete(ctx, id)
+}
+
+func (m *manager) ListStatements(ctx context.Context, projectID int64) ([]*Statement, error) {
+	docs, err := m.dao.List(ctx, q.New(q.KeyWords{"ProjectID": projectID}).First(q.NewSort("creation_time", false)))
+	if err != nil {
+		return nil, err
+	}
+
+	var statements []*Statement
+	for _, doc := range docs {
+		_, sts, err := Parse([]byte(doc.Content))
+		if err != nil {
+			// the content is validated when creating, just skip the broken one
+			log.G(ctx).Warningf("failed to parse the VEX document %d of project %d, error: %v", doc.ID, projectID, err)
+			continue
+		}
+		statements = append(statements, sts...)
+	}
+
+	return statements, nil
+}
+
+func (m *manager) ReadAccessory(ctx context.Context, repository, digest string) ([]*Statement, error) {
+	var statements []*Statement
+	if _, err := registry.ReadLayer(ctx, m.regCli, repository, digest, "VEX document", func(content []byte) (err error) {
+		_, statements, err = Parse(content)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return statements, nil
+}
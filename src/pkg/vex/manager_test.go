@@ -0,0 +1,84 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/vex/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/vex/dao"
+)
+
+type managerTestSuite struct {
+	suite.Suite
+	mgr *manager
+	dao *dao.DAO
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.dao = &dao.DAO{}
+	m.mgr = &manager{
+		dao: m.dao,
+	}
+}
+
+func (m *managerTestSuite) TestCreate() {
+	m.dao.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	doc := &model.Document{Name: "vex.json", Content: openVEXDoc}
+	id, err := m.mgr.Create(context.Background(), doc)
+	m.Require().Nil(err)
+	m.Equal(int64(1), id)
+	m.Equal(FormatOpenVEX, doc.Format)
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestCreateInvalid() {
+	_, err := m.mgr.Create(context.Background(), &model.Document{Content: openVEXDoc})
+	m.Require().NotNil(err)
+	m.Equal(errors.BadRequestCode, errors.ErrCode(err))
+
+	_, err = m.mgr.Create(context.Background(), &model.Document{Name: "vex.json", Content: "{}"})
+	m.Require().NotNil(err)
+	m.Equal(errors.BadRequestCode, errors.ErrCode(err))
+	m.dao.AssertNotCalled(m.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (m *managerTestSuite) TestListStatements() {
+	m.dao.On("List", mock.Anything, mock.Anything).Return([]*model.Document{
+		{ID: 1, Content: openVEXDoc},
+		{ID: 2, Content: "broken"},
+		{ID: 3, Content: cycloneDXDoc},
+	}, nil)
+	statements, err := m.mgr.ListStatements(context.Background(), 1)
+	m.Require().Nil(err)
+	m.Len(statements, 4)
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestDelete() {
+	m.dao.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	err := m.mgr.Delete(context.Background(), 1)
+	m.Nil(err)
+	m.dao.AssertExpectations(m.T())
+}
+
+func TestManagerTestSuite(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
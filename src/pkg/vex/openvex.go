@@ -0,0 +1,172 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vex
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+// openVEXDocument is the OpenVEX document, both v0.0.1 and v0.2.0 are supported
+// see https://github.com/openvex/spec/blob/main/OPENVEX-SPEC.md
+type openVEXDocument struct {
+	Context    string              `json:"@context"`
+	Timestamp  *time.Time          `json:"timestamp"`
+	Statements []*openVEXStatement `json:"statements"`
+}
+
+type openVEXStatement struct {
+	// string in v0.0.1 and object in v0.2.0
+	Vulnerability json.RawMessage `json:"vulnerability"`
+	// strings in v0.0.1 and objects in v0.2.0
+	Products []json.RawMessage `json:"products"`
+	// subcomponents of the statement level, only in v0.0.1
+	Subcomponents   []json.RawMessage `json:"subcomponents"`
+	Status          string            `json:"status"`
+	Justification   string            `json:"justification"`
+	ImpactStatement string            `json:"impact_statement"`
+	Timestamp       *time.Time        `json:"timestamp"`
+}
+
+type openVEXVulnerability struct {
+	ID      string   `json:"@id"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+}
+
+type openVEXComponent struct {
+	ID            string              `json:"@id"`
+	Identifiers   map[string]string   `json:"identifiers"`
+	Subcomponents []*openVEXComponent `json:"subcomponents"`
+}
+
+// identifier returns the purl of the component if present, otherwise the IRI
+func (c *openVEXComponent) identifier() string {
+	if purl := c.Identifiers["purl"]; purl != "" {
+		return purl
+	}
+	return c.ID
+}
+
+func parseOpenVEX(data []byte) ([]*Statement, error) {
+	doc := &openVEXDocument{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, errors.BadRequestError(err).WithMessage("invalid OpenVEX document")
+	}
+
+	var statements []*Statement
+	for _, st := range doc.Statements {
+		vul, err := parseOpenVEXVulnerability(st.Vulnerability)
+		if err != nil {
+			return nil, err
+		}
+
+		var subcomponents []string
+		for _, raw := range st.Subcomponents {
+			c, err := parseOpenVEXComponent(raw)
+			if err != nil {
+				return nil, err
+			}
+			subcomponents = append(subcomponents, c.identifier())
+		}
+
+		timestamp := st.Timestamp
+		if timestamp == nil {
+			timestamp = doc.Timestamp
+		}
+
+		newStatement := func() *Statement {
+			return &Statement{
+				VulnerabilityID: vul.Name,
+				Aliases:         vul.Aliases,
+				Subcomponents:   append([]string{}, subcomponents...),
+				Status:          st.Status,
+				Justification:   st.Justification,
+				ImpactStatement: st.ImpactStatement,
+				Timestamp:       timestamp,
+			}
+		}
+
+		if len(st.Products) == 0 {
+			statements = append(statements, newStatement())
+			continue
+		}
+
+		// products without subcomponents share one statement, while the products which
+		// have subcomponents get their own statements to keep the product-component pairs
+		shared := newStatement()
+		for _, raw := range st.Products {
+			product, err := parseOpenVEXComponent(raw)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(product.Subcomponents) == 0 {
+				shared.Products = append(shared.Products, product.identifier())
+				continue
+			}
+
+			s := newStatement()
+			s.Products = []string{product.identifier()}
+			for _, sub := range product.Subcomponents {
+				s.Subcomponents = append(s.Subcomponents, sub.identifier())
+			}
+			statements = append(statements, s)
+		}
+		if len(shared.Products) > 0 {
+			statements = append(statements, shared)
+		}
+	}
+
+	return statements, nil
+}
+
+func parseOpenVEXVulnerability(raw json.RawMessage) (*openVEXVulnerability, error) {
+	vul := &openVEXVulnerability{}
+	if len(raw) == 0 {
+		return vul, nil
+	}
+
+	var name string
+	if err := json.Unmarshal(raw, &name); err == nil {
+		vul.Name = name
+		return vul, nil
+	}
+
+	if err := json.Unmarshal(raw, vul); err != nil {
+		return nil, errors.BadRequestError(err).WithMessage("invalid vulnerability of the OpenVEX statement")
+	}
+	if vul.Name == "" {
+		vul.Name = vul.ID
+	}
+
+	return vul, nil
+}
+
+func parseOpenVEXComponent(raw json.RawMessage) (*openVEXComponent, error) {
+	var id string
+	if err := json.Unmarshal(raw, &id); err == nil {
+		return &openVEXComponent{ID: id}, nil
+	}
+
+	c := &openVEXComponent{}
+	if err := json.Unmarshal(raw, c); err != nil {
+		return nil, errors.BadRequestError(err).WithMessage("invalid product or subcomponent of the OpenVEX statement")
+	}
+
+	return c, nil
+}
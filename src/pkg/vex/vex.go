@@ -0,0 +1,139 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vex
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+const (
+	// FormatOpenVEX identifies the documents following the OpenVEX specification
+	FormatOpenVEX = "openvex"
+	// FormatCycloneDX identifies the CycloneDX documents carrying the vulnerability analysis
+	FormatCycloneDX = "cyclonedx"
+
+	// StatusNotAffected the product is not affected by the vulnerability
+	StatusNotAffected = "not_affected"
+	// StatusAffected the product is affected by the vulnerability
+	StatusAffected = "affected"
+	// StatusFixed the product contains the fix of the vulnerability
+	StatusFixed = "fixed"
+	// StatusUnderInvestigation it's not yet known whether the product is affected
+	StatusUnderInvestigation = "under_investigation"
+
+	// JustificationComponentNotPresent the vulnerable component is not included in the product
+	JustificationComponentNotPresent = "component_not_present"
+	// JustificationVulnerableCodeNotPresent the vulnerable code is not included in the component
+	JustificationVulnerableCodeNotPresent = "vulnerable_code_not_present"
+	// JustificationVulnerableCodeNotInExecutePath the vulnerable code can't be executed
+	JustificationVulnerableCodeNotInExecutePath = "vulnerable_code_not_in_execute_path"
+	// JustificationVulnerableCodeCannotBeControlledByAdversary the vulnerable code can't be controlled by an attacker
+	JustificationVulnerableCodeCannotBeControlledByAdversary = "vulnerable_code_cannot_be_controlled_by_adversary"
+	// JustificationInlineMitigationsAlreadyExist the product includes the built-in protections
+	JustificationInlineMitigationsAlreadyExist = "inline_mitigations_already_exist"
+
+	openVEXContextPrefix = "https://openvex.dev/ns"
+	cycloneDXBOMFormat   = "CycloneDX"
+)
+
+// Statement is the format independent form of a VEX statement
+type Statement struct {
+	// The ID of the vulnerability, e.g. CVE-2021-44228
+	VulnerabilityID string `json:"vulnerability_id"`
+	// The other IDs of the vulnerability, e.g. GHSA-jfh8-c2jp-5v3q
+	Aliases []string `json:"aliases,omitempty"`
+	// The products the statement applies to, an empty list means the statement applies to any product
+	Products []string `json:"products,omitempty"`
+	// The components of the products the statement applies to, an empty list means any component
+	Subcomponents []string `json:"subcomponents,omitempty"`
+	// The status of the vulnerability in the products
+	Status string `json:"status"`
+	// The justification for the not_affected status
+	Justification string `json:"justification,omitempty"`
+	// The free form explanation of the status
+	ImpactStatement string `json:"impact_statement,omitempty"`
+	// The time when the statement was made
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+}
+
+// IsNotAffected returns true when the statement declares the vulnerability is not exploitable
+func (s *Statement) IsNotAffected() bool {
+	return s.Status == StatusNotAffected
+}
+
+// IDs returns the vulnerability ID and its aliases
+func (s *Statement) IDs() []string {
+	return append([]string{s.VulnerabilityID}, s.Aliases...)
+}
+
+// Parse detects the format of the VEX document and returns its format and statements
+func Parse(data []byte) (string, []*Statement, error) {
+	probe := struct {
+		Context   string `json:"@context"`
+		BOMFormat string `json:"bomFormat"`
+	}{}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return "", nil, errors.BadRequestError(err).WithMessage("the VEX document is not a valid JSON document")
+	}
+
+	var (
+		format     string
+		statements []*Statement
+		err        error
+	)
+	switch {
+	case strings.HasPrefix(probe.Context, openVEXContextPrefix):
+		format = FormatOpenVEX
+		statements, err = parseOpenVEX(data)
+	case probe.BOMFormat == cycloneDXBOMFormat:
+		format = FormatCycloneDX
+		statements, err = parseCycloneDX(data)
+	default:
+		return "", nil, errors.BadRequestError(nil).WithMessage("unsupported VEX document, only OpenVEX and CycloneDX are supported")
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, s := range statements {
+		if err := validate(s); err != nil {
+			return "", nil, err
+		}
+	}
+
+	return format, statements, nil
+}
+
+func validate(s *Statement) error {
+	if s.VulnerabilityID == "" {
+		return errors.BadRequestError(nil).WithMessage("the vulnerability of the VEX statement is required")
+	}
+
+	switch s.Status {
+	case StatusNotAffected:
+		if s.Justification == "" && s.ImpactStatement == "" {
+			return errors.BadRequestError(nil).WithMessagef("the not_affected statement of %s requires a justification or an impact statement", s.VulnerabilityID)
+		}
+	case StatusAffected, StatusFixed, StatusUnderInvestigation:
+	default:
+		return errors.BadRequestError(nil).WithMessagef("invalid status %q of the VEX statement for %s", s.Status, s.VulnerabilityID)
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+const openVEXDoc = `{
+  "@context": "https://openvex.dev/ns/v0.2.0",
+  "@id": "https://openvex.dev/docs/public/vex-2e67563e",
+  "author": "Harbor",
+  "timestamp": "2023-01-08T18:02:03.647787998-06:00",
+  "version": 1,
+  "statements": [
+    {
+      "vulnerability": {"name": "CVE-2021-44228", "aliases": ["GHSA-jfh8-c2jp-5v3q"]},
+      "products": [
+        {
+          "@id": "pkg:oci/nginx@sha256%3A05bef0d6a1a6f8d4d4bd8cd3b2d6b3ee49f6f5f9c2aa4bd5b2d1c1e2f3a4b5c6",
+          "subcomponents": [{"@id": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}]
+        }
+      ],
+      "status": "not_affected",
+      "justification": "vulnerable_code_not_in_execute_path"
+    },
+    {
+      "vulnerability": {"name": "CVE-2023-1234"},
+      "products": ["library/nginx:1.25", "library/redis"],
+      "status": "affected",
+      "action_statement": "upgrade"
+    }
+  ]
+}`
+
+const openVEXDocV001 = `{
+  "@context": "https://openvex.dev/ns",
+  "statements": [
+    {
+      "vulnerability": "CVE-2022-3715",
+      "products": ["pkg:oci/alpine"],
+      "subcomponents": ["pkg:apk/alpine/bash@5.2.15-r0"],
+      "status": "fixed"
+    }
+  ]
+}`
+
+const cycloneDXDoc = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "metadata": {
+    "component": {"bom-ref": "app", "name": "library/app", "purl": "pkg:oci/app"}
+  },
+  "components": [
+    {"bom-ref": "openssl", "name": "openssl", "version": "3.0.7", "purl": "pkg:deb/debian/openssl@3.0.7"}
+  ],
+  "vulnerabilities": [
+    {
+      "id": "CVE-2023-0286",
+      "references": [{"id": "DSA-5343-1"}],
+      "analysis": {"state": "not_affected", "justification": "code_not_reachable", "detail": "x400 is not used"},
+      "affects": [{"ref": "openssl"}, {"ref": "app"}]
+    },
+    {
+      "id": "CVE-2023-0464",
+      "analysis": {"state": "in_triage"},
+      "affects": [{"ref": "urn:cdx:3e671687/1#openssl"}]
+    },
+    {
+      "id": "CVE-2023-0465",
+      "ratings": [{"severity": "high"}]
+    }
+  ]
+}`
+
+type vexTestSuite struct {
+	suite.Suite
+}
+
+func (v *vexTestSuite) TestParseOpenVEX() {
+	format, statements, err := Parse([]byte(openVEXDoc))
+	v.Require().Nil(err)
+	v.Equal(FormatOpenVEX, format)
+	v.Require().Len(statements, 2)
+
+	s := statements[0]
+	v.Equal("CVE-2021-44228", s.VulnerabilityID)
+	v.Equal([]string{"CVE-2021-44228", "GHSA-jfh8-c2jp-5v3q"}, s.IDs())
+	v.Equal([]string{"pkg:oci/nginx@sha256%3A05bef0d6a1a6f8d4d4bd8cd3b2d6b3ee49f6f5f9c2aa4bd5b2d1c1e2f3a4b5c6"}, s.Products)
+	v.Equal([]string{"pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}, s.Subcomponents)
+	v.True(s.IsNotAffected())
+	v.Equal("vulnerable_code_not_in_execute_path", s.Justification)
+	v.NotNil(s.Timestamp)
+
+	s = statements[1]
+	v.Equal("CVE-2023-1234", s.VulnerabilityID)
+	v.Equal([]string{"library/nginx:1.25", "library/redis"}, s.Products)
+	v.Empty(s.Subcomponents)
+	v.False(s.IsNotAffected())
+}
+
+func (v *vexTestSuite) TestParseOpenVEXV001() {
+	format, statements, err := Parse([]byte(openVEXDocV001))
+	v.Require().Nil(err)
+	v.Equal(FormatOpenVEX, format)
+	v.Require().Len(statements, 1)
+	v.Equal("CVE-2022-3715", statements[0].VulnerabilityID)
+	v.Equal([]string{"pkg:oci/alpine"}, statements[0].Products)
+	v.Equal([]string{"pkg:apk/alpine/bash@5.2.15-r0"}, statements[0].Subcomponents)
+	v.Equal(StatusFixed, statements[0].Status)
+}
+
+func (v *vexTestSuite) TestParseCycloneDX() {
+	format, statements, err := Parse([]byte(cycloneDXDoc))
+	v.Require().Nil(err)
+	v.Equal(FormatCycloneDX, format)
+	v.Require().Len(statements, 2)
+
+	s := statements[0]
+	v.Equal("CVE-2023-0286", s.VulnerabilityID)
+	v.Equal([]string{"DSA-5343-1"}, s.Aliases)
+	v.Equal([]string{"pkg:oci/app"}, s.Products)
+	v.Equal([]string{"pkg:deb/debian/openssl@3.0.7"}, s.Subcomponents)
+	v.Equal(StatusNotAffected, s.Status)
+	v.Equal("code_not_reachable", s.Justification)
+	v.Equal("x400 is not used", s.ImpactStatement)
+
+	s = statements[1]
+	v.Equal(StatusUnderInvestigation, s.Status)
+	v.Equal([]string{"urn:cdx:3e671687/1#openssl"}, s.Subcomponents)
+}
+
+func (v *vexTestSuite) TestParseInvalid() {
+	cases := []string{
+		`not json`,
+		`{"bomFormat": "SPDX"}`,
+		`{"@context": "https://openvex.dev/ns/v0.2.0", "statements": [{"vulnerability": {"name": "CVE-1"}, "status": "unknown"}]}`,
+		`{"@context": "https://openvex.dev/ns/v0.2.0", "statements": [{"vulnerability": {"name": "CVE-1"}, "status": "not_affected"}]}`,
+		`{"@context": "https://openvex.dev/ns/v0.2.0", "statements": [{"status": "affected"}]}`,
+	}
+	for _, c := range cases {
+		_, _, err := Parse([]byte(c))
+		v.Require().NotNil(err, c)
+		v.Equal(errors.BadRequestCode, errors.ErrCode(err), c)
+	}
+}
+
+func TestVEXTestSuite(t *testing.T) {
+	suite.Run(t, &vexTestSuite{})
+}
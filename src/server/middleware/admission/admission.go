@@ -0,0 +1,243 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/event/metadata"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/admission"
+	"github.com/goharbor/harbor/src/pkg/admission/model"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/server/middleware"
+	"github.com/goharbor/harbor/src/server/middleware/util"
+)
+
+// Middleware evaluates the admission policies of the project and the system for
+// PUT /v2/<name>/manifests/<reference> (push) and GET|HEAD /v2/<name>/manifests/<reference> (pull) APIs,
+// the decisions are recorded in the audit log
+func Middleware() func(http.Handler) http.Handler {
+	return middleware.BeforeRequest(func(r *http.Request) error {
+		ctx := r.Context()
+
+		logger := log.G(ctx).WithFields(log.Fields{"middleware": "admission"})
+
+		none := lib.ArtifactInfo{}
+		info := lib.GetArtifactInfo(ctx)
+		if info == none {
+			return errors.New("artifactinfo middleware required before this middleware").WithCode(errors.NotFoundCode)
+		}
+
+		action := model.ActionPull
+		if r.Method == http.MethodPut {
+			action = model.ActionPush
+		}
+
+		proj, err := projectController.Get(ctx, info.ProjectName, project.WithEffectCVEAllowlist())
+		if err != nil {
+			logger.Errorf("get the project %s failed, error: %v", info.ProjectName, err)
+			return err
+		}
+
+		policies, err := admissionMgr.ListApplicable(ctx, proj.ProjectID, action)
+		if err != nil {
+			logger.Errorf("list the admission policies of project %s failed, error: %v", proj.Name, err)
+			return err
+		}
+		if len(policies) == 0 {
+			return nil
+		}
+
+		input := &admission.Input{
+			Action:    action,
+			Principal: principalOf(ctx),
+			Project: &admission.Project{
+				ID:       proj.ProjectID,
+				Name:     proj.Name,
+				Public:   proj.IsPublic(),
+				Metadata: proj.Metadata,
+			},
+		}
+
+		if action == model.ActionPush {
+			input.Artifact, err = pushingArtifact(r, info)
+		} else {
+			var skip bool
+			input.Artifact, skip, err = pullingArtifact(r, info, proj)
+			if skip {
+				logger.Debugf("artifact %s@%s is pulling by the scanner/cosign, skip the admission", info.Repository, info.Reference)
+				return nil
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		decision, err := admissionMgr.Evaluate(ctx, policies, input)
+		if err != nil {
+			logger.Errorf("evaluate the admission policies of project %s failed, error: %v", proj.Name, err)
+			return err
+		}
+
+		// the allowed HEAD requests are not recorded as the clients usually send the GET request following it
+		if !decision.Allowed || r.Method != http.MethodHead {
+			evt := &metadata.AdmissionEventMetadata{
+				Ctx:        ctx,
+				ProjectID:  proj.ProjectID,
+				Action:     action,
+				Repository: info.Repository,
+				Reference:  info.Reference,
+				Decision:   decision,
+			}
+			if decision.Allowed {
+				// the allowed decision is only recorded when the request succeeds
+				notification.AddEvent(ctx, evt)
+			} else {
+				// the denied request fails, force to send the event
+				notification.AddEvent(ctx, evt, true)
+			}
+		}
+
+		if !decision.Allowed {
+			msg := fmt.Sprintf("current image cannot be %s due to the admission policies: %s", pastTense(action), strings.Join(decision.Reasons, "; "))
+			return errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).WithMessage(msg)
+		}
+
+		return nil
+	})
+}
+
+func principalOf(ctx context.Context) *admission.Principal {
+	principal := &admission.Principal{}
+	if secCtx, ok := security.FromContext(ctx); ok {
+		principal.Name = secCtx.GetUsername()
+		principal.Authenticated = secCtx.IsAuthenticated()
+		principal.SysAdmin = secCtx.IsSysAdmin()
+		principal.Type = secCtx.Name()
+	}
+	return principal
+}
+
+// pushingArtifact builds the artifact of the input from the manifest in the request body
+func pushingArtifact(r *http.Request, info lib.ArtifactInfo) (*admission.Artifact, error) {
+	lib.NopCloseRequest(r) // make the r.Body re-readable
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	mf := struct {
+		MediaType    string            `json:"mediaType"`
+		ArtifactType string            `json:"artifactType"`
+		Annotations  map[string]string `json:"annotations"`
+	}{}
+	if err := json.Unmarshal(body, &mf); err != nil {
+		return nil, errors.Wrapf(err, "unmarshal manifest failed").WithCode(errors.MANIFESTINVALID)
+	}
+
+	mediaType := mf.MediaType
+	if mediaType == "" {
+		mediaType = r.Header.Get("Content-Type")
+	}
+
+	return &admission.Artifact{
+		Repository:   info.Repository,
+		Reference:    info.Reference,
+		Tag:          info.Tag,
+		Digest:       digest.FromBytes(body).String(),
+		MediaType:    mediaType,
+		ArtifactType: mf.ArtifactType,
+		Annotations:  mf.Annotations,
+	}, nil
+}
+
+// pullingArtifact builds the artifact of the input from the stored artifact, returns true
+// when the request is from the scanner or the signing tools which skip the policy checking
+func pullingArtifact(r *http.Request, info lib.ArtifactInfo, proj *project.Project) (*admission.Artifact, bool, error) {
+	ctx := r.Context()
+
+	art, err := artifactController.GetByReference(ctx, info.Repository, info.Reference, &artifact.Option{
+		WithTag:       true,
+		WithLabel:     true,
+		WithAccessory: true,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	skip, err := util.SkipPolicyChecking(r, proj.ProjectID, art.ID)
+	if err != nil || skip {
+		return nil, skip, err
+	}
+
+	result := &admission.Artifact{
+		Repository:   info.Repository,
+		Reference:    info.Reference,
+		Tag:          info.Tag,
+		Digest:       art.Digest,
+		MediaType:    art.ManifestMediaType,
+		ArtifactType: art.ArtifactType,
+		Type:         art.Type,
+		Annotations:  art.Annotations,
+		Labels:       []string{},
+		Accessories:  []*admission.Accessory{},
+	}
+	for _, label := range art.Labels {
+		result.Labels = append(result.Labels, label.Name)
+	}
+	for _, acc := range art.Accessories {
+		result.Accessories = append(result.Accessories, &admission.Accessory{
+			Type:   acc.GetData().Type,
+			Digest: acc.GetData().Digest,
+		})
+	}
+
+	vulnerable, err := scanController.GetVulnerable(ctx, art, proj.CVEAllowlist.CVESet(), proj.CVEAllowlist.IsExpired())
+	if err != nil && !errors.IsNotFoundErr(err) {
+		return nil, false, err
+	}
+	if vulnerable != nil {
+		result.ScanSummary = &admission.ScanSummary{
+			ScanStatus: vulnerable.ScanStatus,
+			Total:      vulnerable.VulnerabilitiesCount,
+			Bypassed:   vulnerable.CVEBypassed,
+		}
+		if vulnerable.Severity != nil {
+			result.ScanSummary.Severity = vulnerable.Severity.String()
+		}
+	}
+
+	return result, false, nil
+}
+
+func pastTense(action string) string {
+	if action == model.ActionPush {
+		return "pushed"
+	}
+	return "pulled"
+}
@@ -0,0 +1,284 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"container/list"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/event/metadata"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/scan"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/accessory"
+	accessorymodel "github.com/goharbor/harbor/src/pkg/accessory/model"
+	basemodel "github.com/goharbor/harbor/src/pkg/accessory/model/base"
+	"github.com/goharbor/harbor/src/pkg/admission"
+	"github.com/goharbor/harbor/src/pkg/admission/model"
+	labelmodel "github.com/goharbor/harbor/src/pkg/label/model"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	scantesting "github.com/goharbor/harbor/src/testing/controller/scan"
+	"github.com/goharbor/harbor/src/testing/mock"
+	accessorytesting "github.com/goharbor/harbor/src/testing/pkg/accessory"
+	admissiontesting "github.com/goharbor/harbor/src/testing/pkg/admission"
+)
+
+const manifest = `{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.manifest.v1+json",
+  "config": {
+    "mediaType": "application/vnd.oci.image.config.v1+json",
+    "size": 7023,
+    "digest": "sha256:b5b2b2c507a0944348e0303114d8d93aaaa081732b86451d9bce1f432a537bc7"
+  },
+  "layers": [],
+  "annotations": {
+    "org.opencontainers.image.source": "https://github.com/goharbor/harbor"
+  }
+}`
+
+type MiddlewareTestSuite struct {
+	suite.Suite
+
+	originalArtifactController artifact.Controller
+	artifactController         *artifacttesting.Controller
+
+	originalProjectController project.Controller
+	projectController         *projecttesting.Controller
+
+	originalScanController scan.Controller
+	scanController         *scantesting.Controller
+
+	originalAdmissionMgr admission.Manager
+	admissionMgr         *admissiontesting.Manager
+
+	originalAccessMgr accessory.Manager
+	accessMgr         *accessorytesting.Manager
+
+	artifact *artifact.Artifact
+	project  *proModels.Project
+	events   *list.List
+	eventCtx *notification.EventCtx
+
+	next http.Handler
+}
+
+func (suite *MiddlewareTestSuite) SetupTest() {
+	suite.originalArtifactController = artifactController
+	suite.artifactController = &artifacttesting.Controller{}
+	artifactController = suite.artifactController
+
+	suite.originalProjectController = projectController
+	suite.projectController = &projecttesting.Controller{}
+	projectController = suite.projectController
+
+	suite.originalScanController = scanController
+	suite.scanController = &scantesting.Controller{}
+	scanController = suite.scanController
+
+	suite.originalAdmissionMgr = admissionMgr
+	suite.admissionMgr = &admissiontesting.Manager{}
+	admissionMgr = suite.admissionMgr
+
+	suite.originalAccessMgr = accessory.Mgr
+	suite.accessMgr = &accessorytesting.Manager{}
+	accessory.Mgr = suite.accessMgr
+
+	suite.artifact = &artifact.Artifact{}
+	suite.artifact.ID = 1
+	suite.artifact.ProjectID = 1
+	suite.artifact.RepositoryName = "library/photon"
+	suite.artifact.Digest = "sha256:418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180"
+	suite.artifact.Labels = []*labelmodel.Label{{Name: "prod"}}
+	suite.artifact.Accessories = []accessorymodel.Accessory{
+		&basemodel.Default{Data: accessorymodel.AccessoryData{Type: accessorymodel.TypeCosignSignature, Digest: "sha256:signature"}},
+	}
+
+	suite.project = &proModels.Project{
+		ProjectID: suite.artifact.ProjectID,
+		Name:      "library",
+		Metadata:  map[string]string{},
+	}
+
+	suite.events = list.New()
+	suite.next = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func (suite *MiddlewareTestSuite) TearDownTest() {
+	artifactController = suite.originalArtifactController
+	projectController = suite.originalProjectController
+	scanController = suite.originalScanController
+	admissionMgr = suite.originalAdmissionMgr
+	accessory.Mgr = suite.originalAccessMgr
+}
+
+func (suite *MiddlewareTestSuite) makeRequest(method string, body string) *http.Request {
+	req := httptest.NewRequest(method, "/v2/library/photon/manifests/2.0", strings.NewReader(body))
+
+	info := lib.ArtifactInfo{
+		ProjectName: "library",
+		Repository:  "library/photon",
+		Reference:   "2.0",
+		Tag:         "2.0",
+	}
+
+	suite.eventCtx = &notification.EventCtx{Events: suite.events}
+	ctx := notification.NewContext(req.Context(), suite.eventCtx)
+	return req.WithContext(lib.WithArtifactInfo(ctx, info))
+}
+
+func (suite *MiddlewareTestSuite) TestNoArtifactInfo() {
+	req := httptest.NewRequest(http.MethodGet, "/v2/library/photon/manifests/2.0", nil)
+	rr := httptest.NewRecorder()
+
+	Middleware()(suite.next).ServeHTTP(rr, req)
+	suite.Equal(http.StatusNotFound, rr.Code)
+}
+
+func (suite *MiddlewareTestSuite) TestNoPolicies() {
+	mock.OnAnything(suite.projectController, "Get").Return(suite.project, nil)
+	mock.OnAnything(suite.admissionMgr, "ListApplicable").Return(nil, nil)
+
+	rr := httptest.NewRecorder()
+	Middleware()(suite.next).ServeHTTP(rr, suite.makeRequest(http.MethodGet, ""))
+	suite.Equal(http.StatusOK, rr.Code)
+	suite.admissionMgr.AssertNotCalled(suite.T(), "Evaluate", mock.Anything, mock.Anything, mock.Anything)
+	suite.Equal(0, suite.events.Len())
+}
+
+func (suite *MiddlewareTestSuite) TestPullDenied() {
+	policies := []*model.Policy{{ID: 1, Name: "require-scan"}}
+	mock.OnAnything(suite.projectController, "Get").Return(suite.project, nil)
+	mock.OnAnything(suite.admissionMgr, "ListApplicable").Return(policies, nil)
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(suite.artifact, nil)
+	mock.OnAnything(suite.accessMgr, "List").Return([]accessorymodel.Accessory{}, nil)
+	high := vuln.High
+	mock.OnAnything(suite.scanController, "GetVulnerable").Return(&scan.Vulnerable{
+		ScanStatus:           "Success",
+		Severity:             &high,
+		VulnerabilitiesCount: 2,
+	}, nil)
+
+	var input *admission.Input
+	suite.admissionMgr.On("Evaluate", mock.Anything, policies, mock.Anything).Run(func(args mock.Arguments) {
+		input = args.Get(2).(*admission.Input)
+	}).Return(&admission.Decision{Allowed: false, Reasons: []string{"require-scan: high vulnerabilities found"}}, nil)
+
+	rr := httptest.NewRecorder()
+	Middleware()(suite.next).ServeHTTP(rr, suite.makeRequest(http.MethodGet, ""))
+	suite.Equal(http.StatusPreconditionFailed, rr.Code)
+	suite.Contains(rr.Body.String(), "require-scan: high vulnerabilities found")
+
+	suite.Require().NotNil(input)
+	suite.Equal(model.ActionPull, input.Action)
+	suite.Equal("library", input.Project.Name)
+	suite.Equal(suite.artifact.Digest, input.Artifact.Digest)
+	suite.Equal([]string{"prod"}, input.Artifact.Labels)
+	suite.Require().Len(input.Artifact.Accessories, 1)
+	suite.Equal(accessorymodel.TypeCosignSignature, input.Artifact.Accessories[0].Type)
+	suite.Require().NotNil(input.Artifact.ScanSummary)
+	suite.Equal("High", input.Artifact.ScanSummary.Severity)
+	suite.Equal(2, input.Artifact.ScanSummary.Total)
+
+	suite.Require().Equal(1, suite.events.Len())
+	evt, ok := suite.events.Front().Value.(*metadata.AdmissionEventMetadata)
+	suite.Require().True(ok)
+	suite.False(evt.Decision.Allowed)
+	// the request is denied, the event must be sent
+	suite.True(suite.eventCtx.MustNotify)
+}
+
+func (suite *MiddlewareTestSuite) TestPullNotScanned() {
+	policies := []*model.Policy{{ID: 1, Name: "require-scan"}}
+	mock.OnAnything(suite.projectController, "Get").Return(suite.project, nil)
+	mock.OnAnything(suite.admissionMgr, "ListApplicable").Return(policies, nil)
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(suite.artifact, nil)
+	mock.OnAnything(suite.accessMgr, "List").Return([]accessorymodel.Accessory{}, nil)
+	mock.OnAnything(suite.scanController, "GetVulnerable").Return(nil, errors.NotFoundError(nil))
+
+	var input *admission.Input
+	suite.admissionMgr.On("Evaluate", mock.Anything, policies, mock.Anything).Run(func(args mock.Arguments) {
+		input = args.Get(2).(*admission.Input)
+	}).Return(&admission.Decision{Allowed: true}, nil)
+
+	// the allowed HEAD request is not recorded
+	rr := httptest.NewRecorder()
+	Middleware()(suite.next).ServeHTTP(rr, suite.makeRequest(http.MethodHead, ""))
+	suite.Equal(http.StatusOK, rr.Code)
+	suite.Require().NotNil(input)
+	suite.Nil(input.Artifact.ScanSummary)
+	suite.Equal(0, suite.events.Len())
+}
+
+func (suite *MiddlewareTestSuite) TestPushAllowed() {
+	policies := []*model.Policy{{ID: 1, Name: "require-source"}}
+	mock.OnAnything(suite.projectController, "Get").Return(suite.project, nil)
+	mock.OnAnything(suite.admissionMgr, "ListApplicable").Return(policies, nil)
+
+	var input *admission.Input
+	suite.admissionMgr.On("Evaluate", mock.Anything, policies, mock.Anything).Run(func(args mock.Arguments) {
+		input = args.Get(2).(*admission.Input)
+	}).Return(&admission.Decision{Allowed: true}, nil)
+
+	var body string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	rr := httptest.NewRecorder()
+	Middleware()(next).ServeHTTP(rr, suite.makeRequest(http.MethodPut, manifest))
+	suite.Equal(http.StatusCreated, rr.Code)
+	suite.Equal(manifest, body)
+
+	suite.Require().NotNil(input)
+	suite.Equal(model.ActionPush, input.Action)
+	suite.Equal("application/vnd.oci.image.manifest.v1+json", input.Artifact.MediaType)
+	suite.Equal("https://github.com/goharbor/harbor", input.Artifact.Annotations["org.opencontainers.image.source"])
+	suite.Equal(1, suite.events.Len())
+	// the event is only sent when the push succeeds
+	suite.False(suite.eventCtx.MustNotify)
+	suite.artifactController.AssertNotCalled(suite.T(), "GetByReference", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *MiddlewareTestSuite) TestEvaluateFailed() {
+	policies := []*model.Policy{{ID: 1, Name: "require-source"}}
+	mock.OnAnything(suite.projectController, "Get").Return(suite.project, nil)
+	mock.OnAnything(suite.admissionMgr, "ListApplicable").Return(policies, nil)
+	mock.OnAnything(suite.admissionMgr, "Evaluate").Return(nil, errors.New("OPA server unavailable"))
+
+	rr := httptest.NewRecorder()
+	Middleware()(suite.next).ServeHTTP(rr, suite.makeRequest(http.MethodPut, manifest))
+	suite.Equal(http.StatusInternalServerError, rr.Code)
+}
+
+func TestMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, &MiddlewareTestSuite{})
+}
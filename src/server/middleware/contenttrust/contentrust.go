@@ -24,6 +24,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/pkg/signature/cosign"
 	"github.com/goharbor/harbor/src/server/middleware"
 	"github.com/goharbor/harbor/src/server/middleware/util"
 )
@@ -51,6 +52,18 @@ func ContentTrust() func(http.Handler) http.Handler {
 				}
 				return err
 			}
+			policy := &cosign.Policy{
+				Issuers:    pro.CosignKeylessIssuers(),
+				Identities: pro.CosignKeylessIdentities(),
+			}
+			if !policy.IsEmpty() {
+				if err := keylessChecking(ctx, r, af, pro.ProjectID, policy); err != nil {
+					if errors.IsErr(err, errors.PROJECTPOLICYVIOLATION) {
+						return errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).WithMessage("The image is not signed by the allowed keyless identities.")
+					}
+					return err
+				}
+			}
 		}
 		if pro.ContentTrustEnabled() {
 			if err := signatureChecking(ctx, r, af, pro.ProjectID, model.TypeNotationSignature); err != nil {
@@ -100,3 +113,38 @@ func signatureChecking(ctx context.Context, r *http.Request, af lib.ArtifactInfo
 
 	return nil
 }
+
+// keylessChecking verifies the cosign signatures of the artifact against the keyless policy of the project
+func keylessChecking(ctx context.Context, r *http.Request, af lib.ArtifactInfo, projectID int64, policy *cosign.Policy) error {
+	logger := log.G(ctx)
+
+	art, err := artifact.Ctl.GetByReference(ctx, af.Repository, af.Reference, &artifact.Option{
+		WithAccessory: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	ok, err := util.SkipPolicyChecking(r, projectID, art.ID)
+	if err != nil {
+		return err
+	}
+	if ok {
+		logger.Debugf("skip the keyless checking of pulling artifact %s@%s", af.Repository, af.Digest)
+		return nil
+	}
+
+	var signatures []string
+	for _, acc := range art.Accessories {
+		if acc.GetData().Type == model.TypeCosignSignature {
+			signatures = append(signatures, acc.GetData().Digest)
+		}
+	}
+	if err := cosign.DefaultVerifier.Verify(ctx, art.RepositoryName, art.Digest, signatures, policy); err != nil {
+		if errors.IsErr(err, errors.PROJECTPOLICYVIOLATION) {
+			logger.Warningf("the keyless signatures of artifact %s@%s aren't trusted: %v", art.RepositoryName, art.Digest, err)
+		}
+		return err
+	}
+	return nil
+}
@@ -27,15 +27,18 @@ import (
 	"github.com/goharbor/harbor/src/controller/artifact/processor/image"
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/pkg/accessory"
 	accessorymodel "github.com/goharbor/harbor/src/pkg/accessory/model"
 	basemodel "github.com/goharbor/harbor/src/pkg/accessory/model/base"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/signature/cosign"
 	securitytesting "github.com/goharbor/harbor/src/testing/common/security"
 	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
 	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
 	"github.com/goharbor/harbor/src/testing/mock"
 	accessorytesting "github.com/goharbor/harbor/src/testing/pkg/accessory"
+	cosigntesting "github.com/goharbor/harbor/src/testing/pkg/signature/cosign"
 )
 
 type ContentTrustMiddlewareTestSuite struct {
@@ -50,6 +53,9 @@ type ContentTrustMiddlewareTestSuite struct {
 	originalAccessMgr accessory.Manager
 	accessMgr         *accessorytesting.Manager
 
+	originalVerifier cosign.Verifier
+	verifier         *cosigntesting.Verifier
+
 	artifact *artifact.Artifact
 	project  *proModels.Project
 
@@ -69,6 +75,10 @@ func (suite *ContentTrustMiddlewareTestSuite) SetupTest() {
 	suite.accessMgr = &accessorytesting.Manager{}
 	accessory.Mgr = suite.accessMgr
 
+	suite.originalVerifier = cosign.DefaultVerifier
+	suite.verifier = &cosigntesting.Verifier{}
+	cosign.DefaultVerifier = suite.verifier
+
 	suite.artifact = &artifact.Artifact{}
 	suite.artifact.Type = image.ArtifactTypeImage
 	suite.artifact.ProjectID = 1
@@ -93,6 +103,7 @@ func (suite *ContentTrustMiddlewareTestSuite) TearDownTest() {
 	artifact.Ctl = suite.originalArtifactController
 	project.Ctl = suite.originalProjectController
 	accessory.Mgr = suite.originalAccessMgr
+	cosign.DefaultVerifier = suite.originalVerifier
 }
 
 func (suite *ContentTrustMiddlewareTestSuite) makeRequest(setHeader ...bool) *http.Request {
@@ -244,6 +255,52 @@ func (suite *ContentTrustMiddlewareTestSuite) TestCosignSignaturePulling() {
 	suite.Equal(rr.Code, http.StatusOK)
 }
 
+// pull cosign keyless signed artifact when keyless policy is configured.
+func (suite *ContentTrustMiddlewareTestSuite) TestCosignKeylessPulling() {
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(suite.artifact, nil)
+	mock.OnAnything(suite.projectController, "GetByName").Return(suite.project, nil)
+	acc := &basemodel.Default{
+		Data: accessorymodel.AccessoryData{
+			ID:                1,
+			ArtifactID:        2,
+			SubArtifactDigest: suite.artifact.Digest,
+			Type:              accessorymodel.TypeCosignSignature,
+			Digest:            "sha256:signature",
+		},
+	}
+	suite.project.Metadata[proModels.ProMetaCosignKeylessIssuers] = "https://token.actions.githubusercontent.com"
+	suite.project.Metadata[proModels.ProMetaCosignKeylessIdentities] = "https://github.com/goharbor/harbor/.github/workflows/*"
+	suite.artifact.Accessories = []accessorymodel.Accessory{acc}
+	mock.OnAnything(suite.accessMgr, "List").Return([]accessorymodel.Accessory{}, nil)
+	policy := &cosign.Policy{
+		Issuers:    []string{"https://token.actions.githubusercontent.com"},
+		Identities: []string{"https://github.com/goharbor/harbor/.github/workflows/*"},
+	}
+	suite.verifier.On("Verify", mock.Anything, suite.artifact.RepositoryName, suite.artifact.Digest, []string{"sha256:signature"}, policy).Return(nil).Once()
+
+	req := suite.makeRequest()
+	rr := httptest.NewRecorder()
+	ContentTrust()(suite.next).ServeHTTP(rr, req)
+	suite.Equal(http.StatusOK, rr.Code)
+
+	// signed by the identity not allowed
+	suite.verifier.On("Verify", mock.Anything, suite.artifact.RepositoryName, suite.artifact.Digest, []string{"sha256:signature"}, policy).
+		Return(errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION)).Once()
+	req = suite.makeRequest()
+	rr = httptest.NewRecorder()
+	ContentTrust()(suite.next).ServeHTTP(rr, req)
+	suite.Equal(http.StatusPreconditionFailed, rr.Code)
+
+	// failed to verify
+	suite.verifier.On("Verify", mock.Anything, suite.artifact.RepositoryName, suite.artifact.Digest, []string{"sha256:signature"}, policy).
+		Return(fmt.Errorf("error")).Once()
+	req = suite.makeRequest()
+	rr = httptest.NewRecorder()
+	ContentTrust()(suite.next).ServeHTTP(rr, req)
+	suite.Equal(http.StatusInternalServerError, rr.Code)
+	suite.verifier.AssertExpectations(suite.T())
+}
+
 // notation signature checking when policy checker is enabled.
 func (suite *ContentTrustMiddlewareTestSuite) TestNotationSignaturePulling() {
 	mock.OnAnything(suite.artifactController, "GetByReference").Return(suite.artifact, nil)
@@ -49,6 +49,19 @@ var (
 
 	// media type of harbor sbom
 	mediaTypeHarborSBOM = "application/vnd.goharbor.harbor.sbom.v1"
+
+	// media types of the VEX documents
+	mediaTypeOpenVEX      = "application/vnd.openvex+json"
+	mediaTypeCycloneDXVEX = "application/vnd.cyclonedx.vex+json"
+
+	// media types of the SBOMs generated outside of Harbor
+	mediaTypeCycloneDXSBOM = "application/vnd.cyclonedx+json"
+	mediaTypeSPDXSBOM      = "application/spdx+json"
+	mediaTypeSPDXSBOMVnd   = "application/vnd.spdx+json"
+
+	// media types of the in-toto attestations, either the bare statement or wrapped in the DSSE envelope
+	mediaTypeInToto       = "application/vnd.in-toto+json"
+	mediaTypeDSSEEnvelope = "application/vnd.dsse.envelope.v1+json"
 )
 
 /*
@@ -161,6 +174,16 @@ func Middleware() func(http.Handler) http.Handler {
 				accData.Type = model.TypeCosignSignature
 			case mediaTypeHarborSBOM:
 				accData.Type = model.TypeHarborSBOM
+			case mediaTypeOpenVEX:
+				accData.Type = model.TypeOpenVEX
+			case mediaTypeCycloneDXVEX:
+				accData.Type = model.TypeCycloneDXVEX
+			case mediaTypeCycloneDXSBOM:
+				accData.Type = model.TypeCycloneDXSBOM
+			case mediaTypeSPDXSBOM, mediaTypeSPDXSBOMVnd:
+				accData.Type = model.TypeSPDXSBOM
+			case mediaTypeInToto, mediaTypeDSSEEnvelope:
+				accData.Type = model.TypeInTotoAttestation
 			}
 			if subjectArt != nil {
 				accData.SubArtifactID = subjectArt.ID
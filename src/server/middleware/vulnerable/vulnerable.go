@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/goharbor/harbor/src/controller/artifact/processor/cnab"
 	"github.com/goharbor/harbor/src/controller/artifact/processor/image"
@@ -121,7 +122,19 @@ func Middleware() func(http.Handler) http.Handler {
 		}
 
 		// Do judgement
-		if vulnerable.Severity != nil && vulnerable.Severity.Code() >= projectSeverity.Code() {
+		policy := preventionPolicy(proj, projectSeverity)
+		if !policy.IsSeverityOnly() {
+			if matched := policy.Filter(vulnerable.Vulnerabilities, time.Now()); len(matched) > 0 {
+				thing := "vulnerability"
+				if len(matched) > 1 {
+					thing = "vulnerabilities"
+				}
+				msg := fmt.Sprintf(`current image with %d %s cannot be pulled due to configured policy in 'Prevent images with vulnerabilities of %s from running.' `+
+					`To continue with pull, please contact your project administrator to exempt matched vulnerabilities through configuring the CVE allowlist.`,
+					len(matched), thing, policy)
+				return errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).WithMessage(msg)
+			}
+		} else if vulnerable.Severity != nil && vulnerable.Severity.Code() >= projectSeverity.Code() {
 			thing := "vulnerability"
 			if vulnerable.VulnerabilitiesCount > 1 {
 				thing = "vulnerabilities"
@@ -137,6 +150,23 @@ func Middleware() func(http.Handler) http.Handler {
 			logger.Infof("Vulnerable policy check: bypassed CVE %s", cve)
 		}
 
+		// Print the CVEs declared not affected by the VEX statements
+		for _, cve := range vulnerable.CVESuppressed {
+			logger.Infof("Vulnerable policy check: suppressed CVE %s of package %s by VEX, justification: %s", cve.ID, cve.Package, cve.Justification)
+		}
+
 		return nil
 	})
 }
+
+// preventionPolicy returns the vulnerability prevention policy configured in the project
+func preventionPolicy(proj *project.Project, severity vuln.Severity) *vuln.PreventionPolicy {
+	return &vuln.PreventionPolicy{
+		Severity:       severity,
+		CVSSScore:      proj.PreventVulCVSSScore(),
+		FixableOnly:    proj.PreventVulFixableOnly(),
+		MinAgeDays:     proj.PreventVulMinAgeDays(),
+		KnownExploited: proj.PreventVulKnownExploited(),
+		EPSSScore:      proj.PreventVulEPSSScore(),
+	}
+}
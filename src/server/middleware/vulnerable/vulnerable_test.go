@@ -377,6 +377,59 @@ func (suite *MiddlewareTestSuite) TestPrevented() {
 	}
 }
 
+func (suite *MiddlewareTestSuite) TestPreventedByPolicy() {
+	suite.project.Metadata[proModels.ProMetaPreventVulCVSSScore] = "7"
+	suite.project.Metadata[proModels.ProMetaPreventVulFixableOnly] = "true"
+
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(suite.artifact, nil)
+	mock.OnAnything(suite.projectController, "Get").Return(suite.project, nil)
+	mock.OnAnything(suite.checker, "IsScannable").Return(true, nil)
+	mock.OnAnything(suite.accessMgr, "List").Return([]accessorymodel.Accessory{}, nil)
+
+	critical := vuln.Critical
+	score := 9.8
+
+	{
+		// the critical vulnerability without fix is not matched
+		mock.OnAnything(suite.scanController, "GetVulnerable").Return(&scan.Vulnerable{
+			ScanStatus:           "Success",
+			Severity:             &critical,
+			VulnerabilitiesCount: 1,
+			Vulnerabilities: []*vuln.VulnerabilityItem{
+				{ID: "cve-2020", Severity: vuln.Critical, CVSSDetails: vuln.CVSS{ScoreV3: &score}},
+			},
+		}, nil).Once()
+
+		req := suite.makeRequest()
+		rr := httptest.NewRecorder()
+
+		Middleware()(suite.next).ServeHTTP(rr, req)
+		suite.Equal(rr.Code, http.StatusOK)
+	}
+
+	{
+		// the critical vulnerability with fix is matched
+		mock.OnAnything(suite.scanController, "GetVulnerable").Return(&scan.Vulnerable{
+			ScanStatus:           "Success",
+			Severity:             &critical,
+			VulnerabilitiesCount: 2,
+			Vulnerabilities: []*vuln.VulnerabilityItem{
+				{ID: "cve-2020", Severity: vuln.Critical, CVSSDetails: vuln.CVSS{ScoreV3: &score}},
+				{ID: "cve-2021", Severity: vuln.Critical, FixVersion: "1.0", CVSSDetails: vuln.CVSS{ScoreV3: &score}},
+			},
+		}, nil).Once()
+
+		req := suite.makeRequest()
+		rr := httptest.NewRecorder()
+
+		Middleware()(suite.next).ServeHTTP(rr, req)
+		suite.Equal(rr.Code, http.StatusPreconditionFailed)
+
+		suite.Contains(rr.Body.String(), "current image with 1 vulnerability cannot be pulled")
+		suite.Contains(rr.Body.String(), "CVSS score of 7 or higher, fix available")
+	}
+}
+
 func (suite *MiddlewareTestSuite) TestArtifactIsImageIndex() {
 	critical := vuln.Critical
 
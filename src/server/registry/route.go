@@ -17,6 +17,7 @@ package registry
 import (
 	"net/http"
 
+	"github.com/goharbor/harbor/src/server/middleware/admission"
 	"github.com/goharbor/harbor/src/server/middleware/blob"
 	"github.com/goharbor/harbor/src/server/middleware/contenttrust"
 	"github.com/goharbor/harbor/src/server/middleware/cosign"
@@ -56,6 +57,7 @@ func RegisterRoutes() {
 		Middleware(repoproxy.ManifestMiddleware()).
 		Middleware(contenttrust.ContentTrust()).
 		Middleware(vulnerable.Middleware()).
+		Middleware(admission.Middleware()).
 		HandlerFunc(getManifest)
 	root.NewRoute().
 		Method(http.MethodHead).
@@ -64,6 +66,7 @@ func RegisterRoutes() {
 		Middleware(repoproxy.ManifestMiddleware()).
 		Middleware(contenttrust.ContentTrust()).
 		Middleware(vulnerable.Middleware()).
+		Middleware(admission.Middleware()).
 		HandlerFunc(getManifest)
 	root.NewRoute().
 		Method(http.MethodDelete).
@@ -77,6 +80,7 @@ func RegisterRoutes() {
 		Middleware(metric.InjectOpIDMiddleware(metric.ManifestOperationID)).
 		Middleware(repoproxy.DisableBlobAndManifestUploadMiddleware()).
 		Middleware(immutable.Middleware()).
+		Middleware(admission.Middleware()).
 		Middleware(quota.PutManifestMiddleware()).
 		Middleware(cosign.SignatureMiddleware()).
 		Middleware(subject.Middleware()).
@@ -0,0 +1,311 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/admission"
+	"github.com/goharbor/harbor/src/pkg/admission/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/admission"
+)
+
+func newAdmissionAPI() *admissionAPI {
+	return &admissionAPI{
+		admissionMgr: admission.Mgr,
+		projectCtl:   project.Ctl,
+	}
+}
+
+type admissionAPI struct {
+	BaseAPI
+	admissionMgr admission.Manager
+	projectCtl   project.Controller
+}
+
+func (a *admissionAPI) ListProjectAdmissionPolicies(ctx context.Context, params operation.ListProjectAdmissionPoliciesParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := a.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionList, rbac.ResourceAdmissionPolicy); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	query, err := a.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	p, err := a.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	total, policies, err := a.listPolicies(ctx, p.ProjectID, query)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewListProjectAdmissionPoliciesOK().
+		WithXTotalCount(total).
+		WithLink(a.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(policies)
+}
+
+func (a *admissionAPI) CreateProjectAdmissionPolicy(ctx context.Context, params operation.CreateProjectAdmissionPolicyParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	// the policies are evaluated by the OPA server shared by all the projects, only the system admin can author them
+	if err := a.RequireSystemAccess(ctx, rbac.ActionCreate, rbac.ResourceAdmissionPolicy); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	p, err := a.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	id, err := a.createPolicy(ctx, p.ProjectID, params.Policy)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	location := fmt.Sprintf("%s/%d", strings.TrimSuffix(params.HTTPRequest.URL.Path, "/"), id)
+	return operation.NewCreateProjectAdmissionPolicyCreated().WithLocation(location)
+}
+
+func (a *admissionAPI) GetProjectAdmissionPolicy(ctx context.Context, params operation.GetProjectAdmissionPolicyParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := a.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionRead, rbac.ResourceAdmissionPolicy); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	p, err := a.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	policy, err := a.getPolicy(ctx, p.ProjectID, params.AdmissionPolicyID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewGetProjectAdmissionPolicyOK().WithPayload(toAdmissionPolicy(policy))
+}
+
+func (a *admissionAPI) UpdateProjectAdmissionPolicy(ctx context.Context, params operation.UpdateProjectAdmissionPolicyParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := a.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceAdmissionPolicy); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	p, err := a.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	if err := a.updatePolicy(ctx, p.ProjectID, params.AdmissionPolicyID, params.Policy); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewUpdateProjectAdmissionPolicyOK()
+}
+
+func (a *admissionAPI) DeleteProjectAdmissionPolicy(ctx context.Context, params operation.DeleteProjectAdmissionPolicyParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := a.RequireSystemAccess(ctx, rbac.ActionDelete, rbac.ResourceAdmissionPolicy); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	p, err := a.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	if err := a.deletePolicy(ctx, p.ProjectID, params.AdmissionPolicyID); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewDeleteProjectAdmissionPolicyOK()
+}
+
+func (a *admissionAPI) ListSystemAdmissionPolicies(ctx context.Context, params operation.ListSystemAdmissionPoliciesParams) middleware.Responder {
+	if err := a.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceAdmissionPolicy); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	query, err := a.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	total, policies, err := a.listPolicies(ctx, 0, query)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewListSystemAdmissionPoliciesOK().
+		WithXTotalCount(total).
+		WithLink(a.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(policies)
+}
+
+func (a *admissionAPI) CreateSystemAdmissionPolicy(ctx context.Context, params operation.CreateSystemAdmissionPolicyParams) middleware.Responder {
+	if err := a.RequireSystemAccess(ctx, rbac.ActionCreate, rbac.ResourceAdmissionPolicy); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	id, err := a.createPolicy(ctx, 0, params.Policy)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	location := fmt.Sprintf("%s/%d", strings.TrimSuffix(params.HTTPRequest.URL.Path, "/"), id)
+	return operation.NewCreateSystemAdmissionPolicyCreated().WithLocation(location)
+}
+
+func (a *admissionAPI) GetSystemAdmissionPolicy(ctx context.Context, params operation.GetSystemAdmissionPolicyParams) middleware.Responder {
+	if err := a.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceAdmissionPolicy); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	policy, err := a.getPolicy(ctx, 0, params.AdmissionPolicyID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewGetSystemAdmissionPolicyOK().WithPayload(toAdmissionPolicy(policy))
+}
+
+func (a *admissionAPI) UpdateSystemAdmissionPolicy(ctx context.Context, params operation.UpdateSystemAdmissionPolicyParams) middleware.Responder {
+	if err := a.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceAdmissionPolicy); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	if err := a.updatePolicy(ctx, 0, params.AdmissionPolicyID, params.Policy); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewUpdateSystemAdmissionPolicyOK()
+}
+
+func (a *admissionAPI) DeleteSystemAdmissionPolicy(ctx context.Context, params operation.DeleteSystemAdmissionPolicyParams) middleware.Responder {
+	if err := a.RequireSystemAccess(ctx, rbac.ActionDelete, rbac.ResourceAdmissionPolicy); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	if err := a.deletePolicy(ctx, 0, params.AdmissionPolicyID); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewDeleteSystemAdmissionPolicyOK()
+}
+
+func (a *admissionAPI) listPolicies(ctx context.Context, projectID int64, query *q.Query) (int64, []*models.AdmissionPolicy, error) {
+	query.Keywords["ProjectID"] = projectID
+
+	total, err := a.admissionMgr.Count(ctx, query)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	policies, err := a.admissionMgr.List(ctx, query)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var results []*models.AdmissionPolicy
+	for _, policy := range policies {
+		results = append(results, toAdmissionPolicy(policy))
+	}
+
+	return total, results, nil
+}
+
+func (a *admissionAPI) createPolicy(ctx context.Context, projectID int64, policy *models.AdmissionPolicy) (int64, error) {
+	if policy == nil {
+		return 0, errors.BadRequestError(nil).WithMessage("the admission policy is required")
+	}
+
+	return a.admissionMgr.Create(ctx, &model.Policy{
+		ProjectID:   projectID,
+		Name:        policy.Name,
+		Description: policy.Description,
+		Module:      policy.Module,
+		Actions:     strings.Join(policy.Actions, ","),
+		Enabled:     policy.Enabled,
+	})
+}
+
+func (a *admissionAPI) updatePolicy(ctx context.Context, projectID, id int64, policy *models.AdmissionPolicy) error {
+	if policy == nil {
+		return errors.BadRequestError(nil).WithMessage("the admission policy is required")
+	}
+
+	existing, err := a.getPolicy(ctx, projectID, id)
+	if err != nil {
+		return err
+	}
+
+	existing.Name = policy.Name
+	existing.Description = policy.Description
+	existing.Module = policy.Module
+	existing.Actions = strings.Join(policy.Actions, ",")
+	existing.Enabled = policy.Enabled
+	return a.admissionMgr.Update(ctx, existing)
+}
+
+func (a *admissionAPI) deletePolicy(ctx context.Context, projectID, id int64) error {
+	policy, err := a.getPolicy(ctx, projectID, id)
+	if err != nil {
+		return err
+	}
+
+	return a.admissionMgr.Delete(ctx, policy.ID)
+}
+
+// getPolicy returns the admission policy and makes sure it belongs to the project, the project ID 0 means the system level
+func (a *admissionAPI) getPolicy(ctx context.Context, projectID, id int64) (*model.Policy, error) {
+	policy, err := a.admissionMgr.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if policy.ProjectID != projectID {
+		return nil, errors.NotFoundError(nil).WithMessagef("admission policy %d not found", id)
+	}
+
+	return policy, nil
+}
+
+func toAdmissionPolicy(policy *model.Policy) *models.AdmissionPolicy {
+	return &models.AdmissionPolicy{
+		ID:           policy.ID,
+		ProjectID:    policy.ProjectID,
+		Name:         policy.Name,
+		Description:  policy.Description,
+		Module:       policy.Module,
+		Actions:      policy.GetActions(),
+		Enabled:      policy.Enabled,
+		CreationTime: strfmt.DateTime(policy.CreationTime),
+		UpdateTime:   strfmt.DateTime(policy.UpdateTime),
+	}
+}
@@ -15,20 +15,29 @@
 package handler
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"strconv"
 	"strings"
 
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/controller/gc"
 	"github.com/goharbor/harbor/src/jobservice/job"
+	gcjob "github.com/goharbor/harbor/src/jobservice/job/impl/gc"
+	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/task"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
@@ -36,6 +45,9 @@ import (
 	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/gc"
 )
 
+// the default grace period of the online GC in minutes
+const defaultGCGracePeriod = 120
+
 type gcAPI struct {
 	BaseAPI
 	gcCtr gc.Controller
@@ -55,7 +67,7 @@ func (g *gcAPI) CreateGCSchedule(ctx context.Context, params operation.CreateGCS
 	if err := g.RequireSystemAccess(ctx, rbac.ActionCreate, rbac.ResourceGarbageCollection); err != nil {
 		return g.SendError(ctx, err)
 	}
-	id, err := g.kick(ctx, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, params.Schedule.Parameters)
+	id, err := g.kick(ctx, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, model.ScheduleOptions(params.Schedule.Schedule), params.Schedule.Parameters)
 	if err != nil {
 		return g.SendError(ctx, err)
 	}
@@ -72,14 +84,14 @@ func (g *gcAPI) UpdateGCSchedule(ctx context.Context, params operation.UpdateGCS
 	if err := g.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceGarbageCollection); err != nil {
 		return g.SendError(ctx, err)
 	}
-	_, err := g.kick(ctx, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, params.Schedule.Parameters)
+	_, err := g.kick(ctx, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, model.ScheduleOptions(params.Schedule.Schedule), params.Schedule.Parameters)
 	if err != nil {
 		return g.SendError(ctx, err)
 	}
 	return operation.NewUpdateGCScheduleOK()
 }
 
-func (g *gcAPI) kick(ctx context.Context, scheType string, cron string, parameters map[string]any) (int64, error) {
+func (g *gcAPI) kick(ctx context.Context, scheType string, cron string, options *job.ScheduleOptions, parameters map[string]any) (int64, error) {
 	if parameters == nil {
 		parameters = make(map[string]any)
 	}
@@ -113,6 +125,15 @@ func (g *gcAPI) kick(ctx context.Context, scheType string, cron string, paramete
 			}
 			policy.Workers = int(wInt)
 		}
+		if policy.ProjectIDs, err = parseGCProjectIDs(parameters["project_ids"]); err != nil {
+			return 0, err
+		}
+		if err = parseGCOnline(parameters, &policy); err != nil {
+			return 0, err
+		}
+		if policy.Shards, err = parseGCShards(parameters["shards"]); err != nil {
+			return 0, err
+		}
 
 		id, err = g.gcCtr.Start(ctx, policy, task.ExecutionTriggerManual)
 	case ScheduleNone:
@@ -140,20 +161,29 @@ func (g *gcAPI) kick(ctx context.Context, scheType string, cron string, paramete
 			}
 			policy.Workers = int(wInt)
 		}
-		err = g.updateSchedule(ctx, scheType, cron, policy)
+		if policy.ProjectIDs, err = parseGCProjectIDs(parameters["project_ids"]); err != nil {
+			return 0, err
+		}
+		if err = parseGCOnline(parameters, &policy); err != nil {
+			return 0, err
+		}
+		if policy.Shards, err = parseGCShards(parameters["shards"]); err != nil {
+			return 0, err
+		}
+		err = g.updateSchedule(ctx, scheType, cron, options, policy)
 	}
 	return id, err
 }
 
-func (g *gcAPI) createSchedule(ctx context.Context, cronType, cron string, policy gc.Policy) error {
-	_, err := g.gcCtr.CreateSchedule(ctx, cronType, cron, policy)
+func (g *gcAPI) createSchedule(ctx context.Context, cronType, cron string, options *job.ScheduleOptions, policy gc.Policy) error {
+	_, err := g.gcCtr.CreateSchedule(ctx, cronType, cron, options, policy)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (g *gcAPI) updateSchedule(ctx context.Context, cronType, cron string, policy gc.Policy) error {
+func (g *gcAPI) updateSchedule(ctx context.Context, cronType, cron string, options *job.ScheduleOptions, policy gc.Policy) error {
 	if err := utils.ValidateCronString(cron); err != nil {
 		return errors.New(nil).WithCode(errors.BadRequestCode).
 			WithMessagef("invalid cron string for scheduled gc: %s, error: %v", cron, err)
@@ -161,7 +191,7 @@ func (g *gcAPI) updateSchedule(ctx context.Context, cronType, cron string, polic
 	if err := g.gcCtr.DeleteSchedule(ctx); err != nil {
 		return err
 	}
-	return g.createSchedule(ctx, cronType, cron, policy)
+	return g.createSchedule(ctx, cronType, cron, options, policy)
 }
 
 func (g *gcAPI) GetGCSchedule(ctx context.Context, _ operation.GetGCScheduleParams) middleware.Responder {
@@ -270,6 +300,17 @@ func (g *gcAPI) GetGCLog(ctx context.Context, params operation.GetGCLogParams) m
 	if len(tasks) == 0 {
 		return g.SendError(ctx, errors.New(nil).WithCode(errors.NotFoundCode).WithMessagef("garbage collection %d log is not found", params.GCID))
 	}
+	if lib.BoolValue(params.Follow) {
+		taskID, offset, err := gcLogOffset(params.HTTPRequest)
+		if err != nil {
+			return g.SendError(ctx, err)
+		}
+		stream, err := g.followGCLog(ctx, params.GCID, taskID, offset)
+		if err != nil {
+			return g.SendError(ctx, err)
+		}
+		return streamLog(stream)
+	}
 	log, err := g.gcCtr.GetTaskLog(ctx, tasks[0].ID)
 	if err != nil {
 		return g.SendError(ctx, err)
@@ -277,6 +318,153 @@ func (g *gcAPI) GetGCLog(ctx context.Context, params operation.GetGCLogParams) m
 	return operation.NewGetGCLogOK().WithPayload(string(log))
 }
 
+// gcLogOffset returns the task and the log offset to follow the gc log from, which are in the id
+// of the last Server-Sent Event received by the client and sent back in the 'Last-Event-ID' header
+func gcLogOffset(req *http.Request) (int64, int64, error) {
+	id := req.Header.Get("Last-Event-ID")
+	if len(id) == 0 {
+		return 0, 0, nil
+	}
+	task, offset, ok := strings.Cut(id, "-")
+	if ok {
+		taskID, err1 := strconv.ParseInt(task, 10, 64)
+		off, err2 := strconv.ParseInt(offset, 10, 64)
+		if err1 == nil && err2 == nil && taskID > 0 && off >= 0 {
+			return taskID, off, nil
+		}
+	}
+	return 0, 0, errors.BadRequestError(nil).WithMessagef("invalid Last-Event-ID: %s", id)
+}
+
+// followGCLog follows the logs of the tasks of the gc execution one by one from the task and the offset,
+// the mark task is followed first and then the sweep tasks of the shards which are created by it
+func (g *gcAPI) followGCLog(ctx context.Context, gcID int64, taskID int64, offset int64) (io.ReadCloser, error) {
+	first, err := g.nextGCTask(ctx, gcID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if first == nil {
+		return nil, errors.New(nil).WithCode(errors.NotFoundCode).WithMessagef("garbage collection %d log is not found", gcID)
+	}
+	if first.ID != taskID {
+		offset = 0
+	}
+	// the first stream is opened here so the error is returned before the response starts
+	stream, err := g.gcCtr.FollowTaskLog(ctx, first.ID, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(g.relayGCLog(ctx, gcID, first.ID, stream, writer))
+	}()
+	return reader, nil
+}
+
+// relayGCLog writes the Server-Sent Events of the task logs into the writer starting with the stream of the task,
+// the id of each event is rewritten as "<task ID>-<offset>" and only the end event of the last task is kept
+func (g *gcAPI) relayGCLog(ctx context.Context, gcID int64, taskID int64, stream io.ReadCloser, w io.Writer) error {
+	for {
+		end, err := relayTaskLog(taskID, stream, w)
+		if err != nil || end == nil {
+			return err
+		}
+		// the sweep tasks are created before the mark task finishes, so they're listed once the mark task is done
+		next, err := g.nextGCTask(ctx, gcID, taskID+1)
+		if err != nil {
+			return err
+		}
+		if next == nil {
+			_, err = w.Write(end)
+			return err
+		}
+		taskID = next.ID
+		if stream, err = g.gcCtr.FollowTaskLog(ctx, taskID, 0); err != nil {
+			return err
+		}
+	}
+}
+
+// nextGCTask returns the first task of the gc execution whose ID is not less than the given one, nil if there is none
+func (g *gcAPI) nextGCTask(ctx context.Context, gcID int64, taskID int64) (*gc.Task, error) {
+	query := q.New(q.KeyWords{
+		"ExecutionID": gcID,
+		"ID":          &q.Range{Min: taskID},
+	})
+	query.Sorts = []*q.Sort{q.NewSort("ID", false)}
+	query.PageSize = 1
+	tasks, err := g.gcCtr.ListTasks(ctx, query)
+	if err != nil || len(tasks) == 0 {
+		return nil, err
+	}
+	return tasks[0], nil
+}
+
+// relayTaskLog relays the Server-Sent Events of the task log stream into the writer until the log ends.
+// The end event is returned to be sent after the last task, nil is returned if an error event is relayed.
+func relayTaskLog(taskID int64, stream io.ReadCloser, w io.Writer) ([]byte, error) {
+	defer stream.Close()
+
+	var (
+		reader = bufio.NewReader(stream)
+		event  bytes.Buffer
+		name   string
+	)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				// the stream is closed without the end event
+				return nil, nil
+			}
+			return nil, err
+		}
+		switch {
+		case line == "\n":
+			event.WriteString(line)
+			switch name {
+			case "end":
+				return event.Bytes(), nil
+			case "error":
+				_, err = w.Write(event.Bytes())
+				return nil, err
+			}
+			if _, err := w.Write(event.Bytes()); err != nil {
+				return nil, err
+			}
+			event.Reset()
+			name = ""
+		case strings.HasPrefix(line, "id: "):
+			fmt.Fprintf(&event, "id: %d-%s", taskID, strings.TrimPrefix(line, "id: "))
+		case strings.HasPrefix(line, "event: "):
+			name = strings.TrimSpace(strings.TrimPrefix(line, "event: "))
+			event.WriteString(line)
+		default:
+			event.WriteString(line)
+		}
+	}
+}
+
+func (g *gcAPI) GetGCReport(ctx context.Context, params operation.GetGCReportParams) middleware.Responder {
+	if err := g.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceGarbageCollection); err != nil {
+		return g.SendError(ctx, err)
+	}
+	report, err := g.gcCtr.GetReport(ctx, params.GCID)
+	if err != nil {
+		return g.SendError(ctx, err)
+	}
+	return middleware.ResponderFunc(func(writer http.ResponseWriter, _ runtime.Producer) {
+		defer report.Close()
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("gc_report_%d.json", params.GCID)))
+		if _, err := io.Copy(writer, report); err != nil {
+			log.Errorf("failed to copy the gc report %d: %v", params.GCID, err)
+		}
+	})
+}
+
 func (g *gcAPI) StopGC(ctx context.Context, params operation.StopGCParams) middleware.Responder {
 	if err := g.RequireSystemAccess(ctx, rbac.ActionStop, rbac.ResourceGarbageCollection); err != nil {
 		return g.SendError(ctx, err)
@@ -289,6 +477,62 @@ func (g *gcAPI) StopGC(ctx context.Context, params operation.StopGCParams) middl
 	return operation.NewStopGCOK()
 }
 
+// parseGCProjectIDs parses the IDs of the projects which the GC is scoped to
+func parseGCProjectIDs(v any) ([]int64, error) {
+	if v == nil {
+		return nil, nil
+	}
+	items, ok := v.([]any)
+	if !ok {
+		return nil, errors.BadRequestError(nil).WithMessage("project_ids should be an array of project IDs")
+	}
+	var ids []int64
+	for _, item := range items {
+		n, ok := item.(json.Number)
+		if !ok {
+			return nil, errors.BadRequestError(nil).WithMessagef("invalid project ID: %v", item)
+		}
+		id, err := n.Int64()
+		if err != nil || id <= 0 {
+			return nil, errors.BadRequestError(nil).WithMessagef("invalid project ID: %s", n)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// parseGCOnline parses the online mode and its grace period in minutes
+func parseGCOnline(parameters map[string]any, policy *gc.Policy) error {
+	if online, ok := parameters["online"].(bool); ok {
+		policy.Online = online
+	}
+	if !policy.Online {
+		return nil
+	}
+	policy.GracePeriod = defaultGCGracePeriod
+	if gracePeriod, ok := parameters["grace_period"].(json.Number); ok {
+		gInt, err := gracePeriod.Int64()
+		if err != nil || gInt < 0 {
+			return errors.BadRequestError(nil).WithMessagef("invalid grace period: %s, it should be a non-negative integer in minutes", gracePeriod)
+		}
+		policy.GracePeriod = int(gInt)
+	}
+	return nil
+}
+
+// parseGCShards parses the count of the shards which the sweep is split into
+func parseGCShards(v any) (int, error) {
+	shards, ok := v.(json.Number)
+	if !ok {
+		return 0, nil
+	}
+	sInt, err := shards.Int64()
+	if err != nil || sInt < 1 || sInt > gcjob.MaxShards {
+		return 0, errors.BadRequestError(nil).WithMessagef("invalid shards: %s, it should be an integer between 1 and %d", shards, gcjob.MaxShards)
+	}
+	return int(sInt), nil
+}
+
 func validateWorkers(workers int) bool {
 	if workers <= 0 || workers > 10 {
 		return false
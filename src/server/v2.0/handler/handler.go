@@ -70,6 +70,9 @@ func New() http.Handler {
 		ScheduleAPI:           newScheduleAPI(),
 		SecurityhubAPI:        newSecurityAPI(),
 		PermissionsAPI:        newPermissionsAPIAPI(),
+		VexAPI:                newVEXAPI(),
+		SigningAPI:            newSigningAPI(),
+		AdmissionAPI:          newAdmissionAPI(),
 	})
 	if err != nil {
 		log.Fatal(err)
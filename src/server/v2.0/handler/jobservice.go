@@ -17,29 +17,37 @@ package handler
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
 
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
 
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/controller/jobmonitor"
+	"github.com/goharbor/harbor/src/controller/task"
+	jobSvc "github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/log"
 	jm "github.com/goharbor/harbor/src/pkg/jobmonitor"
+	"github.com/goharbor/harbor/src/pkg/task/dao"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 	"github.com/goharbor/harbor/src/server/v2.0/restapi/operations/jobservice"
 )
 
 type jobServiceAPI struct {
 	BaseAPI
-	jobCtr jobmonitor.MonitorController
+	jobCtr  jobmonitor.MonitorController
+	execCtl task.ExecutionController
 }
 
 func newJobServiceAPI() *jobServiceAPI {
-	return &jobServiceAPI{jobCtr: jobmonitor.Ctl}
+	return &jobServiceAPI{jobCtr: jobmonitor.Ctl, execCtl: task.ExecutionCtl}
 }
 
 func (j *jobServiceAPI) GetWorkerPools(ctx context.Context, _ jobservice.GetWorkerPoolsParams) middleware.Responder {
@@ -207,3 +215,180 @@ func (j *jobServiceAPI) ActionGetJobLog(ctx context.Context, params jobservice.A
 	}
 	return jobservice.NewActionGetJobLogOK().WithContentType("text/plain").WithPayload(string(log))
 }
+
+func (j *jobServiceAPI) GetJobPriorities(ctx context.Context, _ jobservice.GetJobPrioritiesParams) middleware.Responder {
+	if err := j.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceJobServiceMonitor); err != nil {
+		return j.SendError(ctx, err)
+	}
+	priorities, err := j.jobCtr.GetJobPriorities(ctx)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	return jobservice.NewGetJobPrioritiesOK().WithPayload(toJobPrioritiesResponse(priorities))
+}
+
+func (j *jobServiceAPI) UpdateJobPriorities(ctx context.Context, params jobservice.UpdateJobPrioritiesParams) middleware.Responder {
+	if err := j.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceJobServiceMonitor); err != nil {
+		return j.SendError(ctx, err)
+	}
+	priorities, err := fromJobPrioritiesRequest(params.Priorities)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	if err := j.jobCtr.UpdateJobPriorities(ctx, priorities); err != nil {
+		return j.SendError(ctx, err)
+	}
+	return jobservice.NewUpdateJobPrioritiesOK()
+}
+
+func (j *jobServiceAPI) ResetJobPriorities(ctx context.Context, _ jobservice.ResetJobPrioritiesParams) middleware.Responder {
+	if err := j.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceJobServiceMonitor); err != nil {
+		return j.SendError(ctx, err)
+	}
+	if err := j.jobCtr.ResetJobPriorities(ctx); err != nil {
+		return j.SendError(ctx, err)
+	}
+	return jobservice.NewResetJobPrioritiesOK()
+}
+
+func (j *jobServiceAPI) ListJobExecutions(ctx context.Context, params jobservice.ListJobExecutionsParams) middleware.Responder {
+	if err := j.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceJobServiceMonitor); err != nil {
+		return j.SendError(ctx, err)
+	}
+	query, err := j.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	total, err := j.execCtl.Count(ctx, query)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	executions, err := j.execCtl.List(ctx, query)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+
+	var payloads []*models.Execution
+	for _, exec := range executions {
+		p, err := convertExecutionToPayload(exec)
+		if err != nil {
+			return j.SendError(ctx, err)
+		}
+		payloads = append(payloads, p)
+	}
+
+	return jobservice.NewListJobExecutionsOK().WithPayload(payloads).WithXTotalCount(total).
+		WithLink(j.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String())
+}
+
+func (j *jobServiceAPI) GetJobExecutionStats(ctx context.Context, params jobservice.GetJobExecutionStatsParams) middleware.Responder {
+	if err := j.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceJobServiceMonitor); err != nil {
+		return j.SendError(ctx, err)
+	}
+	query, err := j.BuildQuery(ctx, params.Q, nil, nil, nil)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	stats, err := j.execCtl.Stats(ctx, query)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	return jobservice.NewGetJobExecutionStatsOK().WithPayload(toExecutionStatsOverview(stats))
+}
+
+func (j *jobServiceAPI) ExportJobExecutions(ctx context.Context, params jobservice.ExportJobExecutionsParams) middleware.Responder {
+	if err := j.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceJobServiceMonitor); err != nil {
+		return j.SendError(ctx, err)
+	}
+	query, err := j.BuildQuery(ctx, params.Q, nil, nil, nil)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	total, err := j.execCtl.Count(ctx, query)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	return middleware.ResponderFunc(func(writer http.ResponseWriter, _ runtime.Producer) {
+		writer.Header().Set("Content-Type", "text/csv")
+		writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "executions.csv"))
+		writer.Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
+		if total > task.MaxExportedExecutions {
+			writer.Header().Set("X-Truncated", "true")
+		}
+		// the headers have been sent, only log the error
+		if err := j.execCtl.Export(ctx, query, writer); err != nil {
+			log.Errorf("failed to export the executions: %v", err)
+		}
+	})
+}
+
+func toExecutionStatsOverview(stats []*dao.ExecutionStats) *models.ExecutionStatsOverview {
+	overview := &models.ExecutionStatsOverview{
+		VendorTypes: []*models.ExecutionStats{},
+	}
+	for _, s := range stats {
+		es := &models.ExecutionStats{
+			VendorType:       s.VendorType,
+			Total:            s.Total,
+			SuccessCount:     s.SuccessCount,
+			ErrorCount:       s.ErrorCount,
+			StoppedCount:     s.StoppedCount,
+			RunningCount:     s.RunningCount,
+			SuccessRate:      s.SuccessRate,
+			P50Duration:      s.P50Duration,
+			P95Duration:      s.P95Duration,
+			BytesTransferred: s.BytesTransferred,
+		}
+		// the statistics across all the vendor types has no vendor type
+		if len(s.VendorType) == 0 {
+			overview.Overall = es
+			continue
+		}
+		overview.VendorTypes = append(overview.VendorTypes, es)
+	}
+	return overview
+}
+
+func toJobPrioritiesResponse(priorities *jobSvc.Priorities) *models.JobPriorities {
+	result := &models.JobPriorities{
+		Default:             int64(priorities.Default),
+		MaxRunningPerTenant: int64(priorities.MaxRunningPerTenant),
+		VendorTypes:         make(map[string]int64),
+		Tenants:             make(map[string]int64),
+	}
+	for k, v := range priorities.VendorTypes {
+		result.VendorTypes[k] = int64(v)
+	}
+	for k, v := range priorities.Tenants {
+		result.Tenants[k] = int64(v)
+	}
+	return result
+}
+
+func fromJobPrioritiesRequest(req *models.JobPriorities) (*jobSvc.Priorities, error) {
+	if req == nil {
+		return nil, errors.BadRequestError(nil).WithMessage("the job priorities are required")
+	}
+	if req.Default < 0 || req.MaxRunningPerTenant < 0 {
+		return nil, errors.BadRequestError(nil).WithMessage("the job priorities should not be negative")
+	}
+	priorities := &jobSvc.Priorities{
+		Default:             uint(req.Default),
+		MaxRunningPerTenant: uint(req.MaxRunningPerTenant),
+		VendorTypes:         make(map[string]uint),
+		Tenants:             make(map[string]uint),
+	}
+	for k, v := range req.VendorTypes {
+		if v < 0 {
+			return nil, errors.BadRequestError(nil).WithMessagef("invalid priority of job type %s: %d", k, v)
+		}
+		priorities.VendorTypes[strings.ToUpper(k)] = uint(v)
+	}
+	for k, v := range req.Tenants {
+		if v < 0 {
+			return nil, errors.BadRequestError(nil).WithMessagef("invalid priority of project %s: %d", k, v)
+		}
+		priorities.Tenants[k] = uint(v)
+	}
+	return priorities, nil
+}
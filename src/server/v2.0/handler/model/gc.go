@@ -95,16 +95,18 @@ func (s *GCSchedule) ToSwagger() *models.GCHistory {
 		JobParameters: string(e),
 		Deleted:       false,
 		JobStatus:     s.Status,
-		Schedule: &models.ScheduleObj{
-			Cron:              s.CRON,
-			Type:              s.CRONType,
-			NextScheduledTime: strfmt.DateTime(utils.NextSchedule(s.CRON, time.Now())),
-		},
-		CreationTime: strfmt.DateTime(s.CreationTime),
-		UpdateTime:   strfmt.DateTime(s.UpdateTime),
+		Schedule:      s.scheduleObj(),
+		CreationTime:  strfmt.DateTime(s.CreationTime),
+		UpdateTime:    strfmt.DateTime(s.UpdateTime),
 	}
 }
 
+func (s *GCSchedule) scheduleObj() *models.ScheduleObj {
+	obj := NewScheduleObj(s.CRONType, s.CRON, s.Options)
+	obj.NextScheduledTime = NextScheduledTime(s.CRON, s.Options)
+	return obj
+}
+
 // NewGCSchedule ...
 func NewGCSchedule(s *scheduler.Schedule) *GCSchedule {
 	return &GCSchedule{Schedule: s}
@@ -15,8 +15,12 @@
 package model
 
 import (
+	"time"
+
 	"github.com/go-openapi/strfmt"
 
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 )
@@ -33,12 +37,9 @@ func (s *Schedule) ToSwagger() *models.Schedule {
 	}
 
 	return &models.Schedule{
-		ID:     s.ID,
-		Status: s.Status,
-		Schedule: &models.ScheduleObj{
-			Cron: s.CRON,
-			Type: s.CRONType,
-		},
+		ID:           s.ID,
+		Status:       s.Status,
+		Schedule:     NewScheduleObj(s.CRONType, s.CRON, s.Options),
 		Parameters:   s.ExtraAttrs,
 		CreationTime: strfmt.DateTime(s.CreationTime),
 		UpdateTime:   strfmt.DateTime(s.UpdateTime),
@@ -49,3 +50,56 @@ func (s *Schedule) ToSwagger() *models.Schedule {
 func NewSchedule(schedule *scheduler.Schedule) *Schedule {
 	return &Schedule{Schedule: schedule}
 }
+
+// NewScheduleObj converts the cron and its options to the swagger model
+func NewScheduleObj(cronType, cron string, options *job.ScheduleOptions) *models.ScheduleObj {
+	obj := &models.ScheduleObj{
+		Type: cronType,
+		Cron: cron,
+	}
+	if options != nil {
+		obj.Timezone = options.Timezone
+		obj.Jitter = options.Jitter
+		for _, w := range options.Blackouts {
+			obj.BlackoutWindows = append(obj.BlackoutWindows, &models.BlackoutWindow{
+				Cron:     w.Cron,
+				Duration: w.Duration,
+			})
+		}
+	}
+
+	return obj
+}
+
+// ScheduleOptions returns the timezone, jitter and blackout windows set in the swagger model,
+// nil is returned if none of them is set
+func ScheduleOptions(obj *models.ScheduleObj) *job.ScheduleOptions {
+	if obj == nil || (obj.Timezone == "" && obj.Jitter == 0 && len(obj.BlackoutWindows) == 0) {
+		return nil
+	}
+
+	options := &job.ScheduleOptions{
+		Timezone: obj.Timezone,
+		Jitter:   obj.Jitter,
+	}
+	for _, w := range obj.BlackoutWindows {
+		if w == nil {
+			continue
+		}
+		options.Blackouts = append(options.Blackouts, &job.BlackoutWindow{
+			Cron:     w.Cron,
+			Duration: w.Duration,
+		})
+	}
+
+	return options
+}
+
+// NextScheduledTime returns the next scheduled time of the cron with the options
+func NextScheduledTime(cron string, options *job.ScheduleOptions) strfmt.DateTime {
+	if options == nil {
+		return strfmt.DateTime(utils.NextSchedule(cron, time.Now()))
+	}
+
+	return strfmt.DateTime(job.NextSchedule(cron, options, time.Now()))
+}
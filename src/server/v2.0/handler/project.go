@@ -578,6 +578,9 @@ func (a *projectAPI) UpdateProject(ctx context.Context, params operation.UpdateP
 	if params.Project.Metadata != nil && p.IsProxy() {
 		params.Project.Metadata.EnableContentTrust = nil
 	}
+	if err := validateCosignKeyless(params.Project.Metadata); err != nil {
+		return a.SendError(ctx, err)
+	}
 	if err := lib.JSONCopy(&p.Metadata, params.Project.Metadata); err != nil {
 		log.Warningf("failed to call JSONCopy on project metadata when UpdateProject, error: %v", err)
 	}
@@ -686,6 +689,59 @@ func (a *projectAPI) SetScannerOfProject(ctx context.Context, params operation.S
 	return operation.NewSetScannerOfProjectOK()
 }
 
+func (a *projectAPI) ListScannersOfProject(ctx context.Context, params operation.ListScannersOfProjectParams) middleware.Responder {
+	if err := a.RequireAuthenticated(ctx); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := a.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionRead, rbac.ResourceScanner); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	p, err := a.projectCtl.Get(ctx, projectNameOrID, project.Metadata(false))
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	scanners, err := a.scannerCtl.GetRegistrationsByProject(ctx, p.ProjectID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	payload := make([]*models.ScannerRegistration, len(scanners))
+	for i, s := range scanners {
+		if err := a.scannerCtl.RetrieveCap(ctx, s); err != nil {
+			log.Warningf(scanner.RetrieveCapFailMsg, err)
+		}
+		payload[i] = model.NewScannerRegistration(s).ToSwagger(ctx)
+	}
+
+	return operation.NewListScannersOfProjectOK().WithPayload(payload)
+}
+
+func (a *projectAPI) SetScannersOfProject(ctx context.Context, params operation.SetScannersOfProjectParams) middleware.Responder {
+	if err := a.RequireAuthenticated(ctx); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := a.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionCreate, rbac.ResourceScanner); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	p, err := a.projectCtl.Get(ctx, projectNameOrID, project.Metadata(false))
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	if err := a.scannerCtl.SetRegistrationsByProject(ctx, p.ProjectID, params.Payload.Uuids); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewSetScannersOfProjectOK()
+}
+
 func (a *projectAPI) ListArtifactsOfProject(ctx context.Context, params operation.ListArtifactsOfProjectParams) middleware.Responder {
 	if err := a.RequireAuthenticated(ctx); err != nil {
 		return a.SendError(ctx, err)
@@ -795,6 +851,10 @@ func (a *projectAPI) validateProjectReq(ctx context.Context, req *models.Project
 		return errors.BadRequestError(fmt.Errorf("the retention_id in the request's payload when creating a project should be omitted, alternatively passing an empty string"))
 	}
 
+	if err := validateCosignKeyless(req.Metadata); err != nil {
+		return err
+	}
+
 	if req.RegistryID != nil {
 		if *req.RegistryID <= 0 {
 			return errors.BadRequestError(fmt.Errorf("%d is invalid value of registry_id, it should be geater than 0", *req.RegistryID))
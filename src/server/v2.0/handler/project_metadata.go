@@ -16,6 +16,7 @@ package handler
 
 import (
 	"context"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -27,6 +28,9 @@ import (
 	"github.com/goharbor/harbor/src/lib/errors"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	"github.com/goharbor/harbor/src/pkg/signature/cosign"
+	"github.com/goharbor/harbor/src/pkg/signing"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
 	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/project_metadata"
 )
 
@@ -143,7 +147,8 @@ func (p *projectMetadataAPI) validate(metas map[string]string) (map[string]strin
 
 	switch key {
 	case proModels.ProMetaPublic, proModels.ProMetaEnableContentTrust, proModels.ProMetaEnableContentTrustCosign,
-		proModels.ProMetaAutoSBOMGen, proModels.ProMetaPreventVul, proModels.ProMetaAutoScan, proModels.ProMetaReuseSysCVEAllowlist:
+		proModels.ProMetaAutoSBOMGen, proModels.ProMetaPreventVul, proModels.ProMetaAutoScan, proModels.ProMetaReuseSysCVEAllowlist,
+		proModels.ProMetaPreventVulFixableOnly, proModels.ProMetaPreventVulKnownExploited, proModels.ProMetaAutoSign:
 		v, err := strconv.ParseBool(value)
 		if err != nil {
 			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessagef("invalid value: %s", value)
@@ -155,6 +160,24 @@ func (p *projectMetadataAPI) validate(metas map[string]string) (map[string]strin
 			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessagef("invalid value: %s", value)
 		}
 		metas[proModels.ProMetaSeverity] = strings.ToLower(severity.String())
+	case proModels.ProMetaPreventVulCVSSScore:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil || v < 0 || v > 10 {
+			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessagef("invalid value: %s", value)
+		}
+		metas[key] = strconv.FormatFloat(v, 'f', -1, 64)
+	case proModels.ProMetaPreventVulEPSSScore:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil || v < 0 || v > 1 {
+			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessagef("invalid value: %s", value)
+		}
+		metas[key] = strconv.FormatFloat(v, 'f', -1, 64)
+	case proModels.ProMetaPreventVulMinAgeDays:
+		v, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || v < 0 {
+			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessagef("invalid value: %s", value)
+		}
+		metas[key] = strconv.FormatInt(v, 10)
 	case proModels.ProMetaProxySpeed:
 		v, err := strconv.ParseInt(value, 10, 32)
 		if err != nil {
@@ -167,8 +190,65 @@ func (p *projectMetadataAPI) validate(metas map[string]string) (map[string]strin
 			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessagef("invalid value: %s", value)
 		}
 		metas[proModels.ProMetaMaxUpstreamConn] = strconv.FormatInt(v, 10)
+	case proModels.ProMetaCosignKeylessIssuers, proModels.ProMetaCosignKeylessIdentities:
+		var items []string
+		for item := range strings.SplitSeq(value, ",") {
+			item = strings.TrimSpace(item)
+			if len(item) == 0 {
+				continue
+			}
+			if key == proModels.ProMetaCosignKeylessIssuers {
+				if u, err := url.Parse(item); err != nil || u.Scheme == "" || u.Host == "" {
+					return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessagef("invalid issuer: %s", item)
+				}
+			}
+			items = append(items, item)
+		}
+		if len(items) > 0 {
+			if err := validateCosignTrustRoot(); err != nil {
+				return nil, err
+			}
+		}
+		metas[key] = strings.Join(items, ",")
+	case proModels.ProMetaAutoSignFormat:
+		format := strings.ToLower(value)
+		if !signing.IsValidFormat(format) {
+			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessagef("invalid value: %s", value)
+		}
+		metas[key] = format
+	case proModels.ProMetaAutoSignTrigger:
+		trigger := strings.ToLower(value)
+		if trigger != proModels.AutoSignTriggerPush && trigger != proModels.AutoSignTriggerPromotion {
+			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessagef("invalid value: %s", value)
+		}
+		metas[key] = trigger
 	default:
 		return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessagef("invalid key: %s", key)
 	}
 	return metas, nil
 }
+
+// checkCosignTrustRoot checks the trust root of the cosign keyless verification can be loaded
+var checkCosignTrustRoot = cosign.CheckTrustRoot
+
+// validateCosignTrustRoot rejects the keyless signers if the trust root of the keyless verification can't be loaded,
+// as no image of the project could be pulled then
+func validateCosignTrustRoot() error {
+	if err := checkCosignTrustRoot(); err != nil {
+		return errors.New(nil).WithCode(errors.PreconditionCode).WithMessagef("the trust root of the cosign keyless verification isn't available: %v", err)
+	}
+	return nil
+}
+
+// validateCosignKeyless checks the trust root if the keyless signers are set in the metadata
+func validateCosignKeyless(md *models.ProjectMetadata) error {
+	if md == nil {
+		return nil
+	}
+	for _, signers := range []*string{md.CosignKeylessIssuers, md.CosignKeylessIdentities} {
+		if signers != nil && len(strings.Trim(*signers, ", ")) > 0 {
+			return validateCosignTrustRoot()
+		}
+	}
+	return nil
+}
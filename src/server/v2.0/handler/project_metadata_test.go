@@ -17,12 +17,19 @@ package handler
 import (
 	"testing"
 
-	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/signature/cosign"
 )
 
 func TestValidate(t *testing.T) {
 	api := &projectMetadataAPI{}
+	checkCosignTrustRoot = func() error { return nil }
+	defer func() {
+		checkCosignTrustRoot = cosign.CheckTrustRoot
+	}()
 
 	tests := []struct {
 		name      string
@@ -49,6 +56,71 @@ func TestValidate(t *testing.T) {
 			metas:     map[string]string{proModels.ProMetaMaxUpstreamConn: "30"},
 			expectErr: false,
 		},
+		{
+			name:      "CVSS score out of range",
+			metas:     map[string]string{proModels.ProMetaPreventVulCVSSScore: "11"},
+			expectErr: true,
+		},
+		{
+			name:      "normal CVSS score",
+			metas:     map[string]string{proModels.ProMetaPreventVulCVSSScore: "7.5"},
+			expectErr: false,
+		},
+		{
+			name:      "EPSS score out of range",
+			metas:     map[string]string{proModels.ProMetaPreventVulEPSSScore: "2"},
+			expectErr: true,
+		},
+		{
+			name:      "negative min age days",
+			metas:     map[string]string{proModels.ProMetaPreventVulMinAgeDays: "-1"},
+			expectErr: true,
+		},
+		{
+			name:      "normal fixable only",
+			metas:     map[string]string{proModels.ProMetaPreventVulFixableOnly: "true"},
+			expectErr: false,
+		},
+		{
+			name:      "invalid keyless issuer",
+			metas:     map[string]string{proModels.ProMetaCosignKeylessIssuers: "token.actions.githubusercontent.com"},
+			expectErr: true,
+		},
+		{
+			name:      "normal keyless issuers",
+			metas:     map[string]string{proModels.ProMetaCosignKeylessIssuers: "https://token.actions.githubusercontent.com, https://accounts.google.com"},
+			expectErr: false,
+		},
+		{
+			name:      "normal keyless identities",
+			metas:     map[string]string{proModels.ProMetaCosignKeylessIdentities: "https://github.com/goharbor/harbor/.github/workflows/*,dev@example.com"},
+			expectErr: false,
+		},
+		{
+			name:      "normal auto sign",
+			metas:     map[string]string{proModels.ProMetaAutoSign: "true"},
+			expectErr: false,
+		},
+		{
+			name:      "normal auto sign format",
+			metas:     map[string]string{proModels.ProMetaAutoSignFormat: "Notation"},
+			expectErr: false,
+		},
+		{
+			name:      "invalid auto sign format",
+			metas:     map[string]string{proModels.ProMetaAutoSignFormat: "gpg"},
+			expectErr: true,
+		},
+		{
+			name:      "normal auto sign trigger",
+			metas:     map[string]string{proModels.ProMetaAutoSignTrigger: "promotion"},
+			expectErr: false,
+		},
+		{
+			name:      "invalid auto sign trigger",
+			metas:     map[string]string{proModels.ProMetaAutoSignTrigger: "pull"},
+			expectErr: true,
+		},
 		{
 			name:      "Unsupported key",
 			metas:     map[string]string{"unsupported_key": "value"},
@@ -73,4 +145,11 @@ func TestValidate(t *testing.T) {
 			}
 		})
 	}
+
+	// the keyless signers are rejected without the trust root
+	checkCosignTrustRoot = func() error { return errors.New("no such file or directory") }
+	_, err := api.validate(map[string]string{proModels.ProMetaCosignKeylessIssuers: "https://token.actions.githubusercontent.com"})
+	assert.True(t, errors.IsErr(err, errors.PreconditionCode))
+	_, err = api.validate(map[string]string{proModels.ProMetaCosignKeylessIssuers: ""})
+	assert.NoError(t, err)
 }
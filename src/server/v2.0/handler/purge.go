@@ -19,7 +19,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"path"
-	"time"
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
@@ -63,7 +62,7 @@ func (p *purgeAPI) CreatePurgeSchedule(ctx context.Context, params purge.CreateP
 	if err := verifyCreateRequest(params); err != nil {
 		return p.SendError(ctx, err)
 	}
-	id, err := p.kick(ctx, job.PurgeAuditVendorType, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, params.Schedule.Parameters)
+	id, err := p.kick(ctx, job.PurgeAuditVendorType, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, model.ScheduleOptions(params.Schedule.Schedule), params.Schedule.Parameters)
 	if err != nil {
 		return p.SendError(ctx, err)
 	}
@@ -106,7 +105,7 @@ func retentionHour(m map[string]any) (int, error) {
 	return 0, nil
 }
 
-func (p *purgeAPI) kick(ctx context.Context, vendorType string, scheType string, cron string, parameters map[string]any) (int64, error) {
+func (p *purgeAPI) kick(ctx context.Context, vendorType string, scheType string, cron string, options *job.ScheduleOptions, parameters map[string]any) (int64, error) {
 	if parameters == nil {
 		parameters = make(map[string]any)
 	}
@@ -135,12 +134,12 @@ func (p *purgeAPI) kick(ctx context.Context, vendorType string, scheType string,
 		// delete the schedule of purge
 		err = p.schedulerCtl.Delete(ctx, vendorType)
 	case ScheduleHourly, ScheduleDaily, ScheduleWeekly, ScheduleCustom:
-		err = p.updateSchedule(ctx, vendorType, scheType, cron, policy, parameters)
+		err = p.updateSchedule(ctx, vendorType, scheType, cron, options, policy, parameters)
 	}
 	return id, err
 }
 
-func (p *purgeAPI) updateSchedule(ctx context.Context, vendorType, cronType, cron string, policy pg.JobPolicy, extraParams map[string]any) error {
+func (p *purgeAPI) updateSchedule(ctx context.Context, vendorType, cronType, cron string, options *job.ScheduleOptions, policy pg.JobPolicy, extraParams map[string]any) error {
 	if err := utils.ValidateCronString(cron); err != nil {
 		return errors.New(nil).WithCode(errors.BadRequestCode).
 			WithMessagef("invalid cron string for scheduled log rotation purge: %s, error: %v", cron, err)
@@ -148,7 +147,7 @@ func (p *purgeAPI) updateSchedule(ctx context.Context, vendorType, cronType, cro
 	if err := p.schedulerCtl.Delete(ctx, vendorType); err != nil {
 		return err
 	}
-	return p.createSchedule(ctx, vendorType, cronType, cron, policy, extraParams)
+	return p.createSchedule(ctx, vendorType, cronType, cron, options, policy, extraParams)
 }
 
 func (p *purgeAPI) GetPurgeHistory(ctx context.Context, params purge.GetPurgeHistoryParams) middleware.Responder {
@@ -267,6 +266,8 @@ func (p *purgeAPI) GetPurgeSchedule(ctx context.Context, _ purge.GetPurgeSchedul
 	if err != nil {
 		return p.SendError(ctx, err)
 	}
+	schedule := model.NewScheduleObj(sch.CRONType, sch.CRON, sch.Options)
+	schedule.NextScheduledTime = model.NextScheduledTime(sch.CRON, sch.Options)
 	execHistory := &models.ExecHistory{
 		ID:            sch.ID,
 		JobName:       "",
@@ -274,13 +275,9 @@ func (p *purgeAPI) GetPurgeSchedule(ctx context.Context, _ purge.GetPurgeSchedul
 		JobParameters: pg.String(sch.ExtraAttrs),
 		Deleted:       false,
 		JobStatus:     sch.Status,
-		Schedule: &models.ScheduleObj{
-			Cron:              sch.CRON,
-			Type:              sch.CRONType,
-			NextScheduledTime: strfmt.DateTime(utils.NextSchedule(sch.CRON, time.Now())),
-		},
-		CreationTime: strfmt.DateTime(sch.CreationTime),
-		UpdateTime:   strfmt.DateTime(sch.UpdateTime),
+		Schedule:      schedule,
+		CreationTime:  strfmt.DateTime(sch.CreationTime),
+		UpdateTime:    strfmt.DateTime(sch.UpdateTime),
 	}
 	return purge.NewGetPurgeScheduleOK().WithPayload(execHistory)
 }
@@ -292,7 +289,7 @@ func (p *purgeAPI) UpdatePurgeSchedule(ctx context.Context, params purge.UpdateP
 	if err := verifyUpdateRequest(params); err != nil {
 		return p.SendError(ctx, err)
 	}
-	_, err := p.kick(ctx, job.PurgeAuditVendorType, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, params.Schedule.Parameters)
+	_, err := p.kick(ctx, job.PurgeAuditVendorType, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, model.ScheduleOptions(params.Schedule.Schedule), params.Schedule.Parameters)
 	if err != nil {
 		return p.SendError(ctx, err)
 	}
@@ -318,8 +315,8 @@ func verifyUpdateRequest(params purge.UpdatePurgeScheduleParams) error {
 	return nil
 }
 
-func (p *purgeAPI) createSchedule(ctx context.Context, vendorType string, cronType string, cron string, policy pg.JobPolicy, extraParam map[string]any) error {
-	_, err := p.schedulerCtl.Create(ctx, vendorType, cronType, cron, pg.SchedulerCallback, policy, extraParam)
+func (p *purgeAPI) createSchedule(ctx context.Context, vendorType string, cronType string, cron string, options *job.ScheduleOptions, policy pg.JobPolicy, extraParam map[string]any) error {
+	_, err := p.schedulerCtl.Create(ctx, vendorType, cronType, cron, options, pg.SchedulerCallback, policy, extraParam)
 	if err != nil {
 		return err
 	}
@@ -27,6 +27,7 @@ import (
 	"github.com/goharbor/harbor/src/controller/replication"
 	repctlmodel "github.com/goharbor/harbor/src/controller/replication/model"
 	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/reg/model"
@@ -97,9 +98,7 @@ func (r *replicationAPI) CreateReplicationPolicy(ctx context.Context, params ope
 			Type: params.Policy.Trigger.Type,
 		}
 		if params.Policy.Trigger.TriggerSettings != nil {
-			policy.Trigger.Settings = &model.TriggerSettings{
-				Cron: params.Policy.Trigger.TriggerSettings.Cron,
-			}
+			policy.Trigger.Settings = convertTriggerSettings(params.Policy.Trigger.TriggerSettings)
 		}
 	}
 	if params.Policy.Speed != nil {
@@ -173,9 +172,7 @@ func (r *replicationAPI) UpdateReplicationPolicy(ctx context.Context, params ope
 			Type: params.Policy.Trigger.Type,
 		}
 		if params.Policy.Trigger.TriggerSettings != nil {
-			policy.Trigger.Settings = &model.TriggerSettings{
-				Cron: params.Policy.Trigger.TriggerSettings.Cron,
-			}
+			policy.Trigger.Settings = convertTriggerSettings(params.Policy.Trigger.TriggerSettings)
 		}
 	}
 	if params.Policy.Speed != nil {
@@ -439,6 +436,17 @@ func (r *replicationAPI) GetReplicationLog(ctx context.Context, params operation
 			WithCode(errors.NotFoundCode).
 			WithMessagef("execution %d contains no task with ID %d", params.ID, params.TaskID))
 	}
+	if lib.BoolValue(params.Follow) {
+		offset, err := logOffset(params.HTTPRequest)
+		if err != nil {
+			return r.SendError(ctx, err)
+		}
+		stream, err := r.ctl.FollowTaskLog(ctx, params.TaskID, offset)
+		if err != nil {
+			return r.SendError(ctx, err)
+		}
+		return streamLog(stream)
+	}
 	log, err := r.ctl.GetTaskLog(ctx, params.TaskID)
 	if err != nil {
 		return r.SendError(ctx, err)
@@ -485,7 +493,15 @@ func convertReplicationPolicy(policy *repctlmodel.Policy) *models.ReplicationPol
 		}
 		if policy.Trigger.Settings != nil {
 			trigger.TriggerSettings = &models.ReplicationTriggerSettings{
-				Cron: policy.Trigger.Settings.Cron,
+				Cron:     policy.Trigger.Settings.Cron,
+				Timezone: policy.Trigger.Settings.Timezone,
+				Jitter:   policy.Trigger.Settings.Jitter,
+			}
+			for _, w := range policy.Trigger.Settings.BlackoutWindows {
+				trigger.TriggerSettings.BlackoutWindows = append(trigger.TriggerSettings.BlackoutWindows, &models.BlackoutWindow{
+					Cron:     w.Cron,
+					Duration: w.Duration,
+				})
 			}
 		}
 		p.Trigger = trigger
@@ -586,3 +602,21 @@ func convertTask(task *replication.Task) *models.ReplicationTask {
 	}
 	return tk
 }
+
+func convertTriggerSettings(settings *models.ReplicationTriggerSettings) *model.TriggerSettings {
+	s := &model.TriggerSettings{
+		Cron:     settings.Cron,
+		Timezone: settings.Timezone,
+		Jitter:   settings.Jitter,
+	}
+	for _, w := range settings.BlackoutWindows {
+		if w == nil {
+			continue
+		}
+		s.BlackoutWindows = append(s.BlackoutWindows, &job.BlackoutWindow{
+			Cron:     w.Cron,
+			Duration: w.Duration,
+		})
+	}
+	return s
+}
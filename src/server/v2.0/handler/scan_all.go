@@ -17,6 +17,7 @@ package handler
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/go-openapi/runtime/middleware"
@@ -94,6 +95,11 @@ func (s *scanAllAPI) CreateScanAllSchedule(ctx context.Context, params operation
 		return operation.NewCreateScanAllScheduleCreated()
 	}
 
+	scope, err := scan.ParseScanAllScope(req.Parameters)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+
 	if req.Schedule.Type == ScheduleManual {
 		execution, err := s.getLatestScanAllExecution(ctx, task.ExecutionTriggerManual)
 		if err != nil {
@@ -105,7 +111,7 @@ func (s *scanAllAPI) CreateScanAllSchedule(ctx context.Context, params operation
 			return s.SendError(ctx, errors.ConflictError(nil).WithMessage(message))
 		}
 
-		if _, err := s.scanCtl.ScanAll(ctx, task.ExecutionTriggerManual, true); err != nil {
+		if _, err := s.scanCtl.ScanAll(ctx, task.ExecutionTriggerManual, true, scope); err != nil {
 			return s.SendError(ctx, err)
 		}
 	} else {
@@ -119,7 +125,7 @@ func (s *scanAllAPI) CreateScanAllSchedule(ctx context.Context, params operation
 			return s.SendError(ctx, errors.PreconditionFailedError(nil).WithMessage(message))
 		}
 
-		if _, err := s.createOrUpdateScanAllSchedule(ctx, req.Schedule.Type, req.Schedule.Cron, nil); err != nil {
+		if _, err := s.createOrUpdateScanAllSchedule(ctx, req.Schedule.Type, req.Schedule.Cron, model.ScheduleOptions(req.Schedule), scope, nil); err != nil {
 			return s.SendError(ctx, err)
 		}
 	}
@@ -137,6 +143,11 @@ func (s *scanAllAPI) UpdateScanAllSchedule(ctx context.Context, params operation
 		return s.SendError(ctx, errors.BadRequestError(nil).WithMessagef("fail to update scan all schedule as wrong schedule type: %s", req.Schedule.Type))
 	}
 
+	scope, err := scan.ParseScanAllScope(req.Parameters)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+
 	schedule, err := s.getScanAllSchedule(ctx)
 	if err != nil {
 		return s.SendError(ctx, err)
@@ -147,7 +158,7 @@ func (s *scanAllAPI) UpdateScanAllSchedule(ctx context.Context, params operation
 			err = s.scheduler.UnScheduleByID(ctx, schedule.ID)
 		}
 	} else {
-		_, err = s.createOrUpdateScanAllSchedule(ctx, req.Schedule.Type, req.Schedule.Cron, schedule)
+		_, err = s.createOrUpdateScanAllSchedule(ctx, req.Schedule.Type, req.Schedule.Cron, model.ScheduleOptions(req.Schedule), scope, schedule)
 	}
 
 	if err != nil {
@@ -193,13 +204,15 @@ func (s *scanAllAPI) GetLatestScheduledScanAllMetrics(ctx context.Context, _ ope
 	return operation.NewGetLatestScanAllMetricsOK().WithPayload(stats)
 }
 
-func (s *scanAllAPI) createOrUpdateScanAllSchedule(ctx context.Context, cronType, cron string, previous *scheduler.Schedule) (int64, error) {
+func (s *scanAllAPI) createOrUpdateScanAllSchedule(ctx context.Context, cronType, cron string, options *job.ScheduleOptions, scope *scan.ScanAllScope, previous *scheduler.Schedule) (int64, error) {
 	if err := utils.ValidateCronString(cron); err != nil {
 		return 0, errors.New(nil).WithCode(errors.BadRequestCode).
 			WithMessagef("invalid cron string for scheduled scan all: %s, error: %v", cron, err)
 	}
 	if previous != nil {
-		if cronType == previous.CRONType && cron == previous.CRON {
+		sameScope := scope.IsEmpty() && len(previous.ExtraAttrs) == 0 || reflect.DeepEqual(scope.ToMap(), previous.ExtraAttrs)
+		sameOptions := reflect.DeepEqual(options, previous.Options)
+		if cronType == previous.CRONType && cron == previous.CRON && sameScope && sameOptions {
 			return previous.ID, nil
 		}
 
@@ -212,7 +225,14 @@ func (s *scanAllAPI) createOrUpdateScanAllSchedule(ctx context.Context, cronType
 		// the operator of schedule job is harbor-jobservice
 		"operator": secret.JobserviceUser,
 	}
-	return s.scheduler.Schedule(ctx, job.ScanAllVendorType, 0, cronType, cron, scan.ScanAllCallback, cbParams, nil)
+
+	var extras map[string]any
+	if !scope.IsEmpty() {
+		cbParams["scope"] = scope.ToMap()
+		// keep the scope in the extra attributes to show it in the parameters of the schedule
+		extras = scope.ToMap()
+	}
+	return s.scheduler.ScheduleWithOptions(ctx, job.ScanAllVendorType, 0, cronType, cron, options, scan.ScanAllCallback, cbParams, extras)
 }
 
 func (s *scanAllAPI) getScanAllSchedule(ctx context.Context) (*scheduler.Schedule, error) {
@@ -371,7 +371,7 @@ func (suite *ScanAllTestSuite) TestCreateScanAllSchedule() {
 	{
 		// create scan all schedule with periodic but create schedule failed
 		mock.OnAnything(suite.scheduler, "ListSchedules").Return(nil, nil).Once()
-		mock.OnAnything(suite.scheduler, "Schedule").Return(int64(0), fmt.Errorf("create schedule failed")).Once()
+		mock.OnAnything(suite.scheduler, "ScheduleWithOptions").Return(int64(0), fmt.Errorf("create schedule failed")).Once()
 
 		body := models.Schedule{Schedule: &models.ScheduleObj{Type: ScheduleDaily, Cron: "0 0 0 * * *"}}
 		res, err := suite.PostJSON("/system/scanAll/schedule", body)
@@ -382,7 +382,7 @@ func (suite *ScanAllTestSuite) TestCreateScanAllSchedule() {
 	{
 		// create scan all schedule with periodic
 		mock.OnAnything(suite.scheduler, "ListSchedules").Return(nil, nil).Once()
-		mock.OnAnything(suite.scheduler, "Schedule").Return(int64(1), nil).Once()
+		mock.OnAnything(suite.scheduler, "ScheduleWithOptions").Return(int64(1), nil).Once()
 
 		body := models.Schedule{Schedule: &models.ScheduleObj{Type: ScheduleDaily, Cron: "0 0 0 * * *"}}
 		res, err := suite.PostJSON("/system/scanAll/schedule", body)
@@ -475,7 +475,7 @@ func (suite *ScanAllTestSuite) TestUpdateScanAllSchedule() {
 		// update scan all schedule with periodic and schedule changed
 		mock.OnAnything(suite.scheduler, "ListSchedules").Return([]*scheduler.Schedule{suite.schedule}, nil).Once()
 		mock.OnAnything(suite.scheduler, "UnScheduleByID").Return(nil).Once()
-		mock.OnAnything(suite.scheduler, "Schedule").Return(int64(1), nil).Once()
+		mock.OnAnything(suite.scheduler, "ScheduleWithOptions").Return(int64(1), nil).Once()
 
 		body := models.Schedule{Schedule: &models.ScheduleObj{Type: ScheduleCustom, Cron: "0 1 0 * * *"}}
 		res, err := suite.PutJSON("/system/scanAll/schedule", body)
@@ -498,7 +498,7 @@ func (suite *ScanAllTestSuite) TestUpdateScanAllSchedule() {
 		// update scan all schedule with periodic and schedule changed, but creat new schedule failed
 		mock.OnAnything(suite.scheduler, "ListSchedules").Return([]*scheduler.Schedule{suite.schedule}, nil).Once()
 		mock.OnAnything(suite.scheduler, "UnScheduleByID").Return(nil).Once()
-		mock.OnAnything(suite.scheduler, "Schedule").Return(int64(0), fmt.Errorf("create schedule failed")).Once()
+		mock.OnAnything(suite.scheduler, "ScheduleWithOptions").Return(int64(0), fmt.Errorf("create schedule failed")).Once()
 
 		body := models.Schedule{Schedule: &models.ScheduleObj{Type: ScheduleCustom, Cron: "0 1 0 * * *"}}
 		res, err := suite.PutJSON("/system/scanAll/schedule", body)
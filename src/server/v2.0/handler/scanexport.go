@@ -140,6 +140,7 @@ func (se *scanDataExportAPI) GetScanDataExportExecution(ctx context.Context, par
 		UserID:      execution.UserID,
 		UserName:    execution.UserName,
 		FilePresent: execution.FilePresent,
+		Format:      execution.Format,
 	}
 	// add human friendly message when status is error
 	if sdeExec.Status == job.ErrorStatus.String() && sdeExec.StatusText == "" {
@@ -195,11 +196,17 @@ func (se *scanDataExportAPI) DownloadScanData(ctx context.Context, params operat
 	}
 	log.Infof("reading data from file : %s", repositoryName)
 
+	format, err := export.GetFormat(execution.Format)
+	if err != nil {
+		file.Close()
+		return se.SendError(ctx, err)
+	}
+
 	return middleware.ResponderFunc(func(writer http.ResponseWriter, _ runtime.Producer) {
 		defer se.cleanUpArtifact(ctx, repositoryName, execution.ExportDataDigest, params.ExecutionID, file)
 
-		writer.Header().Set("Content-Type", "text/csv")
-		writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s.csv", repositoryName)))
+		writer.Header().Set("Content-Type", format.ContentType)
+		writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s.%s", repositoryName, format.Extension)))
 		nbytes, err := io.Copy(writer, file)
 		if err != nil {
 			log.Errorf("Encountered error while copying data: %v", err)
@@ -237,6 +244,7 @@ func (se *scanDataExportAPI) GetScanDataExportExecutionList(ctx context.Context,
 			UserID:      execution.UserID,
 			UserName:    execution.UserName,
 			FilePresent: execution.FilePresent,
+			Format:      execution.Format,
 		}
 		// add human friendly message when status is error
 		if sdeExec.Status == job.ErrorStatus.String() && sdeExec.StatusText == "" {
@@ -274,6 +282,7 @@ func (se *scanDataExportAPI) convertToCriteria(requestCriteria *models.ScanDataE
 		Projects:     requestCriteria.Projects,
 		Repositories: requestCriteria.Repositories,
 		Tags:         requestCriteria.Tags,
+		Format:       strings.ToLower(requestCriteria.Format),
 	}
 }
 
@@ -334,6 +343,10 @@ func (se *scanDataExportAPI) validateScanExportParams(ctx context.Context, param
 		return errors.BadRequestError(errors.Errorf("criteria is invalid: %v", criteria))
 	}
 
+	if _, err := export.GetFormat(criteria.Format); err != nil {
+		return err
+	}
+
 	// validate project id, currently we only support single project
 	if len(criteria.Projects) != 1 {
 		return errors.BadRequestError(errors.Errorf("only support export single project, invalid value: %v", criteria.Projects))
@@ -16,11 +16,17 @@ package handler
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
 
 	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	componentModel "github.com/goharbor/harbor/src/pkg/scan/sbom/component/model"
 	"github.com/goharbor/harbor/src/pkg/scan/scanner"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 	securityModel "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/securityhub"
@@ -70,6 +76,57 @@ func toSecuritySummaryModel(summary *secHubModel.Summary) *models.SecuritySummar
 		DangerousArtifacts: toDangerousArtifacts(summary.DangerousArtifacts),
 	}
 }
+func (s *securityAPI) ListSecurityTrends(ctx context.Context, params securityModel.ListSecurityTrendsParams) middleware.Responder {
+	var projectID int64
+	if params.ProjectID != nil {
+		projectID = *params.ProjectID
+		// the trends of a single project are readable to the members who can read its scan results
+		if err := s.RequireProjectAccess(ctx, projectID, rbac.ActionRead, rbac.ResourceScan); err != nil {
+			return s.SendError(ctx, err)
+		}
+	} else if err := s.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceSecurityHub); err != nil {
+		return s.SendError(ctx, err)
+	}
+	to := time.Now()
+	if params.To != nil {
+		to = time.Time(*params.To)
+	}
+	// query the trends of the last 30 days by default
+	from := to.AddDate(0, 0, -30)
+	if params.From != nil {
+		from = time.Time(*params.From)
+	}
+	if from.After(to) {
+		return s.SendError(ctx, errors.BadRequestError(nil).WithMessagef("the start time %s is after the end time %s", from.Format(time.RFC3339), to.Format(time.RFC3339)))
+	}
+	snapshots, err := s.controller.ListTrends(ctx, projectID, from, to)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	var result []*models.SecuritySummarySnapshot
+	for _, snapshot := range snapshots {
+		result = append(result, toSecuritySummarySnapshotModel(snapshot))
+	}
+	return securityModel.NewListSecurityTrendsOK().WithPayload(result)
+}
+
+func toSecuritySummarySnapshotModel(snapshot *secHubModel.Snapshot) *models.SecuritySummarySnapshot {
+	return &models.SecuritySummarySnapshot{
+		ProjectID:     snapshot.ProjectID,
+		SnapshotTime:  strfmt.DateTime(snapshot.SnapshotTime),
+		CriticalCnt:   snapshot.CriticalCnt,
+		HighCnt:       snapshot.HighCnt,
+		MediumCnt:     snapshot.MediumCnt,
+		LowCnt:        snapshot.LowCnt,
+		NoneCnt:       snapshot.NoneCnt,
+		UnknownCnt:    snapshot.UnknownCnt,
+		FixableCnt:    snapshot.FixableCnt,
+		TotalVuls:     snapshot.CriticalCnt + snapshot.HighCnt + snapshot.MediumCnt + snapshot.LowCnt + snapshot.NoneCnt + snapshot.UnknownCnt,
+		ScannedCnt:    snapshot.ScannedCnt,
+		TotalArtifact: snapshot.TotalArtifactCnt,
+	}
+}
+
 func toDangerousArtifacts(artifacts []*secHubModel.DangerousArtifact) []*models.DangerousArtifact {
 	var result []*models.DangerousArtifact
 	for _, artifact := range artifacts {
@@ -148,3 +205,169 @@ func toVulnerabilities(vuls []*secHubModel.VulnerabilityItem) []*models.Vulnerab
 	}
 	return result
 }
+
+func (s *securityAPI) SearchComponents(ctx context.Context, params securityModel.SearchComponentsParams) middleware.Responder {
+	if err := s.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceSecurityHub); err != nil {
+		return s.SendError(ctx, err)
+	}
+	criteria := &componentModel.Criteria{
+		Name:           lib.StringValue(params.Name),
+		Version:        lib.StringValue(params.Version),
+		PURL:           lib.StringValue(params.Purl),
+		RepositoryName: lib.StringValue(params.RepositoryName),
+	}
+	if params.ProjectID != nil {
+		criteria.ProjectID = *params.ProjectID
+	}
+	total, items, err := s.controller.SearchComponents(ctx, criteria, *params.WithTag, *params.Page, *params.PageSize)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	link := s.Links(ctx, params.HTTPRequest.URL, total, *params.Page, *params.PageSize).String()
+	return securityModel.NewSearchComponentsOK().WithPayload(toComponentItems(items)).WithLink(link).WithXTotalCount(total)
+}
+
+func toComponentItems(items []*componentModel.Item) []*models.ComponentItem {
+	result := make([]*models.ComponentItem, 0)
+	for _, item := range items {
+		result = append(result, &models.ComponentItem{
+			ProjectID:      item.ProjectID,
+			RepositoryName: item.RepositoryName,
+			Digest:         item.Digest,
+			Tags:           item.Tags,
+			Name:           item.Name,
+			Version:        item.Version,
+			Purl:           item.PURL,
+			Type:           item.Type,
+			Licenses:       item.Licenses,
+		})
+	}
+	return result
+}
+
+func (s *securityAPI) ListRemediations(ctx context.Context, params securityModel.ListRemediationsParams) middleware.Responder {
+	if params.ProjectID != nil {
+		if err := s.RequireProjectAccess(ctx, *params.ProjectID, rbac.ActionRead, rbac.ResourceScan); err != nil {
+			return s.SendError(ctx, err)
+		}
+	} else if err := s.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceSecurityHub); err != nil {
+		return s.SendError(ctx, err)
+	}
+	query, err := s.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	if params.ProjectID != nil {
+		query.Keywords["project_id"] = *params.ProjectID
+	}
+	total, err := s.controller.CountRemediations(ctx, query)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	remediations, err := s.controller.ListRemediations(ctx, query)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	now := time.Now()
+	result := make([]*models.VulnerabilityRemediation, 0)
+	for _, r := range remediations {
+		result = append(result, toRemediationModel(r, now))
+	}
+	return securityModel.NewListRemediationsOK().
+		WithXTotalCount(total).
+		WithLink(s.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(result)
+}
+
+func (s *securityAPI) CreateRemediation(ctx context.Context, params securityModel.CreateRemediationParams) middleware.Responder {
+	req := params.Remediation
+	if err := s.RequireProjectAccess(ctx, req.ProjectID, rbac.ActionCreate, rbac.ResourceRemediation); err != nil {
+		return s.SendError(ctx, err)
+	}
+	if len(req.CVEID) == 0 || len(req.Package) == 0 {
+		return s.SendError(ctx, errors.BadRequestError(nil).WithMessage("the cve_id and package are required"))
+	}
+	remediation := &secHubModel.Remediation{
+		ProjectID: req.ProjectID,
+		CVEID:     req.CVEID,
+		Package:   req.Package,
+	}
+	applyRemediationReq(remediation, req)
+	id, err := s.controller.CreateRemediation(ctx, remediation)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	location := fmt.Sprintf("%s/%d", strings.TrimSuffix(params.HTTPRequest.URL.Path, "/"), id)
+	return securityModel.NewCreateRemediationCreated().WithLocation(location)
+}
+
+func (s *securityAPI) GetRemediation(ctx context.Context, params securityModel.GetRemediationParams) middleware.Responder {
+	remediation, err := s.controller.GetRemediation(ctx, params.RemediationID)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	if err := s.RequireProjectAccess(ctx, remediation.ProjectID, rbac.ActionRead, rbac.ResourceScan); err != nil {
+		return s.SendError(ctx, err)
+	}
+	return securityModel.NewGetRemediationOK().WithPayload(toRemediationModel(remediation, time.Now()))
+}
+
+func (s *securityAPI) UpdateRemediation(ctx context.Context, params securityModel.UpdateRemediationParams) middleware.Responder {
+	remediation, err := s.controller.GetRemediation(ctx, params.RemediationID)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	if err := s.RequireProjectAccess(ctx, remediation.ProjectID, rbac.ActionUpdate, rbac.ResourceRemediation); err != nil {
+		return s.SendError(ctx, err)
+	}
+	applyRemediationReq(remediation, params.Remediation)
+	if err := s.controller.UpdateRemediation(ctx, remediation); err != nil {
+		return s.SendError(ctx, err)
+	}
+	return securityModel.NewUpdateRemediationOK()
+}
+
+func (s *securityAPI) DeleteRemediation(ctx context.Context, params securityModel.DeleteRemediationParams) middleware.Responder {
+	remediation, err := s.controller.GetRemediation(ctx, params.RemediationID)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	if err := s.RequireProjectAccess(ctx, remediation.ProjectID, rbac.ActionDelete, rbac.ResourceRemediation); err != nil {
+		return s.SendError(ctx, err)
+	}
+	if err := s.controller.DeleteRemediation(ctx, params.RemediationID); err != nil {
+		return s.SendError(ctx, err)
+	}
+	return securityModel.NewDeleteRemediationOK()
+}
+
+// applyRemediationReq applies the mutable properties in the request to the remediation,
+// the due time is kept if it is not specified
+func applyRemediationReq(remediation *secHubModel.Remediation, req *models.RemediationReq) {
+	remediation.Owner = req.Owner
+	remediation.State = req.State
+	remediation.Justification = req.Justification
+	remediation.RiskExpiryTime = time.Time(req.RiskExpiryTime)
+	if dueTime := time.Time(req.DueTime); !dueTime.IsZero() {
+		remediation.DueTime = dueTime
+	}
+}
+
+func toRemediationModel(remediation *secHubModel.Remediation, now time.Time) *models.VulnerabilityRemediation {
+	return &models.VulnerabilityRemediation{
+		ID:             remediation.ID,
+		ProjectID:      remediation.ProjectID,
+		CVEID:          remediation.CVEID,
+		Package:        remediation.Package,
+		Severity:       remediation.Severity,
+		State:          remediation.State,
+		Owner:          remediation.Owner,
+		DueTime:        strfmt.DateTime(remediation.DueTime),
+		Overdue:        remediation.Overdue(now),
+		Justification:  remediation.Justification,
+		RiskExpiryTime: strfmt.DateTime(remediation.RiskExpiryTime),
+		ResolvedTime:   strfmt.DateTime(remediation.ResolvedTime),
+		CreationTime:   strfmt.DateTime(remediation.CreationTime),
+		UpdateTime:     strfmt.DateTime(remediation.UpdateTime),
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/pkg/signing"
+	"github.com/goharbor/harbor/src/pkg/signing/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/signing"
+)
+
+func newSigningAPI() *signingAPI {
+	return &signingAPI{
+		keyMgr:     signing.Mgr,
+		projectCtl: project.Ctl,
+	}
+}
+
+type signingAPI struct {
+	BaseAPI
+	keyMgr     signing.Manager
+	projectCtl project.Controller
+}
+
+func (s *signingAPI) CreateSigningKey(ctx context.Context, params operation.CreateSigningKeyParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := s.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionCreate, rbac.ResourceSigningKey); err != nil {
+		return s.SendError(ctx, err)
+	}
+
+	p, err := s.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+
+	provider, keyRef := model.ProviderDatabase, ""
+	if params.Key != nil {
+		if len(params.Key.Provider) > 0 {
+			provider = params.Key.Provider
+		}
+		keyRef = params.Key.KeyRef
+	}
+	if _, err := s.keyMgr.Create(ctx, p.ProjectID, provider, keyRef); err != nil {
+		return s.SendError(ctx, err)
+	}
+
+	return operation.NewCreateSigningKeyCreated().WithLocation(params.HTTPRequest.URL.Path)
+}
+
+func (s *signingAPI) GetSigningKey(ctx context.Context, params operation.GetSigningKeyParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := s.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionRead, rbac.ResourceSigningKey); err != nil {
+		return s.SendError(ctx, err)
+	}
+
+	p, err := s.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+
+	key, err := s.keyMgr.Get(ctx, p.ProjectID)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+
+	return operation.NewGetSigningKeyOK().WithPayload(&models.SigningKey{
+		ID:           key.ID,
+		ProjectID:    key.ProjectID,
+		Provider:     key.Provider,
+		KeyRef:       key.KeyRef,
+		PublicKey:    key.PublicKey,
+		Certificate:  key.Certificate,
+		CreationTime: strfmt.DateTime(key.CreationTime),
+	})
+}
+
+func (s *signingAPI) DeleteSigningKey(ctx context.Context, params operation.DeleteSigningKeyParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := s.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionDelete, rbac.ResourceSigningKey); err != nil {
+		return s.SendError(ctx, err)
+	}
+
+	p, err := s.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+
+	if err := s.keyMgr.Delete(ctx, p.ProjectID); err != nil {
+		return s.SendError(ctx, err)
+	}
+
+	return operation.NewDeleteSigningKeyOK()
+}
@@ -17,10 +17,16 @@ package handler
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
 
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/lib"
@@ -132,3 +138,53 @@ func getProjectID(ctx context.Context, projectNameOrID any) (int64, error) {
 	}
 	return 0, errors.New("unknown project identifier type")
 }
+
+// logOffset returns the offset to follow the log from, which is the id of the last
+// Server-Sent Event received by the client and sent back in the 'Last-Event-ID' header
+func logOffset(req *http.Request) (int64, error) {
+	id := req.Header.Get("Last-Event-ID")
+	if len(id) == 0 {
+		return 0, nil
+	}
+	offset, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || offset < 0 {
+		return 0, errors.BadRequestError(nil).WithMessagef("invalid Last-Event-ID: %s", id)
+	}
+	return offset, nil
+}
+
+// streamLog returns a responder relaying the Server-Sent Events of the followed log to the client
+func streamLog(stream io.ReadCloser) middleware.Responder {
+	return middleware.ResponderFunc(func(w http.ResponseWriter, _ runtime.Producer) {
+		defer stream.Close()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		rc := http.NewResponseController(w)
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := stream.Read(buf)
+			if n > 0 {
+				// The stream lives longer than the write timeout of the server if there is one
+				_ = rc.SetWriteDeadline(time.Now().Add(time.Minute))
+				if _, err := w.Write(buf[:n]); err != nil {
+					log.Debugf("stop relaying the log: %v", err)
+					return
+				}
+				if err := rc.Flush(); err != nil {
+					log.Debugf("stop relaying the log: %v", err)
+					return
+				}
+			}
+			if err != nil {
+				if err != io.EOF {
+					log.Errorf("failed to read the followed log: %v", err)
+				}
+				return
+			}
+		}
+	})
+}
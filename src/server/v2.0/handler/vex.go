@@ -0,0 +1,196 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/scan"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/vex"
+	"github.com/goharbor/harbor/src/pkg/vex/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/vex"
+)
+
+func newVEXAPI() *vexAPI {
+	return &vexAPI{
+		vexMgr:     vex.Mgr,
+		projectCtl: project.Ctl,
+		scanCtl:    scan.DefaultController,
+	}
+}
+
+type vexAPI struct {
+	BaseAPI
+	vexMgr     vex.Manager
+	projectCtl project.Controller
+	scanCtl    scan.Controller
+}
+
+func (v *vexAPI) CreateVEXDocument(ctx context.Context, params operation.CreateVEXDocumentParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := v.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionCreate, rbac.ResourceVEX); err != nil {
+		return v.SendError(ctx, err)
+	}
+	if params.Vex == nil {
+		return v.SendError(ctx, errors.BadRequestError(nil).WithMessage("the VEX document is required"))
+	}
+
+	p, err := v.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	id, err := v.vexMgr.Create(ctx, &model.Document{
+		ProjectID: p.ProjectID,
+		Name:      params.Vex.Name,
+		Content:   params.Vex.Content,
+	})
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+	v.evictCache(ctx, p.ProjectID)
+
+	location := fmt.Sprintf("%s/%d", strings.TrimSuffix(params.HTTPRequest.URL.Path, "/"), id)
+	return operation.NewCreateVEXDocumentCreated().WithLocation(location)
+}
+
+func (v *vexAPI) GetVEXDocument(ctx context.Context, params operation.GetVEXDocumentParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := v.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionRead, rbac.ResourceVEX); err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	doc, err := v.getDocument(ctx, projectNameOrID, params.VexID)
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	result := toVEXDocument(doc)
+	result.Content = doc.Content
+	return operation.NewGetVEXDocumentOK().WithPayload(result)
+}
+
+func (v *vexAPI) ListVEXDocuments(ctx context.Context, params operation.ListVEXDocumentsParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := v.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionList, rbac.ResourceVEX); err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	query, err := v.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	p, err := v.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+	query.Keywords["ProjectID"] = p.ProjectID
+
+	total, err := v.vexMgr.Count(ctx, query)
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	docs, err := v.vexMgr.List(ctx, query)
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	var results []*models.VEXDocument
+	for _, doc := range docs {
+		results = append(results, toVEXDocument(doc))
+	}
+
+	return operation.NewListVEXDocumentsOK().
+		WithXTotalCount(total).
+		WithLink(v.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(results)
+}
+
+func (v *vexAPI) DeleteVEXDocument(ctx context.Context, params operation.DeleteVEXDocumentParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := v.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionDelete, rbac.ResourceVEX); err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	doc, err := v.getDocument(ctx, projectNameOrID, params.VexID)
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	if err := v.vexMgr.Delete(ctx, doc.ID); err != nil {
+		return v.SendError(ctx, err)
+	}
+	v.evictCache(ctx, doc.ProjectID)
+
+	return operation.NewDeleteVEXDocumentOK()
+}
+
+// evictCache evicts the VEX statements of the project cached by the scan controller, so the changed documents
+// apply to the vulnerabilities at once
+func (v *vexAPI) evictCache(ctx context.Context, projectID int64) {
+	if err := v.scanCtl.EvictVEXCache(ctx, projectID); err != nil {
+		log.Warningf("failed to evict the cached VEX statements of project %d, error: %v", projectID, err)
+	}
+}
+
+// getDocument returns the VEX document and makes sure it belongs to the project
+func (v *vexAPI) getDocument(ctx context.Context, projectNameOrID any, id int64) (*model.Document, error) {
+	p, err := v.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := v.vexMgr.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if doc.ProjectID != p.ProjectID {
+		return nil, errors.NotFoundError(nil).WithMessagef("VEX document %d not found in project %d", id, p.ProjectID)
+	}
+
+	return doc, nil
+}
+
+func toVEXDocument(doc *model.Document) *models.VEXDocument {
+	result := &models.VEXDocument{
+		ID:           doc.ID,
+		ProjectID:    doc.ProjectID,
+		Name:         doc.Name,
+		Format:       doc.Format,
+		CreationTime: strfmt.DateTime(doc.CreationTime),
+		UpdateTime:   strfmt.DateTime(doc.UpdateTime),
+	}
+
+	_, statements, err := vex.Parse([]byte(doc.Content))
+	if err != nil {
+		log.Warningf("failed to parse the VEX document %d, error: %v", doc.ID, err)
+	} else {
+		result.StatementCount = int64(len(statements))
+	}
+
+	return result
+}
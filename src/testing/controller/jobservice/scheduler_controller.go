@@ -7,6 +7,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	job "github.com/goharbor/harbor/src/jobservice/job"
+
 	q "github.com/goharbor/harbor/src/lib/q"
 
 	scheduler "github.com/goharbor/harbor/src/pkg/scheduler"
@@ -45,9 +47,9 @@ func (_m *SchedulerController) Count(ctx context.Context, query *q.Query) (int64
 	return r0, r1
 }
 
-// Create provides a mock function with given fields: ctx, vendorType, cronType, cron, callbackFuncName, policy, extrasParam
-func (_m *SchedulerController) Create(ctx context.Context, vendorType string, cronType string, cron string, callbackFuncName string, policy interface{}, extrasParam map[string]interface{}) (int64, error) {
-	ret := _m.Called(ctx, vendorType, cronType, cron, callbackFuncName, policy, extrasParam)
+// Create provides a mock function with given fields: ctx, vendorType, cronType, cron, options, callbackFuncName, policy, extrasParam
+func (_m *SchedulerController) Create(ctx context.Context, vendorType string, cronType string, cron string, options *job.ScheduleOptions, callbackFuncName string, policy interface{}, extrasParam map[string]interface{}) (int64, error) {
+	ret := _m.Called(ctx, vendorType, cronType, cron, options, callbackFuncName, policy, extrasParam)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -55,17 +57,17 @@ func (_m *SchedulerController) Create(ctx context.Context, vendorType string, cr
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, interface{}, map[string]interface{}) (int64, error)); ok {
-		return rf(ctx, vendorType, cronType, cron, callbackFuncName, policy, extrasParam)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *job.ScheduleOptions, string, interface{}, map[string]interface{}) (int64, error)); ok {
+		return rf(ctx, vendorType, cronType, cron, options, callbackFuncName, policy, extrasParam)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, interface{}, map[string]interface{}) int64); ok {
-		r0 = rf(ctx, vendorType, cronType, cron, callbackFuncName, policy, extrasParam)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *job.ScheduleOptions, string, interface{}, map[string]interface{}) int64); ok {
+		r0 = rf(ctx, vendorType, cronType, cron, options, callbackFuncName, policy, extrasParam)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, interface{}, map[string]interface{}) error); ok {
-		r1 = rf(ctx, vendorType, cronType, cron, callbackFuncName, policy, extrasParam)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, *job.ScheduleOptions, string, interface{}, map[string]interface{}) error); ok {
+		r1 = rf(ctx, vendorType, cronType, cron, options, callbackFuncName, policy, extrasParam)
 	} else {
 		r1 = ret.Error(1)
 	}
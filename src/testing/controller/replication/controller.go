@@ -5,6 +5,8 @@ package replication
 import (
 	context "context"
 
+	io "io"
+
 	model "github.com/goharbor/harbor/src/controller/replication/model"
 	mock "github.com/stretchr/testify/mock"
 
@@ -94,6 +96,36 @@ func (_m *Controller) ExecutionCount(ctx context.Context, query *q.Query) (int64
 	return r0, r1
 }
 
+// FollowTaskLog provides a mock function with given fields: ctx, taskID, offset
+func (_m *Controller) FollowTaskLog(ctx context.Context, taskID int64, offset int64) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, taskID, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FollowTaskLog")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (io.ReadCloser, error)); ok {
+		return rf(ctx, taskID, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) io.ReadCloser); ok {
+		r0 = rf(ctx, taskID, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, taskID, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetExecution provides a mock function with given fields: ctx, executionID
 func (_m *Controller) GetExecution(ctx context.Context, executionID int64) (*replication.Execution, error) {
 	ret := _m.Called(ctx, executionID)
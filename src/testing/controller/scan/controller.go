@@ -21,6 +21,24 @@ type Controller struct {
 	mock.Mock
 }
 
+// EvictVEXCache provides a mock function with given fields: ctx, projectID
+func (_m *Controller) EvictVEXCache(ctx context.Context, projectID int64) error {
+	ret := _m.Called(ctx, projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvictVEXCache")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetReport provides a mock function with given fields: ctx, _a1, mimeTypes
 func (_m *Controller) GetReport(ctx context.Context, _a1 *artifact.Artifact, mimeTypes []string) ([]*scan.Report, error) {
 	ret := _m.Called(ctx, _a1, mimeTypes)
@@ -141,6 +159,34 @@ func (_m *Controller) GetVulnerable(ctx context.Context, _a1 *artifact.Artifact,
 	return r0, r1
 }
 
+// RescanOnDBUpdate provides a mock function with given fields: ctx, trigger
+func (_m *Controller) RescanOnDBUpdate(ctx context.Context, trigger string) (int64, error) {
+	ret := _m.Called(ctx, trigger)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RescanOnDBUpdate")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, trigger)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, trigger)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, trigger)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Scan provides a mock function with given fields: ctx, _a1, options
 func (_m *Controller) Scan(ctx context.Context, _a1 *artifact.Artifact, options ...controllerscan.Option) error {
 	_va := make([]interface{}, len(options))
@@ -166,9 +212,9 @@ func (_m *Controller) Scan(ctx context.Context, _a1 *artifact.Artifact, options
 	return r0
 }
 
-// ScanAll provides a mock function with given fields: ctx, trigger, async
-func (_m *Controller) ScanAll(ctx context.Context, trigger string, async bool) (int64, error) {
-	ret := _m.Called(ctx, trigger, async)
+// ScanAll provides a mock function with given fields: ctx, trigger, async, scope
+func (_m *Controller) ScanAll(ctx context.Context, trigger string, async bool, scope *controllerscan.ScanAllScope) (int64, error) {
+	ret := _m.Called(ctx, trigger, async, scope)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ScanAll")
@@ -176,17 +222,17 @@ func (_m *Controller) ScanAll(ctx context.Context, trigger string, async bool) (
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (int64, error)); ok {
-		return rf(ctx, trigger, async)
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, *controllerscan.ScanAllScope) (int64, error)); ok {
+		return rf(ctx, trigger, async, scope)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, bool) int64); ok {
-		r0 = rf(ctx, trigger, async)
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, *controllerscan.ScanAllScope) int64); ok {
+		r0 = rf(ctx, trigger, async, scope)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
-		r1 = rf(ctx, trigger, async)
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool, *controllerscan.ScanAllScope) error); ok {
+		r1 = rf(ctx, trigger, async, scope)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -20,6 +20,36 @@ type Controller struct {
 	mock.Mock
 }
 
+// CheckDBUpdates provides a mock function with given fields: ctx
+func (_m *Controller) CheckDBUpdates(ctx context.Context) ([]*scanner.Registration, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckDBUpdates")
+	}
+
+	var r0 []*scanner.Registration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*scanner.Registration, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*scanner.Registration); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*scanner.Registration)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateRegistration provides a mock function with given fields: ctx, registration
 func (_m *Controller) CreateRegistration(ctx context.Context, registration *scanner.Registration) (string, error) {
 	ret := _m.Called(ctx, registration)
@@ -175,6 +205,43 @@ func (_m *Controller) GetRegistrationByProject(ctx context.Context, projectID in
 	return r0, r1
 }
 
+// GetRegistrationsByProject provides a mock function with given fields: ctx, projectID, options
+func (_m *Controller) GetRegistrationsByProject(ctx context.Context, projectID int64, options ...controllerscanner.Option) ([]*scanner.Registration, error) {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRegistrationsByProject")
+	}
+
+	var r0 []*scanner.Registration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, ...controllerscanner.Option) ([]*scanner.Registration, error)); ok {
+		return rf(ctx, projectID, options...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, ...controllerscanner.Option) []*scanner.Registration); ok {
+		r0 = rf(ctx, projectID, options...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*scanner.Registration)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, ...controllerscanner.Option) error); ok {
+		r1 = rf(ctx, projectID, options...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTotalOfRegistrations provides a mock function with given fields: ctx, query
 func (_m *Controller) GetTotalOfRegistrations(ctx context.Context, query *q.Query) (int64, error) {
 	ret := _m.Called(ctx, query)
@@ -335,6 +402,24 @@ func (_m *Controller) SetRegistrationByProject(ctx context.Context, projectID in
 	return r0
 }
 
+// SetRegistrationsByProject provides a mock function with given fields: ctx, projectID, scannerIDs
+func (_m *Controller) SetRegistrationsByProject(ctx context.Context, projectID int64, scannerIDs []string) error {
+	ret := _m.Called(ctx, projectID, scannerIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRegistrationsByProject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string) error); ok {
+		r0 = rf(ctx, projectID, scannerIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateRegistration provides a mock function with given fields: ctx, registration
 func (_m *Controller) UpdateRegistration(ctx context.Context, registration *scanner.Registration) error {
 	ret := _m.Called(ctx, registration)
@@ -5,12 +5,17 @@ package securityhub
 import (
 	context "context"
 
-	model "github.com/goharbor/harbor/src/pkg/securityhub/model"
 	mock "github.com/stretchr/testify/mock"
 
+	componentModel "github.com/goharbor/harbor/src/pkg/scan/sbom/component/model"
+
 	q "github.com/goharbor/harbor/src/lib/q"
 
+	secHubModel "github.com/goharbor/harbor/src/pkg/securityhub/model"
+
 	securityhub "github.com/goharbor/harbor/src/controller/securityhub"
+
+	time "time"
 )
 
 // Controller is an autogenerated mock type for the Controller type
@@ -18,6 +23,34 @@ type Controller struct {
 	mock.Mock
 }
 
+// CountRemediations provides a mock function with given fields: ctx, query
+func (_m *Controller) CountRemediations(ctx context.Context, query *q.Query) (int64, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountRemediations")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) (int64, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) int64); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CountVuls provides a mock function with given fields: ctx, scannerUUID, projectID, tuneCount, query
 func (_m *Controller) CountVuls(ctx context.Context, scannerUUID string, projectID int64, tuneCount bool, query *q.Query) (int64, error) {
 	ret := _m.Called(ctx, scannerUUID, projectID, tuneCount, query)
@@ -46,24 +79,160 @@ func (_m *Controller) CountVuls(ctx context.Context, scannerUUID string, project
 	return r0, r1
 }
 
+// CreateRemediation provides a mock function with given fields: ctx, remediation
+func (_m *Controller) CreateRemediation(ctx context.Context, remediation *secHubModel.Remediation) (int64, error) {
+	ret := _m.Called(ctx, remediation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRemediation")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *secHubModel.Remediation) (int64, error)); ok {
+		return rf(ctx, remediation)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *secHubModel.Remediation) int64); ok {
+		r0 = rf(ctx, remediation)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *secHubModel.Remediation) error); ok {
+		r1 = rf(ctx, remediation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteRemediation provides a mock function with given fields: ctx, id
+func (_m *Controller) DeleteRemediation(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRemediation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetRemediation provides a mock function with given fields: ctx, id
+func (_m *Controller) GetRemediation(ctx context.Context, id int64) (*secHubModel.Remediation, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRemediation")
+	}
+
+	var r0 *secHubModel.Remediation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*secHubModel.Remediation, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *secHubModel.Remediation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*secHubModel.Remediation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRemediations provides a mock function with given fields: ctx, query
+func (_m *Controller) ListRemediations(ctx context.Context, query *q.Query) ([]*secHubModel.Remediation, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRemediations")
+	}
+
+	var r0 []*secHubModel.Remediation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) ([]*secHubModel.Remediation, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*secHubModel.Remediation); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*secHubModel.Remediation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTrends provides a mock function with given fields: ctx, projectID, from, to
+func (_m *Controller) ListTrends(ctx context.Context, projectID int64, from time.Time, to time.Time) ([]*secHubModel.Snapshot, error) {
+	ret := _m.Called(ctx, projectID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTrends")
+	}
+
+	var r0 []*secHubModel.Snapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) ([]*secHubModel.Snapshot, error)); ok {
+		return rf(ctx, projectID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) []*secHubModel.Snapshot); ok {
+		r0 = rf(ctx, projectID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*secHubModel.Snapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, projectID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListVuls provides a mock function with given fields: ctx, scannerUUID, projectID, withTag, query
-func (_m *Controller) ListVuls(ctx context.Context, scannerUUID string, projectID int64, withTag bool, query *q.Query) ([]*model.VulnerabilityItem, error) {
+func (_m *Controller) ListVuls(ctx context.Context, scannerUUID string, projectID int64, withTag bool, query *q.Query) ([]*secHubModel.VulnerabilityItem, error) {
 	ret := _m.Called(ctx, scannerUUID, projectID, withTag, query)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListVuls")
 	}
 
-	var r0 []*model.VulnerabilityItem
+	var r0 []*secHubModel.VulnerabilityItem
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int64, bool, *q.Query) ([]*model.VulnerabilityItem, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, bool, *q.Query) ([]*secHubModel.VulnerabilityItem, error)); ok {
 		return rf(ctx, scannerUUID, projectID, withTag, query)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int64, bool, *q.Query) []*model.VulnerabilityItem); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, bool, *q.Query) []*secHubModel.VulnerabilityItem); ok {
 		r0 = rf(ctx, scannerUUID, projectID, withTag, query)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.VulnerabilityItem)
+			r0 = ret.Get(0).([]*secHubModel.VulnerabilityItem)
 		}
 	}
 
@@ -76,8 +245,81 @@ func (_m *Controller) ListVuls(ctx context.Context, scannerUUID string, projectI
 	return r0, r1
 }
 
+// ReconcileExpiredRisks provides a mock function with given fields: ctx
+func (_m *Controller) ReconcileExpiredRisks(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReconcileExpiredRisks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReconcileRemediations provides a mock function with given fields: ctx, projectID
+func (_m *Controller) ReconcileRemediations(ctx context.Context, projectID int64) error {
+	ret := _m.Called(ctx, projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReconcileRemediations")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchComponents provides a mock function with given fields: ctx, criteria, withTag, pageNumber, pageSize
+func (_m *Controller) SearchComponents(ctx context.Context, criteria *componentModel.Criteria, withTag bool, pageNumber int64, pageSize int64) (int64, []*componentModel.Item, error) {
+	ret := _m.Called(ctx, criteria, withTag, pageNumber, pageSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchComponents")
+	}
+
+	var r0 int64
+	var r1 []*componentModel.Item
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *componentModel.Criteria, bool, int64, int64) (int64, []*componentModel.Item, error)); ok {
+		return rf(ctx, criteria, withTag, pageNumber, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *componentModel.Criteria, bool, int64, int64) int64); ok {
+		r0 = rf(ctx, criteria, withTag, pageNumber, pageSize)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *componentModel.Criteria, bool, int64, int64) []*componentModel.Item); ok {
+		r1 = rf(ctx, criteria, withTag, pageNumber, pageSize)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*componentModel.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *componentModel.Criteria, bool, int64, int64) error); ok {
+		r2 = rf(ctx, criteria, withTag, pageNumber, pageSize)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // SecuritySummary provides a mock function with given fields: ctx, projectID, options
-func (_m *Controller) SecuritySummary(ctx context.Context, projectID int64, options ...securityhub.Option) (*model.Summary, error) {
+func (_m *Controller) SecuritySummary(ctx context.Context, projectID int64, options ...securityhub.Option) (*secHubModel.Summary, error) {
 	_va := make([]interface{}, len(options))
 	for _i := range options {
 		_va[_i] = options[_i]
@@ -91,16 +333,16 @@ func (_m *Controller) SecuritySummary(ctx context.Context, projectID int64, opti
 		panic("no return value specified for SecuritySummary")
 	}
 
-	var r0 *model.Summary
+	var r0 *secHubModel.Summary
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, ...securityhub.Option) (*model.Summary, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int64, ...securityhub.Option) (*secHubModel.Summary, error)); ok {
 		return rf(ctx, projectID, options...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64, ...securityhub.Option) *model.Summary); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int64, ...securityhub.Option) *secHubModel.Summary); ok {
 		r0 = rf(ctx, projectID, options...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Summary)
+			r0 = ret.Get(0).(*secHubModel.Summary)
 		}
 	}
 
@@ -113,6 +355,42 @@ func (_m *Controller) SecuritySummary(ctx context.Context, projectID int64, opti
 	return r0, r1
 }
 
+// TakeSnapshot provides a mock function with given fields: ctx
+func (_m *Controller) TakeSnapshot(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TakeSnapshot")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateRemediation provides a mock function with given fields: ctx, remediation
+func (_m *Controller) UpdateRemediation(ctx context.Context, remediation *secHubModel.Remediation) error {
+	ret := _m.Called(ctx, remediation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRemediation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *secHubModel.Remediation) error); ok {
+		r0 = rf(ctx, remediation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewController creates a new instance of Controller. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewController(t interface {
@@ -5,6 +5,10 @@ package task
 import (
 	context "context"
 
+	io "io"
+
+	dao "github.com/goharbor/harbor/src/pkg/task/dao"
+
 	pkgtask "github.com/goharbor/harbor/src/pkg/task"
 	mock "github.com/stretchr/testify/mock"
 
@@ -62,6 +66,24 @@ func (_m *ExecutionController) Delete(ctx context.Context, id int64) error {
 	return r0
 }
 
+// Export provides a mock function with given fields: ctx, query, w
+func (_m *ExecutionController) Export(ctx context.Context, query *q.Query, w io.Writer) error {
+	ret := _m.Called(ctx, query, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query, io.Writer) error); ok {
+		r0 = rf(ctx, query, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, id
 func (_m *ExecutionController) Get(ctx context.Context, id int64) (*pkgtask.Execution, error) {
 	ret := _m.Called(ctx, id)
@@ -122,6 +144,36 @@ func (_m *ExecutionController) List(ctx context.Context, query *q.Query) ([]*pkg
 	return r0, r1
 }
 
+// Stats provides a mock function with given fields: ctx, query
+func (_m *ExecutionController) Stats(ctx context.Context, query *q.Query) ([]*dao.ExecutionStats, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stats")
+	}
+
+	var r0 []*dao.ExecutionStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) ([]*dao.ExecutionStats, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*dao.ExecutionStats); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.ExecutionStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Stop provides a mock function with given fields: ctx, id
 func (_m *ExecutionController) Stop(ctx context.Context, id int64) error {
 	ret := _m.Called(ctx, id)
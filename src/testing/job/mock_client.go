@@ -1,9 +1,12 @@
 package job
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"math/rand"
 	"slices"
+	"strings"
 
 	"github.com/goharbor/harbor/src/common/http"
 	"github.com/goharbor/harbor/src/common/job/models"
@@ -31,6 +34,17 @@ func (mjc *MockJobClient) GetJobLog(uuid string) ([]byte, error) {
 	return nil, &http.Error{404, "not Found"}
 }
 
+// FollowJobLog ...
+func (mjc *MockJobClient) FollowJobLog(_ context.Context, uuid string, _ int64) (io.ReadCloser, error) {
+	if uuid == "500" {
+		return nil, &http.Error{Code: 500, Message: "server side error"}
+	}
+	if mjc.validUUID(uuid) {
+		return io.NopCloser(strings.NewReader("id: 8\ndata: some log\n\n")), nil
+	}
+	return nil, &http.Error{Code: 404, Message: "not Found"}
+}
+
 // SubmitJob ...
 func (mjc *MockJobClient) SubmitJob(data *models.JobData) (string, error) {
 	uuid := fmt.Sprintf("u-%d", rand.Int())
@@ -49,6 +63,19 @@ func (mjc *MockJobClient) PostAction(uuid, action string) error {
 	return nil
 }
 
+// GetJobStats ...
+func (mjc *MockJobClient) GetJobStats(uuid string) (*job.Stats, error) {
+	if !mjc.validUUID(uuid) {
+		return nil, fmt.Errorf("job not found: %s", uuid)
+	}
+	return &job.Stats{
+		Info: &job.StatsInfo{
+			JobID:  uuid,
+			Status: job.RunningStatus.String(),
+		},
+	}, nil
+}
+
 // GetExecutions ...
 func (mjc *MockJobClient) GetExecutions(uuid string) ([]job.Stats, error) {
 	return nil, nil
@@ -201,6 +201,36 @@ func (_m *Manager) List(ctx context.Context, query *q.Query) ([]*artifact.Artifa
 	return r0, r1
 }
 
+// ListByPullTime provides a mock function with given fields: ctx, query, after
+func (_m *Manager) ListByPullTime(ctx context.Context, query *q.Query, after *artifact.Artifact) ([]*artifact.Artifact, error) {
+	ret := _m.Called(ctx, query, after)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByPullTime")
+	}
+
+	var r0 []*artifact.Artifact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query, *artifact.Artifact) ([]*artifact.Artifact, error)); ok {
+		return rf(ctx, query, after)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query, *artifact.Artifact) []*artifact.Artifact); ok {
+		r0 = rf(ctx, query, after)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*artifact.Artifact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query, *artifact.Artifact) error); ok {
+		r1 = rf(ctx, query, after)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListReferences provides a mock function with given fields: ctx, query
 func (_m *Manager) ListReferences(ctx context.Context, query *q.Query) ([]*artifact.Reference, error) {
 	ret := _m.Called(ctx, query)
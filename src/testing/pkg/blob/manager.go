@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	q "github.com/goharbor/harbor/src/lib/q"
+
+	time "time"
 )
 
 // Manager is an autogenerated mock type for the Manager type
@@ -330,6 +332,34 @@ func (_m *Manager) List(ctx context.Context, query *q.Query) ([]*models.Blob, er
 	return r0, r1
 }
 
+// MarkDeleting provides a mock function with given fields: ctx, _a1, updatedBefore
+func (_m *Manager) MarkDeleting(ctx context.Context, _a1 *models.Blob, updatedBefore time.Time) (int64, error) {
+	ret := _m.Called(ctx, _a1, updatedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkDeleting")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Blob, time.Time) (int64, error)); ok {
+		return rf(ctx, _a1, updatedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Blob, time.Time) int64); ok {
+		r0 = rf(ctx, _a1, updatedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Blob, time.Time) error); ok {
+		r1 = rf(ctx, _a1, updatedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, _a1
 func (_m *Manager) Update(ctx context.Context, _a1 *models.Blob) error {
 	ret := _m.Called(ctx, _a1)
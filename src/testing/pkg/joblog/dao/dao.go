@@ -17,6 +17,24 @@ type DAO struct {
 	mock.Mock
 }
 
+// Append provides a mock function with given fields: ctx, uuid, content
+func (_m *DAO) Append(ctx context.Context, uuid string, content string) error {
+	ret := _m.Called(ctx, uuid, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Append")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, uuid, content)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: ctx, jobLog
 func (_m *DAO) Create(ctx context.Context, jobLog *models.JobLog) (int64, error) {
 	ret := _m.Called(ctx, jobLog)
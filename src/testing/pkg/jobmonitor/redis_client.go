@@ -5,6 +5,8 @@ package jobmonitor
 import (
 	context "context"
 
+	job "github.com/goharbor/harbor/src/jobservice/job"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -43,6 +45,54 @@ func (_m *RedisClient) AllJobTypes(ctx context.Context) ([]string, error) {
 	return r0, r1
 }
 
+// DeleteJobPriorities provides a mock function with given fields: ctx
+func (_m *RedisClient) DeleteJobPriorities(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteJobPriorities")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetJobPriorities provides a mock function with given fields: ctx
+func (_m *RedisClient) GetJobPriorities(ctx context.Context) (*job.Priorities, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJobPriorities")
+	}
+
+	var r0 *job.Priorities
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*job.Priorities, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *job.Priorities); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*job.Priorities)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PauseJob provides a mock function with given fields: ctx, jobName
 func (_m *RedisClient) PauseJob(ctx context.Context, jobName string) error {
 	ret := _m.Called(ctx, jobName)
@@ -61,6 +111,24 @@ func (_m *RedisClient) PauseJob(ctx context.Context, jobName string) error {
 	return r0
 }
 
+// SetJobPriorities provides a mock function with given fields: ctx, priorities
+func (_m *RedisClient) SetJobPriorities(ctx context.Context, priorities *job.Priorities) error {
+	ret := _m.Called(ctx, priorities)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetJobPriorities")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *job.Priorities) error); ok {
+		r0 = rf(ctx, priorities)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StopPendingJobs provides a mock function with given fields: ctx, jobType
 func (_m *RedisClient) StopPendingJobs(ctx context.Context, jobType string) ([]string, error) {
 	ret := _m.Called(ctx, jobType)
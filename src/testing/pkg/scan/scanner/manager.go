@@ -226,17 +226,24 @@ func (_m *Manager) SetAsDefault(ctx context.Context, registrationUUID string) er
 	return r0
 }
 
-// Update provides a mock function with given fields: ctx, registration
-func (_m *Manager) Update(ctx context.Context, registration *daoscanner.Registration) error {
-	ret := _m.Called(ctx, registration)
+// Update provides a mock function with given fields: ctx, registration, cols
+func (_m *Manager) Update(ctx context.Context, registration *daoscanner.Registration, cols ...string) error {
+	_va := make([]interface{}, len(cols))
+	for _i := range cols {
+		_va[_i] = cols[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, registration)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *daoscanner.Registration) error); ok {
-		r0 = rf(ctx, registration)
+	if rf, ok := ret.Get(0).(func(context.Context, *daoscanner.Registration, ...string) error); ok {
+		r0 = rf(ctx, registration, cols...)
 	} else {
 		r0 = ret.Error(0)
 	}
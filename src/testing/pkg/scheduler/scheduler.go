@@ -5,9 +5,12 @@ package scheduler
 import (
 	context "context"
 
-	q "github.com/goharbor/harbor/src/lib/q"
+	job "github.com/goharbor/harbor/src/jobservice/job"
+
 	mock "github.com/stretchr/testify/mock"
 
+	q "github.com/goharbor/harbor/src/lib/q"
+
 	scheduler "github.com/goharbor/harbor/src/pkg/scheduler"
 )
 
@@ -132,6 +135,34 @@ func (_m *Scheduler) Schedule(ctx context.Context, vendorType string, vendorID i
 	return r0, r1
 }
 
+// ScheduleWithOptions provides a mock function with given fields: ctx, vendorType, vendorID, cronType, cron, options, callbackFuncName, callbackFuncParams, extraAttrs
+func (_m *Scheduler) ScheduleWithOptions(ctx context.Context, vendorType string, vendorID int64, cronType string, cron string, options *job.ScheduleOptions, callbackFuncName string, callbackFuncParams interface{}, extraAttrs map[string]interface{}) (int64, error) {
+	ret := _m.Called(ctx, vendorType, vendorID, cronType, cron, options, callbackFuncName, callbackFuncParams, extraAttrs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleWithOptions")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string, string, *job.ScheduleOptions, string, interface{}, map[string]interface{}) (int64, error)); ok {
+		return rf(ctx, vendorType, vendorID, cronType, cron, options, callbackFuncName, callbackFuncParams, extraAttrs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string, string, *job.ScheduleOptions, string, interface{}, map[string]interface{}) int64); ok {
+		r0 = rf(ctx, vendorType, vendorID, cronType, cron, options, callbackFuncName, callbackFuncParams, extraAttrs)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, string, string, *job.ScheduleOptions, string, interface{}, map[string]interface{}) error); ok {
+		r1 = rf(ctx, vendorType, vendorID, cronType, cron, options, callbackFuncName, callbackFuncParams, extraAttrs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UnScheduleByID provides a mock function with given fields: ctx, id
 func (_m *Scheduler) UnScheduleByID(ctx context.Context, id int64) error {
 	ret := _m.Called(ctx, id)
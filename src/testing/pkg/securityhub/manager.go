@@ -5,12 +5,15 @@ package securityhub
 import (
 	context "context"
 
-	model "github.com/goharbor/harbor/src/pkg/securityhub/model"
 	mock "github.com/stretchr/testify/mock"
 
+	model "github.com/goharbor/harbor/src/pkg/securityhub/model"
+
 	q "github.com/goharbor/harbor/src/lib/q"
 
 	scan "github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+
+	time "time"
 )
 
 // Manager is an autogenerated mock type for the Manager type
@@ -78,6 +81,36 @@ func (_m *Manager) DangerousCVEs(ctx context.Context, scannerUUID string, projec
 	return r0, r1
 }
 
+// ListSnapshots provides a mock function with given fields: ctx, query
+func (_m *Manager) ListSnapshots(ctx context.Context, query *q.Query) ([]*model.Snapshot, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSnapshots")
+	}
+
+	var r0 []*model.Snapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) ([]*model.Snapshot, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*model.Snapshot); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Snapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListVuls provides a mock function with given fields: ctx, scannerUUID, projectID, query
 func (_m *Manager) ListVuls(ctx context.Context, scannerUUID string, projectID int64, query *q.Query) ([]*model.VulnerabilityItem, error) {
 	ret := _m.Called(ctx, scannerUUID, projectID, query)
@@ -108,6 +141,54 @@ func (_m *Manager) ListVuls(ctx context.Context, scannerUUID string, projectID i
 	return r0, r1
 }
 
+// ProjectSummaries provides a mock function with given fields: ctx, scannerUUID
+func (_m *Manager) ProjectSummaries(ctx context.Context, scannerUUID string) ([]*model.Snapshot, error) {
+	ret := _m.Called(ctx, scannerUUID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProjectSummaries")
+	}
+
+	var r0 []*model.Snapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*model.Snapshot, error)); ok {
+		return rf(ctx, scannerUUID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*model.Snapshot); ok {
+		r0 = rf(ctx, scannerUUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Snapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, scannerUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveSnapshots provides a mock function with given fields: ctx, snapshotTime, snapshots
+func (_m *Manager) SaveSnapshots(ctx context.Context, snapshotTime time.Time, snapshots []*model.Snapshot) error {
+	ret := _m.Called(ctx, snapshotTime, snapshots)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSnapshots")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, []*model.Snapshot) error); ok {
+		r0 = rf(ctx, snapshotTime, snapshots)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ScannedArtifactsCount provides a mock function with given fields: ctx, scannerUUID, projectID, query
 func (_m *Manager) ScannedArtifactsCount(ctx context.Context, scannerUUID string, projectID int64, query *q.Query) (int64, error) {
 	ret := _m.Called(ctx, scannerUUID, projectID, query)
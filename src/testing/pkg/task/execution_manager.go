@@ -5,6 +5,8 @@ package task
 import (
 	context "context"
 
+	dao "github.com/goharbor/harbor/src/pkg/task/dao"
+
 	q "github.com/goharbor/harbor/src/lib/q"
 	mock "github.com/stretchr/testify/mock"
 
@@ -147,6 +149,36 @@ func (_m *ExecutionManager) Get(ctx context.Context, id int64) (*task.Execution,
 	return r0, r1
 }
 
+// IncreaseExtraAttrs provides a mock function with given fields: ctx, id, deltas
+func (_m *ExecutionManager) IncreaseExtraAttrs(ctx context.Context, id int64, deltas map[string]int64) (map[string]int64, error) {
+	ret := _m.Called(ctx, id, deltas)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncreaseExtraAttrs")
+	}
+
+	var r0 map[string]int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, map[string]int64) (map[string]int64, error)); ok {
+		return rf(ctx, id, deltas)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, map[string]int64) map[string]int64); ok {
+		r0 = rf(ctx, id, deltas)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, map[string]int64) error); ok {
+		r1 = rf(ctx, id, deltas)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, query
 func (_m *ExecutionManager) List(ctx context.Context, query *q.Query) ([]*task.Execution, error) {
 	ret := _m.Called(ctx, query)
@@ -213,6 +245,36 @@ func (_m *ExecutionManager) MarkError(ctx context.Context, id int64, message str
 	return r0
 }
 
+// Stats provides a mock function with given fields: ctx, query
+func (_m *ExecutionManager) Stats(ctx context.Context, query *q.Query) ([]*dao.ExecutionStats, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stats")
+	}
+
+	var r0 []*dao.ExecutionStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) ([]*dao.ExecutionStats, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*dao.ExecutionStats); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.ExecutionStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Stop provides a mock function with given fields: ctx, id
 func (_m *ExecutionManager) Stop(ctx context.Context, id int64) error {
 	ret := _m.Called(ctx, id)
@@ -5,6 +5,8 @@ package task
 import (
 	context "context"
 
+	io "io"
+
 	q "github.com/goharbor/harbor/src/lib/q"
 	mock "github.com/stretchr/testify/mock"
 
@@ -79,6 +81,34 @@ func (_m *Manager) Create(ctx context.Context, executionID int64, job *task.Job,
 	return r0, r1
 }
 
+// CreateRecord provides a mock function with given fields: ctx, executionID, extraAttrs
+func (_m *Manager) CreateRecord(ctx context.Context, executionID int64, extraAttrs map[string]interface{}) (int64, error) {
+	ret := _m.Called(ctx, executionID, extraAttrs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRecord")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, map[string]interface{}) (int64, error)); ok {
+		return rf(ctx, executionID, extraAttrs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, map[string]interface{}) int64); ok {
+		r0 = rf(ctx, executionID, extraAttrs)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, map[string]interface{}) error); ok {
+		r1 = rf(ctx, executionID, extraAttrs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExecutionIDsByVendorAndStatus provides a mock function with given fields: ctx, vendorType, status
 func (_m *Manager) ExecutionIDsByVendorAndStatus(ctx context.Context, vendorType string, status string) ([]int64, error) {
 	ret := _m.Called(ctx, vendorType, status)
@@ -109,6 +139,36 @@ func (_m *Manager) ExecutionIDsByVendorAndStatus(ctx context.Context, vendorType
 	return r0, r1
 }
 
+// FollowLog provides a mock function with given fields: ctx, id, offset
+func (_m *Manager) FollowLog(ctx context.Context, id int64, offset int64) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, id, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FollowLog")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (io.ReadCloser, error)); ok {
+		return rf(ctx, id, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) io.ReadCloser); ok {
+		r0 = rf(ctx, id, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, id, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: ctx, id
 func (_m *Manager) Get(ctx context.Context, id int64) (*task.Task, error) {
 	ret := _m.Called(ctx, id)
@@ -313,6 +373,24 @@ func (_m *Manager) Stop(ctx context.Context, id int64) error {
 	return r0
 }
 
+// Submit provides a mock function with given fields: ctx, id, job
+func (_m *Manager) Submit(ctx context.Context, id int64, job *task.Job) error {
+	ret := _m.Called(ctx, id, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Submit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *task.Job) error); ok {
+		r0 = rf(ctx, id, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, _a1, props
 func (_m *Manager) Update(ctx context.Context, _a1 *task.Task, props ...string) error {
 	_va := make([]interface{}, len(props))